@@ -0,0 +1,132 @@
+// Package client sends GraphQL operations to a server over HTTP. It is used
+// by the code goql generate writes for operations, and may be used directly:
+//
+//	c := client.New("https://api.example.com/graphql")
+//
+//	var data struct {
+//		Pet struct {
+//			Name string `json:"name"`
+//		} `json:"pet"`
+//	}
+//	err := c.Do(ctx, `query($id: ID!) { pet(id: $id) { name } }`, map[string]interface{}{"id": "1"}, &data)
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/ijsnow/goql"
+)
+
+// Client posts operations to the URL of a GraphQL server, as JSON.
+type Client struct {
+	/**
+	 * URL is the endpoint of the server.
+	 */
+	URL string
+
+	/**
+	 * HTTPClient sends the requests. It defaults to http.DefaultClient.
+	 */
+	HTTPClient *http.Client
+
+	/**
+	 * Header holds the headers added to every request, such as
+	 * Authorization.
+	 */
+	Header http.Header
+}
+
+// New returns a Client for the server at url.
+func New(url string) *Client {
+	return &Client{URL: url}
+}
+
+// Errors are the errors of a response. The data of the response, which
+// may be partial, is decoded nonetheless.
+type Errors []*goql.FormattedError
+
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for idx, err := range e {
+		messages[idx] = err.Message
+	}
+
+	return strings.Join(messages, "\n")
+}
+
+type request struct {
+	Query     string      `json:"query"`
+	Variables interface{} `json:"variables,omitempty"`
+}
+
+type response struct {
+	Data   json.RawMessage `json:"data"`
+	Errors Errors          `json:"errors"`
+}
+
+// Do sends a document holding one operation, and decodes the data of the
+// response into data, which is typically a pointer to a struct. The
+// variables are encoded as JSON, and may be nil. The errors of the
+// response are returned as Errors.
+func (c *Client) Do(ctx context.Context, query string, variables interface{}, data interface{}) error {
+	body, err := json.Marshal(request{Query: query, Variables: variables})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, c.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	for name, values := range c.Header {
+		req.Header[name] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	// Servers may answer with errors along with a status other than 200, so
+	// the body is read whatever the status.
+	var result response
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("Request failed with status %s.", resp.Status)
+		}
+		return fmt.Errorf("Invalid response: %v.", err)
+	}
+
+	if len(result.Data) != 0 && string(result.Data) != "null" && data != nil {
+		if err := json.Unmarshal(result.Data, data); err != nil {
+			return err
+		}
+	}
+	if len(result.Errors) != 0 {
+		return result.Errors
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Request failed with status %s.", resp.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query     string
+			Variables map[string]interface{}
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("Decode; got error %v", err)
+		}
+
+		if got := r.Header.Get("Content-Type"); got != "application/json" {
+			t.Errorf("Content-Type; got %q wanted application/json", got)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer token" {
+			t.Errorf("Authorization; got %q wanted %q", got, "Bearer token")
+		}
+		if req.Query != "query($id: ID!) { pet(id: $id) { name } }" || req.Variables["id"] != "1" {
+			t.Errorf("request; got %q %v", req.Query, req.Variables)
+		}
+
+		w.Write([]byte(`{"data": {"pet": {"name": "Rex"}}}`))
+	}))
+	defer server.Close()
+
+	c := New(server.URL)
+	c.Header = http.Header{"Authorization": []string{"Bearer token"}}
+
+	var data struct {
+		Pet struct {
+			Name string `json:"name"`
+		} `json:"pet"`
+	}
+	err := c.Do(context.Background(), "query($id: ID!) { pet(id: $id) { name } }", map[string]interface{}{"id": "1"}, &data)
+	if err != nil {
+		t.Fatalf("Do; got error %v", err)
+	}
+	if data.Pet.Name != "Rex" {
+		t.Errorf("data; got %q wanted Rex", data.Pet.Name)
+	}
+}
+
+func TestDoErrors(t *testing.T) {
+	tests := []struct {
+		status int
+		body   string
+		want   string
+		name   string
+	}{
+		{http.StatusOK, `{"data": {"name": "Rex"}, "errors": [{"message": "a"}, {"message": "b", "path": ["pet"]}]}`, "a\nb", "Rex"},
+		{http.StatusBadRequest, `{"errors": [{"message": "Syntax Error"}]}`, "Syntax Error", ""},
+		{http.StatusBadGateway, `<html>`, "Request failed with status 502 Bad Gateway.", ""},
+		{http.StatusOK, `<html>`, "Invalid response: invalid character '<' looking for beginning of value.", ""},
+		{http.StatusInternalServerError, `{"data": null}`, "Request failed with status 500 Internal Server Error.", ""},
+	}
+
+	for _, test := range tests {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(test.status)
+			w.Write([]byte(test.body))
+		}))
+
+		var data struct {
+			Name string `json:"name"`
+		}
+		err := New(server.URL).Do(context.Background(), "{ name }", nil, &data)
+		server.Close()
+
+		if err == nil || err.Error() != test.want {
+			t.Errorf("Do(%s); got error %v wanted %q", test.body, err, test.want)
+		}
+		if data.Name != test.name {
+			t.Errorf("Do(%s); got data %q wanted %q", test.body, data.Name, test.name)
+		}
+	}
+}
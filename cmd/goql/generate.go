@@ -0,0 +1,118 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/ijsnow/goql/internal/codegen"
+)
+
+const generateUsage = `usage: goql generate [-config file]
+
+Generate writes the Go code configured by a JSON file, by default goql.json,
+or by a YAML file when its name ends in .yaml or .yml:
+
+	{
+		"schema": ["schema/*.graphql"],
+		"models": {"DateTime": "time.Time"},
+		"server": {"output": "graph/generated.go", "package": "graph"},
+		"operations": ["operations/*.graphql"],
+		"client": {"output": "api/generated.go"}
+	}
+
+The schema is read from the SDL files matching the patterns of "schema", or
+from a single JSON file holding the result of an introspection query. The
+"server" section generates models for the types of the schema, resolver
+interfaces with typed arguments and NewExecutableSchema, which binds an
+implementation of the resolvers to the schema. The "client" section
+validates the operations and fragments of the files matching the patterns
+of "operations", and generates the types of their variables and responses
+and a function sending each of them. The "models" section maps GraphQL
+types to existing Go types, which are used instead of generating them.
+Paths are relative to the directory of the configuration file.
+
+Flags:
+`
+
+func runGenerate(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("generate", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	configPath := flags.String("config", "goql.json", "configuration `file`")
+	flags.Usage = func() {
+		fmt.Fprint(stderr, generateUsage)
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return exitError
+	}
+	if flags.NArg() != 0 {
+		flags.Usage()
+		return exitError
+	}
+
+	if err := generate(*configPath, stdout); err != nil {
+		fmt.Fprintf(stderr, "goql generate: %v\n", err)
+		return exitError
+	}
+
+	return exitOK
+}
+
+func generate(configPath string, stdout io.Writer) error {
+	config, err := codegen.LoadConfig(configPath)
+	if err != nil {
+		return err
+	}
+	if config.Server == nil && config.Client == nil {
+		return fmt.Errorf("%s: nothing to generate; configure the server or client section", configPath)
+	}
+
+	s, sdl, err := config.LoadSchema()
+	if err != nil {
+		return err
+	}
+
+	if config.Server != nil {
+		source, err := codegen.GenerateServer(config, s, sdl)
+		if err != nil {
+			return err
+		}
+		if err := writeGenerated(config.Path(config.Server.Output), source, stdout); err != nil {
+			return err
+		}
+	}
+
+	if config.Client != nil {
+		documents, err := config.LoadOperations()
+		if err != nil {
+			return err
+		}
+		source, err := codegen.GenerateClient(config, s, documents)
+		if err != nil {
+			return err
+		}
+		if err := writeGenerated(config.Path(config.Client.Output), source, stdout); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeGenerated(path string, source []byte, stdout io.Writer) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(path, source, 0644); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "wrote %s\n", path)
+
+	return nil
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const generateSDL = `type Query {
+  pet(id: ID!): Pet
+  born: DateTime
+}
+
+type Pet {
+  id: ID!
+  name: String
+}
+
+scalar DateTime
+`
+
+func TestGenerate(t *testing.T) {
+	dir := tempDir(t)
+	if err := os.Mkdir(filepath.Join(dir, "schema"), 0755); err != nil {
+		t.Fatalf("Mkdir; got error %v", err)
+	}
+	writeFile(t, filepath.Join(dir, "schema"), "pet.graphql", generateSDL)
+	configPath := writeFile(t, dir, "goql.json", `{
+  "schema": ["schema/*.graphql"],
+  "models": {"DateTime": "time.Time"},
+  "server": {"output": "pet-graph/generated.go"}
+}`)
+
+	code, stdout, stderr := runCommand("generate", "-config", configPath)
+	if code != exitOK {
+		t.Fatalf("exit code; got %d wanted %d (stderr %s)", code, exitOK, stderr)
+	}
+
+	output := filepath.Join(dir, "pet-graph", "generated.go")
+	if want := "wrote " + output + "\n"; stdout != want {
+		t.Errorf("output; got %q wanted %q", stdout, want)
+	}
+
+	data, err := ioutil.ReadFile(output)
+	if err != nil {
+		t.Fatalf("ReadFile; got error %v", err)
+	}
+	source := string(data)
+	for _, snippet := range []string{
+		"package pet_graph\n",
+		"type Pet struct {",
+		"Pet(ctx context.Context, id string) (*Pet, error)",
+		"Born(ctx context.Context) (*time.Time, error)",
+		"func NewExecutableSchema(config Config) (*schema.Schema, error) {",
+	} {
+		if !strings.Contains(source, snippet) {
+			t.Errorf("generated code; got\n%s\nwanted it to contain\n%s", source, snippet)
+		}
+	}
+}
+
+func TestGenerateYAMLConfig(t *testing.T) {
+	dir := tempDir(t)
+	writeFile(t, dir, "schema.graphql", generateSDL)
+	configPath := writeFile(t, dir, "goql.yaml", `# The pet service.
+schema:
+  - schema.graphql
+models:
+  DateTime: time.Time
+server:
+  output: graph/generated.go
+  package: pets
+`)
+
+	code, _, stderr := runCommand("generate", "-config", configPath)
+	if code != exitOK {
+		t.Fatalf("exit code; got %d wanted %d (stderr %s)", code, exitOK, stderr)
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, "graph", "generated.go"))
+	if err != nil {
+		t.Fatalf("ReadFile; got error %v", err)
+	}
+	for _, snippet := range []string{"package pets\n", "Born(ctx context.Context) (*time.Time, error)"} {
+		if !strings.Contains(string(data), snippet) {
+			t.Errorf("generated code; got\n%s\nwanted it to contain\n%s", data, snippet)
+		}
+	}
+}
+
+func TestGenerateClient(t *testing.T) {
+	dir := tempDir(t)
+	writeFile(t, dir, "schema.json", diffIntrospection)
+	writeFile(t, dir, "a.graphql", "query GetA {\n  a\n}\n")
+	configPath := writeFile(t, dir, "goql.json", `{
+  "schema": ["schema.json"],
+  "operations": ["*.graphql"],
+  "client": {"output": "api/generated.go"}
+}`)
+
+	code, stdout, stderr := runCommand("generate", "-config", configPath)
+	if code != exitOK {
+		t.Fatalf("exit code; got %d wanted %d (stderr %s)", code, exitOK, stderr)
+	}
+
+	output := filepath.Join(dir, "api", "generated.go")
+	if want := "wrote " + output + "\n"; stdout != want {
+		t.Errorf("output; got %q wanted %q", stdout, want)
+	}
+
+	data, err := ioutil.ReadFile(output)
+	if err != nil {
+		t.Fatalf("ReadFile; got error %v", err)
+	}
+	source := string(data)
+	for _, snippet := range []string{
+		"package api\n",
+		"type GetAResponse struct {\n\tA *string `json:\"a\"`\n}",
+		"func GetA(ctx context.Context, c *client.Client) (*GetAResponse, error) {",
+	} {
+		if !strings.Contains(source, snippet) {
+			t.Errorf("generated code; got\n%s\nwanted it to contain\n%s", source, snippet)
+		}
+	}
+
+	writeFile(t, dir, "b.graphql", "query GetB {\n  b\n}\n")
+	code, _, stderr = runCommand("generate", "-config", configPath)
+	if code != exitError {
+		t.Errorf("invalid operation: exit code; got %d wanted %d", code, exitError)
+	}
+	want := "goql generate: " + filepath.Join(dir, "b.graphql") + ":2:3: Cannot query field \"b\" on type \"Query\". Did you mean \"a\"?\n"
+	if stderr != want {
+		t.Errorf("invalid operation: error; got %q wanted %q", stderr, want)
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	dir := tempDir(t)
+	writeFile(t, dir, "schema.graphql", generateSDL)
+	writeFile(t, dir, "invalid.graphql", "type Query { pet: Missing }")
+
+	tests := [][]string{
+		[]string{"missing.json", "", "missing.json"},
+		[]string{"syntax.json", `{"schema": `, "unexpected end of JSON input"},
+		[]string{"syntax.yaml", "schema: [schema.graphql", "line 1: expected , or ] in a flow sequence"},
+		[]string{"empty.json", `{"server": {"output": "a.go"}}`, "no schema files are configured"},
+		[]string{"noserver.json", `{"schema": ["schema.graphql"]}`, "nothing to generate"},
+		[]string{"nooperations.json", `{"schema": ["schema.graphql"], "client": {"output": "a.go"}}`, "no operation files are configured for the client"},
+		[]string{"nomatchoperations.json", `{"schema": ["schema.graphql"], "operations": ["*.gql"], "client": {"output": "a.go"}}`, "no operation files match *.gql"},
+		[]string{"nooutput.json", `{"schema": ["schema.graphql"], "server": {}}`, "server.output is not set"},
+		[]string{"package.json", `{"schema": ["schema.graphql"], "server": {"output": "a.go", "package": "a-b"}}`, `server.package "a-b" is not a valid package name`},
+		[]string{"model.json", `{"schema": ["schema.graphql"], "models": {"DateTime": "*time.Time"}, "server": {"output": "a.go"}}`, `model of DateTime must be an import path and a type name, but is "*time.Time"`},
+		[]string{"nomatch.json", `{"schema": ["*.gql"], "server": {"output": "a.go"}}`, "no schema files match *.gql"},
+		[]string{"invalid.json", `{"schema": ["invalid.graphql"], "server": {"output": "a.go"}}`, "Missing"},
+	}
+
+	for _, test := range tests {
+		configPath := filepath.Join(dir, test[0])
+		if test[1] != "" {
+			writeFile(t, dir, test[0], test[1])
+		}
+
+		code, _, stderr := runCommand("generate", "-config", configPath)
+		if code != exitError {
+			t.Errorf("%s: exit code; got %d wanted %d", test[0], code, exitError)
+		}
+		if !strings.HasPrefix(stderr, "goql generate: ") || !strings.Contains(stderr, test[2]) {
+			t.Errorf("%s: error; got %q wanted it to contain %q", test[0], stderr, test[2])
+		}
+	}
+
+	if code, _, _ := runCommand("generate", "extra"); code != exitError {
+		t.Errorf("extra argument: exit code; got %d wanted %d", code, exitError)
+	}
+}
@@ -0,0 +1,80 @@
+// Command goql works with GraphQL schemas and the code built on them.
+//
+// Usage:
+//
+//	goql <command> [arguments]
+//
+// The commands are:
+//
+//	generate       generate Go code from a schema
+//	schema diff    compare two schemas and print a changelog
+//
+// Run "goql <command> -h" for the arguments of a command.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// The exit codes of the commands.
+const (
+	exitOK       = 0
+	exitBreaking = 1
+	exitError    = 2
+)
+
+type command struct {
+	name    string
+	summary string
+	run     func(args []string, stdout, stderr io.Writer) int
+}
+
+var commands = []command{
+	{
+		name:    "generate",
+		summary: "generate Go code from a schema",
+		run:     runGenerate,
+	},
+	{
+		name:    "schema diff",
+		summary: "compare two schemas and print a changelog",
+		run:     runSchemaDiff,
+	},
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run runs the command named by the first arguments and returns the exit
+// code.
+func run(args []string, stdout, stderr io.Writer) int {
+	for _, cmd := range commands {
+		words := strings.Fields(cmd.name)
+		if len(args) >= len(words) && strings.Join(args[:len(words)], " ") == cmd.name {
+			return cmd.run(args[len(words):], stdout, stderr)
+		}
+	}
+
+	if len(args) > 0 && args[0] != "help" && args[0] != "-h" && args[0] != "-help" {
+		fmt.Fprintf(stderr, "goql: unknown command %q\n\n", strings.Join(args, " "))
+	}
+	usage(stderr)
+
+	return exitError
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "usage: goql <command> [arguments]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "The commands are:")
+	fmt.Fprintln(w)
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "\t%-14s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, `Run "goql <command> -h" for the arguments of a command.`)
+}
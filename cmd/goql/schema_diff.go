@@ -0,0 +1,340 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/ijsnow/goql/schema"
+)
+
+const schemaDiffUsage = `usage: goql schema diff [-format text|markdown|json] [-allowlist file] old new
+
+Diff compares the old and new versions of a schema and prints a changelog of
+the breaking, dangerous and safe changes between them. Each schema is read
+from a file written in the schema definition language or, when its name ends
+in .json, from the result of an introspection query.
+
+Diff exits with status 1 if there are breaking changes that the allowlist
+does not approve. Each line of the allowlist approves the breaking changes
+to a schema coordinate, such as Query.user(id:), or only those of one type
+when the coordinate follows the type, as in "FIELD_REMOVED Query.user".
+Blank lines and lines starting with # are ignored.
+
+Flags:
+`
+
+// changelog lists the changes between two schemas by category.
+type changelog struct {
+	Breaking  []change `json:"breaking"`
+	Dangerous []change `json:"dangerous"`
+	Safe      []change `json:"safe"`
+}
+
+// change is a change between two schemas, of any category.
+type change struct {
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	Coordinate  string `json:"coordinate"`
+
+	/**
+	 * Location is the file, line and column of the changed element, when
+	 * the schema it is found in was read from SDL.
+	 */
+	Location string `json:"location,omitempty"`
+
+	/**
+	 * Approved is set on the breaking changes the allowlist approves.
+	 */
+	Approved bool `json:"approved,omitempty"`
+}
+
+// unapproved returns the number of breaking changes the allowlist does not
+// approve.
+func (c *changelog) unapproved() int {
+	count := 0
+	for _, change := range c.Breaking {
+		if !change.Approved {
+			count++
+		}
+	}
+
+	return count
+}
+
+var changelogFormats = map[string]func(io.Writer, *changelog) error{
+	"text":     printTextChangelog,
+	"markdown": printMarkdownChangelog,
+	"json":     printJSONChangelog,
+}
+
+func runSchemaDiff(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("schema diff", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	format := flags.String("format", "text", "output `format`: text, markdown or json")
+	allowlistPath := flags.String("allowlist", "", "`file` of approved breaking changes")
+	flags.Usage = func() {
+		fmt.Fprint(stderr, schemaDiffUsage)
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return exitError
+	}
+	if flags.NArg() != 2 {
+		flags.Usage()
+		return exitError
+	}
+
+	printChangelog, ok := changelogFormats[*format]
+	if !ok {
+		fmt.Fprintf(stderr, "goql schema diff: unknown format %q\n", *format)
+		return exitError
+	}
+
+	oldPath, newPath := flags.Arg(0), flags.Arg(1)
+
+	oldSchema, err := loadSchema(oldPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "goql schema diff: %s: %v\n", oldPath, err)
+		return exitError
+	}
+	newSchema, err := loadSchema(newPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "goql schema diff: %s: %v\n", newPath, err)
+		return exitError
+	}
+
+	var allowlist []allowlistEntry
+	if *allowlistPath != "" {
+		if allowlist, err = readAllowlist(*allowlistPath); err != nil {
+			fmt.Fprintf(stderr, "goql schema diff: %v\n", err)
+			return exitError
+		}
+	}
+
+	log := diffSchemas(oldSchema, newSchema, oldPath, newPath)
+	for idx := range log.Breaking {
+		log.Breaking[idx].Approved = approves(allowlist, log.Breaking[idx])
+	}
+
+	if err := printChangelog(stdout, log); err != nil {
+		fmt.Fprintf(stderr, "goql schema diff: %v\n", err)
+		return exitError
+	}
+
+	if log.unapproved() > 0 {
+		return exitBreaking
+	}
+
+	return exitOK
+}
+
+// loadSchema reads a schema from SDL, or from an introspection result if
+// the file name ends in .json.
+func loadSchema(path string) (*schema.Schema, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.HasSuffix(path, ".json") {
+		return schema.BuildClientSchema(data)
+	}
+
+	return schema.BuildFromSDL(string(data))
+}
+
+// diffSchemas builds the changelog between two schemas. The locations of
+// removed elements are in the old file, and the others in the new file.
+func diffSchemas(oldSchema, newSchema *schema.Schema, oldPath, newPath string) *changelog {
+	log := &changelog{
+		Breaking:  []change{},
+		Dangerous: []change{},
+		Safe:      []change{},
+	}
+
+	for _, c := range schema.FindBreakingChanges(oldSchema, newSchema) {
+		path := newPath
+		switch c.Type {
+		case schema.BreakingChangeTypeRemoved,
+			schema.BreakingChangeFieldRemoved,
+			schema.BreakingChangeArgRemoved,
+			schema.BreakingChangeValueRemovedFromEnum:
+			path = oldPath
+		}
+
+		log.Breaking = append(log.Breaking, change{
+			Type:        string(c.Type),
+			Description: c.Description,
+			Coordinate:  c.Coordinate,
+			Location:    location(path, c.Locations),
+		})
+	}
+
+	for _, c := range schema.FindDangerousChanges(oldSchema, newSchema) {
+		log.Dangerous = append(log.Dangerous, change{
+			Type:        string(c.Type),
+			Description: c.Description,
+			Coordinate:  c.Coordinate,
+			Location:    location(newPath, c.Locations),
+		})
+	}
+
+	for _, c := range schema.FindSafeChanges(oldSchema, newSchema) {
+		log.Safe = append(log.Safe, change{
+			Type:        string(c.Type),
+			Description: c.Description,
+			Coordinate:  c.Coordinate,
+			Location:    location(newPath, c.Locations),
+		})
+	}
+
+	return log
+}
+
+func location(path string, locations []schema.SourceLocation) string {
+	if len(locations) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("%s:%d:%d", path, locations[0].Line, locations[0].Column)
+}
+
+// allowlistEntry approves the breaking changes to a coordinate, of any type
+// if Type is empty.
+type allowlistEntry struct {
+	Type       string
+	Coordinate string
+}
+
+func readAllowlist(path string) ([]allowlistEntry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var entries []allowlistEntry
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		switch words := strings.Fields(text); len(words) {
+		case 1:
+			entries = append(entries, allowlistEntry{Coordinate: words[0]})
+		case 2:
+			entries = append(entries, allowlistEntry{Type: words[0], Coordinate: words[1]})
+		default:
+			return nil, fmt.Errorf("%s:%d: expected a coordinate, optionally preceded by a change type", path, line)
+		}
+	}
+
+	return entries, scanner.Err()
+}
+
+func approves(allowlist []allowlistEntry, c change) bool {
+	for _, entry := range allowlist {
+		if entry.Coordinate == c.Coordinate && (entry.Type == "" || entry.Type == c.Type) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func printTextChangelog(w io.Writer, log *changelog) error {
+	if len(log.Breaking)+len(log.Dangerous)+len(log.Safe) == 0 {
+		_, err := fmt.Fprintln(w, "No changes.")
+		return err
+	}
+
+	b := bufio.NewWriter(w)
+	sections := []struct {
+		title   string
+		changes []change
+	}{
+		{"Breaking changes", log.Breaking},
+		{"Dangerous changes", log.Dangerous},
+		{"Safe changes", log.Safe},
+	}
+	for _, section := range sections {
+		if len(section.changes) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(b, "%s:\n", section.title)
+		for _, c := range section.changes {
+			fmt.Fprintf(b, "  - %s [%s]", c.Description, c.Type)
+			if c.Location != "" {
+				fmt.Fprintf(b, " %s", c.Location)
+			}
+			if c.Approved {
+				fmt.Fprint(b, " (approved)")
+			}
+			fmt.Fprintln(b)
+		}
+		fmt.Fprintln(b)
+	}
+
+	fmt.Fprintf(b, "%d breaking (%d approved), %d dangerous, %d safe changes.\n",
+		len(log.Breaking), len(log.Breaking)-log.unapproved(), len(log.Dangerous), len(log.Safe))
+
+	return b.Flush()
+}
+
+func printMarkdownChangelog(w io.Writer, log *changelog) error {
+	if len(log.Breaking)+len(log.Dangerous)+len(log.Safe) == 0 {
+		_, err := fmt.Fprintln(w, "No changes.")
+		return err
+	}
+
+	b := bufio.NewWriter(w)
+	sections := []struct {
+		title   string
+		changes []change
+	}{
+		{"Breaking changes", log.Breaking},
+		{"Dangerous changes", log.Dangerous},
+		{"Safe changes", log.Safe},
+	}
+	first := true
+	for _, section := range sections {
+		if len(section.changes) == 0 {
+			continue
+		}
+		if !first {
+			fmt.Fprintln(b)
+		}
+		first = false
+
+		fmt.Fprintf(b, "## %s\n\n", section.title)
+		for _, c := range section.changes {
+			fmt.Fprintf(b, "- %s `%s`", c.Description, c.Type)
+			if c.Location != "" {
+				fmt.Fprintf(b, " (%s)", c.Location)
+			}
+			if c.Approved {
+				fmt.Fprint(b, " **approved**")
+			}
+			fmt.Fprintln(b)
+		}
+	}
+
+	return b.Flush()
+}
+
+func printJSONChangelog(w io.Writer, log *changelog) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(log)
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const oldDiffSDL = `type Query {
+  pet(id: ID!): Pet
+  pets(first: Int = 10): [Pet]
+  removed: String
+}
+
+type Pet {
+  name: String
+}
+
+enum Kind { DOG CAT }
+`
+
+const newDiffSDL = `type Query {
+  pet(id: ID!): Pet
+  pets(first: Int = 20): [Pet]
+  kinds: [Kind] @deprecated
+}
+
+type Pet {
+  name: String
+  age: Int
+}
+
+enum Kind { DOG CAT BIRD }
+
+type Owner { name: String }
+`
+
+const diffIntrospection = `{
+  "__schema": {
+    "queryType": { "name": "Query" },
+    "types": [
+      {
+        "kind": "OBJECT",
+        "name": "Query",
+        "fields": [
+          {
+            "name": "a",
+            "args": [],
+            "type": { "kind": "SCALAR", "name": "String", "ofType": null },
+            "isDeprecated": false
+          }
+        ],
+        "interfaces": []
+      }
+    ]
+  }
+}`
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("WriteFile; got error %v", err)
+	}
+
+	return path
+}
+
+func tempDir(t *testing.T) string {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "goql")
+	if err != nil {
+		t.Fatalf("TempDir; got error %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	return dir
+}
+
+func runCommand(args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(args, &stdout, &stderr)
+
+	return code, stdout.String(), stderr.String()
+}
+
+func TestSchemaDiff(t *testing.T) {
+	dir := tempDir(t)
+	oldPath := writeFile(t, dir, "old.graphql", oldDiffSDL)
+	newPath := writeFile(t, dir, "new.graphql", newDiffSDL)
+
+	code, stdout, stderr := runCommand("schema", "diff", oldPath, newPath)
+	if code != exitBreaking {
+		t.Fatalf("exit code; got %d wanted %d (stderr %s)", code, exitBreaking, stderr)
+	}
+
+	want := `Breaking changes:
+  - Query.removed was removed. [FIELD_REMOVED] ` + oldPath + `:4:3
+
+Dangerous changes:
+  - BIRD was added to enum type Kind. [VALUE_ADDED_TO_ENUM] ` + newPath + `:12:21
+  - Query.pets arg first has changed defaultValue [ARG_DEFAULT_VALUE_CHANGE] ` + newPath + `:3:8
+
+Safe changes:
+  - Owner was added. [TYPE_ADDED] ` + newPath + `:14:1
+  - Pet.age was added. [FIELD_ADDED] ` + newPath + `:9:3
+  - Query.kinds was added. [FIELD_ADDED] ` + newPath + `:4:3
+
+1 breaking (0 approved), 2 dangerous, 3 safe changes.
+`
+	if stdout != want {
+		t.Errorf("output; got\n%s\nwanted\n%s", stdout, want)
+	}
+}
+
+func TestSchemaDiffAllowlist(t *testing.T) {
+	dir := tempDir(t)
+	oldPath := writeFile(t, dir, "old.graphql", oldDiffSDL)
+	newPath := writeFile(t, dir, "new.graphql", newDiffSDL)
+
+	for _, test := range [][]string{
+		[]string{"Query.removed", "0"},
+		[]string{"# Approved.\n\nFIELD_REMOVED Query.removed\n", "0"},
+		[]string{"TYPE_REMOVED Query.removed", "1"},
+		[]string{"Query.pets", "1"},
+	} {
+		allowlist := writeFile(t, dir, "allowlist", test[0])
+
+		code, stdout, _ := runCommand("schema", "diff", "-allowlist", allowlist, oldPath, newPath)
+		if want := map[string]int{"0": exitOK, "1": exitBreaking}[test[1]]; code != want {
+			t.Errorf("allowlist %q; got exit code %d wanted %d", test[0], code, want)
+		}
+		if approved := strings.Contains(stdout, "(approved)"); approved != (test[1] == "0") {
+			t.Errorf("allowlist %q; got\n%s", test[0], stdout)
+		}
+	}
+
+	allowlist := writeFile(t, dir, "allowlist", "FIELD_REMOVED Query.removed extra")
+	if code, _, stderr := runCommand("schema", "diff", "-allowlist", allowlist, oldPath, newPath); code != exitError || !strings.Contains(stderr, "allowlist:1:") {
+		t.Errorf("invalid allowlist; got exit code %d and %q", code, stderr)
+	}
+}
+
+func TestSchemaDiffFormats(t *testing.T) {
+	dir := tempDir(t)
+	oldPath := writeFile(t, dir, "old.graphql", oldDiffSDL)
+	newPath := writeFile(t, dir, "new.graphql", newDiffSDL)
+
+	_, stdout, _ := runCommand("schema", "diff", "-format", "markdown", oldPath, newPath)
+	if !strings.HasPrefix(stdout, "## Breaking changes\n\n- Query.removed was removed. `FIELD_REMOVED` ("+oldPath+":4:3)\n\n## Dangerous changes\n") {
+		t.Errorf("markdown; got\n%s", stdout)
+	}
+
+	_, stdout, _ = runCommand("schema", "diff", "-format", "json", oldPath, newPath)
+	var log changelog
+	if err := json.Unmarshal([]byte(stdout), &log); err != nil {
+		t.Fatalf("json; got error %v in\n%s", err, stdout)
+	}
+	if len(log.Breaking) != 1 || len(log.Dangerous) != 2 || len(log.Safe) != 3 {
+		t.Errorf("json; got %+v", log)
+	}
+	if c := log.Breaking[0]; c.Type != "FIELD_REMOVED" || c.Coordinate != "Query.removed" || c.Location != oldPath+":4:3" {
+		t.Errorf("json breaking change; got %+v", c)
+	}
+
+	code, stdout, _ := runCommand("schema", "diff", "-format", "json", oldPath, oldPath)
+	if code != exitOK || stdout != "{\n  \"breaking\": [],\n  \"dangerous\": [],\n  \"safe\": []\n}\n" {
+		t.Errorf("json without changes; got exit code %d and\n%s", code, stdout)
+	}
+
+	if code, stdout, _ := runCommand("schema", "diff", oldPath, oldPath); code != exitOK || stdout != "No changes.\n" {
+		t.Errorf("text without changes; got exit code %d and\n%s", code, stdout)
+	}
+
+	if code, _, stderr := runCommand("schema", "diff", "-format", "yaml", oldPath, newPath); code != exitError || !strings.Contains(stderr, `unknown format "yaml"`) {
+		t.Errorf("unknown format; got exit code %d and %q", code, stderr)
+	}
+}
+
+func TestSchemaDiffIntrospection(t *testing.T) {
+	dir := tempDir(t)
+	oldPath := writeFile(t, dir, "old.json", diffIntrospection)
+	newPath := writeFile(t, dir, "new.graphql", "type Query { b: String }")
+
+	code, stdout, stderr := runCommand("schema", "diff", oldPath, newPath)
+	if code != exitBreaking {
+		t.Fatalf("exit code; got %d wanted %d (stderr %s)", code, exitBreaking, stderr)
+	}
+	// Schemas read from introspection results have no locations.
+	if !strings.Contains(stdout, "  - Query.a was removed. [FIELD_REMOVED]\n") ||
+		!strings.Contains(stdout, "  - Query.b was added. [FIELD_ADDED] "+newPath+":1:14\n") {
+		t.Errorf("output; got\n%s", stdout)
+	}
+}
+
+func TestSchemaDiffErrors(t *testing.T) {
+	dir := tempDir(t)
+	validPath := writeFile(t, dir, "valid.graphql", oldDiffSDL)
+	invalidPath := writeFile(t, dir, "invalid.graphql", "type Query {")
+
+	for _, args := range [][]string{
+		[]string{"schema", "diff"},
+		[]string{"schema", "diff", validPath},
+		[]string{"schema", "diff", validPath, invalidPath},
+		[]string{"schema", "diff", validPath, filepath.Join(dir, "missing.graphql")},
+		[]string{"schema", "diff", "-allowlist", filepath.Join(dir, "missing"), validPath, validPath},
+		[]string{"schema", "diff", "-unknown", validPath, validPath},
+		[]string{"schema"},
+		[]string{"unknown"},
+	} {
+		if code, _, stderr := runCommand(args...); code != exitError || stderr == "" {
+			t.Errorf("%v; got exit code %d and %q wanted %d and an error", args, code, stderr, exitError)
+		}
+	}
+}
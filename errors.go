@@ -0,0 +1,100 @@
+package goql
+
+import "github.com/ijsnow/goql/internal/errors"
+
+// Error describes an error found during the parse, validate, or execute
+// phases of performing a GraphQL operation.
+type Error = errors.GraphQLError
+
+// ErrorCode is a machine-readable classification of an Error, exposed to
+// clients as extensions.code.
+type ErrorCode = errors.Code
+
+// The codes an Error may carry.
+const (
+	CodeParseFailed         = errors.CodeParseFailed
+	CodeValidationFailed    = errors.CodeValidationFailed
+	CodeBadUserInput        = errors.CodeBadUserInput
+	CodeUnauthenticated     = errors.CodeUnauthenticated
+	CodeForbidden           = errors.CodeForbidden
+	CodeInternalServerError = errors.CodeInternalServerError
+)
+
+// Sentinels for the phase that produced an Error. Use errors.Is to test an
+// error against them.
+var (
+	ErrSyntax     = errors.ErrSyntax
+	ErrValidation = errors.ErrValidation
+	ErrExecution  = errors.ErrExecution
+)
+
+// WithCode annotates err with the given code so that, when returned from a
+// resolver, the resulting Error reports it in its extensions.
+func WithCode(err error, code ErrorCode) error {
+	return errors.WithCode(err, code)
+}
+
+// WithStack annotates err with the current call stack. When returned from a
+// resolver, the resulting Error reports that stack rather than the one at
+// which the error was located.
+func WithStack(err error) error {
+	return errors.WithStack(err)
+}
+
+// FormattedError is an Error formatted for the response.
+type FormattedError = errors.GraphQLFormattedError
+
+// FormatOptions options to control how errors are formatted.
+type FormatOptions = errors.FormatOptions
+
+// FormatError formats an Error according to the Response Format, Errors
+// section of the GraphQL Specification.
+func FormatError(err *Error, options ...FormatOptions) (*FormattedError, error) {
+	return errors.NewFormatError(err, options...)
+}
+
+// PrintOptions options to control how PrintError renders an error.
+type PrintOptions = errors.PrintOptions
+
+// PrintError renders an error for terminals and test output, showing every
+// location of an Error with the surrounding lines of its source.
+func PrintError(err error, options ...PrintOptions) string {
+	return errors.PrintError(err, options...)
+}
+
+// Presenter decides what clients see of an Error; set it on FormatOptions.
+type Presenter = errors.Presenter
+
+// MaskOptions configures NewMaskingPresenter.
+type MaskOptions = errors.MaskOptions
+
+// NewMaskingPresenter returns a Presenter that replaces the message of every
+// Error not marked safe with a generic one and a correlation ID, handing the
+// full Error to the configured log hook.
+func NewMaskingPresenter(options MaskOptions) Presenter {
+	return errors.NewMaskingPresenter(options)
+}
+
+// MarkSafe marks err as safe to show to clients when errors are masked.
+func MarkSafe(err error) error {
+	return errors.MarkSafe(err)
+}
+
+// MessageID identifies a message in the catalog of error messages.
+type MessageID = errors.MessageID
+
+// DefaultLocale is the locale of the built-in messages.
+const DefaultLocale = errors.DefaultLocale
+
+// RegisterTranslations adds templates for a locale to the catalog of error
+// messages. Placeholders are written as {name}; see DefaultMessages for the
+// built-in templates and the placeholders each one uses.
+func RegisterTranslations(locale string, templates map[MessageID]string) {
+	errors.RegisterTranslations(locale, templates)
+}
+
+// DefaultMessages returns the built-in templates of the catalog of error
+// messages keyed by ID.
+func DefaultMessages() map[MessageID]string {
+	return errors.DefaultMessages()
+}
@@ -1,8 +1,13 @@
 package goql
 
-import "github.com/ijsnow/goql-js/schema"
+import "github.com/ijsnow/goql/schema"
+
+// Do executes the given query. The schema is validated first, and an invalid
+// schema is reported as a *schema.InvalidSchemaError.
+func Do(s *schema.Schema) (interface{}, error) {
+	if err := schema.AssertValidSchema(s); err != nil {
+		return nil, err
+	}
 
-// Do executes the given query
-func Do(schema schema.Schema) (interface{}, error) {
 	return nil, nil
 }
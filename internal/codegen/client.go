@@ -0,0 +1,574 @@
+package codegen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ijsnow/goql/internal/language"
+	"github.com/ijsnow/goql/schema"
+)
+
+const clientPackage = "github.com/ijsnow/goql/client"
+
+// GenerateClient validates operations against a schema and generates the
+// client code of the operations:
+//
+//   - A struct for the variables of every operation, and for every
+//     InputObject type they use. Nullable values are omitted when nil.
+//   - A struct for the data of the response of every operation, and for
+//     every selection of its fields. The fields are tagged with their keys
+//     in the response.
+//   - A struct for every fragment, which is embedded in the structs of the
+//     selections spreading it.
+//   - For the selections on types other than their parent type, by inline
+//     fragments or by spreading fragments, a pointer to their struct which
+//     is set when the __typename of the value is one of those types.
+//   - A named string type for every Enum type used, with a constant per
+//     value.
+//   - A function sending each operation with a client.Client.
+//
+// The operations are returned as an *InvalidOperationsError if they are not
+// valid, or if the generated code could not decode their responses.
+func GenerateClient(config *Config, s *schema.Schema, documents []*schema.DocumentNode) ([]byte, error) {
+	set := newOperationSet(documents)
+	if errs := validateOperations(s, set); len(errs) != 0 {
+		return nil, &InvalidOperationsError{Errors: errs}
+	}
+
+	g := &clientGenerator{
+		generator: newGenerator(config, s),
+		set:       set,
+		declared:  map[string]bool{},
+		enums:     map[string]*schema.Enum{},
+		inputs:    map[string]*schema.InputObject{},
+	}
+
+	var fragments []*selectionStruct
+	for _, name := range set.fragmentNames {
+		fragment := set.fragments[name]
+		doc := fmt.Sprintf("holds the selections of the fragment %s.", name)
+		st := g.newStruct(goName(name), doc, goName(name), s.GetType(fragment.TypeCondition.Name.Value), fragment)
+		g.collect(st, fragment.SelectionSet, false)
+		fragments = append(fragments, st)
+	}
+
+	var operations []*clientOperation
+	for _, node := range set.operations {
+		operations = append(operations, g.newOperation(node))
+	}
+
+	if len(g.errors) != 0 {
+		return nil, &InvalidOperationsError{Errors: g.errors}
+	}
+
+	g.writeTypes()
+	for _, st := range fragments {
+		g.writeStruct(st)
+	}
+	for _, operation := range operations {
+		g.writeOperation(operation)
+	}
+
+	return g.source(config.Client.Package)
+}
+
+type clientGenerator struct {
+	*generator
+	set    *operationSet
+	errors []error
+
+	// The names of the generated types, and the Enum and InputObject types
+	// used by the operations.
+	declared map[string]bool
+	enums    map[string]*schema.Enum
+	inputs   map[string]*schema.InputObject
+}
+
+// selectionStruct is the Go struct of the selections on a type, by an
+// operation, a fragment, a field or an inline fragment.
+type selectionStruct struct {
+	name   string
+	doc    string
+	parent schema.NamedType
+
+	// prefix starts the names of the structs of the nested selections.
+	prefix string
+
+	fields    []*selectedField
+	fragments []string
+	variants  []*selectionVariant
+
+	// The Go names of the fields, fragments and variants, for reporting
+	// those which would be the same.
+	goNames map[string]string
+}
+
+// selectedField is a field of the response, selected once or more.
+type selectedField struct {
+	key   string
+	field *schema.Field
+	t     schema.Type
+
+	// optional is set for fields which are all skipped or included by a
+	// directive, and which may be missing from the response.
+	optional bool
+
+	sub *selectionStruct
+}
+
+// selectionVariant holds the selections on a type other than the parent
+// type, which apply to some of its values only.
+type selectionVariant struct {
+	field     string
+	condition schema.NamedType
+	goType    string
+	sub       *selectionStruct
+}
+
+// clientOperation is an operation and the Go names of its code.
+type clientOperation struct {
+	node      *language.OperationDefinitionNode
+	name      string
+	variables []*language.VariableDefinitionNode
+	response  *selectionStruct
+}
+
+// declare reserves the name of a generated type or function.
+func (g *clientGenerator) declare(name string, node language.ASTNode) {
+	if g.declared[name] {
+		g.errors = append(g.errors, newError(fmt.Sprintf("The generated name %s is already taken by another operation, fragment or type.", name), node))
+	}
+	g.declared[name] = true
+}
+
+func (g *clientGenerator) newStruct(name, doc, prefix string, parent schema.NamedType, node language.ASTNode) *selectionStruct {
+	g.declare(name, node)
+
+	return &selectionStruct{
+		name:    name,
+		doc:     doc,
+		prefix:  prefix,
+		parent:  parent,
+		goNames: map[string]string{},
+	}
+}
+
+// addGoName reserves the Go name of a member of a struct.
+func (g *clientGenerator) addGoName(st *selectionStruct, goName, member string, node language.ASTNode) {
+	if other, ok := st.goNames[goName]; ok && other != member {
+		g.errors = append(g.errors, newError(fmt.Sprintf("The selections %s and %s have the same Go name %s in %s.", other, member, goName, st.name), node))
+	}
+	st.goNames[goName] = member
+}
+
+func (g *clientGenerator) newOperation(node *language.OperationDefinitionNode) *clientOperation {
+	name := goName(node.Name.Value)
+	operation := &clientOperation{node: node, name: name}
+
+	g.declare(name, node)
+	g.declare(name+"Operation", node)
+	if node.VariableDefinitions != nil && len(*node.VariableDefinitions) != 0 {
+		g.declare(name+"Variables", node)
+		for idx := range *node.VariableDefinitions {
+			definition := &(*node.VariableDefinitions)[idx]
+			operation.variables = append(operation.variables, definition)
+			g.useType(schema.GetNamedType(typeFromAST(g.schema, definition.Type)), definition)
+		}
+	}
+
+	root := g.schema.GetQueryType()
+	switch node.Operation {
+	case language.OperationTypeMutation:
+		root = g.schema.GetMutationType()
+	case language.OperationTypeSubscription:
+		root = g.schema.GetSubscriptionType()
+	}
+	doc := fmt.Sprintf("is the data of the response to %s.", node.Name.Value)
+	operation.response = g.newStruct(name+"Response", doc, name, root, node)
+	g.collect(operation.response, node.SelectionSet, false)
+
+	return operation
+}
+
+// useType records an Enum or InputObject type used by the operations, and
+// the types of the fields of an InputObject type.
+func (g *clientGenerator) useType(t schema.NamedType, node language.ASTNode) {
+	if _, ok := g.config.Models[t.GetName()]; ok {
+		return
+	}
+
+	switch t := t.(type) {
+	case *schema.Enum:
+		if _, ok := g.enums[t.Name]; !ok {
+			g.enums[t.Name] = t
+			g.declare(goName(t.Name), node)
+		}
+	case *schema.InputObject:
+		if _, ok := g.inputs[t.Name]; !ok {
+			g.inputs[t.Name] = t
+			g.declare(goName(t.Name), node)
+			for _, field := range t.GetFields() {
+				g.useType(schema.GetNamedType(field.Type), node)
+			}
+		}
+	}
+}
+
+// collect adds the selections of a selection set to a struct. The fields
+// are optional if the selections are skipped or included by a directive.
+func (g *clientGenerator) collect(st *selectionStruct, set language.SelectionSetNode, optional bool) {
+	for _, selection := range set.Selections {
+		switch selection := selection.(type) {
+		case *language.FieldNode:
+			g.collectField(st, selection, optional || hasCondition(selection.Directives))
+
+		case *language.InlineFragmentNode:
+			condition := st.parent
+			if selection.TypeCondition != nil {
+				condition = g.schema.GetType(selection.TypeCondition.Name.Value)
+			}
+			if appliesTo(g.schema, st.parent, condition) {
+				g.collect(st, *selection.SelectionSet, optional || hasCondition(selection.Directives))
+				continue
+			}
+
+			field := "On" + goName(condition.GetName())
+			variant := st.variant(field)
+			if variant == nil {
+				g.addGoName(st, field, "... on "+condition.GetName(), selection)
+				doc := fmt.Sprintf("holds the selections on %s of %s.", condition.GetName(), st.name)
+				sub := g.newStruct(st.prefix+field, doc, st.prefix+field, condition, selection)
+				variant = &selectionVariant{field: field, condition: condition, goType: sub.name, sub: sub}
+				st.variants = append(st.variants, variant)
+			}
+			g.collect(variant.sub, *selection.SelectionSet, false)
+
+		case *language.FragmentSpreadNode:
+			fragment := g.set.fragments[selection.Name.Value]
+			condition := g.schema.GetType(fragment.TypeCondition.Name.Value)
+			name := goName(selection.Name.Value)
+
+			g.addGoName(st, name, "..."+selection.Name.Value, selection)
+			if appliesTo(g.schema, st.parent, condition) {
+				if !contains(st.fragments, name) {
+					st.fragments = append(st.fragments, name)
+				}
+			} else if st.variant(name) == nil {
+				st.variants = append(st.variants, &selectionVariant{field: name, condition: condition, goType: name})
+			}
+		}
+	}
+}
+
+func (g *clientGenerator) collectField(st *selectionStruct, node *language.FieldNode, optional bool) {
+	key := responseKey(node)
+
+	f := st.field(key)
+	if f == nil {
+		f = &selectedField{key: key, optional: optional}
+		if node.Name.Value == "__typename" {
+			f.t = schema.NewNonNull(g.schema.GetType("String"))
+		} else {
+			f.field = fieldOf(st.parent, node.Name.Value)
+			f.t = f.field.Type
+		}
+		g.addGoName(st, goName(key), key, node)
+
+		named := schema.GetNamedType(f.t)
+		if schema.IsCompositeType(named) {
+			doc := fmt.Sprintf("holds the selections of %s in %s.", key, st.name)
+			f.sub = g.newStruct(st.prefix+goName(key), doc, st.prefix+goName(key), named, node)
+		} else {
+			g.useType(named, node)
+		}
+		st.fields = append(st.fields, f)
+	} else if !optional {
+		f.optional = false
+	}
+
+	if f.sub != nil && node.SelectionSet != nil {
+		g.collect(f.sub, *node.SelectionSet, false)
+	}
+}
+
+func (st *selectionStruct) field(key string) *selectedField {
+	for _, f := range st.fields {
+		if f.key == key {
+			return f
+		}
+	}
+
+	return nil
+}
+
+func (st *selectionStruct) variant(field string) *selectionVariant {
+	for _, variant := range st.variants {
+		if variant.field == field {
+			return variant
+		}
+	}
+
+	return nil
+}
+
+// hasCondition reports whether directives skip or include a selection.
+func hasCondition(directives *[]language.DirectiveNode) bool {
+	if directives == nil {
+		return false
+	}
+
+	for _, directive := range *directives {
+		if directive.Name.Value == "skip" || directive.Name.Value == "include" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func contains(names []string, name string) bool {
+	for _, candidate := range names {
+		if candidate == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// writeTypes writes the Enum and InputObject types used by the operations.
+func (g *clientGenerator) writeTypes() {
+	var names []string
+	for name := range g.enums {
+		names = append(names, name)
+	}
+	for name := range g.inputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if enum, ok := g.enums[name]; ok {
+			g.printf("%s", comment("", enum.Description, ""))
+			g.writeEnum(enum)
+			continue
+		}
+
+		input := g.inputs[name]
+		g.printf("%s", comment("", input.Description, ""))
+		g.printf("type %s struct {\n", goName(input.Name))
+		for _, field := range input.GetFields() {
+			g.printf("%s", comment("\t", field.Description, ""))
+			g.writeInputField(field.Name, field.Type)
+		}
+		g.printf("}\n\n")
+	}
+}
+
+// writeInputField writes a field of the variables, which is omitted from
+// them when nullable and nil.
+func (g *clientGenerator) writeInputField(name string, t schema.Type) {
+	tag := name
+	if _, nonNull := t.(*schema.NonNull); !nonNull {
+		tag += ",omitempty"
+	}
+
+	g.printf("\t%s %s `json:%q`\n", goName(name), g.goType(t), tag)
+}
+
+// selectionType returns the Go type of a selected field. Values which may
+// be null, or missing, are pointers unless they may be nil already.
+func (g *clientGenerator) selectionType(f *selectedField) string {
+	base := "string"
+	if f.sub != nil {
+		base = f.sub.name
+	} else if f.field != nil {
+		base = g.namedGoType(schema.GetNamedType(f.t))
+	}
+
+	t := f.t
+	if f.optional {
+		t = schema.GetNullableType(t)
+	}
+
+	return wrapGoType(t, base)
+}
+
+func wrapGoType(t schema.Type, base string) string {
+	wrapper, nonNull := t.(*schema.NonNull)
+	if nonNull {
+		t = wrapper.OfType
+	}
+
+	if list, ok := t.(*schema.List); ok {
+		return "[]" + wrapGoType(list.OfType, base)
+	}
+	if !nonNull && base != "interface{}" {
+		return "*" + base
+	}
+
+	return base
+}
+
+func (g *clientGenerator) writeStruct(st *selectionStruct) {
+	g.printf("// %s %s\n", st.name, st.doc)
+	g.printf("type %s struct {\n", st.name)
+	for _, f := range st.fields {
+		if f.field != nil {
+			g.printf("%s", comment("\t", f.field.Description, f.field.DeprecationReason))
+		}
+		g.printf("\t%s %s `json:%q`\n", goName(f.key), g.selectionType(f), f.key)
+	}
+	for _, fragment := range st.fragments {
+		g.printf("\t%s `json:\"-\"`\n", fragment)
+	}
+	for _, variant := range st.variants {
+		g.printf("\n\t// %s is set when the value is of type %s.\n", variant.field, variant.condition.GetName())
+		g.printf("\t%s *%s `json:\"-\"`\n", variant.field, variant.goType)
+	}
+	g.printf("}\n\n")
+
+	if len(st.fragments) != 0 || len(st.variants) != 0 {
+		g.writeUnmarshal(st)
+	}
+
+	for _, f := range st.fields {
+		if f.sub != nil {
+			g.writeStruct(f.sub)
+		}
+	}
+	for _, variant := range st.variants {
+		if variant.sub != nil {
+			g.writeStruct(variant.sub)
+		}
+	}
+}
+
+// writeUnmarshal writes the method decoding a struct holding fragments or
+// variants, which are decoded from the same object as its fields.
+func (g *clientGenerator) writeUnmarshal(st *selectionStruct) {
+	json := g.use("encoding/json")
+
+	g.printf("// UnmarshalJSON decodes the fields, fragments and variants of %s.\n", st.name)
+	g.printf("func (v *%s) UnmarshalJSON(data []byte) error {\n", st.name)
+
+	// The fields are decoded apart, as the methods of the fragments would
+	// otherwise decode the whole struct.
+	g.printf("var fields struct {\n")
+	for _, f := range st.fields {
+		g.printf("%s %s `json:%q`\n", goName(f.key), g.selectionType(f), f.key)
+	}
+	g.printf("}\n")
+	g.printf("if err := %s.Unmarshal(data, &fields); err != nil {\nreturn err\n}\n", json)
+	for _, f := range st.fields {
+		g.printf("v.%s = fields.%s\n", goName(f.key), goName(f.key))
+	}
+
+	for _, fragment := range st.fragments {
+		g.printf("if err := %s.Unmarshal(data, &v.%s); err != nil {\nreturn err\n}\n", json, fragment)
+	}
+
+	// The __typename is decoded again if it is not one of the fields, or if
+	// it may be missing.
+	typename := "fields.Typename"
+	if f := st.field("__typename"); len(st.variants) != 0 && (f == nil || f.field != nil || f.optional) {
+		typename = "typename.Typename"
+		g.printf("\nvar typename struct {\nTypename string `json:\"__typename\"`\n}\n")
+		g.printf("if err := %s.Unmarshal(data, &typename); err != nil {\nreturn err\n}\n", json)
+	}
+	for _, variant := range st.variants {
+		var names []string
+		for _, object := range g.possibleTypes(variant.condition) {
+			if appliesTo(g.schema, object, st.parent) {
+				names = append(names, fmt.Sprintf("%q", object.Name))
+			}
+		}
+
+		g.printf("\nswitch %s {\n", typename)
+		g.printf("case %s:\n", strings.Join(names, ", "))
+		g.printf("v.%s = &%s{}\n", variant.field, variant.goType)
+		g.printf("if err := %s.Unmarshal(data, v.%s); err != nil {\nreturn err\n}\n", json, variant.field)
+		g.printf("}\n")
+	}
+
+	g.printf("\nreturn nil\n}\n\n")
+}
+
+// possibleTypes returns the Object types of the values of a composite type.
+func (g *clientGenerator) possibleTypes(t schema.NamedType) []*schema.Object {
+	if abstract, ok := t.(schema.AbstractType); ok {
+		return g.schema.PossibleTypes(abstract)
+	}
+
+	return []*schema.Object{t.(*schema.Object)}
+}
+
+func (g *clientGenerator) writeOperation(operation *clientOperation) {
+	ctx := g.use("context")
+	pkg := g.use(clientPackage)
+	name := operation.name
+
+	if len(operation.variables) != 0 {
+		g.printf("// %sVariables are the variables of %s.\n", name, name)
+		g.printf("type %sVariables struct {\n", name)
+		for _, definition := range operation.variables {
+			g.writeInputField(definition.Variable.Name.Value, typeFromAST(g.schema, definition.Type))
+		}
+		g.printf("}\n\n")
+	}
+
+	g.writeStruct(operation.response)
+
+	g.printf("// %sOperation is the document sent by %s, holding the operation and the\n// fragments it spreads.\n", name, name)
+	g.printf("const %sOperation = %s\n\n", name, stringLiteral(g.document(operation.node)))
+
+	g.printf("// %s sends the %s %s. The data of the response is returned\n// along with its errors, as it may be partial.\n", name, operation.node.Operation, operation.node.Name.Value)
+	g.printf("func %s(ctx %s.Context, c *%s.Client", name, ctx, pkg)
+	variables := "nil"
+	if len(operation.variables) != 0 {
+		g.printf(", variables %sVariables", name)
+		variables = "variables"
+	}
+	g.printf(") (*%s, error) {\n", operation.response.name)
+	g.printf("var data %s\n", operation.response.name)
+	g.printf("err := c.Do(ctx, %sOperation, %s, &data)\n\n", name, variables)
+	g.printf("return &data, err\n}\n\n")
+}
+
+// document returns the source of an operation, followed by the sources of
+// the fragments it spreads, directly or not.
+func (g *clientGenerator) document(operation *language.OperationDefinitionNode) string {
+	sources := []string{nodeSource(operation)}
+
+	spread := map[string]bool{}
+	var spreadFragments func(set language.SelectionSetNode)
+	spreadFragments = func(set language.SelectionSetNode) {
+		for _, selection := range set.Selections {
+			switch selection := selection.(type) {
+			case *language.FieldNode:
+				if selection.SelectionSet != nil {
+					spreadFragments(*selection.SelectionSet)
+				}
+			case *language.InlineFragmentNode:
+				spreadFragments(*selection.SelectionSet)
+			case *language.FragmentSpreadNode:
+				if !spread[selection.Name.Value] {
+					spread[selection.Name.Value] = true
+					fragment := g.set.fragments[selection.Name.Value]
+					sources = append(sources, nodeSource(fragment))
+					spreadFragments(fragment.SelectionSet)
+				}
+			}
+		}
+	}
+	spreadFragments(operation.SelectionSet)
+
+	return strings.Join(sources, "\n\n") + "\n"
+}
+
+// nodeSource returns the text of a node in its source.
+func nodeSource(node language.ASTNode) string {
+	loc := node.GetLoc()
+	return loc.Source.Body[loc.Start:loc.End]
+}
@@ -0,0 +1,140 @@
+package codegen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ijsnow/goql/schema"
+)
+
+const clientOperations = `query GetPet($id: ID!, $withOwner: Boolean = false) {
+  pet(id: $id) {
+    __typename
+    ...PetFields
+    ... on Dog { kind friends(first: 2) { name } }
+    ... on Cat { owner @include(if: $withOwner) { name } }
+  }
+}
+
+fragment PetFields on Pet {
+  id
+  name
+}
+
+mutation Adopt($input: AdoptInput!) {
+  adopt(input: $input) { id nickname }
+}
+`
+
+func generateClient(t *testing.T, models map[string]string, sources ...string) (string, error) {
+	t.Helper()
+
+	s, err := schema.BuildFromSDL(serverSDL)
+	if err != nil {
+		t.Fatalf("BuildFromSDL; got error %v", err)
+	}
+
+	config := &Config{
+		Models: models,
+		Client: &PackageConfig{Output: "api/generated.go", Package: "api"},
+	}
+	source, err := GenerateClient(config, s, parseOperations(t, sources...))
+	if err != nil {
+		return "", err
+	}
+
+	typeCheck(t, source)
+
+	return string(source), nil
+}
+
+func TestGenerateClient(t *testing.T) {
+	source, err := generateClient(t, map[string]string{"DateTime": "time.Time"}, clientOperations)
+	if err != nil {
+		t.Fatalf("GenerateClient; got error %v", err)
+	}
+
+	snippets := []string{
+		"// Code generated by goql generate. DO NOT EDIT.\n\npackage api\n",
+		"import (\n\t\"context\"\n\t\"encoding/json\"\n\n\t\"github.com/ijsnow/goql/client\"\n)",
+		"type AdoptInput struct {\n\tPetID string   `json:\"petId\"`\n\tTags  []string `json:\"tags,omitempty\"`\n}",
+		"// PetFields holds the selections of the fragment PetFields.\ntype PetFields struct {\n\tID   string  `json:\"id\"`\n\tName *string `json:\"name\"`\n}",
+		"type GetPetVariables struct {\n\tID        string `json:\"id\"`\n\tWithOwner *bool  `json:\"withOwner,omitempty\"`\n}",
+		"type GetPetResponse struct {\n\tPet *GetPetPet `json:\"pet\"`\n}",
+		"\tTypename  string `json:\"__typename\"`\n\tPetFields `json:\"-\"`\n",
+		"\t// OnDog is set when the value is of type Dog.\n\tOnDog *GetPetPetOnDog `json:\"-\"`",
+		"switch fields.Typename {\n\tcase \"Cat\":\n\t\tv.OnCat = &GetPetPetOnCat{}",
+		"if err := json.Unmarshal(data, &v.PetFields); err != nil {",
+		"\tKind    Kind                     `json:\"kind\"`\n\tFriends []*GetPetPetOnDogFriends `json:\"friends\"`",
+		"Owner *GetPetPetOnCatOwner `json:\"owner\"`",
+		"KindGreatDane Kind = \"GREAT_DANE\"",
+		"const GetPetOperation = `query GetPet($id: ID!, $withOwner: Boolean = false) {",
+		"}\n\nfragment PetFields on Pet {\n  id\n  name\n}\n`",
+		"func GetPet(ctx context.Context, c *client.Client, variables GetPetVariables) (*GetPetResponse, error) {",
+		"err := c.Do(ctx, GetPetOperation, variables, &data)",
+		"\t// Deprecated: Use name.\n\tNickname *string `json:\"nickname\"`",
+		"func Adopt(ctx context.Context, c *client.Client, variables AdoptVariables) (*AdoptResponse, error) {",
+	}
+	for _, snippet := range snippets {
+		if !strings.Contains(source, snippet) {
+			t.Errorf("generated code; got\n%s\nwanted it to contain\n%s", source, snippet)
+		}
+	}
+
+	// Only the types used by the operations are generated, and the fields
+	// are decoded directly when there is no fragment or variant.
+	for _, missing := range []string{"type SearchResult", "type Owner struct", "func (v *AdoptAdopt) UnmarshalJSON"} {
+		if strings.Contains(source, missing) {
+			t.Errorf("generated code; got %q wanted none", missing)
+		}
+	}
+}
+
+func TestGenerateClientWithoutVariables(t *testing.T) {
+	source, err := generateClient(t, nil, `query Now { now }`)
+	if err != nil {
+		t.Fatalf("GenerateClient; got error %v", err)
+	}
+
+	snippets := []string{
+		"type NowResponse struct {\n\tNow interface{} `json:\"now\"`\n}",
+		"func Now(ctx context.Context, c *client.Client) (*NowResponse, error) {",
+		"err := c.Do(ctx, NowOperation, nil, &data)",
+	}
+	for _, snippet := range snippets {
+		if !strings.Contains(source, snippet) {
+			t.Errorf("generated code; got\n%s\nwanted it to contain\n%s", source, snippet)
+		}
+	}
+	if strings.Contains(source, "NowVariables") {
+		t.Errorf("generated code; got NowVariables wanted none")
+	}
+}
+
+func TestGenerateClientErrors(t *testing.T) {
+	tests := [][]string{
+		[]string{
+			`query A { pet(id: "1") { nmae } }`,
+			`operations.graphql:1:26: Cannot query field "nmae" on type "Pet". Did you mean "name"?`,
+		},
+		[]string{
+			`query Kind($kind: Kind) { pets(kind: $kind) { id } }`,
+			`operations.graphql:1:12: The generated name Kind is already taken by another operation, fragment or type.`,
+		},
+		[]string{
+			`query A { pet(id: "1") { ...APet } } fragment APet on Pet { id }`,
+			`operations.graphql:1:11: The generated name APet is already taken by another operation, fragment or type.`,
+		},
+		[]string{
+			`query A { pet(id: "1") { userId: id userID: name } }`,
+			`operations.graphql:1:37: The selections userId and userID have the same Go name UserID in APet.`,
+		},
+	}
+
+	for _, test := range tests {
+		_, err := generateClient(t, nil, test[0])
+		if _, ok := err.(*InvalidOperationsError); !ok || err.Error() != test[1] {
+			t.Errorf("%s; got error %v wanted\n%s", test[0], err, test[1])
+		}
+	}
+}
@@ -0,0 +1,238 @@
+// Package codegen generates Go code from GraphQL schemas for the goql
+// generate command. What is generated, and from which files, is described
+// by a Config.
+package codegen
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ijsnow/goql/schema"
+)
+
+// Config is the configuration of the generated code, read from a JSON or
+// YAML file such as:
+//
+//	{
+//		"schema": ["schema/*.graphql"],
+//		"models": {
+//			"DateTime": "time.Time",
+//			"User": "github.com/acme/app/models.User"
+//		},
+//		"server": {"output": "graph/generated.go"},
+//		"operations": ["operations/*.graphql"],
+//		"client": {"output": "api/generated.go"}
+//	}
+//
+// or, in YAML:
+//
+//	schema:
+//	  - schema/*.graphql
+//	models:
+//	  DateTime: time.Time
+//	  User: github.com/acme/app/models.User
+//	server:
+//	  output: graph/generated.go
+//	operations: [operations/*.graphql]
+//	client:
+//	  output: api/generated.go
+//
+// Paths are relative to the directory of the file.
+type Config struct {
+	/**
+	 * Schema lists the SDL files of the schema, as glob patterns. The files
+	 * are read in order and concatenated. The schema may instead be the
+	 * result of an introspection query, given by a single JSON file.
+	 */
+	Schema []string `json:"schema"`
+
+	/**
+	 * Models maps the names of GraphQL types to existing Go types, given by
+	 * their import path and name, which are used instead of generating
+	 * them. Custom scalars which are not mapped are interface{} values.
+	 */
+	Models map[string]string `json:"models"`
+
+	/**
+	 * Server configures the generated server code: the models of the
+	 * types, the interfaces of their resolvers and the function binding
+	 * them to the schema. It is not generated if Server is nil.
+	 */
+	Server *PackageConfig `json:"server"`
+
+	/**
+	 * Operations lists the files of the operations and fragments the
+	 * client code is generated for, as glob patterns.
+	 */
+	Operations []string `json:"operations"`
+
+	/**
+	 * Client configures the generated client code: the types of the
+	 * variables and responses of the operations, and the functions sending
+	 * them. It is not generated if Client is nil.
+	 */
+	Client *PackageConfig `json:"client"`
+
+	dir string
+}
+
+// PackageConfig configures a generated file.
+type PackageConfig struct {
+	/**
+	 * Output is the path of the generated file.
+	 */
+	Output string `json:"output"`
+
+	/**
+	 * Package is the name of the package of the generated file, which
+	 * defaults to the name of its directory.
+	 */
+	Package string `json:"package"`
+}
+
+// LoadConfig reads a Config from a JSON file, or from a YAML file when its
+// name ends in .yaml or .yml.
+func LoadConfig(path string) (*Config, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch filepath.Ext(path) {
+	case ".yaml", ".yml":
+		if data, err = yamlToJSON(data); err != nil {
+			return nil, fmt.Errorf("%s: %v", path, err)
+		}
+	}
+
+	config := &Config{}
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	config.dir = filepath.Dir(path)
+
+	if len(config.Schema) == 0 {
+		return nil, fmt.Errorf("%s: no schema files are configured", path)
+	}
+	for name, model := range config.Models {
+		if ref := parseGoRef(model); ref.name == "" || strings.HasPrefix(model, "*") {
+			return nil, fmt.Errorf("%s: model of %s must be an import path and a type name, but is %q", path, name, model)
+		}
+	}
+	if config.Server != nil {
+		if err := config.Server.check(path, "server"); err != nil {
+			return nil, err
+		}
+	}
+	if config.Client != nil {
+		if len(config.Operations) == 0 {
+			return nil, fmt.Errorf("%s: no operation files are configured for the client", path)
+		}
+		if err := config.Client.check(path, "client"); err != nil {
+			return nil, err
+		}
+	}
+
+	return config, nil
+}
+
+// check fills in the package name and reports missing settings.
+func (c *PackageConfig) check(path string, section string) error {
+	if c.Output == "" {
+		return fmt.Errorf("%s: %s.output is not set", path, section)
+	}
+
+	if c.Package == "" {
+		abs, err := filepath.Abs(filepath.Join(filepath.Dir(path), c.Output))
+		if err != nil {
+			return err
+		}
+		c.Package = strings.Replace(filepath.Base(filepath.Dir(abs)), "-", "_", -1)
+	}
+	if !token.IsIdentifier(c.Package) {
+		return fmt.Errorf("%s: %s.package %q is not a valid package name", path, section, c.Package)
+	}
+
+	return nil
+}
+
+// Path returns a path of the configuration relative to the current
+// directory.
+func (c *Config) Path(path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+
+	return filepath.Join(c.dir, path)
+}
+
+// LoadSchema reads and builds the schema, and returns it with its SDL. The
+// SDL of a schema read from an introspection result is printed from it.
+func (c *Config) LoadSchema() (*schema.Schema, string, error) {
+	files, err := c.glob(c.Schema, "schema")
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(files) == 1 && strings.HasSuffix(files[0], ".json") {
+		data, err := ioutil.ReadFile(files[0])
+		if err != nil {
+			return nil, "", err
+		}
+		s, err := schema.BuildClientSchema(data)
+		if err != nil {
+			return nil, "", fmt.Errorf("%s: %v", files[0], err)
+		}
+
+		sdl, err := schema.PrintSchema(s)
+		if err != nil {
+			return nil, "", fmt.Errorf("%s: %v", files[0], err)
+		}
+
+		return s, sdl, nil
+	}
+
+	var sources []string
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, "", err
+		}
+		sources = append(sources, strings.TrimSpace(string(data)))
+	}
+	sdl := strings.Join(sources, "\n\n") + "\n"
+
+	s, err := schema.BuildFromSDL(sdl)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := schema.AssertValidSchema(s); err != nil {
+		return nil, "", err
+	}
+
+	return s, sdl, nil
+}
+
+// glob returns the files matching the patterns, in the order of the
+// patterns. Every pattern must match a file.
+func (c *Config) glob(patterns []string, what string) ([]string, error) {
+	var files []string
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(c.Path(pattern))
+		if err != nil {
+			return nil, err
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no %s files match %s", what, pattern)
+		}
+		sort.Strings(matches)
+		files = append(files, matches...)
+	}
+
+	return files, nil
+}
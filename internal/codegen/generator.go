@@ -0,0 +1,180 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ijsnow/goql/schema"
+)
+
+// generator writes a Go file. The imports are collected while the body is
+// written, and the file is formatted by gofmt once complete.
+type generator struct {
+	config  *Config
+	schema  *schema.Schema
+	imports map[string]bool
+	body    bytes.Buffer
+}
+
+func newGenerator(config *Config, s *schema.Schema) *generator {
+	return &generator{config: config, schema: s, imports: map[string]bool{}}
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.body, format, args...)
+}
+
+// use imports a package and returns the name it is referred to by.
+func (g *generator) use(importPath string) string {
+	g.imports[importPath] = true
+	return packageName(importPath)
+}
+
+// source returns the formatted file.
+func (g *generator) source(packageName string) ([]byte, error) {
+	var file bytes.Buffer
+	file.WriteString("// Code generated by goql generate. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&file, "package %s\n\n", packageName)
+
+	if len(g.imports) != 0 {
+		paths := make([]string, 0, len(g.imports))
+		for importPath := range g.imports {
+			paths = append(paths, importPath)
+		}
+		sort.Strings(paths)
+
+		// The standard library is imported first, apart from the others.
+		sort.SliceStable(paths, func(i, j int) bool {
+			return isStandard(paths[i]) && !isStandard(paths[j])
+		})
+
+		file.WriteString("import (\n")
+		for idx, importPath := range paths {
+			if idx > 0 && isStandard(paths[idx-1]) && !isStandard(importPath) {
+				file.WriteString("\n")
+			}
+			fmt.Fprintf(&file, "\t%s\n", strconv.Quote(importPath))
+		}
+		file.WriteString(")\n\n")
+	}
+	file.Write(g.body.Bytes())
+
+	formatted, err := format.Source(file.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting the generated code: %v", err)
+	}
+
+	return formatted, nil
+}
+
+// isStandard reports whether an import path is of the standard library,
+// whose first element has no dot.
+func isStandard(importPath string) bool {
+	return !strings.Contains(strings.SplitN(importPath, "/", 2)[0], ".")
+}
+
+// namedTypes returns the types of the schema other than the introspection
+// types and the specified scalars, sorted by name.
+func (g *generator) namedTypes() []schema.NamedType {
+	var types []schema.NamedType
+	for name, t := range g.schema.GetTypeMap() {
+		if strings.HasPrefix(name, "__") || schema.IsSpecifiedScalarType(t) {
+			continue
+		}
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i].GetName() < types[j].GetName() })
+
+	return types
+}
+
+// model returns the existing Go type a GraphQL type is mapped to, if any.
+func (g *generator) model(t schema.NamedType) (string, bool) {
+	model, ok := g.config.Models[t.GetName()]
+	if !ok {
+		return "", false
+	}
+
+	ref := parseGoRef(model)
+	if ref.path == "" {
+		return ref.name, true
+	}
+
+	return g.use(ref.path) + "." + ref.name, true
+}
+
+// namedGoType returns the Go type of the values of a named type.
+func (g *generator) namedGoType(t schema.NamedType) string {
+	if model, ok := g.model(t); ok {
+		return model
+	}
+
+	switch t.GetName() {
+	case "ID", "String":
+		return "string"
+	case "Int":
+		return "int"
+	case "Float":
+		return "float64"
+	case "Boolean":
+		return "bool"
+	}
+	if _, ok := t.(*schema.Scalar); ok {
+		return "interface{}"
+	}
+
+	return goName(t.GetName())
+}
+
+// goType returns the Go type of the values of a type. Values of Object
+// types are pointers, and values of other nullable types are pointers
+// unless they may be nil already.
+func (g *generator) goType(t schema.Type) string {
+	wrapper, nonNull := t.(*schema.NonNull)
+	if nonNull {
+		t = wrapper.OfType
+	}
+
+	switch t := t.(type) {
+	case *schema.List:
+		return "[]" + g.goType(t.OfType)
+	case *schema.Object:
+		return "*" + g.namedGoType(t)
+	case *schema.Interface, *schema.Union:
+		return g.namedGoType(t.(schema.NamedType))
+	}
+
+	goType := g.namedGoType(t.(schema.NamedType))
+	if !nonNull && goType != "interface{}" {
+		return "*" + goType
+	}
+
+	return goType
+}
+
+// writeEnum writes the Go type of an Enum type, a string type with a
+// constant for each value.
+func (g *generator) writeEnum(enum *schema.Enum) {
+	name := goName(enum.Name)
+
+	g.printf("type %s string\n\n", name)
+	g.printf("const (\n")
+	for _, value := range enum.GetValues() {
+		g.printf("%s", comment("\t", value.Description, value.DeprecationReason))
+		g.printf("\t%s%s %s = %q\n", name, goName(value.Name), name, value.Name)
+	}
+	g.printf(")\n\n")
+}
+
+// stringLiteral returns a Go literal of a string, raw when possible.
+func stringLiteral(s string) string {
+	if strings.Contains(s, "`") || strings.Contains(s, "\r") {
+		return strconv.Quote(s)
+	}
+
+	return "`" + s + "`"
+}
@@ -0,0 +1,136 @@
+package codegen
+
+import (
+	"go/token"
+	"path"
+	"strings"
+	"unicode"
+)
+
+// initialisms are written in capitals in Go names, as golint suggests.
+var initialisms = map[string]bool{
+	"API": true, "CSS": true, "DNS": true, "HTML": true, "HTTP": true,
+	"HTTPS": true, "ID": true, "IP": true, "JSON": true, "SQL": true,
+	"URI": true, "URL": true, "UUID": true, "XML": true,
+}
+
+// goName returns the exported Go name of a GraphQL name: userId becomes
+// UserID and GREAT_DANE becomes GreatDane.
+func goName(name string) string {
+	var b strings.Builder
+	for _, word := range words(name) {
+		if upper := strings.ToUpper(word); initialisms[upper] {
+			b.WriteString(upper)
+			continue
+		}
+		if strings.ToUpper(word) == word {
+			word = strings.ToLower(word)
+		}
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+
+	if b.Len() == 0 || !unicode.IsLetter(rune(b.String()[0])) {
+		return "X" + b.String()
+	}
+
+	return b.String()
+}
+
+// goArgName returns the unexported Go name of an argument, which is never a
+// keyword nor the name of the other parameters of a resolver.
+func goArgName(name string) string {
+	exported := goName(name)
+
+	var arg string
+	if upper := strings.ToUpper(exported); initialisms[upper] {
+		arg = strings.ToLower(exported)
+	} else {
+		arg = strings.ToLower(exported[:1]) + exported[1:]
+	}
+
+	if token.Lookup(arg).IsKeyword() || arg == "ctx" || arg == "obj" {
+		arg += "Arg"
+	}
+
+	return arg
+}
+
+// words splits a name at underscores and where a lowercase letter or digit
+// is followed by a capital.
+func words(name string) []string {
+	var words []string
+	start := 0
+	for idx := 0; idx <= len(name); idx++ {
+		split := idx == len(name) || name[idx] == '_'
+		if !split && idx > start && isUpper(name[idx]) && !isUpper(name[idx-1]) {
+			split = true
+		}
+		if !split {
+			continue
+		}
+
+		if idx > start {
+			words = append(words, name[start:idx])
+		}
+		if idx < len(name) && name[idx] == '_' {
+			start = idx + 1
+		} else {
+			start = idx
+		}
+	}
+
+	return words
+}
+
+func isUpper(c byte) bool {
+	return c >= 'A' && c <= 'Z'
+}
+
+// goRef is a reference to a Go type, such as time.Time.
+type goRef struct {
+	path string
+	name string
+}
+
+// parseGoRef parses the import path and name of a Go type, such as
+// "github.com/acme/app/models.User". Predeclared types have no path.
+func parseGoRef(ref string) goRef {
+	idx := strings.LastIndex(ref, ".")
+	if idx < 0 {
+		return goRef{name: ref}
+	}
+	if strings.LastIndex(ref, "/") > idx {
+		return goRef{}
+	}
+
+	return goRef{path: ref[:idx], name: ref[idx+1:]}
+}
+
+// packageName returns the name a package is imported as, assumed to be the
+// last element of its path.
+func packageName(importPath string) string {
+	return strings.Replace(path.Base(importPath), "-", "_", -1)
+}
+
+// comment returns a description, and the reason of a deprecation if any, as
+// the paragraphs of a Go comment.
+func comment(indent string, description string, deprecationReason string) string {
+	var paragraphs []string
+	if description != "" {
+		paragraphs = append(paragraphs, description)
+	}
+	if deprecationReason != "" {
+		paragraphs = append(paragraphs, "Deprecated: "+deprecationReason)
+	}
+	if len(paragraphs) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	for _, line := range strings.Split(strings.Join(paragraphs, "\n\n"), "\n") {
+		b.WriteString(strings.TrimRight(indent+"// "+line, " "))
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
@@ -0,0 +1,67 @@
+package codegen
+
+import "testing"
+
+func TestGoName(t *testing.T) {
+	tests := [][]string{
+		[]string{"user", "User"},
+		[]string{"userId", "UserID"},
+		[]string{"urlPath", "URLPath"},
+		[]string{"GREAT_DANE", "GreatDane"},
+		[]string{"snake_case_name", "SnakeCaseName"},
+		[]string{"HTMLBody", "HTMLBody"},
+		[]string{"_private", "Private"},
+		[]string{"v2", "V2"},
+	}
+
+	for _, test := range tests {
+		if got := goName(test[0]); got != test[1] {
+			t.Errorf("goName(%q); got %q wanted %q", test[0], got, test[1])
+		}
+	}
+}
+
+func TestGoArgName(t *testing.T) {
+	tests := [][]string{
+		[]string{"first", "first"},
+		[]string{"id", "id"},
+		[]string{"userId", "userID"},
+		[]string{"type", "typeArg"},
+		[]string{"ctx", "ctxArg"},
+		[]string{"obj", "objArg"},
+	}
+
+	for _, test := range tests {
+		if got := goArgName(test[0]); got != test[1] {
+			t.Errorf("goArgName(%q); got %q wanted %q", test[0], got, test[1])
+		}
+	}
+}
+
+func TestParseGoRef(t *testing.T) {
+	tests := [][]string{
+		[]string{"time.Time", "time", "Time"},
+		[]string{"github.com/acme/app/models.User", "github.com/acme/app/models", "User"},
+		[]string{"string", "", "string"},
+		[]string{"github.com/acme/app", "", ""},
+	}
+
+	for _, test := range tests {
+		ref := parseGoRef(test[0])
+		if ref.path != test[1] || ref.name != test[2] {
+			t.Errorf("parseGoRef(%q); got %q %q wanted %q %q", test[0], ref.path, ref.name, test[1], test[2])
+		}
+	}
+}
+
+func TestComment(t *testing.T) {
+	got := comment("\t", "The name.\n\nMore.", "Use title.")
+	want := "\t// The name.\n\t//\n\t// More.\n\t//\n\t// Deprecated: Use title.\n"
+	if got != want {
+		t.Errorf("comment; got %q wanted %q", got, want)
+	}
+
+	if got := comment("", "", ""); got != "" {
+		t.Errorf("empty comment; got %q wanted none", got)
+	}
+}
@@ -0,0 +1,815 @@
+package codegen
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/ijsnow/goql/internal/errors"
+	"github.com/ijsnow/goql/internal/language"
+	"github.com/ijsnow/goql/internal/query"
+	"github.com/ijsnow/goql/schema"
+)
+
+// LoadOperations parses the operation files. The sources of the documents
+// are named after the files.
+func (c *Config) LoadOperations() ([]*schema.DocumentNode, error) {
+	files, err := c.glob(c.Operations, "operation")
+	if err != nil {
+		return nil, err
+	}
+
+	var documents []*schema.DocumentNode
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		document, err := query.Parse(language.NewSource(string(data), file))
+		if err != nil {
+			return nil, err
+		}
+		documents = append(documents, document)
+	}
+
+	return documents, nil
+}
+
+// InvalidOperationsError is returned by GenerateClient for operations which
+// are not valid against the schema, or which code cannot be generated for.
+type InvalidOperationsError struct {
+	Errors []error
+}
+
+// Error lists the errors, each prefixed by the file, line and column of its
+// first location.
+func (e *InvalidOperationsError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for idx, err := range e.Errors {
+		messages[idx] = err.Error()
+
+		gqlerr, ok := err.(*errors.GraphQLError)
+		if ok && gqlerr.Source != nil && len(gqlerr.Locations) != 0 {
+			location := gqlerr.Locations[0]
+			messages[idx] = fmt.Sprintf("%s:%d:%d: %s", gqlerr.Source.GetName(), location.Line, location.Column, gqlerr.Message)
+		}
+	}
+
+	return strings.Join(messages, "\n")
+}
+
+// newError returns an error located at the given nodes, for the requirements
+// of the generated code which are not validation rules.
+func newError(message string, nodes ...language.ASTNode) error {
+	return errors.NewGraphQLError(message, nodes, nil, nil, nil, nil, nil)
+}
+
+// operationSet holds the operations and fragments of a set of documents.
+// The fragments are keyed by name, and the first definition of a name is
+// the one used.
+type operationSet struct {
+	operations    []*language.OperationDefinitionNode
+	fragments     map[string]*language.FragmentDefinitionNode
+	fragmentNames []string
+	allFragments  []*language.FragmentDefinitionNode
+}
+
+func newOperationSet(documents []*schema.DocumentNode) *operationSet {
+	set := &operationSet{fragments: map[string]*language.FragmentDefinitionNode{}}
+	for _, document := range documents {
+		for _, definition := range document.Definitions {
+			switch definition := definition.(type) {
+			case *language.OperationDefinitionNode:
+				set.operations = append(set.operations, definition)
+			case *language.FragmentDefinitionNode:
+				set.allFragments = append(set.allFragments, definition)
+				if _, ok := set.fragments[definition.Name.Value]; !ok {
+					set.fragments[definition.Name.Value] = definition
+					set.fragmentNames = append(set.fragmentNames, definition.Name.Value)
+				}
+			}
+		}
+	}
+
+	return set
+}
+
+// variableUsage is a variable used where a value of a type is expected.
+type variableUsage struct {
+	node *language.VariableNode
+	t    schema.Type
+}
+
+// definitionUsages are the variables used and the fragments spread by an
+// operation or a fragment, not counting those of the fragments it spreads.
+type definitionUsages struct {
+	variables []variableUsage
+	spreads   []*language.FragmentSpreadNode
+}
+
+// operationValidator checks operations against the validation rules of the
+// specification which matter to the generated code, and against the
+// requirements of the generated code itself.
+type operationValidator struct {
+	schema *schema.Schema
+	set    *operationSet
+	errors []error
+
+	// The usages of each operation and fragment, by the node defining it.
+	usages  map[interface{}]*definitionUsages
+	current *definitionUsages
+}
+
+// validateOperations returns the errors found in a set of operations.
+func validateOperations(s *schema.Schema, set *operationSet) []error {
+	v := &operationValidator{
+		schema: s,
+		set:    set,
+		usages: map[interface{}]*definitionUsages{},
+	}
+
+	v.validateDefinitions()
+	for _, operation := range set.operations {
+		v.validateVariableUsages(operation)
+	}
+	v.validateFragmentSpreads()
+
+	return v.errors
+}
+
+func (v *operationValidator) report(message errors.Message, nodes ...language.ASTNode) {
+	v.errors = append(v.errors, errors.NewValidationError(message, nodes...))
+}
+
+func (v *operationValidator) validateDefinitions() {
+	operationNames := map[string]bool{}
+	for _, operation := range v.set.operations {
+		name := operation.Name.Value
+		if name == "" {
+			v.errors = append(v.errors, newError("Operations must be named to generate code for them.", operation))
+		} else if operationNames[name] {
+			v.report(errors.Message{
+				ID:   errors.MsgDuplicateOperationName,
+				Args: map[string]interface{}{"operation": name},
+			}, &operation.Name)
+		}
+		operationNames[name] = true
+
+		v.current = &definitionUsages{}
+		v.usages[operation] = v.current
+
+		v.validateVariableDefinitions(operation)
+		v.validateDirectives(operation.Directives)
+
+		root := v.rootType(operation.Operation)
+		if root == nil {
+			v.report(errors.Message{
+				ID:   errors.MsgRootTypeNotDefined,
+				Args: map[string]interface{}{"operation": string(operation.Operation)},
+			}, operation)
+			continue
+		}
+		v.validateSelectionSet(root, operation.SelectionSet)
+	}
+
+	fragmentNames := map[string]bool{}
+	for _, fragment := range v.set.allFragments {
+		if fragmentNames[fragment.Name.Value] {
+			v.report(errors.Message{
+				ID:   errors.MsgDuplicateFragmentName,
+				Args: map[string]interface{}{"fragment": fragment.Name.Value},
+			}, &fragment.Name)
+		}
+		fragmentNames[fragment.Name.Value] = true
+	}
+
+	for _, name := range v.set.fragmentNames {
+		fragment := v.set.fragments[name]
+
+		v.current = &definitionUsages{}
+		v.usages[fragment] = v.current
+
+		v.validateDirectives(fragment.Directives)
+
+		t := v.schema.GetType(fragment.TypeCondition.Name.Value)
+		if t == nil {
+			v.reportUnknownType(&fragment.TypeCondition)
+			continue
+		}
+		if !schema.IsCompositeType(t) {
+			v.report(errors.Message{
+				ID:   errors.MsgFragmentOnNonComposite,
+				Args: map[string]interface{}{"fragment": name, "type": t.GetName()},
+			}, &fragment.TypeCondition)
+			continue
+		}
+		v.validateSelectionSet(t, fragment.SelectionSet)
+	}
+}
+
+// rootType returns the root type of an operation type, if the schema
+// supports it.
+func (v *operationValidator) rootType(operation language.OperationTypeNode) *schema.Object {
+	switch operation {
+	case language.OperationTypeMutation:
+		return v.schema.GetMutationType()
+	case language.OperationTypeSubscription:
+		return v.schema.GetSubscriptionType()
+	}
+
+	return v.schema.GetQueryType()
+}
+
+func (v *operationValidator) reportUnknownType(node *language.NamedTypeNode) {
+	var typeNames []string
+	for name := range v.schema.GetTypeMap() {
+		typeNames = append(typeNames, name)
+	}
+	sort.Strings(typeNames)
+
+	v.report(errors.UnknownTypeMessage(node.Name.Value, errors.SuggestionList(node.Name.Value, typeNames)), node)
+}
+
+func (v *operationValidator) validateVariableDefinitions(operation *language.OperationDefinitionNode) {
+	if operation.VariableDefinitions == nil {
+		return
+	}
+
+	names := map[string]bool{}
+	for idx := range *operation.VariableDefinitions {
+		definition := &(*operation.VariableDefinitions)[idx]
+		name := definition.Variable.Name.Value
+		if names[name] {
+			v.report(errors.Message{
+				ID:   errors.MsgDuplicateVariable,
+				Args: map[string]interface{}{"variable": name},
+			}, &definition.Variable)
+		}
+		names[name] = true
+
+		t := typeFromAST(v.schema, definition.Type)
+		if t == nil {
+			v.reportUnknownType(namedTypeNode(definition.Type))
+			continue
+		}
+		if !schema.IsInputType(t) {
+			v.report(errors.Message{
+				ID:   errors.MsgNonInputTypeOnVariable,
+				Args: map[string]interface{}{"variable": name, "type": language.Print(definition.Type.(language.ASTNode))},
+			}, definition.Type.(language.ASTNode))
+		}
+	}
+}
+
+func (v *operationValidator) validateDirectives(directives *[]language.DirectiveNode) {
+	if directives == nil {
+		return
+	}
+
+	for idx := range *directives {
+		node := &(*directives)[idx]
+		name := node.Name.Value
+
+		directive := v.schema.GetDirective(name)
+		if directive == nil {
+			v.report(errors.Message{
+				ID:   errors.MsgUnknownDirective,
+				Args: map[string]interface{}{"directive": name},
+			}, node)
+			continue
+		}
+
+		provided := v.validateArgumentValues(directive.Args, node.Arguments, func(arg string, suggestedArgs []string) errors.Message {
+			return errors.UnknownDirectiveArgMessage(arg, name, suggestedArgs)
+		})
+		for _, arg := range directive.Args {
+			if _, nonNull := arg.Type.(*schema.NonNull); nonNull && arg.DefaultValue == nil && !provided[arg.Name] {
+				v.report(errors.Message{
+					ID:   errors.MsgDirectiveArgNotProvided,
+					Args: map[string]interface{}{"directive": name, "arg": arg.Name, "type": arg.Type.String()},
+				}, node)
+			}
+		}
+	}
+}
+
+// validateSelectionSet validates the selections of a composite type.
+func (v *operationValidator) validateSelectionSet(parent schema.NamedType, set language.SelectionSetNode) {
+	fieldNames := map[string]*language.FieldNode{}
+	conditional := false
+	var typename bool
+
+	for _, selection := range set.Selections {
+		switch selection := selection.(type) {
+		case *language.FieldNode:
+			key := responseKey(selection)
+			if other, ok := fieldNames[key]; ok && other.Name.Value != selection.Name.Value {
+				v.report(errors.Message{
+					ID:   errors.MsgFieldsConflict,
+					Args: map[string]interface{}{"response": key, "field": other.Name.Value, "other": selection.Name.Value},
+				}, other, selection)
+			}
+			fieldNames[key] = selection
+			if key == "__typename" && selection.Name.Value == "__typename" {
+				typename = true
+			}
+
+			v.validateField(parent, selection)
+
+		case *language.InlineFragmentNode:
+			v.validateDirectives(selection.Directives)
+
+			condition := parent
+			if selection.TypeCondition != nil {
+				condition = v.schema.GetType(selection.TypeCondition.Name.Value)
+				if condition == nil {
+					v.reportUnknownType(selection.TypeCondition)
+					continue
+				}
+				if !schema.IsCompositeType(condition) {
+					v.report(errors.Message{
+						ID:   errors.MsgInlineFragmentOnNonComposite,
+						Args: map[string]interface{}{"type": condition.GetName()},
+					}, selection.TypeCondition)
+					continue
+				}
+				if !schema.DoTypesOverlap(v.schema, parent.(schema.CompositeType), condition.(schema.CompositeType)) {
+					v.report(errors.Message{
+						ID:   errors.MsgImpossibleInlineFragment,
+						Args: map[string]interface{}{"parent": parent.GetName(), "type": condition.GetName()},
+					}, selection)
+					continue
+				}
+			}
+			if !appliesTo(v.schema, parent, condition) {
+				conditional = true
+			}
+			v.validateSelectionSet(condition, *selection.SelectionSet)
+
+		case *language.FragmentSpreadNode:
+			v.validateDirectives(selection.Directives)
+			v.current.spreads = append(v.current.spreads, selection)
+
+			name := selection.Name.Value
+			fragment, ok := v.set.fragments[name]
+			if !ok {
+				v.report(errors.UnknownFragmentMessage(name, errors.SuggestionList(name, v.set.fragmentNames)), selection)
+				continue
+			}
+
+			condition := v.schema.GetType(fragment.TypeCondition.Name.Value)
+			if condition == nil || !schema.IsCompositeType(condition) {
+				// Reported with the fragment.
+				continue
+			}
+			if !schema.DoTypesOverlap(v.schema, parent.(schema.CompositeType), condition.(schema.CompositeType)) {
+				v.report(errors.Message{
+					ID:   errors.MsgImpossibleFragmentSpread,
+					Args: map[string]interface{}{"fragment": name, "parent": parent.GetName(), "type": condition.GetName()},
+				}, selection)
+				continue
+			}
+			if !appliesTo(v.schema, parent, condition) {
+				conditional = true
+			}
+		}
+	}
+
+	// The generated code decodes the selections on other types than the
+	// parent type by the __typename of the value.
+	if conditional && !typename {
+		v.errors = append(v.errors, newError(fmt.Sprintf("Selections on type %q with type conditions must select __typename to generate code for them.", parent.GetName()), &set))
+	}
+}
+
+func (v *operationValidator) validateField(parent schema.NamedType, node *language.FieldNode) {
+	v.validateDirectives(node.Directives)
+
+	name := node.Name.Value
+	if name == "__typename" {
+		if node.SelectionSet != nil {
+			v.report(errors.Message{
+				ID:   errors.MsgNoSubselectionAllowed,
+				Args: map[string]interface{}{"field": name, "type": "String!"},
+			}, node.SelectionSet)
+		}
+		return
+	}
+
+	field := fieldOf(parent, name)
+	if field == nil {
+		v.reportUndefinedField(parent, node)
+		return
+	}
+
+	v.validateArguments(parent, field, node)
+
+	t := schema.GetNamedType(field.Type)
+	switch {
+	case schema.IsLeafType(t) && node.SelectionSet != nil:
+		v.report(errors.Message{
+			ID:   errors.MsgNoSubselectionAllowed,
+			Args: map[string]interface{}{"field": name, "type": field.Type.String()},
+		}, node.SelectionSet)
+	case !schema.IsLeafType(t) && node.SelectionSet == nil:
+		v.report(errors.Message{
+			ID:   errors.MsgRequiredSubselection,
+			Args: map[string]interface{}{"field": name, "type": field.Type.String()},
+		}, node)
+	case node.SelectionSet != nil:
+		v.validateSelectionSet(t, *node.SelectionSet)
+	}
+}
+
+// reportUndefinedField reports a field which is not defined on its parent
+// type. It suggests the types defining it, when the parent type is
+// abstract, or otherwise the fields with similar names.
+func (v *operationValidator) reportUndefinedField(parent schema.NamedType, node *language.FieldNode) {
+	name := node.Name.Value
+
+	var suggestedTypes []string
+	if abstract, ok := parent.(schema.AbstractType); ok {
+		interfaces := map[string]bool{}
+		for _, object := range v.schema.PossibleTypes(abstract) {
+			if object.GetField(name) != nil {
+				suggestedTypes = append(suggestedTypes, object.Name)
+			}
+			for _, iface := range object.GetInterfaces() {
+				if iface.Name != parent.GetName() && !interfaces[iface.Name] && iface.GetField(name) != nil {
+					interfaces[iface.Name] = true
+				}
+			}
+		}
+
+		// Interfaces are suggested first, as they apply to more types.
+		var interfaceNames []string
+		for iface := range interfaces {
+			interfaceNames = append(interfaceNames, iface)
+		}
+		sort.Strings(interfaceNames)
+		sort.Strings(suggestedTypes)
+		suggestedTypes = append(interfaceNames, suggestedTypes...)
+	}
+
+	var suggestedFields []string
+	if len(suggestedTypes) == 0 {
+		var fieldNames []string
+		for _, field := range fieldsOf(parent) {
+			fieldNames = append(fieldNames, field.Name)
+		}
+		suggestedFields = errors.SuggestionList(name, fieldNames)
+	}
+
+	v.report(errors.UndefinedFieldMessage(name, parent.GetName(), suggestedTypes, suggestedFields), node)
+}
+
+func (v *operationValidator) validateArguments(parent schema.NamedType, field *schema.Field, node *language.FieldNode) {
+	provided := v.validateArgumentValues(field.Args, node.Arguments, func(arg string, suggestedArgs []string) errors.Message {
+		return errors.UnknownArgMessage(arg, field.Name, parent.GetName(), suggestedArgs)
+	})
+
+	for _, arg := range field.Args {
+		if _, nonNull := arg.Type.(*schema.NonNull); nonNull && arg.DefaultValue == nil && !provided[arg.Name] {
+			v.report(errors.Message{
+				ID:   errors.MsgFieldArgNotProvided,
+				Args: map[string]interface{}{"field": field.Name, "arg": arg.Name, "type": arg.Type.String()},
+			}, node)
+		}
+	}
+}
+
+// validateArgumentValues validates the arguments given to a field or a
+// directive, and returns the names of those given.
+func (v *operationValidator) validateArgumentValues(
+	definitions []*schema.Argument,
+	nodes *[]language.ArgumentNode,
+	unknownArgMessage func(arg string, suggestedArgs []string) errors.Message,
+) map[string]bool {
+	provided := map[string]bool{}
+	if nodes == nil {
+		return provided
+	}
+
+	var argNames []string
+	for _, definition := range definitions {
+		argNames = append(argNames, definition.Name)
+	}
+
+	for idx := range *nodes {
+		arg := &(*nodes)[idx]
+		provided[arg.Name.Value] = true
+
+		var definition *schema.Argument
+		for _, candidate := range definitions {
+			if candidate.Name == arg.Name.Value {
+				definition = candidate
+			}
+		}
+		if definition == nil {
+			v.report(unknownArgMessage(arg.Name.Value, errors.SuggestionList(arg.Name.Value, argNames)), arg)
+			continue
+		}
+
+		if !v.validateValue(arg.Value, definition.Type) {
+			v.report(errors.Message{
+				ID:   errors.MsgArgInvalidValue,
+				Args: map[string]interface{}{"arg": arg.Name.Value, "value": language.Print(arg.Value)},
+			}, arg.Value)
+		}
+	}
+
+	return provided
+}
+
+// validateValue reports whether a literal is a valid value of a type, and
+// records the variables it holds with the types expected of them.
+func (v *operationValidator) validateValue(node language.ValueNode, t schema.Type) bool {
+	if variable, ok := node.(*language.VariableNode); ok {
+		v.current.variables = append(v.current.variables, variableUsage{node: variable, t: t})
+		return true
+	}
+
+	if nonNull, ok := t.(*schema.NonNull); ok {
+		if _, isNull := node.(*language.NullValueNode); isNull {
+			return false
+		}
+		return v.validateValue(node, nonNull.OfType)
+	}
+	if _, isNull := node.(*language.NullValueNode); isNull {
+		return true
+	}
+
+	switch t := t.(type) {
+	case *schema.List:
+		list, ok := node.(*language.ListValueNode)
+		if !ok {
+			return v.validateValue(node, t.OfType)
+		}
+
+		valid := true
+		for _, item := range list.Values {
+			valid = v.validateValue(item, t.OfType) && valid
+		}
+		return valid
+
+	case *schema.InputObject:
+		object, ok := node.(*language.ObjectValueNode)
+		if !ok {
+			return false
+		}
+
+		valid := true
+		provided := map[string]bool{}
+		for idx := range object.Fields {
+			objectField := &object.Fields[idx]
+			provided[objectField.Name.Value] = true
+
+			field := t.GetField(objectField.Name.Value)
+			if field == nil {
+				valid = false
+				continue
+			}
+			valid = v.validateValue(objectField.Value, field.Type) && valid
+		}
+		for _, field := range t.GetFields() {
+			if _, nonNull := field.Type.(*schema.NonNull); nonNull && field.DefaultValue == nil && !provided[field.Name] {
+				valid = false
+			}
+		}
+		return valid
+	}
+
+	_, ok := schema.ValueFromAST(node, t, nil)
+	return ok
+}
+
+// validateVariableUsages checks the variables used by an operation, and by
+// the fragments it spreads, against the variables it defines.
+func (v *operationValidator) validateVariableUsages(operation *language.OperationDefinitionNode) {
+	definitions := map[string]*language.VariableDefinitionNode{}
+	if operation.VariableDefinitions != nil {
+		for idx := range *operation.VariableDefinitions {
+			definition := &(*operation.VariableDefinitions)[idx]
+			definitions[definition.Variable.Name.Value] = definition
+		}
+	}
+
+	used := map[string]bool{}
+	for _, usage := range v.variableUsages(operation) {
+		name := usage.node.Name.Value
+		used[name] = true
+
+		definition, ok := definitions[name]
+		if !ok {
+			v.report(errors.Message{
+				ID:   errors.MsgUndefinedVariable,
+				Args: map[string]interface{}{"variable": name, "operation": operation.Name.Value},
+			}, usage.node, operation)
+			continue
+		}
+
+		t := typeFromAST(v.schema, definition.Type)
+		if t == nil || !schema.IsInputType(t) {
+			continue
+		}
+
+		// A variable with a default value is never null.
+		effective := t
+		if _, nonNull := t.(*schema.NonNull); !nonNull && definition.DefaultValue != nil {
+			if _, isNull := definition.DefaultValue.(*language.NullValueNode); !isNull {
+				effective = schema.NewNonNull(t)
+			}
+		}
+		if !schema.IsTypeSubTypeOf(v.schema, effective, usage.t) {
+			v.report(errors.Message{
+				ID:   errors.MsgBadVariablePosition,
+				Args: map[string]interface{}{"variable": name, "type": t.String(), "expected": usage.t.String()},
+			}, &definition.Variable, usage.node)
+		}
+	}
+
+	if operation.VariableDefinitions != nil {
+		for idx := range *operation.VariableDefinitions {
+			definition := &(*operation.VariableDefinitions)[idx]
+			if !used[definition.Variable.Name.Value] {
+				v.report(errors.Message{
+					ID:   errors.MsgUnusedVariable,
+					Args: map[string]interface{}{"variable": definition.Variable.Name.Value, "operation": operation.Name.Value},
+				}, definition)
+			}
+		}
+	}
+}
+
+// variableUsages returns the variables used by an operation and by the
+// fragments it spreads, directly or not.
+func (v *operationValidator) variableUsages(operation *language.OperationDefinitionNode) []variableUsage {
+	usages := v.usages[operation]
+	variables := append([]variableUsage{}, usages.variables...)
+
+	visited := map[string]bool{}
+	spreads := append([]*language.FragmentSpreadNode{}, usages.spreads...)
+	for len(spreads) != 0 {
+		spread := spreads[0]
+		spreads = spreads[1:]
+
+		fragment, ok := v.set.fragments[spread.Name.Value]
+		if !ok || visited[spread.Name.Value] {
+			continue
+		}
+		visited[spread.Name.Value] = true
+
+		if fragmentUsages, ok := v.usages[fragment]; ok {
+			variables = append(variables, fragmentUsages.variables...)
+			spreads = append(spreads, fragmentUsages.spreads...)
+		}
+	}
+
+	return variables
+}
+
+// validateFragmentSpreads reports the fragments which are never used, and
+// the fragments which spread themselves.
+func (v *operationValidator) validateFragmentSpreads() {
+	used := map[string]bool{}
+	var use func(spreads []*language.FragmentSpreadNode)
+	use = func(spreads []*language.FragmentSpreadNode) {
+		for _, spread := range spreads {
+			fragment, ok := v.set.fragments[spread.Name.Value]
+			if !ok || used[spread.Name.Value] {
+				continue
+			}
+			used[spread.Name.Value] = true
+			if usages, ok := v.usages[fragment]; ok {
+				use(usages.spreads)
+			}
+		}
+	}
+	for _, operation := range v.set.operations {
+		use(v.usages[operation].spreads)
+	}
+
+	for _, name := range v.set.fragmentNames {
+		fragment := v.set.fragments[name]
+		if !used[name] {
+			v.report(errors.Message{
+				ID:   errors.MsgUnusedFragment,
+				Args: map[string]interface{}{"fragment": name},
+			}, fragment)
+		}
+
+		if spread := v.selfSpread(fragment); spread != nil {
+			v.report(errors.Message{
+				ID:   errors.MsgFragmentCycle,
+				Args: map[string]interface{}{"fragment": name},
+			}, spread)
+		}
+	}
+}
+
+// selfSpread returns the spread through which a fragment spreads itself,
+// directly or not, if any.
+func (v *operationValidator) selfSpread(fragment *language.FragmentDefinitionNode) *language.FragmentSpreadNode {
+	visited := map[string]bool{}
+
+	var find func(definition *language.FragmentDefinitionNode) *language.FragmentSpreadNode
+	find = func(definition *language.FragmentDefinitionNode) *language.FragmentSpreadNode {
+		usages, ok := v.usages[definition]
+		if !ok {
+			return nil
+		}
+
+		for _, spread := range usages.spreads {
+			if spread.Name.Value == fragment.Name.Value {
+				return spread
+			}
+			if visited[spread.Name.Value] {
+				continue
+			}
+			visited[spread.Name.Value] = true
+
+			if next, ok := v.set.fragments[spread.Name.Value]; ok && find(next) != nil {
+				return spread
+			}
+		}
+
+		return nil
+	}
+
+	return find(fragment)
+}
+
+// responseKey returns the key of a field in the response: its alias, or
+// otherwise its name.
+func responseKey(node *language.FieldNode) string {
+	if node.Alias != nil {
+		return node.Alias.Value
+	}
+
+	return node.Name.Value
+}
+
+// appliesTo reports whether the selections on a type condition apply to
+// every value of a parent type.
+func appliesTo(s *schema.Schema, parent schema.NamedType, condition schema.NamedType) bool {
+	return schema.IsTypeSubTypeOf(s, parent, condition)
+}
+
+// fieldsOf returns the fields of an Object or Interface type.
+func fieldsOf(t schema.NamedType) []*schema.Field {
+	switch t := t.(type) {
+	case *schema.Object:
+		return t.GetFields()
+	case *schema.Interface:
+		return t.GetFields()
+	}
+
+	return nil
+}
+
+// fieldOf returns a field of an Object or Interface type.
+func fieldOf(t schema.NamedType, name string) *schema.Field {
+	switch t := t.(type) {
+	case *schema.Object:
+		return t.GetField(name)
+	case *schema.Interface:
+		return t.GetField(name)
+	}
+
+	return nil
+}
+
+// typeFromAST returns the type a type reference refers to, or nil if the
+// schema has no such type.
+func typeFromAST(s *schema.Schema, node language.TypeNode) schema.Type {
+	switch node := node.(type) {
+	case *language.ListTypeNode:
+		if t := typeFromAST(s, node.Type); t != nil {
+			return schema.NewList(t)
+		}
+	case *language.NonNullTypeNode:
+		if t := typeFromAST(s, node.Type); t != nil {
+			return schema.NewNonNull(t)
+		}
+	case *language.NamedTypeNode:
+		if t := s.GetType(node.Name.Value); t != nil {
+			return t
+		}
+	}
+
+	return nil
+}
+
+// namedTypeNode returns the named type a type reference wraps.
+func namedTypeNode(node language.TypeNode) *language.NamedTypeNode {
+	switch node := node.(type) {
+	case *language.ListTypeNode:
+		return namedTypeNode(node.Type)
+	case *language.NonNullTypeNode:
+		return namedTypeNode(node.Type)
+	}
+
+	return node.(*language.NamedTypeNode)
+}
@@ -0,0 +1,187 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/ijsnow/goql/internal/language"
+	"github.com/ijsnow/goql/internal/query"
+	"github.com/ijsnow/goql/schema"
+)
+
+func parseOperations(t *testing.T, sources ...string) []*schema.DocumentNode {
+	t.Helper()
+
+	var documents []*schema.DocumentNode
+	for _, source := range sources {
+		document, err := query.Parse(language.NewSource(source, "operations.graphql"))
+		if err != nil {
+			t.Fatalf("Parse; got error %v", err)
+		}
+		documents = append(documents, document)
+	}
+
+	return documents
+}
+
+func operationErrors(t *testing.T, source string) string {
+	t.Helper()
+
+	s, err := schema.BuildFromSDL(serverSDL)
+	if err != nil {
+		t.Fatalf("BuildFromSDL; got error %v", err)
+	}
+
+	errs := validateOperations(s, newOperationSet(parseOperations(t, source)))
+	if len(errs) == 0 {
+		return ""
+	}
+
+	return (&InvalidOperationsError{Errors: errs}).Error()
+}
+
+func TestValidateOperations(t *testing.T) {
+	valid := `query Pets($kind: Kind, $first: Int = 5, $withName: Boolean!) {
+  pets(kind: $kind, first: $first) {
+    __typename
+    ...PetFields
+    ... on Dog { kind friends(first: 1) { id } }
+  }
+  search(text: "rex") { __typename ... on Owner { name } }
+}
+
+fragment PetFields on Pet {
+  id
+  name @include(if: $withName)
+}
+
+mutation Adopt($tags: [String!]) {
+  adopt(input: {petId: "1", tags: $tags}) { id }
+}`
+	if got := operationErrors(t, valid); got != "" {
+		t.Errorf("valid operations; got errors\n%s", got)
+	}
+}
+
+func TestValidateOperationsErrors(t *testing.T) {
+	tests := [][]string{
+		[]string{
+			`{ now }`,
+			`operations.graphql:1:1: Operations must be named to generate code for them.`,
+		},
+		[]string{
+			"query A { now }\nquery A { now }",
+			`operations.graphql:2:7: There can be only one operation named "A".`,
+		},
+		[]string{
+			`subscription A { now }`,
+			`operations.graphql:1:1: Schema is not configured for subscription operations.`,
+		},
+		[]string{
+			`query A { pet(id: "1") { nmae } }`,
+			`operations.graphql:1:26: Cannot query field "nmae" on type "Pet". Did you mean "name"?`,
+		},
+		[]string{
+			`query A { pet(id: "1") { kind } }`,
+			`operations.graphql:1:26: Cannot query field "kind" on type "Pet". Did you mean to use an inline fragment on "Dog"?`,
+		},
+		[]string{
+			`query A { pet(di: "1") { id } }`,
+			"operations.graphql:1:15: Unknown argument \"di\" on field \"pet\" of type \"Query\". Did you mean \"id\"?\n" +
+				`operations.graphql:1:11: Field "pet" argument "id" of type "ID!" is required but not provided.`,
+		},
+		[]string{
+			`query A { pets(first: "ten") { id } }`,
+			`operations.graphql:1:23: Argument "first" got invalid value "ten".`,
+		},
+		[]string{
+			`query A { pet(id: "1") }`,
+			`operations.graphql:1:11: Field "pet" of type "Pet" must have a selection of subfields. Did you mean "pet { ... }"?`,
+		},
+		[]string{
+			`query A { now { id } }`,
+			`operations.graphql:1:15: Field "now" must not have a selection since type "DateTime" has no subfields.`,
+		},
+		[]string{
+			`query A { pet(id: "1") { id: name id } }`,
+			`operations.graphql:1:26: Fields "id" conflict because "name" and "id" are different fields. Use different aliases on the fields to fetch both if this was intentional.`,
+		},
+		[]string{
+			`query A { pet(id: "1") { ... on Dog { kind } } }`,
+			`operations.graphql:1:24: Selections on type "Pet" with type conditions must select __typename to generate code for them.`,
+		},
+		[]string{
+			`query A { pet(id: "1") { ... on Owner { name } } }`,
+			`operations.graphql:1:26: Fragment cannot be spread here as objects of type "Pet" can never be of type "Owner".`,
+		},
+		[]string{
+			`query A { pet(id: "1") { ... on Kind { id } } }`,
+			`operations.graphql:1:33: Fragment cannot condition on non composite type "Kind".`,
+		},
+		[]string{
+			`query A { pet(id: "1") { ... on Dgo { id } } }`,
+			`operations.graphql:1:33: Unknown type "Dgo". Did you mean "Dog"?`,
+		},
+		[]string{
+			`query A { pet(id: "1") { ...PetFeilds } } fragment PetFields on Pet { id }`,
+			"operations.graphql:1:26: Unknown fragment \"PetFeilds\". Did you mean \"PetFields\"?\n" +
+				`operations.graphql:1:43: Fragment "PetFields" is never used.`,
+		},
+		[]string{
+			`query A { pet(id: "1") { ...F } } fragment F on Pet { ...G } fragment G on Pet { ...F }`,
+			"operations.graphql:1:55: Cannot spread fragment \"F\" within itself.\n" +
+				`operations.graphql:1:82: Cannot spread fragment "G" within itself.`,
+		},
+		[]string{
+			`query A { pet(id: "1") { ...F } } fragment F on Owner { name }`,
+			`operations.graphql:1:26: Fragment "F" cannot be spread here as objects of type "Pet" can never be of type "Owner".`,
+		},
+		[]string{
+			`query A { pet(id: "1") { ...F } } fragment F on Kind { name }`,
+			`operations.graphql:1:49: Fragment "F" cannot condition on non composite type "Kind".`,
+		},
+		[]string{
+			`query A { pet(id: "1") { ...F } } fragment F on Pet { id } fragment F on Pet { name }`,
+			`operations.graphql:1:69: There can be only one fragment named "F".`,
+		},
+		[]string{
+			`query A($id: ID!, $id: ID!) { pet(id: $id) { id } }`,
+			`operations.graphql:1:19: There can be only one variable named "$id".`,
+		},
+		[]string{
+			`query A($pet: Pet) { now }`,
+			"operations.graphql:1:15: Variable \"$pet\" cannot be non-input type \"Pet\".\n" +
+				`operations.graphql:1:9: Variable "$pet" is never used in operation "A".`,
+		},
+		[]string{
+			`query A($id: Idd) { now }`,
+			"operations.graphql:1:14: Unknown type \"Idd\".\n" +
+				`operations.graphql:1:9: Variable "$id" is never used in operation "A".`,
+		},
+		[]string{
+			`query A { pet(id: $id) { id } }`,
+			`operations.graphql:1:19: Variable "$id" is not defined by operation "A".`,
+		},
+		[]string{
+			`query A($id: ID) { pet(id: $id) { id } }`,
+			`operations.graphql:1:9: Variable "$id" of type "ID" used in position expecting type "ID!".`,
+		},
+		[]string{
+			`query A($tag: Int) { adopt: pets { ...F } } fragment F on Pet { id @skip(if: $tag) }`,
+			`operations.graphql:1:9: Variable "$tag" of type "Int" used in position expecting type "Boolean!".`,
+		},
+		[]string{
+			`query A { now @cached }`,
+			`operations.graphql:1:15: Unknown directive "cached".`,
+		},
+		[]string{
+			`query A { now @skip }`,
+			`operations.graphql:1:15: Directive "@skip" argument "if" of type "Boolean!" is required but not provided.`,
+		},
+	}
+
+	for _, test := range tests {
+		if got := operationErrors(t, test[0]); got != test[1] {
+			t.Errorf("%s; got\n%s\nwanted\n%s", test[0], got, test[1])
+		}
+	}
+}
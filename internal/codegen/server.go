@@ -0,0 +1,310 @@
+package codegen
+
+import (
+	"sort"
+
+	"github.com/ijsnow/goql/schema"
+)
+
+const schemaPackage = "github.com/ijsnow/goql/schema"
+
+// GenerateServer generates the server code of a schema built from sdl:
+//
+//   - A struct for every Object type, holding its fields without arguments,
+//     and a struct for every InputObject type. The fields are tagged with
+//     their GraphQL names.
+//   - A named string type for every Enum type, with a constant per value.
+//   - An interface for every Interface and Union type, implemented by the
+//     structs of its possible types through a method such as IsPet().
+//   - A resolver interface for every Object type with fields taking
+//     arguments, and for the root types, with a method per such field
+//     taking typed arguments. ResolverRoot returns each of them.
+//   - NewExecutableSchema, which builds the schema and binds the resolvers
+//     to it with schema.BindResolvers.
+//
+// The types mapped to existing Go types by config.Models are not generated.
+// Their fields without arguments are left to the default resolver, and
+// those of Interface and Union types must implement the interface methods
+// themselves.
+func GenerateServer(config *Config, s *schema.Schema, sdl string) ([]byte, error) {
+	g := &serverGenerator{generator: newGenerator(config, s)}
+	for _, root := range []*schema.Object{s.GetQueryType(), s.GetMutationType(), s.GetSubscriptionType()} {
+		if root != nil {
+			g.roots = append(g.roots, root)
+		}
+	}
+
+	g.writeModels()
+	g.writeResolvers()
+	g.writeBinding()
+	g.printf("const sdl = %s\n", stringLiteral(sdl))
+
+	return g.source(config.Server.Package)
+}
+
+type serverGenerator struct {
+	*generator
+	roots []*schema.Object
+}
+
+func (g *serverGenerator) isRoot(object *schema.Object) bool {
+	for _, root := range g.roots {
+		if root == object {
+			return true
+		}
+	}
+
+	return false
+}
+
+// resolved returns the fields of an Object type which are resolved by the
+// methods of its resolver interface.
+func (g *serverGenerator) resolved(object *schema.Object) []*schema.Field {
+	var fields []*schema.Field
+	for _, field := range object.GetFields() {
+		if g.isRoot(object) || len(field.Args) != 0 {
+			fields = append(fields, field)
+		}
+	}
+
+	return fields
+}
+
+// generated reports whether the Go type of a named type is generated.
+func (g *serverGenerator) generated(t schema.NamedType) bool {
+	if _, ok := g.config.Models[t.GetName()]; ok {
+		return false
+	}
+	if object, ok := t.(*schema.Object); ok && g.isRoot(object) {
+		return false
+	}
+	_, scalar := t.(*schema.Scalar)
+
+	return !scalar
+}
+
+func (g *serverGenerator) writeModels() {
+	// The abstract types each Object type belongs to, for the methods
+	// implementing their interfaces.
+	abstracts := map[string][]string{}
+	for _, t := range g.namedTypes() {
+		switch t := t.(type) {
+		case *schema.Object:
+			for _, iface := range t.GetInterfaces() {
+				abstracts[t.Name] = append(abstracts[t.Name], iface.Name)
+			}
+		case *schema.Union:
+			for _, object := range t.GetTypes() {
+				abstracts[object.Name] = append(abstracts[object.Name], t.Name)
+			}
+		}
+	}
+
+	for _, t := range g.namedTypes() {
+		if !g.generated(t) {
+			continue
+		}
+
+		name := goName(t.GetName())
+		g.printf("%s", comment("", t.GetDescription(), ""))
+
+		switch t := t.(type) {
+		case *schema.Enum:
+			g.writeEnum(t)
+
+		case *schema.Interface, *schema.Union:
+			g.printf("type %s interface {\n\tIs%s()\n}\n\n", name, name)
+
+		case *schema.Object:
+			g.printf("type %s struct {\n", name)
+			for _, field := range t.GetFields() {
+				if len(field.Args) == 0 {
+					g.writeStructField(field.Name, field.Description, field.DeprecationReason, field.Type)
+				}
+			}
+			g.printf("}\n\n")
+
+			abstractNames := abstracts[t.Name]
+			sort.Strings(abstractNames)
+			for _, abstract := range abstractNames {
+				g.printf("// Is%s implements %s.\n", goName(abstract), goName(abstract))
+				g.printf("func (*%s) Is%s() {}\n\n", name, goName(abstract))
+			}
+
+		case *schema.InputObject:
+			g.printf("type %s struct {\n", name)
+			for _, field := range t.GetFields() {
+				g.writeStructField(field.Name, field.Description, field.DeprecationReason, field.Type)
+			}
+			g.printf("}\n\n")
+		}
+	}
+}
+
+func (g *serverGenerator) writeStructField(name, description, deprecationReason string, t schema.Type) {
+	g.printf("%s", comment("\t", description, deprecationReason))
+	g.printf("\t%s %s `graphql:%q json:%q`\n", goName(name), g.goType(t), name, name)
+}
+
+// resolverTypes returns the Object types with a resolver interface.
+func (g *serverGenerator) resolverTypes() []*schema.Object {
+	var objects []*schema.Object
+	for _, t := range g.namedTypes() {
+		if object, ok := t.(*schema.Object); ok && len(g.resolved(object)) != 0 {
+			objects = append(objects, object)
+		}
+	}
+
+	return objects
+}
+
+func (g *serverGenerator) writeResolvers() {
+	ctx := g.use("context")
+	objects := g.resolverTypes()
+
+	for _, object := range objects {
+		name := goName(object.Name)
+		g.printf("// %sResolver resolves the fields of %s.\n", name, object.Name)
+		g.printf("type %sResolver interface {\n", name)
+		for _, field := range g.resolved(object) {
+			g.printf("%s", comment("\t", field.Description, field.DeprecationReason))
+			g.printf("\t%s(ctx %s.Context", goName(field.Name), ctx)
+			if !g.isRoot(object) {
+				g.printf(", obj %s", g.goType(object))
+			}
+			for _, arg := range field.Args {
+				g.printf(", %s %s", goArgName(arg.Name), g.goType(arg.Type))
+			}
+			g.printf(") (%s, error)\n", g.goType(field.Type))
+		}
+		g.printf("}\n\n")
+	}
+
+	g.printf("// ResolverRoot provides the resolvers of the fields of each type.\n")
+	g.printf("type ResolverRoot interface {\n")
+	for _, object := range objects {
+		g.printf("\t%s() %sResolver\n", goName(object.Name), goName(object.Name))
+	}
+	g.printf("}\n\n")
+}
+
+func (g *serverGenerator) writeBinding() {
+	pkg := g.use(schemaPackage)
+
+	g.printf("// Config configures NewExecutableSchema.\n")
+	g.printf("type Config struct {\n")
+	g.printf("\tResolvers ResolverRoot\n\n")
+	g.printf("\t// Scalars implement the custom scalars, keyed by name.\n")
+	g.printf("\tScalars map[string]%s.ScalarDefinition\n", pkg)
+	g.printf("}\n\n")
+
+	g.printf("// NewExecutableSchema builds the schema and binds the resolvers to it.\n")
+	g.printf("func NewExecutableSchema(config Config) (*%s.Schema, error) {\n", pkg)
+	g.printf("s, err := %s.BuildFromSDL(sdl)\n", pkg)
+	g.printf("if err != nil {\nreturn nil, err\n}\n\n")
+
+	g.printf("resolvers := %s.ResolverMap{\n", pkg)
+	g.writeFieldResolvers(pkg)
+	g.writeTypeResolvers(pkg)
+	g.printf("Scalars: config.Scalars,\n")
+	g.writeEnumValues()
+	g.printf("}\n")
+	g.printf("if err := %s.BindResolvers(s, resolvers); err != nil {\nreturn nil, err\n}\n\n", pkg)
+	g.printf("return s, nil\n}\n\n")
+}
+
+func (g *serverGenerator) writeFieldResolvers(pkg string) {
+	g.printf("Fields: map[string]%s.FieldResolveFn{\n", pkg)
+
+	for _, t := range g.namedTypes() {
+		object, ok := t.(*schema.Object)
+		if !ok {
+			continue
+		}
+
+		for _, field := range object.GetFields() {
+			root, args := g.isRoot(object), len(field.Args) != 0
+			if !root && !args && !g.generated(object) {
+				continue
+			}
+
+			g.printf("%q: func(p %s.ResolveParams) (interface{}, error) {\n", object.Name+"."+field.Name, pkg)
+			if !root && !args {
+				g.printf("return p.Source.(%s).%s, nil\n", g.goType(object), goName(field.Name))
+				g.printf("},\n")
+				continue
+			}
+
+			call := "config.Resolvers." + goName(object.Name) + "()." + goName(field.Name) + "(p.Context"
+			if !root {
+				call += ", p.Source.(" + g.goType(object) + ")"
+			}
+			if args {
+				g.printf("var args struct {\n")
+				for _, arg := range field.Args {
+					g.printf("%s %s `graphql:%q`\n", goName(arg.Name), g.goType(arg.Type), arg.Name)
+					call += ", args." + goName(arg.Name)
+				}
+				g.printf("}\n")
+				g.printf("if err := %s.DecodeArgs(p.Args, &args); err != nil {\nreturn nil, err\n}\n", pkg)
+			}
+			g.printf("return %s)\n", call)
+			g.printf("},\n")
+		}
+	}
+
+	g.printf("},\n")
+}
+
+func (g *serverGenerator) writeTypeResolvers(pkg string) {
+	var abstracts []schema.AbstractType
+	for _, t := range g.namedTypes() {
+		if abstract, ok := t.(schema.AbstractType); ok {
+			abstracts = append(abstracts, abstract)
+		}
+	}
+	if len(abstracts) == 0 {
+		return
+	}
+
+	g.printf("ResolveType: map[string]%s.TypeResolveFn{\n", pkg)
+	for _, abstract := range abstracts {
+		g.printf("%q: func(p %s.ResolveTypeParams) *%s.Object {\n", abstract.GetName(), pkg, pkg)
+		g.printf("switch p.Value.(type) {\n")
+		for _, object := range g.schema.PossibleTypes(abstract) {
+			g.printf("case %s:\n", g.goType(object))
+			g.printf("return s.GetType(%q).(*%s.Object)\n", object.Name, pkg)
+		}
+		g.printf("}\n\nreturn nil\n")
+		g.printf("},\n")
+	}
+	g.printf("},\n")
+}
+
+func (g *serverGenerator) writeEnumValues() {
+	var enums []*schema.Enum
+	for _, t := range g.namedTypes() {
+		if enum, ok := t.(*schema.Enum); ok {
+			enums = append(enums, enum)
+		}
+	}
+	if len(enums) == 0 {
+		return
+	}
+
+	g.printf("Enums: map[string]map[string]interface{}{\n")
+	for _, enum := range enums {
+		goType := g.namedGoType(enum)
+
+		g.printf("%q: {\n", enum.Name)
+		for _, value := range enum.GetValues() {
+			if g.generated(enum) {
+				g.printf("%q: %s%s,\n", value.Name, goType, goName(value.Name))
+			} else {
+				g.printf("%q: %s(%q),\n", value.Name, goType, value.Name)
+			}
+		}
+		g.printf("},\n")
+	}
+	g.printf("},\n")
+}
@@ -0,0 +1,217 @@
+package codegen
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+
+	"github.com/ijsnow/goql/schema"
+)
+
+const serverSDL = `type Query {
+  pets(kind: Kind, first: Int = 10): [Pet!]!
+  pet(id: ID!): Pet
+  search(text: String!): [SearchResult]
+  now: DateTime
+}
+
+type Mutation {
+  adopt(input: AdoptInput!): Dog
+}
+
+"A pet."
+interface Pet {
+  id: ID!
+  name: String
+}
+
+type Dog implements Pet {
+  id: ID!
+  name: String
+  "Use name."
+  nickname: String @deprecated(reason: "Use name.")
+  kind: Kind!
+  friends(first: Int): [Pet]
+}
+
+type Cat implements Pet {
+  id: ID!
+  name: String
+  owner: Owner
+}
+
+type Owner {
+  name: String!
+}
+
+union SearchResult = Dog | Owner
+
+enum Kind {
+  DOG
+  CAT
+  GREAT_DANE
+}
+
+input AdoptInput {
+  petId: ID!
+  tags: [String!]
+}
+
+scalar DateTime
+`
+
+func generateServer(t *testing.T, models map[string]string) string {
+	t.Helper()
+
+	s, err := schema.BuildFromSDL(serverSDL)
+	if err != nil {
+		t.Fatalf("BuildFromSDL; got error %v", err)
+	}
+
+	config := &Config{
+		Models: models,
+		Server: &PackageConfig{Output: "graph/generated.go", Package: "graph"},
+	}
+	source, err := GenerateServer(config, s, serverSDL)
+	if err != nil {
+		t.Fatalf("GenerateServer; got error %v", err)
+	}
+
+	typeCheck(t, source)
+
+	return string(source)
+}
+
+// modelsSource stands in for the package of the models the tests map
+// GraphQL types to.
+const modelsSource = `package models
+
+type Owner struct {
+	Name string
+}
+
+type Kind string
+`
+
+// sourceImporter imports packages from their sources, and is shared by the
+// tests so that each package is only type-checked once.
+var sourceImporter = importer.ForCompiler(token.NewFileSet(), "source", nil)
+
+// typeCheck fails the test unless the generated source compiles against the
+// packages it imports, which are type-checked from their sources.
+func typeCheck(t *testing.T, source []byte) {
+	t.Helper()
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "generated.go", source, 0)
+	if err != nil {
+		t.Fatalf("ParseFile; got error %v", err)
+	}
+
+	imports := &stubImporter{
+		fset:     fset,
+		stubs:    map[string]string{"github.com/acme/app/models": modelsSource},
+		fallback: sourceImporter,
+	}
+	config := types.Config{Importer: imports}
+	if _, err := config.Check(file.Name.Name, fset, []*ast.File{file}, nil); err != nil {
+		t.Fatalf("Check; got error %v in\n%s", err, source)
+	}
+}
+
+// stubImporter imports the packages of stubs from their source, and the
+// other packages from the fallback importer.
+type stubImporter struct {
+	fset     *token.FileSet
+	stubs    map[string]string
+	fallback types.Importer
+}
+
+func (i *stubImporter) Import(path string) (*types.Package, error) {
+	source, ok := i.stubs[path]
+	if !ok {
+		return i.fallback.Import(path)
+	}
+
+	file, err := parser.ParseFile(i.fset, path+".go", source, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	config := types.Config{Importer: i}
+	return config.Check(path, i.fset, []*ast.File{file}, nil)
+}
+
+func TestGenerateServer(t *testing.T) {
+	source := generateServer(t, nil)
+
+	snippets := []string{
+		"// Code generated by goql generate. DO NOT EDIT.\n\npackage graph\n",
+		"type Kind string",
+		`KindGreatDane Kind = "GREAT_DANE"`,
+		"// A pet.\ntype Pet interface {\n\tIsPet()\n}",
+		"type SearchResult interface {\n\tIsSearchResult()\n}",
+		"func (*Dog) IsPet() {}",
+		"// IsSearchResult implements SearchResult.\nfunc (*Dog) IsSearchResult() {}",
+		"\t// Deprecated: Use name.\n\tNickname *string `graphql:\"nickname\" json:\"nickname\"`",
+		"Kind     Kind    `graphql:\"kind\" json:\"kind\"`",
+		"PetID string   `graphql:\"petId\" json:\"petId\"`",
+		"Tags  []string `graphql:\"tags\" json:\"tags\"`",
+		"Pets(ctx context.Context, kind *Kind, first *int) ([]Pet, error)",
+		"Pet(ctx context.Context, id string) (Pet, error)",
+		"Now(ctx context.Context) (interface{}, error)",
+		"Friends(ctx context.Context, obj *Dog, first *int) ([]Pet, error)",
+		"Adopt(ctx context.Context, input AdoptInput) (*Dog, error)",
+		"type ResolverRoot interface {\n\tDog() DogResolver\n\tMutation() MutationResolver\n\tQuery() QueryResolver\n}",
+		`"Dog.name": func(p schema.ResolveParams) (interface{}, error) {`,
+		"return p.Source.(*Dog).Name, nil",
+		"return config.Resolvers.Dog().Friends(p.Context, p.Source.(*Dog), args.First)",
+		"case *Owner:\n\t\t\t\t\treturn s.GetType(\"Owner\").(*schema.Object)",
+		`"GREAT_DANE": KindGreatDane,`,
+		"const sdl = `type Query {",
+	}
+	for _, snippet := range snippets {
+		if !strings.Contains(source, snippet) {
+			t.Errorf("generated code; got\n%s\nwanted it to contain\n%s", source, snippet)
+		}
+	}
+
+	// The root types and the custom scalars have no models.
+	for _, missing := range []string{"type Query struct", "type Mutation struct", "type DateTime"} {
+		if strings.Contains(source, missing) {
+			t.Errorf("generated code; got %q wanted none", missing)
+		}
+	}
+}
+
+func TestGenerateServerModels(t *testing.T) {
+	source := generateServer(t, map[string]string{
+		"DateTime": "time.Time",
+		"Owner":    "github.com/acme/app/models.Owner",
+		"Kind":     "github.com/acme/app/models.Kind",
+	})
+
+	snippets := []string{
+		"import (\n\t\"context\"\n\t\"time\"\n\n\t\"github.com/acme/app/models\"\n\t\"github.com/ijsnow/goql/schema\"\n)",
+		"Now(ctx context.Context) (*time.Time, error)",
+		"Owner *models.Owner `graphql:\"owner\" json:\"owner\"`",
+		"Pets(ctx context.Context, kind *models.Kind, first *int) ([]Pet, error)",
+		`"GREAT_DANE": models.Kind("GREAT_DANE"),`,
+	}
+	for _, snippet := range snippets {
+		if !strings.Contains(source, snippet) {
+			t.Errorf("generated code; got\n%s\nwanted it to contain\n%s", source, snippet)
+		}
+	}
+
+	// Mapped types are neither generated nor read by the generated glue.
+	for _, missing := range []string{"type Owner struct", "type Kind string", `"Owner.name"`} {
+		if strings.Contains(source, missing) {
+			t.Errorf("generated code; got %q wanted none", missing)
+		}
+	}
+}
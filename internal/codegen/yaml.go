@@ -0,0 +1,405 @@
+package codegen
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// yamlToJSON converts a YAML configuration file to JSON, so that it is
+// decoded and checked like a JSON one. It reads the subset of YAML that
+// configurations are written in: block mappings and sequences nested by
+// indentation, flow sequences and mappings such as [a, b] and {output: a.go},
+// plain, single-quoted and double-quoted scalars, and comments. Every scalar
+// other than null and ~ is read as a string. Anchors, tags, multi-line
+// scalars and mappings inside block sequences are not supported.
+func yamlToJSON(data []byte) ([]byte, error) {
+	p := &yamlParser{}
+	for idx, line := range strings.Split(strings.Replace(string(data), "\r\n", "\n", -1), "\n") {
+		if err := p.addLine(idx+1, line); err != nil {
+			return nil, err
+		}
+	}
+
+	var value interface{} = map[string]interface{}{}
+	if len(p.lines) > 0 {
+		var err error
+		if value, err = p.parseBlock(p.lines[0].indent); err != nil {
+			return nil, err
+		}
+		if p.pos < len(p.lines) {
+			return nil, p.lines[p.pos].errorf("unexpected indentation")
+		}
+	}
+
+	return json.Marshal(value)
+}
+
+// yamlLine is a line of a YAML document without its indentation and
+// comment.
+type yamlLine struct {
+	num    int
+	indent int
+	text   string
+}
+
+func (l yamlLine) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("line %d: %s", l.num, fmt.Sprintf(format, args...))
+}
+
+type yamlParser struct {
+	lines []yamlLine
+	pos   int
+}
+
+// addLine records a line unless it is blank, a comment or a document
+// marker.
+func (p *yamlParser) addLine(num int, line string) error {
+	text := strings.TrimRight(stripYAMLComment(line), " \t")
+	content := strings.TrimLeft(text, " ")
+	if content == "" || content == "---" {
+		return nil
+	}
+
+	l := yamlLine{num: num, indent: len(text) - len(content), text: content}
+	if strings.HasPrefix(content, "\t") {
+		return l.errorf("tabs are not allowed for indentation")
+	}
+
+	p.lines = append(p.lines, l)
+	return nil
+}
+
+// stripYAMLComment removes the comment a line ends with, if any. Comments
+// start with a # at the start of the line or after a space, outside of
+// quoted scalars. Quotes only start a scalar at the start of a value, so that
+// apostrophes in plain scalars do not hide comments.
+func stripYAMLComment(line string) string {
+	var quote byte
+	for idx := 0; idx < len(line); idx++ {
+		switch c := line[idx]; {
+		case quote == '"' && c == '\\':
+			idx++
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case (c == '"' || c == '\'') && (idx == 0 || strings.IndexByte(" \t[{,", line[idx-1]) >= 0):
+			quote = c
+		case c == '#' && (idx == 0 || line[idx-1] == ' ' || line[idx-1] == '\t'):
+			return line[:idx]
+		}
+	}
+
+	return line
+}
+
+// parseBlock parses the mapping or sequence whose lines have the given
+// indentation.
+func (p *yamlParser) parseBlock(indent int) (interface{}, error) {
+	if isYAMLSequenceItem(p.lines[p.pos].text) {
+		return p.parseSequence(indent)
+	}
+
+	return p.parseMapping(indent)
+}
+
+func (p *yamlParser) parseMapping(indent int) (interface{}, error) {
+	mapping := map[string]interface{}{}
+	for p.pos < len(p.lines) && p.lines[p.pos].indent == indent {
+		line := p.lines[p.pos]
+		if isYAMLSequenceItem(line.text) {
+			return nil, line.errorf("expected a key, but found a sequence item")
+		}
+
+		key, rest, err := splitYAMLKey(line)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := mapping[key]; ok {
+			return nil, line.errorf("key %q is defined more than once", key)
+		}
+		p.pos++
+
+		var value interface{}
+		switch {
+		case rest != "":
+			if value, err = parseYAMLFlow(line, rest); err != nil {
+				return nil, err
+			}
+		case p.pos < len(p.lines) && p.lines[p.pos].indent > indent:
+			if value, err = p.parseBlock(p.lines[p.pos].indent); err != nil {
+				return nil, err
+			}
+		case p.pos < len(p.lines) && p.lines[p.pos].indent == indent && isYAMLSequenceItem(p.lines[p.pos].text):
+			// Sequences may be indented as much as the key they are the
+			// value of.
+			if value, err = p.parseSequence(indent); err != nil {
+				return nil, err
+			}
+		}
+		mapping[key] = value
+	}
+
+	if p.pos < len(p.lines) && p.lines[p.pos].indent > indent {
+		return nil, p.lines[p.pos].errorf("unexpected indentation")
+	}
+
+	return mapping, nil
+}
+
+func (p *yamlParser) parseSequence(indent int) (interface{}, error) {
+	sequence := []interface{}{}
+	for p.pos < len(p.lines) && p.lines[p.pos].indent == indent && isYAMLSequenceItem(p.lines[p.pos].text) {
+		line := p.lines[p.pos]
+		item := strings.TrimSpace(line.text[1:])
+		p.pos++
+
+		var value interface{}
+		switch {
+		case item != "":
+			if _, _, err := splitYAMLKey(yamlLine{text: item}); err == nil && !isYAMLFlowStart(item) {
+				return nil, line.errorf("mappings in sequences are not supported")
+			}
+
+			var err error
+			if value, err = parseYAMLFlow(line, item); err != nil {
+				return nil, err
+			}
+		case p.pos < len(p.lines) && p.lines[p.pos].indent > indent:
+			var err error
+			if value, err = p.parseBlock(p.lines[p.pos].indent); err != nil {
+				return nil, err
+			}
+		}
+		sequence = append(sequence, value)
+	}
+
+	return sequence, nil
+}
+
+func isYAMLSequenceItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+func isYAMLFlowStart(text string) bool {
+	return strings.HasPrefix(text, "[") || strings.HasPrefix(text, "{") ||
+		strings.HasPrefix(text, `"`) || strings.HasPrefix(text, "'")
+}
+
+// splitYAMLKey splits a line of a mapping into its key and the text of its
+// value, which is empty when the value is given by the following lines.
+func splitYAMLKey(line yamlLine) (string, string, error) {
+	s := &yamlScanner{line: line, text: line.text}
+
+	var key string
+	if s.peek() == '"' || s.peek() == '\'' {
+		quoted, err := s.quoted()
+		if err != nil {
+			return "", "", err
+		}
+		key = quoted
+	} else {
+		key = strings.TrimSpace(s.until(":"))
+		if key == "" {
+			return "", "", line.errorf("expected a key")
+		}
+	}
+
+	if s.peek() != ':' {
+		return "", "", line.errorf("expected a colon after the key %q", key)
+	}
+	s.pos++
+	if s.pos < len(s.text) && s.text[s.pos] != ' ' {
+		return "", "", line.errorf("expected a space after the colon of the key %q", key)
+	}
+
+	return key, strings.TrimSpace(s.text[s.pos:]), nil
+}
+
+// parseYAMLFlow parses the value following a key or a sequence item dash.
+func parseYAMLFlow(line yamlLine, text string) (interface{}, error) {
+	s := &yamlScanner{line: line, text: text}
+
+	var value interface{}
+	var err error
+	if isYAMLFlowStart(text) {
+		if value, err = s.value(""); err != nil {
+			return nil, err
+		}
+	} else {
+		// Plain scalars outside of flow collections run to the end of the
+		// line, commas and colons included.
+		value = plainYAMLScalar(s.until(""))
+	}
+
+	s.skipSpaces()
+	if s.pos < len(s.text) {
+		return nil, line.errorf("unexpected %q after the value", s.text[s.pos:])
+	}
+
+	return value, nil
+}
+
+// yamlScanner reads the values of flow collections.
+type yamlScanner struct {
+	line yamlLine
+	text string
+	pos  int
+}
+
+func (s *yamlScanner) peek() byte {
+	s.skipSpaces()
+	if s.pos >= len(s.text) {
+		return 0
+	}
+
+	return s.text[s.pos]
+}
+
+func (s *yamlScanner) skipSpaces() {
+	for s.pos < len(s.text) && s.text[s.pos] == ' ' {
+		s.pos++
+	}
+}
+
+// until reads text up to, but not including, the first of the stop
+// characters, where a colon only stops when followed by a space or the end.
+func (s *yamlScanner) until(stops string) string {
+	start := s.pos
+	for ; s.pos < len(s.text); s.pos++ {
+		c := s.text[s.pos]
+		if c == ':' && strings.IndexByte(stops, ':') >= 0 {
+			if s.pos+1 == len(s.text) || s.text[s.pos+1] == ' ' || strings.IndexByte(stops, s.text[s.pos+1]) >= 0 {
+				break
+			}
+			continue
+		}
+		if strings.IndexByte(stops, c) >= 0 {
+			break
+		}
+	}
+
+	return s.text[start:s.pos]
+}
+
+// value reads a value of a flow collection, ending at one of the stop
+// characters.
+func (s *yamlScanner) value(stops string) (interface{}, error) {
+	switch s.peek() {
+	case '[':
+		return s.sequence()
+	case '{':
+		return s.mapping()
+	case '"', '\'':
+		return s.quoted()
+	case 0:
+		return nil, nil
+	}
+
+	return plainYAMLScalar(s.until(stops)), nil
+}
+
+func (s *yamlScanner) sequence() (interface{}, error) {
+	s.pos++
+	sequence := []interface{}{}
+	for {
+		if s.peek() == ']' {
+			s.pos++
+			return sequence, nil
+		}
+
+		value, err := s.value(",]")
+		if err != nil {
+			return nil, err
+		}
+		sequence = append(sequence, value)
+
+		switch s.peek() {
+		case ',':
+			s.pos++
+		case ']':
+		default:
+			return nil, s.line.errorf("expected , or ] in a flow sequence")
+		}
+	}
+}
+
+func (s *yamlScanner) mapping() (interface{}, error) {
+	s.pos++
+	mapping := map[string]interface{}{}
+	for {
+		if s.peek() == '}' {
+			s.pos++
+			return mapping, nil
+		}
+
+		var key string
+		if c := s.peek(); c == '"' || c == '\'' {
+			quoted, err := s.quoted()
+			if err != nil {
+				return nil, err
+			}
+			key = quoted
+		} else {
+			key = strings.TrimSpace(s.until(":,}"))
+		}
+		if s.peek() != ':' {
+			return nil, s.line.errorf("expected a colon after the key %q in a flow mapping", key)
+		}
+		s.pos++
+
+		value, err := s.value(",}")
+		if err != nil {
+			return nil, err
+		}
+		mapping[key] = value
+
+		switch s.peek() {
+		case ',':
+			s.pos++
+		case '}':
+		default:
+			return nil, s.line.errorf("expected , or } in a flow mapping")
+		}
+	}
+}
+
+// quoted reads a single-quoted or double-quoted scalar.
+func (s *yamlScanner) quoted() (string, error) {
+	quote := s.text[s.pos]
+	for end := s.pos + 1; end < len(s.text); end++ {
+		switch c := s.text[end]; {
+		case quote == '"' && c == '\\':
+			end++
+		case c == quote && quote == '\'' && end+1 < len(s.text) && s.text[end+1] == '\'':
+			end++
+		case c == quote:
+			raw := s.text[s.pos : end+1]
+			s.pos = end + 1
+			if quote == '\'' {
+				return strings.Replace(raw[1:len(raw)-1], "''", "'", -1), nil
+			}
+
+			value, err := strconv.Unquote(raw)
+			if err != nil {
+				return "", s.line.errorf("invalid double-quoted scalar %s", raw)
+			}
+			return value, nil
+		}
+	}
+
+	return "", s.line.errorf("unterminated quoted scalar")
+}
+
+// plainYAMLScalar returns the value of a plain scalar, which is nil for
+// null and ~ and otherwise the scalar as a string.
+func plainYAMLScalar(text string) interface{} {
+	switch text = strings.TrimSpace(text); text {
+	case "", "~", "null", "Null", "NULL":
+		return nil
+	}
+
+	return text
+}
@@ -0,0 +1,74 @@
+package codegen
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestYAMLToJSON(t *testing.T) {
+	tests := []struct {
+		yaml string
+		want string
+	}{
+		{"", `{}`},
+		{"# Only a comment.\n---\n", `{}`},
+		{
+			"schema:\n  - schema/*.graphql # The SDL.\n  - 'extra.graphql'\n",
+			`{"schema":["schema/*.graphql","extra.graphql"]}`,
+		},
+		{
+			"schema:\n- a.graphql\noperations: [ops/*.graphql, \"b, c.graphql\"]\n",
+			`{"operations":["ops/*.graphql","b, c.graphql"],"schema":["a.graphql"]}`,
+		},
+		{
+			"models:\n  DateTime: time.Time\n  \"User\": github.com/acme/app/models.User\n",
+			`{"models":{"DateTime":"time.Time","User":"github.com/acme/app/models.User"}}`,
+		},
+		{
+			"server: {output: graph/generated.go, package: graph}\nclient:\n  output: api/generated.go\n  package: ~\n",
+			`{"client":{"output":"api/generated.go","package":null},"server":{"output":"graph/generated.go","package":"graph"}}`,
+		},
+		{
+			"name: it's number#1 # The name.\nquoted: \"a # b\\tc\"\nsingle: 'it''s'\nempty:\n",
+			`{"empty":null,"name":"it's number#1","quoted":"a # b\tc","single":"it's"}`,
+		},
+		{"url: http://example.com/a:b\n", `{"url":"http://example.com/a:b"}`},
+	}
+
+	for _, test := range tests {
+		got, err := yamlToJSON([]byte(test.yaml))
+		if err != nil {
+			t.Errorf("yamlToJSON(%q); got error %v", test.yaml, err)
+			continue
+		}
+		if string(got) != test.want {
+			t.Errorf("yamlToJSON(%q); got %s wanted %s", test.yaml, got, test.want)
+		}
+	}
+}
+
+func TestYAMLToJSONErrors(t *testing.T) {
+	tests := []struct {
+		yaml string
+		want string
+	}{
+		{"a: b\n  c: d\n", "line 2: unexpected indentation"},
+		{"a: b\na: c\n", `line 2: key "a" is defined more than once`},
+		{"a\n", `line 1: expected a colon after the key "a"`},
+		{"a:b\n", `line 1: expected a colon after the key "a:b"`},
+		{"a:\n- b\n- c: d\n", "line 3: mappings in sequences are not supported"},
+		{"a:\n\t- b\n", "line 2: tabs are not allowed for indentation"},
+		{"a: [b, c\n", "line 1: expected , or ] in a flow sequence"},
+		{"a: {b c}\n", `line 1: expected a colon after the key "b c" in a flow mapping`},
+		{"a: \"b\n", "line 1: unterminated quoted scalar"},
+		{"a: \"b\" c\n", `line 1: unexpected "c" after the value`},
+		{"a: b\n- c\n", "line 2: expected a key, but found a sequence item"},
+	}
+
+	for _, test := range tests {
+		_, err := yamlToJSON([]byte(test.yaml))
+		if err == nil || !strings.HasPrefix(err.Error(), test.want) {
+			t.Errorf("yamlToJSON(%q); got error %v wanted %s", test.yaml, err, test.want)
+		}
+	}
+}
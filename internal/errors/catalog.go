@@ -0,0 +1,117 @@
+package errors
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// DefaultLocale is the locale of the built-in messages, and the locale used
+// when no translation exists for the requested one.
+const DefaultLocale = "en"
+
+// MessageID identifies a message in the catalog. IDs are stable across
+// releases, so translations registered for them keep working.
+type MessageID string
+
+// Message is a message from the catalog along with the values for the
+// placeholders of its template. Placeholders are written as {name}.
+type Message struct {
+	ID   MessageID
+	Args map[string]interface{}
+}
+
+// localizer is implemented by template arguments that need the locale to be
+// rendered, such as nested messages.
+type localizer interface {
+	Localize(locale string) string
+}
+
+var placeholder = regexp.MustCompile(`\{(\w+)\}`)
+
+// Localize renders the message in the given locale.
+func (m Message) Localize(locale string) string {
+	return placeholder.ReplaceAllStringFunc(lookupTemplate(locale, m.ID), func(match string) string {
+		arg, ok := m.Args[match[1:len(match)-1]]
+		if !ok {
+			return match
+		}
+
+		if l, ok := arg.(localizer); ok {
+			return l.Localize(locale)
+		}
+
+		return fmt.Sprint(arg)
+	})
+}
+
+// String renders the message in the default locale.
+func (m Message) String() string {
+	return m.Localize(DefaultLocale)
+}
+
+var (
+	catalogMu sync.RWMutex
+	catalogs  = map[string]map[MessageID]string{
+		DefaultLocale: defaultMessages,
+	}
+)
+
+// RegisterTranslations adds the templates for a locale to the catalog,
+// replacing any previously registered for the same IDs. Messages without a
+// translation fall back to the base language of the locale ("pt" for
+// "pt-BR"), then to DefaultLocale.
+func RegisterTranslations(locale string, templates map[MessageID]string) {
+	locale = normalizeLocale(locale)
+
+	catalogMu.Lock()
+	defer catalogMu.Unlock()
+
+	catalog := make(map[MessageID]string, len(catalogs[locale])+len(templates))
+	for id, template := range catalogs[locale] {
+		catalog[id] = template
+	}
+	for id, template := range templates {
+		catalog[id] = template
+	}
+
+	catalogs[locale] = catalog
+}
+
+// DefaultMessages returns a copy of the built-in templates keyed by ID, as a
+// starting point for translations.
+func DefaultMessages() map[MessageID]string {
+	out := make(map[MessageID]string, len(defaultMessages))
+	for id, template := range defaultMessages {
+		out[id] = template
+	}
+
+	return out
+}
+
+func lookupTemplate(locale string, id MessageID) string {
+	catalogMu.RLock()
+	defer catalogMu.RUnlock()
+
+	locale = normalizeLocale(locale)
+	for _, candidate := range []string{locale, baseLanguage(locale), DefaultLocale} {
+		if template, ok := catalogs[candidate][id]; ok {
+			return template
+		}
+	}
+
+	return string(id)
+}
+
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.Replace(locale, "_", "-", -1))
+}
+
+func baseLanguage(locale string) string {
+	if idx := strings.Index(locale, "-"); idx >= 0 {
+		return locale[:idx]
+	}
+
+	return locale
+}
@@ -0,0 +1,121 @@
+package errors
+
+import (
+	"testing"
+
+	"github.com/ijsnow/goql/internal/language"
+)
+
+// registerFrench registers French translations for the duration of a test,
+// restoring the catalog when it ends.
+func registerFrench(t *testing.T) {
+	catalogMu.RLock()
+	saved := make(map[string]map[MessageID]string, len(catalogs))
+	for locale, catalog := range catalogs {
+		saved[locale] = catalog
+	}
+	catalogMu.RUnlock()
+
+	t.Cleanup(func() {
+		catalogMu.Lock()
+		defer catalogMu.Unlock()
+		catalogs = saved
+	})
+
+	RegisterTranslations("fr", map[MessageID]string{
+		MsgUnknownError:       "Une erreur inconnue s'est produite.",
+		MsgUnknownEnumValue:   "La valeur « {value} » n'existe pas dans l'énumération « {enum} ».{suggestion}",
+		MsgDidYouMean:         "Vouliez-vous dire {suggestions} ?",
+		MsgOr:                 "ou",
+		MsgSyntaxError:        "Erreur de syntaxe {source} ({line}:{column}) {description}",
+		MsgUnterminatedString: "Chaîne non terminée.",
+	})
+}
+
+func TestLocalizesMessages(t *testing.T) {
+	registerFrench(t)
+
+	tests := []struct {
+		locale string
+		want   string
+	}{
+		{"", `An unknown error occurred.`},
+		{"en", `An unknown error occurred.`},
+		{"fr", `Une erreur inconnue s'est produite.`},
+		{"fr_CA", `Une erreur inconnue s'est produite.`},
+		{"de", `An unknown error occurred.`},
+	}
+
+	e := NewValidationError(Message{ID: MsgUnknownError}).(*GraphQLError)
+	for _, test := range tests {
+		formatted, err := NewFormatError(e, FormatOptions{Locale: test.locale})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if formatted.Message != test.want {
+			t.Errorf("unexpected message for locale %q; got\n%v\nwanted\n%v", test.locale, formatted.Message, test.want)
+		}
+	}
+
+	if e.Message != tests[0].want {
+		t.Errorf("expected message in the default locale; got %v", e.Message)
+	}
+}
+
+func TestLocalizesQuotedOrList(t *testing.T) {
+	registerFrench(t)
+
+	if got := quotedOrList([]string{"A", "B"}).Localize("fr"); got != `"A" ou "B"` {
+		t.Errorf("unexpected list; got %v", got)
+	}
+}
+
+func TestLocalizesSuggestions(t *testing.T) {
+	registerFrench(t)
+
+	message := UnknownEnumValueMessage("SITT", "DogCommand", []string{"SIT", "STAY"})
+
+	want := `La valeur « SITT » n'existe pas dans l'énumération « DogCommand ». Vouliez-vous dire "SIT" ou "STAY" ?`
+	if got := message.Localize("fr"); got != want {
+		t.Errorf("unexpected message; got\n%v\nwanted\n%v", got, want)
+	}
+}
+
+func TestLocalizesSyntaxErrors(t *testing.T) {
+	registerFrench(t)
+
+	source := language.NewSource(`"abc`)
+	e := NewSyntaxError(source, 4, Message{ID: MsgUnterminatedString}).(*GraphQLError)
+
+	want := "Erreur de syntaxe GraphQL request (1:5) Chaîne non terminée.\n\n1: \"abc\n       ^\n"
+	if got := e.LocalizedMessage("fr"); got != want {
+		t.Errorf("unexpected message; got\n%q\nwanted\n%q", got, want)
+	}
+
+	want = "Erreur de syntaxe GraphQL request (1:5) Chaîne non terminée."
+	if got := PrintError(e, PrintOptions{Locale: "fr"}); got[:len(want)] != want {
+		t.Errorf("unexpected printed error; got\n%v", got)
+	}
+}
+
+func TestErrorsWithoutCatalogMessageAreNotLocalized(t *testing.T) {
+	registerFrench(t)
+
+	e := NewGraphQLError("custom", nil, nil, nil, nil, nil, nil).(*GraphQLError)
+
+	if got := e.LocalizedMessage("fr"); got != "custom" {
+		t.Errorf("unexpected message; got %v", got)
+	}
+}
+
+func TestRegisteredTranslationsAreRestored(t *testing.T) {
+	t.Run("register", registerFrench)
+
+	catalogMu.RLock()
+	_, registered := catalogs["fr"]
+	catalogMu.RUnlock()
+	if registered {
+		t.Errorf("fr translations; still registered after the test")
+	}
+}
@@ -0,0 +1,93 @@
+package errors
+
+import "errors"
+
+// Code is a machine-readable classification of a GraphQLError. It is exposed
+// to clients as the "code" key of the error's extensions.
+type Code string
+
+// The codes a GraphQLError may carry.
+const (
+	// CodeParseFailed is used for syntax errors in the GraphQL document.
+	CodeParseFailed Code = "GRAPHQL_PARSE_FAILED"
+
+	// CodeValidationFailed is used when a document fails validation against the schema.
+	CodeValidationFailed Code = "GRAPHQL_VALIDATION_FAILED"
+
+	// CodeBadUserInput is used when an argument or variable value is invalid.
+	CodeBadUserInput Code = "BAD_USER_INPUT"
+
+	// CodeUnauthenticated is used when the request is not authenticated.
+	CodeUnauthenticated Code = "UNAUTHENTICATED"
+
+	// CodeForbidden is used when the request is not authorized to access a field.
+	CodeForbidden Code = "FORBIDDEN"
+
+	// CodeInternalServerError is used for any error that was not classified,
+	// including errors returned from field resolvers.
+	CodeInternalServerError Code = "INTERNAL_SERVER_ERROR"
+)
+
+// Sentinels for the phase that produced a GraphQLError. Use errors.Is to
+// test an error against them.
+var (
+	ErrSyntax     = errors.New("graphql: syntax error")
+	ErrValidation = errors.New("graphql: validation error")
+	ErrExecution  = errors.New("graphql: execution error")
+)
+
+// coder is implemented by errors that know their own Code.
+type coder interface {
+	Code() Code
+}
+
+// codedError attaches a Code to an arbitrary error.
+type codedError struct {
+	err  error
+	code Code
+}
+
+func (e codedError) Error() string {
+	return e.err.Error()
+}
+
+func (e codedError) Unwrap() error {
+	return e.err
+}
+
+func (e codedError) Code() Code {
+	return e.code
+}
+
+// WithCode annotates err with the given code. When the error is returned from a
+// field resolver, the resulting GraphQLError carries the code in its extensions
+// instead of CodeInternalServerError.
+func WithCode(err error, code Code) error {
+	if err == nil {
+		return nil
+	}
+
+	return codedError{err: err, code: code}
+}
+
+// codeOf finds the first Code in err's chain, falling back to def.
+func codeOf(err error, def Code) Code {
+	for ; err != nil; err = errors.Unwrap(err) {
+		if c, ok := err.(coder); ok && c.Code() != "" {
+			return c.Code()
+		}
+	}
+
+	return def
+}
+
+// withCode returns a copy of extensions with the code key set.
+func withCode(extensions map[string]interface{}, code Code) map[string]interface{} {
+	out := make(map[string]interface{}, len(extensions)+1)
+	for k, v := range extensions {
+		out[k] = v
+	}
+	out["code"] = code
+
+	return out
+}
@@ -0,0 +1,105 @@
+package errors
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ijsnow/goql/internal/language"
+)
+
+type resolverError struct{}
+
+func (resolverError) Error() string { return "boom" }
+
+func TestLocatedErrorUnwrapsToOriginalError(t *testing.T) {
+	e := NewLocatedError(resolverError{}, nil, []interface{}{"field"})
+
+	var target resolverError
+	if !errors.As(e, &target) {
+		t.Errorf("expected errors.As to reach the resolver error")
+	}
+
+	if !errors.Is(e, ErrExecution) {
+		t.Errorf("expected located error to match ErrExecution")
+	}
+
+	if errors.Is(e, ErrSyntax) {
+		t.Errorf("did not expect located error to match ErrSyntax")
+	}
+
+	var gqlerr *GraphQLError
+	if !errors.As(e, &gqlerr) {
+		t.Fatal("expected errors.As to find the GraphQLError")
+	}
+
+	if gqlerr.Code() != CodeInternalServerError {
+		t.Errorf("unexpected code; got %v wanted %v", gqlerr.Code(), CodeInternalServerError)
+	}
+
+	if gqlerr.Message != "boom" {
+		t.Errorf("unexpected message; got %v wanted %v", gqlerr.Message, "boom")
+	}
+}
+
+func TestLocatedErrorUsesCodeFromOriginalError(t *testing.T) {
+	e := NewLocatedError(WithCode(resolverError{}, CodeBadUserInput), nil, []interface{}{"field"})
+
+	testMarshal(t, e, `{"message":"boom","path":["field"],"extensions":{"code":"BAD_USER_INPUT"}}`)
+
+	if !errors.Is(e, ErrExecution) {
+		t.Errorf("expected located error to match ErrExecution")
+	}
+}
+
+func TestLocatedErrorKeepsKindOfOriginalError(t *testing.T) {
+	tests := []struct {
+		orig error
+		kind error
+	}{
+		{NewSyntaxError(language.NewSource("{"), 1, Message{ID: MsgUnterminatedString}), ErrSyntax},
+		{NewValidationError(Message{ID: MsgUnknownError}), ErrValidation},
+	}
+
+	for _, test := range tests {
+		e := NewLocatedError(test.orig, nil, []interface{}{"field"})
+
+		if !errors.Is(e, test.kind) {
+			t.Errorf("expected located error to match %v", test.kind)
+		}
+
+		if errors.Is(e, ErrExecution) {
+			t.Errorf("did not expect located %v error to match ErrExecution", test.kind)
+		}
+	}
+}
+
+func TestLocatedErrorReturnsErrorsWithPath(t *testing.T) {
+	orig := NewLocatedError(resolverError{}, nil, []interface{}{"a"})
+	e := NewLocatedError(orig, nil, []interface{}{"b"})
+
+	if e != orig {
+		t.Errorf("expected error with a path to be returned unchanged")
+	}
+}
+
+func TestSyntaxErrorIsClassified(t *testing.T) {
+	e := NewSyntaxError(language.NewSource("{"), 1, Message{ID: MsgUnterminatedString})
+
+	if !errors.Is(e, ErrSyntax) {
+		t.Errorf("expected syntax error to match ErrSyntax")
+	}
+
+	if code := e.(*GraphQLError).Code(); code != CodeParseFailed {
+		t.Errorf("unexpected code; got %v wanted %v", code, CodeParseFailed)
+	}
+}
+
+func TestValidationErrorIsClassified(t *testing.T) {
+	e := NewValidationError(Message{ID: MsgUnknownError})
+
+	if !errors.Is(e, ErrValidation) {
+		t.Errorf("expected validation error to match ErrValidation")
+	}
+
+	testMarshal(t, e, `{"message":"An unknown error occurred.","extensions":{"code":"GRAPHQL_VALIDATION_FAILED"}}`)
+}
@@ -6,23 +6,72 @@ import (
 	"github.com/ijsnow/goql/internal/language"
 )
 
+// FormatOptions options to control how errors are formatted
+type FormatOptions struct {
+	/**
+	 * Debug includes the stack trace captured when the error was created
+	 * under the "stacktrace" key of the extensions. It is meant for
+	 * development only, as it exposes the layout of the server's code.
+	 */
+	Debug bool
+
+	/**
+	 * Presenter is applied to the error before it is formatted, to decide
+	 * what clients see of it. See NewMaskingPresenter.
+	 */
+	Presenter Presenter
+
+	/**
+	 * Locale is the locale messages from the catalog are rendered in.
+	 * Defaults to DefaultLocale.
+	 */
+	Locale string
+}
+
 // NewFormatError takes a GraphQLError and formats it according to the rules described by the
 // Response Format, Errors section of the GraphQL Specification.
-func NewFormatError(gqlerr *GraphQLError) (*GraphQLFormattedError, error) {
+func NewFormatError(gqlerr *GraphQLError, options ...FormatOptions) (*GraphQLFormattedError, error) {
 	if gqlerr == nil {
 		return nil, errors.New("cannot format nil error")
 	}
 
+	var opts FormatOptions
+	if len(options) > 0 {
+		opts = options[0]
+	}
+
+	locale := opts.Locale
+	if locale == "" {
+		locale = DefaultLocale
+	}
+
+	if opts.Presenter != nil {
+		gqlerr = opts.Presenter(gqlerr)
+	}
+
+	extensions := gqlerr.Extensions
+	if opts.Debug && len(gqlerr.stack) > 0 {
+		extensions = make(map[string]interface{}, len(gqlerr.Extensions)+1)
+		for k, v := range gqlerr.Extensions {
+			extensions[k] = v
+		}
+		extensions["stacktrace"] = gqlerr.stack.Lines()
+	}
+
 	return &GraphQLFormattedError{
-		Message:   gqlerr.Message,
-		Locations: gqlerr.Locations,
-		Path:      gqlerr.Path,
+		Message:    gqlerr.LocalizedMessage(locale),
+		Locations:  gqlerr.Locations,
+		Path:       gqlerr.Path,
+		Extensions: extensions,
 	}, nil
 }
 
-// GraphQLFormattedError is an error formatted for marshalling
+// GraphQLFormattedError is an error formatted for marshalling. Empty
+// locations, path and extensions are omitted so the output matches the
+// GraphQL response format exactly.
 type GraphQLFormattedError struct {
-	Message   string                    `json:"message"`
-	Locations []language.SourceLocation `json:"locations"`
-	Path      []string                  `json:"path"`
+	Message    string                    `json:"message"`
+	Locations  []language.SourceLocation `json:"locations,omitempty"`
+	Path       []interface{}             `json:"path,omitempty"`
+	Extensions map[string]interface{}    `json:"extensions,omitempty"`
 }
@@ -26,15 +26,24 @@ type GraphQLError struct {
 	 *
 	 * Enumerable, and appears in the result of JSON.stringify().
 	 */
-	Locations []language.SourceLocation `json:"locations"`
+	Locations []language.SourceLocation `json:"locations,omitempty"`
 
 	/**
 	 * Path is an array describing the JSON-path into the execution response which
 	 * corresponds to this error. Only included for errors during execution.
+	 * Segments are either field names (string) or list indices (int).
 	 *
 	 * Enumerable, and appears in the result of JSON.stringify().
 	 */
-	Path []string `json:"path"`
+	Path []interface{} `json:"path,omitempty"`
+
+	/**
+	 * Extensions is a map of additional information a server may attach to the
+	 * error, such as an error code. Keys are not restricted by the specification.
+	 *
+	 * Enumerable, and appears in the result of JSON.stringify().
+	 */
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
 
 	/**
 	 * An array of GraphQL AST Nodes corresponding to this error.
@@ -50,26 +59,100 @@ type GraphQLError struct {
 	 * Positions is an array of character offsets within the source GraphQL document
 	 * which correspond to this error.
 	 */
-	Positions []int `json:"_"`
+	Positions []int `json:"-"`
 
 	/**
 	 * OriginalError the original error thrown from a field resolver during execution.
 	 */
 	OriginalError error `json:"-"`
+
+	/**
+	 * Localizable is the catalog message the Message was rendered from, if
+	 * any. It is used to render the message in the locale of a request.
+	 */
+	Localizable *Message `json:"-"`
+
+	/**
+	 * Safe marks the message as safe to show to clients when errors are
+	 * masked. Errors created by this library are safe, errors wrapping an
+	 * arbitrary error are safe only if it was marked with MarkSafe.
+	 */
+	Safe bool `json:"-"`
+
+	// kind is the sentinel identifying the phase that produced the error.
+	kind error
+
+	// excerpt is the part of the source quoted after the message of syntax
+	// errors.
+	excerpt string
+
+	// stack is the call stack captured when the error was created, or the
+	// stack of the original error if it carried one.
+	stack Stack
 }
 
 func (e GraphQLError) Error() string {
 	return e.Message
 }
 
-// NewGraphQLError creates a new GraphQLError
+// Unwrap returns the original error so errors.Is and errors.As can reach
+// errors returned from field resolvers.
+func (e GraphQLError) Unwrap() error {
+	return e.OriginalError
+}
+
+// Is reports whether the error was produced by the phase that the given
+// sentinel (ErrSyntax, ErrValidation or ErrExecution) represents.
+func (e GraphQLError) Is(target error) bool {
+	return e.kind != nil && e.kind == target
+}
+
+// LocalizedMessage returns the message rendered in the given locale. Errors
+// whose message does not come from the catalog are returned as is.
+func (e GraphQLError) LocalizedMessage(locale string) string {
+	if e.Localizable == nil {
+		return e.Message
+	}
+
+	if e.excerpt != "" {
+		return e.summary(locale) + "\n\n" + e.excerpt
+	}
+
+	return e.summary(locale)
+}
+
+// summary returns the message rendered in the given locale, without any
+// excerpt of the source.
+func (e GraphQLError) summary(locale string) string {
+	if e.Localizable == nil {
+		return e.Message
+	}
+
+	return e.Localizable.Localize(locale)
+}
+
+// StackTrace returns the call stack captured when the error was created.
+func (e GraphQLError) StackTrace() Stack {
+	return e.stack
+}
+
+// Code returns the machine-readable code stored in the error's extensions,
+// or an empty Code if there is none.
+func (e GraphQLError) Code() Code {
+	code, _ := e.Extensions["code"].(Code)
+	return code
+}
+
+// NewGraphQLError creates a new GraphQLError. If extensions is nil and the
+// original error is a GraphQLError, its extensions are carried over.
 func NewGraphQLError(
 	message string,
 	nodes language.NodeList,
 	source *language.Source,
 	positions []int,
-	path []string,
+	path []interface{},
 	originalError error,
+	extensions map[string]interface{},
 ) error {
 	// Compute locations in the source for the given nodes/positions.
 	_source := source
@@ -97,33 +180,45 @@ func NewGraphQLError(
 		}
 	}
 
+	_extensions := extensions
+	if _extensions == nil {
+		if orig, ok := originalError.(*GraphQLError); ok {
+			_extensions = orig.Extensions
+		}
+	}
+
 	gqlerr := GraphQLError{
 		Message:       message,
 		Locations:     _locations,
 		Path:          path,
+		Extensions:    _extensions,
 		Nodes:         nodes,
 		Source:        _source,
 		Positions:     _positions,
 		OriginalError: originalError,
+		Safe:          originalError == nil || isSafe(originalError),
+	}
+
+	if orig, ok := originalError.(*GraphQLError); ok {
+		gqlerr.kind = orig.kind
+	}
+
+	// Include the stack trace, preferring the one of the original error so
+	// that resolver failures point at the resolver rather than the executor.
+	gqlerr.stack = stackOf(originalError)
+	if gqlerr.stack == nil {
+		gqlerr.stack = callers()
 	}
 
-	// TODO: Equivalent of this in go?
-	// Include (non-enumerable) stack trace.
-	//   if (originalError && originalError.stack) {
-	//     Object.defineProperty(this, 'stack', {
-	//       value: originalError.stack,
-	//       writable: true,
-	//       configurable: true
-	//     });
-	//   } else if (Error.captureStackTrace) {
-	//     Error.captureStackTrace(this, GraphQLError);
-	//   } else {
-	//     Object.defineProperty(this, 'stack', {
-	//       value: Error().stack,
-	//       writable: true,
-	//       configurable: true
-	//     });
-	//   }
+	return &gqlerr
+}
+
+// NewLocalizableError creates a GraphQLError with a message from the catalog,
+// located at the given nodes. It is used for errors that are not specific to
+// a phase, such as the failure to coerce a value.
+func NewLocalizableError(message Message, nodes ...language.ASTNode) error {
+	gqlerr := NewGraphQLError(message.String(), nodes, nil, nil, nil, nil, nil).(*GraphQLError)
+	gqlerr.Localizable = &message
 
 	return gqlerr
 }
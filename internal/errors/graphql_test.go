@@ -1,7 +1,11 @@
 package errors
 
 import (
+	"encoding/json"
+	"reflect"
 	"testing"
+
+	"github.com/ijsnow/goql/internal/language"
 )
 
 // import { expect } from 'chai';
@@ -46,45 +50,73 @@ func TestConvertsNodesToPositionsAndLocations(t *testing.T) {
 //     expect(e.locations).to.deep.equal([ { line: 2, column: 9 } ]);
 //   });
 
-//   it('serializes to include message', () => {
-//     const e = new GraphQLError('msg');
-//     expect(JSON.stringify(e)).to.equal('{"message":"msg"}');
-//   });
+func TestSerializesToIncludeMessage(t *testing.T) {
+	e := NewGraphQLError("msg", nil, nil, nil, nil, nil, nil)
 
-//   it('serializes to include message and locations', () => {
-//     const node = parse('{ field }').definitions[0].selectionSet.selections[0];
-//     const e = new GraphQLError('msg', [ node ]);
-//     expect(JSON.stringify(e)).to.equal(
-//       '{"message":"msg","locations":[{"line":1,"column":3}]}'
-//     );
-//   });
+	testMarshal(t, e, `{"message":"msg"}`)
+}
 
-//   it('serializes to include path', () => {
-//     const e = new GraphQLError(
-//       'msg',
-//       null,
-//       null,
-//       null,
-//       [ 'path', 3, 'to', 'field' ]
-//     );
-//     expect(e.path).to.deep.equal([ 'path', 3, 'to', 'field' ]);
-//     expect(JSON.stringify(e)).to.equal(
-//       '{"message":"msg","path":["path",3,"to","field"]}'
-//     );
-//   });
+func TestSerializesToIncludeMessageAndLocations(t *testing.T) {
+	source := language.NewSource("{ field }")
+	e := NewGraphQLError("msg", nil, &source, []int{2}, nil, nil, nil)
 
-//   it('default error formatter includes path', () => {
-//     const e = new GraphQLError(
-//       'msg',
-//       null,
-//       null,
-//       null,
-//       [ 'path', 3, 'to', 'field' ]
-//     );
-
-//     expect(formatError(e)).to.deep.equal({
-//       message: 'msg',
-//       locations: undefined,
-//       path: [ 'path', 3, 'to', 'field' ]
-//     });
-//   });
+	testMarshal(t, e, `{"message":"msg","locations":[{"line":1,"column":3}]}`)
+}
+
+func TestSerializesToIncludePath(t *testing.T) {
+	path := []interface{}{"path", 3, "to", "field"}
+	e := NewGraphQLError("msg", nil, nil, nil, path, nil, nil)
+
+	if !reflect.DeepEqual(e.(*GraphQLError).Path, path) {
+		t.Errorf("unexpected path; got %v wanted %v", e.(*GraphQLError).Path, path)
+	}
+
+	testMarshal(t, e, `{"message":"msg","path":["path",3,"to","field"]}`)
+}
+
+func TestSerializesToIncludeExtensions(t *testing.T) {
+	e := NewGraphQLError("msg", nil, nil, nil, []interface{}{"field"}, nil, map[string]interface{}{
+		"foo": "bar",
+	})
+
+	testMarshal(t, e, `{"message":"msg","path":["field"],"extensions":{"foo":"bar"}}`)
+}
+
+func TestInheritsExtensionsFromOriginalError(t *testing.T) {
+	orig := NewGraphQLError("msg", nil, nil, nil, nil, nil, map[string]interface{}{
+		"foo": "bar",
+	})
+	e := NewGraphQLError("msg", nil, nil, nil, nil, orig, nil)
+
+	testMarshal(t, e, `{"message":"msg","extensions":{"foo":"bar"}}`)
+}
+
+func TestDefaultErrorFormatterIncludesPath(t *testing.T) {
+	e := NewGraphQLError("msg", nil, nil, nil, []interface{}{"path", 3, "to", "field"}, nil, nil)
+	formatted, err := NewFormatError(e.(*GraphQLError))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &GraphQLFormattedError{
+		Message: "msg",
+		Path:    []interface{}{"path", 3, "to", "field"},
+	}
+
+	if !reflect.DeepEqual(formatted, want) {
+		t.Errorf("unexpected formatted error; got %+v wanted %+v", formatted, want)
+	}
+
+	testMarshal(t, formatted, `{"message":"msg","path":["path",3,"to","field"]}`)
+}
+
+func testMarshal(t *testing.T, v interface{}, want string) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := string(b); got != want {
+		t.Errorf("json serialization yielded unexpected result; got\n%v\nwanted\n%v", got, want)
+	}
+}
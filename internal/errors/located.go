@@ -5,30 +5,63 @@ import "github.com/ijsnow/goql/internal/language"
 // NewLocatedError , given an arbitrary Error, presumably thrown while attempting to execute a
 // GraphQL operation, produce a new GraphQLError aware of the location in the
 // document responsible for the original Error.
+//
+// The returned error unwraps to originalError and matches the same sentinel
+// when originalError is a syntax or validation GraphQLError, and ErrExecution
+// otherwise. Its code is taken from originalError when it carries one, and is
+// otherwise CodeInternalServerError.
 func NewLocatedError(
-	originalError *GraphQLError,
+	originalError error,
 	nodes []language.ASTNode,
-	path []string,
+	path []interface{},
 ) error {
 	// Note: this uses a brand-check to support GraphQL errors originating from
 	// other contexts.
-	if originalError != nil && len(originalError.Path) > 0 {
-		return originalError
+	orig, isGraphQLError := originalError.(*GraphQLError)
+	if isGraphQLError && len(orig.Path) > 0 {
+		return orig
 	}
 
-	var message string
+	var (
+		message     string
+		localizable *Message
+	)
 	if originalError != nil {
-		message = originalError.Message
+		message = originalError.Error()
 	} else {
-		message = "An unknown error occurred."
+		localizable = &Message{ID: MsgUnknownError}
+		message = localizable.String()
+	}
+
+	var (
+		_nodes      language.NodeList = nodes
+		_source     *language.Source
+		_positions  []int
+		_extensions map[string]interface{}
+	)
+	if isGraphQLError {
+		if orig.Nodes != nil {
+			_nodes = orig.Nodes
+		}
+		_source = orig.Source
+		_positions = orig.Positions
+		_extensions = orig.Extensions
+		localizable = orig.Localizable
 	}
 
-	return NewGraphQLError(
+	gqlerr := NewGraphQLError(
 		message,
-		originalError.Nodes,
-		originalError.Source,
-		originalError.Positions,
+		_nodes,
+		_source,
+		_positions,
 		path,
 		originalError,
-	)
+		withCode(_extensions, codeOf(originalError, CodeInternalServerError)),
+	).(*GraphQLError)
+	if gqlerr.kind == nil {
+		gqlerr.kind = ErrExecution
+	}
+	gqlerr.Localizable = localizable
+
+	return gqlerr
 }
@@ -0,0 +1,127 @@
+package errors
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+)
+
+// Presenter decides what clients see of an error. It returns the error to
+// format in place of the given one.
+type Presenter func(gqlerr *GraphQLError) *GraphQLError
+
+// MaskOptions configures the presenter returned by NewMaskingPresenter.
+type MaskOptions struct {
+	/**
+	 * Message replaces the message of unsafe errors. Defaults to the
+	 * MsgInternalServerError message of the catalog.
+	 */
+	Message string
+
+	/**
+	 * CorrelationID returns the identifier that links a masked error to its
+	 * log entry. Defaults to 16 random bytes encoded as hex.
+	 */
+	CorrelationID func() string
+
+	/**
+	 * Log receives every masked error in full along with its correlation ID.
+	 */
+	Log func(correlationID string, gqlerr *GraphQLError)
+}
+
+// safeError marks an arbitrary error as safe to show to clients.
+type safeError struct {
+	err error
+}
+
+func (e safeError) Error() string {
+	return e.err.Error()
+}
+
+func (e safeError) Unwrap() error {
+	return e.err
+}
+
+// MarkSafe marks err as safe to show to clients, so that a GraphQLError
+// wrapping it passes through a masking presenter unchanged. Wrapping the
+// result in an error with a different message, such as with fmt.Errorf,
+// makes it unsafe again.
+func MarkSafe(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return safeError{err: err}
+}
+
+// isSafe reports whether err, or an error in its chain, was marked safe. A
+// safe error in the chain is only trusted when err has the same message, so
+// that wrappers adding details of their own, such as the query that failed,
+// are not shown to clients.
+func isSafe(err error) bool {
+	message := err.Error()
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		switch e := e.(type) {
+		case safeError:
+			return e.Error() == message
+		case *GraphQLError:
+			if e.Safe {
+				return e.Error() == message
+			}
+		}
+	}
+
+	return false
+}
+
+// NewMaskingPresenter returns a Presenter for public APIs. Safe errors pass
+// through unchanged. Every other error is handed to the Log hook and replaced
+// with a generic message whose extensions carry only the code and a
+// correlation ID, so that nothing from the original error reaches the client.
+func NewMaskingPresenter(options MaskOptions) Presenter {
+	var localizable *Message
+	message := options.Message
+	if message == "" {
+		localizable = &Message{ID: MsgInternalServerError}
+		message = localizable.String()
+	}
+
+	correlationID := options.CorrelationID
+	if correlationID == nil {
+		correlationID = randomID
+	}
+
+	return func(gqlerr *GraphQLError) *GraphQLError {
+		if gqlerr.Safe {
+			return gqlerr
+		}
+
+		id := correlationID()
+		if options.Log != nil {
+			options.Log(id, gqlerr)
+		}
+
+		return &GraphQLError{
+			Message:   message,
+			Locations: gqlerr.Locations,
+			Path:      gqlerr.Path,
+			Extensions: map[string]interface{}{
+				"code":          CodeInternalServerError,
+				"correlationId": id,
+			},
+			Localizable: localizable,
+			Safe:        true,
+			kind:        gqlerr.kind,
+		}
+	}
+}
+
+func randomID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,91 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestMaskingPresenter(t *testing.T) {
+	var logged []string
+	opts := FormatOptions{
+		Presenter: NewMaskingPresenter(MaskOptions{
+			CorrelationID: func() string { return "abc123" },
+			Log: func(id string, gqlerr *GraphQLError) {
+				logged = append(logged, id+": "+gqlerr.Message)
+			},
+		}),
+	}
+
+	t.Run("masks resolver errors", func(t *testing.T) {
+		logged = nil
+		e := NewLocatedError(errors.New("pq: password authentication failed"), nil, []interface{}{"user"})
+
+		formatted, err := NewFormatError(e.(*GraphQLError), opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testMarshal(t, formatted, `{"message":"Internal server error","path":["user"],`+
+			`"extensions":{"code":"INTERNAL_SERVER_ERROR","correlationId":"abc123"}}`)
+
+		if len(logged) != 1 || logged[0] != "abc123: pq: password authentication failed" {
+			t.Errorf("unexpected log entries; got %v", logged)
+		}
+	})
+
+	t.Run("passes errors marked safe through", func(t *testing.T) {
+		logged = nil
+		e := NewLocatedError(
+			WithCode(MarkSafe(errors.New("user not found")), CodeBadUserInput),
+			nil,
+			[]interface{}{"user"},
+		)
+
+		formatted, err := NewFormatError(e.(*GraphQLError), opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testMarshal(t, formatted, `{"message":"user not found","path":["user"],"extensions":{"code":"BAD_USER_INPUT"}}`)
+
+		if len(logged) != 0 {
+			t.Errorf("did not expect safe errors to be logged; got %v", logged)
+		}
+	})
+
+	t.Run("masks errors wrapping errors marked safe", func(t *testing.T) {
+		logged = nil
+		e := NewLocatedError(
+			fmt.Errorf("SELECT * FROM users WHERE password='hunter2': %w", MarkSafe(errors.New("not found"))),
+			nil,
+			[]interface{}{"user"},
+		)
+
+		formatted, err := NewFormatError(e.(*GraphQLError), opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testMarshal(t, formatted, `{"message":"Internal server error","path":["user"],`+
+			`"extensions":{"code":"INTERNAL_SERVER_ERROR","correlationId":"abc123"}}`)
+
+		if len(logged) != 1 {
+			t.Errorf("expected the wrapped error to be logged; got %v", logged)
+		}
+	})
+
+	t.Run("passes errors created by the library through", func(t *testing.T) {
+		logged = nil
+		e := NewValidationError(Message{ID: MsgUnknownError})
+
+		formatted, err := NewFormatError(e.(*GraphQLError), opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if formatted.Message != "An unknown error occurred." {
+			t.Errorf("unexpected message; got %v", formatted.Message)
+		}
+	})
+}
@@ -0,0 +1,337 @@
+package errors
+
+// The IDs of the built-in messages.
+const (
+	MsgSyntaxError                  MessageID = "SYNTAX_ERROR"
+	MsgUnknownError                 MessageID = "UNKNOWN_ERROR"
+	MsgInternalServerError          MessageID = "INTERNAL_SERVER_ERROR"
+	MsgInvalidCharacter             MessageID = "INVALID_CHARACTER"
+	MsgUnexpectedCharacter          MessageID = "UNEXPECTED_CHARACTER"
+	MsgUnexpectedSingleQuote        MessageID = "UNEXPECTED_SINGLE_QUOTE"
+	MsgUnexpectedDigitAfterZero     MessageID = "UNEXPECTED_DIGIT_AFTER_ZERO"
+	MsgExpectedDigit                MessageID = "EXPECTED_DIGIT"
+	MsgInvalidStringCharacter       MessageID = "INVALID_STRING_CHARACTER"
+	MsgInvalidEscapeSequence        MessageID = "INVALID_ESCAPE_SEQUENCE"
+	MsgUnterminatedString           MessageID = "UNTERMINATED_STRING"
+	MsgExpectedToken                MessageID = "EXPECTED_TOKEN"
+	MsgExpectedKeyword              MessageID = "EXPECTED_KEYWORD"
+	MsgUnexpectedToken              MessageID = "UNEXPECTED_TOKEN"
+	MsgUndefinedField               MessageID = "UNDEFINED_FIELD"
+	MsgUndefinedFieldOnAbstract     MessageID = "UNDEFINED_FIELD_ON_ABSTRACT"
+	MsgUnknownType                  MessageID = "UNKNOWN_TYPE"
+	MsgUnknownArg                   MessageID = "UNKNOWN_ARG"
+	MsgUnknownDirectiveArg          MessageID = "UNKNOWN_DIRECTIVE_ARG"
+	MsgUnknownEnumValue             MessageID = "UNKNOWN_ENUM_VALUE"
+	MsgUnknownFragment              MessageID = "UNKNOWN_FRAGMENT"
+	MsgDidYouMean                   MessageID = "DID_YOU_MEAN"
+	MsgOr                           MessageID = "OR"
+	MsgIntNonInteger                MessageID = "INT_NON_INTEGER"
+	MsgIntOutOfRange                MessageID = "INT_OUT_OF_RANGE"
+	MsgFloatNonNumeric              MessageID = "FLOAT_NON_NUMERIC"
+	MsgStringCannotRepresent        MessageID = "STRING_CANNOT_REPRESENT"
+	MsgStringNonString              MessageID = "STRING_NON_STRING"
+	MsgBooleanNonBoolean            MessageID = "BOOLEAN_NON_BOOLEAN"
+	MsgIDCannotRepresent            MessageID = "ID_CANNOT_REPRESENT"
+	MsgIDNonStringNonInteger        MessageID = "ID_NON_STRING_NON_INTEGER"
+	MsgEnumCannotRepresent          MessageID = "ENUM_CANNOT_REPRESENT"
+	MsgEnumNonString                MessageID = "ENUM_NON_STRING"
+	MsgEnumNonEnum                  MessageID = "ENUM_NON_ENUM"
+	MsgScalarCannotRepresent        MessageID = "SCALAR_CANNOT_REPRESENT"
+	MsgUnknownDirectiveLocation     MessageID = "UNKNOWN_DIRECTIVE_LOCATION"
+	MsgDuplicateDirectiveLocation   MessageID = "DUPLICATE_DIRECTIVE_LOCATION"
+	MsgMisplacedDirective           MessageID = "MISPLACED_DIRECTIVE"
+	MsgQueryRootMissing             MessageID = "QUERY_ROOT_MISSING"
+	MsgInvalidName                  MessageID = "INVALID_NAME"
+	MsgReservedName                 MessageID = "RESERVED_NAME"
+	MsgDirectiveWithoutLocations    MessageID = "DIRECTIVE_WITHOUT_LOCATIONS"
+	MsgInputTypeExpected            MessageID = "INPUT_TYPE_EXPECTED"
+	MsgOutputTypeExpected           MessageID = "OUTPUT_TYPE_EXPECTED"
+	MsgDuplicateArgDefinition       MessageID = "DUPLICATE_ARG_DEFINITION"
+	MsgDuplicateFieldDefinition     MessageID = "DUPLICATE_FIELD_DEFINITION"
+	MsgTypeWithoutFields            MessageID = "TYPE_WITHOUT_FIELDS"
+	MsgInputObjectWithoutFields     MessageID = "INPUT_OBJECT_WITHOUT_FIELDS"
+	MsgInputObjectCycle             MessageID = "INPUT_OBJECT_CYCLE"
+	MsgNonInterfaceImplemented      MessageID = "NON_INTERFACE_IMPLEMENTED"
+	MsgDuplicateInterface           MessageID = "DUPLICATE_INTERFACE"
+	MsgMissingInterfaceField        MessageID = "MISSING_INTERFACE_FIELD"
+	MsgInterfaceFieldType           MessageID = "INTERFACE_FIELD_TYPE"
+	MsgMissingInterfaceArg          MessageID = "MISSING_INTERFACE_ARG"
+	MsgInterfaceArgType             MessageID = "INTERFACE_ARG_TYPE"
+	MsgRequiredArgNotInInterface    MessageID = "REQUIRED_ARG_NOT_IN_INTERFACE"
+	MsgUnionWithoutMembers          MessageID = "UNION_WITHOUT_MEMBERS"
+	MsgNonObjectUnionMember         MessageID = "NON_OBJECT_UNION_MEMBER"
+	MsgDuplicateUnionMember         MessageID = "DUPLICATE_UNION_MEMBER"
+	MsgEnumWithoutValues            MessageID = "ENUM_WITHOUT_VALUES"
+	MsgReservedEnumValue            MessageID = "RESERVED_ENUM_VALUE"
+	MsgDuplicateEnumValue           MessageID = "DUPLICATE_ENUM_VALUE"
+	MsgRequiredArgDeprecated        MessageID = "REQUIRED_ARG_DEPRECATED"
+	MsgRequiredInputFieldDeprecated MessageID = "REQUIRED_INPUT_FIELD_DEPRECATED"
+	MsgArgNotProvided               MessageID = "ARG_NOT_PROVIDED"
+	MsgArgVariableNotProvided       MessageID = "ARG_VARIABLE_NOT_PROVIDED"
+	MsgArgInvalidValue              MessageID = "ARG_INVALID_VALUE"
+	MsgDuplicateSchemaDefinition    MessageID = "DUPLICATE_SCHEMA_DEFINITION"
+	MsgDuplicateTypeDefinition      MessageID = "DUPLICATE_TYPE_DEFINITION"
+	MsgDuplicateOperationType       MessageID = "DUPLICATE_OPERATION_TYPE"
+	MsgUnknownOperationType         MessageID = "UNKNOWN_OPERATION_TYPE"
+	MsgInvalidDefaultValue          MessageID = "INVALID_DEFAULT_VALUE"
+	MsgNonObjectOperationType       MessageID = "NON_OBJECT_OPERATION_TYPE"
+	MsgQueryTypeNotDefined          MessageID = "QUERY_TYPE_NOT_DEFINED"
+	MsgUnknownTypeInDocument        MessageID = "UNKNOWN_TYPE_IN_DOCUMENT"
+	MsgTypeAlreadyExists            MessageID = "TYPE_ALREADY_EXISTS"
+	MsgDirectiveAlreadyExists       MessageID = "DIRECTIVE_ALREADY_EXISTS"
+	MsgExtendUnknownType            MessageID = "EXTEND_UNKNOWN_TYPE"
+	MsgExtendNonObjectType          MessageID = "EXTEND_NON_OBJECT_TYPE"
+	MsgInterfaceAlreadyImplemented  MessageID = "INTERFACE_ALREADY_IMPLEMENTED"
+	MsgFieldAlreadyExists           MessageID = "FIELD_ALREADY_EXISTS"
+	MsgUnknownTypeInExtension       MessageID = "UNKNOWN_TYPE_IN_EXTENSION"
+	MsgResolverMissing              MessageID = "RESOLVER_MISSING"
+	MsgResolveTypeMissing           MessageID = "RESOLVE_TYPE_MISSING"
+	MsgScalarImplementationMissing  MessageID = "SCALAR_IMPLEMENTATION_MISSING"
+	MsgResolverNotInSchema          MessageID = "RESOLVER_NOT_IN_SCHEMA"
+	MsgResolveTypeNotInSchema       MessageID = "RESOLVE_TYPE_NOT_IN_SCHEMA"
+	MsgIsTypeOfNotInSchema          MessageID = "IS_TYPE_OF_NOT_IN_SCHEMA"
+	MsgScalarNotInSchema            MessageID = "SCALAR_NOT_IN_SCHEMA"
+	MsgEnumValueNotInSchema         MessageID = "ENUM_VALUE_NOT_IN_SCHEMA"
+	MsgDuplicateOperationName       MessageID = "DUPLICATE_OPERATION_NAME"
+	MsgDuplicateFragmentName        MessageID = "DUPLICATE_FRAGMENT_NAME"
+	MsgDuplicateVariable            MessageID = "DUPLICATE_VARIABLE"
+	MsgRootTypeNotDefined           MessageID = "ROOT_TYPE_NOT_DEFINED"
+	MsgNonInputTypeOnVariable       MessageID = "NON_INPUT_TYPE_ON_VARIABLE"
+	MsgUndefinedVariable            MessageID = "UNDEFINED_VARIABLE"
+	MsgUnusedVariable               MessageID = "UNUSED_VARIABLE"
+	MsgBadVariablePosition          MessageID = "BAD_VARIABLE_POSITION"
+	MsgNoSubselectionAllowed        MessageID = "NO_SUBSELECTION_ALLOWED"
+	MsgRequiredSubselection         MessageID = "REQUIRED_SUBSELECTION"
+	MsgFieldArgNotProvided          MessageID = "FIELD_ARG_NOT_PROVIDED"
+	MsgDirectiveArgNotProvided      MessageID = "DIRECTIVE_ARG_NOT_PROVIDED"
+	MsgFieldsConflict               MessageID = "FIELDS_CONFLICT"
+	MsgFragmentOnNonComposite       MessageID = "FRAGMENT_ON_NON_COMPOSITE"
+	MsgInlineFragmentOnNonComposite MessageID = "INLINE_FRAGMENT_ON_NON_COMPOSITE"
+	MsgImpossibleFragmentSpread     MessageID = "IMPOSSIBLE_FRAGMENT_SPREAD"
+	MsgImpossibleInlineFragment     MessageID = "IMPOSSIBLE_INLINE_FRAGMENT"
+	MsgUnusedFragment               MessageID = "UNUSED_FRAGMENT"
+	MsgFragmentCycle                MessageID = "FRAGMENT_CYCLE"
+	MsgUnknownDirective             MessageID = "UNKNOWN_DIRECTIVE"
+)
+
+// defaultMessages holds the templates of the DefaultLocale.
+var defaultMessages = map[MessageID]string{
+	MsgSyntaxError:                  "Syntax Error {source} ({line}:{column}) {description}",
+	MsgUnknownError:                 "An unknown error occurred.",
+	MsgInternalServerError:          "Internal server error",
+	MsgInvalidCharacter:             "Cannot contain the invalid character {char}.",
+	MsgUnexpectedCharacter:          "Cannot parse the unexpected character {char}.",
+	MsgUnexpectedSingleQuote:        "Unexpected single quote character ('), did you mean to use a double quote (\")?",
+	MsgUnexpectedDigitAfterZero:     "Invalid number, unexpected digit after 0: {digit}.",
+	MsgExpectedDigit:                "Invalid number, expected digit but got: {char}.",
+	MsgInvalidStringCharacter:       "Invalid character within String: {char}.",
+	MsgInvalidEscapeSequence:        "Invalid character escape sequence: {sequence}.",
+	MsgUnterminatedString:           "Unterminated string.",
+	MsgExpectedToken:                "Expected {expected}, found {found}",
+	MsgExpectedKeyword:              "Expected \"{keyword}\", found {found}",
+	MsgUnexpectedToken:              "Unexpected {token}",
+	MsgUndefinedField:               "Cannot query field \"{field}\" on type \"{type}\".{suggestion}",
+	MsgUndefinedFieldOnAbstract:     "Cannot query field \"{field}\" on type \"{type}\". Did you mean to use an inline fragment on {types}?",
+	MsgUnknownType:                  "Unknown type \"{type}\".{suggestion}",
+	MsgUnknownArg:                   "Unknown argument \"{arg}\" on field \"{field}\" of type \"{type}\".{suggestion}",
+	MsgUnknownDirectiveArg:          "Unknown argument \"{arg}\" on directive \"@{directive}\".{suggestion}",
+	MsgUnknownEnumValue:             "Value \"{value}\" does not exist in \"{enum}\" enum.{suggestion}",
+	MsgUnknownFragment:              "Unknown fragment \"{fragment}\".{suggestion}",
+	MsgDidYouMean:                   "Did you mean {suggestions}?",
+	MsgOr:                           "or",
+	MsgIntNonInteger:                "Int cannot represent non-integer value: {value}",
+	MsgIntOutOfRange:                "Int cannot represent non 32-bit signed integer value: {value}",
+	MsgFloatNonNumeric:              "Float cannot represent non numeric value: {value}",
+	MsgStringCannotRepresent:        "String cannot represent value: {value}",
+	MsgStringNonString:              "String cannot represent a non string value: {value}",
+	MsgBooleanNonBoolean:            "Boolean cannot represent a non boolean value: {value}",
+	MsgIDCannotRepresent:            "ID cannot represent value: {value}",
+	MsgIDNonStringNonInteger:        "ID cannot represent a non-string and non-integer value: {value}",
+	MsgEnumCannotRepresent:          "Enum \"{enum}\" cannot represent value: {value}",
+	MsgEnumNonString:                "Enum \"{enum}\" cannot represent non-string value: {value}.",
+	MsgEnumNonEnum:                  "Enum \"{enum}\" cannot represent non-enum value: {value}.",
+	MsgScalarCannotRepresent:        "{type} cannot represent value: {value}",
+	MsgUnknownDirectiveLocation:     "Directive \"@{directive}\" has the unknown location \"{location}\".{suggestion}",
+	MsgDuplicateDirectiveLocation:   "Directive \"@{directive}\" has the location \"{location}\" more than once.",
+	MsgMisplacedDirective:           "Directive \"{directive}\" may not be used on {location}.",
+	MsgQueryRootMissing:             "Query root type must be provided.",
+	MsgInvalidName:                  "Names must match /^[_a-zA-Z][_a-zA-Z0-9]*$/ but \"{name}\" does not.",
+	MsgReservedName:                 "Name \"{name}\" must not begin with \"__\", which is reserved by GraphQL introspection.",
+	MsgDirectiveWithoutLocations:    "Directive @{directive} must include 1 or more locations.",
+	MsgInputTypeExpected:            "The type of {coordinate} must be Input Type but got: {type}.",
+	MsgOutputTypeExpected:           "The type of {coordinate} must be Output Type but got: {type}.",
+	MsgDuplicateArgDefinition:       "Argument {coordinate} can only be defined once.",
+	MsgDuplicateFieldDefinition:     "Field {coordinate} can only be defined once.",
+	MsgTypeWithoutFields:            "Type {type} must define one or more fields.",
+	MsgInputObjectWithoutFields:     "Input Object type {type} must define one or more fields.",
+	MsgInputObjectCycle:             "Cannot reference Input Object \"{type}\" within itself through a series of non-null fields: \"{path}\".",
+	MsgNonInterfaceImplemented:      "Type {type} must only implement Interface types, it cannot implement {interface}.",
+	MsgDuplicateInterface:           "Type {type} can only implement {interface} once.",
+	MsgMissingInterfaceField:        "Interface field {interface}.{field} expected but {type} does not provide it.",
+	MsgInterfaceFieldType:           "Interface field {interface}.{field} expects type {interfaceType} but {type}.{field} is type {fieldType}.",
+	MsgMissingInterfaceArg:          "Interface field argument {interface}.{field}({arg}:) expected but {type}.{field} does not provide it.",
+	MsgInterfaceArgType:             "Interface field argument {interface}.{field}({arg}:) expects type {interfaceType} but {type}.{field}({arg}:) is type {argType}.",
+	MsgRequiredArgNotInInterface:    "Object field {type}.{field} includes required argument {arg} that is missing from the Interface field {interface}.{field}.",
+	MsgUnionWithoutMembers:          "Union type {union} must define one or more member types.",
+	MsgNonObjectUnionMember:         "Union type {union} can only include Object types, it cannot include {type}.",
+	MsgDuplicateUnionMember:         "Union type {union} can only include type {type} once.",
+	MsgEnumWithoutValues:            "Enum type {enum} must define one or more values.",
+	MsgReservedEnumValue:            "Enum type {enum} cannot include value: {value}.",
+	MsgDuplicateEnumValue:           "Enum value {enum}.{value} can only be defined once.",
+	MsgRequiredArgDeprecated:        "Required argument {coordinate} cannot be deprecated.",
+	MsgRequiredInputFieldDeprecated: "Required input field {coordinate} cannot be deprecated.",
+	MsgArgNotProvided:               "Argument \"{arg}\" of required type \"{type}\" was not provided.",
+	MsgArgVariableNotProvided:       "Argument \"{arg}\" of required type \"{type}\" was provided the variable \"${variable}\" which was not provided a runtime value.",
+	MsgArgInvalidValue:              "Argument \"{arg}\" got invalid value {value}.",
+	MsgDuplicateSchemaDefinition:    "Must provide only one schema definition.",
+	MsgDuplicateTypeDefinition:      "Type \"{type}\" was defined more than once.",
+	MsgDuplicateOperationType:       "Must provide only one {operation} type in schema.",
+	MsgUnknownOperationType:         "Specified {operation} type \"{type}\" not found in document.",
+	MsgInvalidDefaultValue:          "Default value {value} of \"{name}\" is not a valid value of type \"{type}\".",
+	MsgNonObjectOperationType:       "Specified {operation} type \"{type}\" must be an Object type.",
+	MsgQueryTypeNotDefined:          "Must provide schema definition with query type or a type named Query.",
+	MsgUnknownTypeInDocument:        "Type \"{type}\" not found in document.",
+	MsgTypeAlreadyExists:            "Type \"{type}\" already exists in the schema. It cannot also be defined in this type definition.",
+	MsgDirectiveAlreadyExists:       "Directive \"{directive}\" already exists in the schema. It cannot be redefined.",
+	MsgExtendUnknownType:            "Cannot extend type \"{type}\" because it does not exist in the existing schema.",
+	MsgExtendNonObjectType:          "Cannot extend non-object type \"{type}\".",
+	MsgInterfaceAlreadyImplemented:  "Type \"{type}\" already implements \"{interface}\". It cannot also be implemented in this type extension.",
+	MsgFieldAlreadyExists:           "Field \"{type}.{field}\" already exists in the schema. It cannot also be defined in this type extension.",
+	MsgUnknownTypeInExtension:       "Unknown type: \"{type}\". Ensure that this type exists either in the original schema, or is added in a type definition.",
+	MsgResolverMissing:              "Field \"{type}.{field}\" has no resolver.",
+	MsgResolveTypeMissing:           "Abstract type \"{type}\" has no ResolveType function, and not all of its possible types have an IsTypeOf function.",
+	MsgScalarImplementationMissing:  "Scalar \"{type}\" has no implementation.",
+	MsgResolverNotInSchema:          "Resolver \"{name}\" is defined, but the schema has no such field.{suggestion}",
+	MsgResolveTypeNotInSchema:       "ResolveType \"{name}\" is defined, but the schema has no such Interface or Union type.{suggestion}",
+	MsgIsTypeOfNotInSchema:          "IsTypeOf \"{name}\" is defined, but the schema has no such Object type.{suggestion}",
+	MsgScalarNotInSchema:            "Scalar \"{name}\" is implemented, but the schema has no such custom scalar.{suggestion}",
+	MsgEnumValueNotInSchema:         "Enum value \"{name}\" is defined, but the schema has no such enum value.{suggestion}",
+	MsgDuplicateOperationName:       "There can be only one operation named \"{operation}\".",
+	MsgDuplicateFragmentName:        "There can be only one fragment named \"{fragment}\".",
+	MsgDuplicateVariable:            "There can be only one variable named \"${variable}\".",
+	MsgRootTypeNotDefined:           "Schema is not configured for {operation} operations.",
+	MsgNonInputTypeOnVariable:       "Variable \"${variable}\" cannot be non-input type \"{type}\".",
+	MsgUndefinedVariable:            "Variable \"${variable}\" is not defined by operation \"{operation}\".",
+	MsgUnusedVariable:               "Variable \"${variable}\" is never used in operation \"{operation}\".",
+	MsgBadVariablePosition:          "Variable \"${variable}\" of type \"{type}\" used in position expecting type \"{expected}\".",
+	MsgNoSubselectionAllowed:        "Field \"{field}\" must not have a selection since type \"{type}\" has no subfields.",
+	MsgRequiredSubselection:         "Field \"{field}\" of type \"{type}\" must have a selection of subfields. Did you mean \"{field} { ... }\"?",
+	MsgFieldArgNotProvided:          "Field \"{field}\" argument \"{arg}\" of type \"{type}\" is required but not provided.",
+	MsgDirectiveArgNotProvided:      "Directive \"@{directive}\" argument \"{arg}\" of type \"{type}\" is required but not provided.",
+	MsgFieldsConflict:               "Fields \"{response}\" conflict because \"{field}\" and \"{other}\" are different fields. Use different aliases on the fields to fetch both if this was intentional.",
+	MsgFragmentOnNonComposite:       "Fragment \"{fragment}\" cannot condition on non composite type \"{type}\".",
+	MsgInlineFragmentOnNonComposite: "Fragment cannot condition on non composite type \"{type}\".",
+	MsgImpossibleFragmentSpread:     "Fragment \"{fragment}\" cannot be spread here as objects of type \"{parent}\" can never be of type \"{type}\".",
+	MsgImpossibleInlineFragment:     "Fragment cannot be spread here as objects of type \"{parent}\" can never be of type \"{type}\".",
+	MsgUnusedFragment:               "Fragment \"{fragment}\" is never used.",
+	MsgFragmentCycle:                "Cannot spread fragment \"{fragment}\" within itself.",
+	MsgUnknownDirective:             "Unknown directive \"{directive}\".",
+}
+
+// didYouMean renders as " Did you mean ...?" in the requested locale, or as
+// nothing if there are no suggestions.
+type didYouMean []string
+
+func (d didYouMean) Localize(locale string) string {
+	if len(d) == 0 {
+		return ""
+	}
+
+	return " " + Message{MsgDidYouMean, map[string]interface{}{
+		"suggestions": quotedOrList(d),
+	}}.Localize(locale)
+}
+
+// UndefinedFieldMessage reports a field that is not defined on a type,
+// suggesting the types of an inline fragment that would define it, or
+// otherwise similarly named fields.
+func UndefinedFieldMessage(
+	fieldName string,
+	typeName string,
+	suggestedTypeNames []string,
+	suggestedFieldNames []string,
+) Message {
+	if len(suggestedTypeNames) != 0 {
+		return Message{MsgUndefinedFieldOnAbstract, map[string]interface{}{
+			"field": fieldName,
+			"type":  typeName,
+			"types": quotedOrList(suggestedTypeNames),
+		}}
+	}
+
+	return Message{MsgUndefinedField, map[string]interface{}{
+		"field":      fieldName,
+		"type":       typeName,
+		"suggestion": didYouMean(suggestedFieldNames),
+	}}
+}
+
+// UnknownTypeMessage reports a reference to a type that is not defined.
+func UnknownTypeMessage(typeName string, suggestedTypes []string) Message {
+	return Message{MsgUnknownType, map[string]interface{}{
+		"type":       typeName,
+		"suggestion": didYouMean(suggestedTypes),
+	}}
+}
+
+// UnknownArgMessage reports an argument that is not defined on a field.
+func UnknownArgMessage(
+	argName string,
+	fieldName string,
+	typeName string,
+	suggestedArgs []string,
+) Message {
+	return Message{MsgUnknownArg, map[string]interface{}{
+		"arg":        argName,
+		"field":      fieldName,
+		"type":       typeName,
+		"suggestion": didYouMean(suggestedArgs),
+	}}
+}
+
+// UnknownDirectiveArgMessage reports an argument that is not defined on a directive.
+func UnknownDirectiveArgMessage(argName string, directiveName string, suggestedArgs []string) Message {
+	return Message{MsgUnknownDirectiveArg, map[string]interface{}{
+		"arg":        argName,
+		"directive":  directiveName,
+		"suggestion": didYouMean(suggestedArgs),
+	}}
+}
+
+// UnknownEnumValueMessage reports a value that is not defined on an enum.
+func UnknownEnumValueMessage(value string, enumName string, suggestedValues []string) Message {
+	return Message{MsgUnknownEnumValue, map[string]interface{}{
+		"value":      value,
+		"enum":       enumName,
+		"suggestion": didYouMean(suggestedValues),
+	}}
+}
+
+// UnknownFragmentMessage reports a spread of a fragment that is not defined.
+func UnknownFragmentMessage(fragName string, suggestedFragments []string) Message {
+	return Message{MsgUnknownFragment, map[string]interface{}{
+		"fragment":   fragName,
+		"suggestion": didYouMean(suggestedFragments),
+	}}
+}
+
+// UnknownDirectiveLocationMessage reports a location in a directive
+// definition that is not a valid directive location.
+func UnknownDirectiveLocationMessage(directiveName string, location string, suggestedLocations []string) Message {
+	return Message{MsgUnknownDirectiveLocation, map[string]interface{}{
+		"directive":  directiveName,
+		"location":   location,
+		"suggestion": didYouMean(suggestedLocations),
+	}}
+}
+
+// NotInSchemaMessage reports an entry of a resolver map that names nothing
+// in the schema, for one of the messages MsgResolverNotInSchema,
+// MsgResolveTypeNotInSchema, MsgIsTypeOfNotInSchema, MsgScalarNotInSchema
+// and MsgEnumValueNotInSchema.
+func NotInSchemaMessage(id MessageID, name string, suggestedNames []string) Message {
+	return Message{id, map[string]interface{}{
+		"name":       name,
+		"suggestion": didYouMean(suggestedNames),
+	}}
+}
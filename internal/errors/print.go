@@ -0,0 +1,217 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ijsnow/goql/internal/language"
+)
+
+// ANSI escape codes used when PrintOptions.Color is set.
+const (
+	ansiReset = "\x1b[0m"
+	ansiBold  = "\x1b[1m"
+	ansiDim   = "\x1b[2m"
+	ansiRed   = "\x1b[31m"
+	ansiCyan  = "\x1b[36m"
+)
+
+var lineTerminator = regexp.MustCompile("\r\n|[\n\r]")
+
+// PrintOptions options to control how PrintError renders an error
+type PrintOptions struct {
+	/**
+	 * ContextLines is the number of source lines shown before and after
+	 * the line of each location. Defaults to the ContextLines of
+	 * DefaultPrintOptions when zero.
+	 */
+	ContextLines int
+
+	/**
+	 * NoContext shows only the line of each location, ignoring
+	 * ContextLines.
+	 */
+	NoContext bool
+
+	/**
+	 * Color highlights the output with ANSI escape codes, for terminals.
+	 */
+	Color bool
+
+	/**
+	 * Locale is the locale messages from the catalog are rendered in.
+	 * Defaults to DefaultLocale.
+	 */
+	Locale string
+}
+
+// DefaultPrintOptions are the options PrintError uses when none are given,
+// and for the options left unset in the options given.
+var DefaultPrintOptions = PrintOptions{
+	ContextLines: 1,
+}
+
+// PrintError renders an error for humans. For a GraphQLError, every location
+// is printed with the name of its source, the surrounding lines, and carets
+// underlining the whole range of the node the location belongs to. Any other
+// error is rendered as its message.
+func PrintError(err error, options ...PrintOptions) string {
+	if err == nil {
+		return ""
+	}
+
+	opts := DefaultPrintOptions
+	if len(options) > 0 {
+		opts = mergePrintOptions(opts, options[0])
+	}
+
+	var gqlerr *GraphQLError
+	if !errors.As(err, &gqlerr) {
+		return err.Error()
+	}
+
+	locale := opts.Locale
+	if locale == "" {
+		locale = DefaultLocale
+	}
+	message := gqlerr.summary(locale)
+
+	p := printer{options: opts}
+	out := p.style(ansiBold+ansiRed, message)
+
+	if len(gqlerr.Nodes) > 0 {
+		for _, node := range gqlerr.Nodes {
+			if loc := node.GetLoc(); loc != nil {
+				out += "\n\n" + p.printLocation(loc.Source, loc.Start, loc.End)
+			}
+		}
+	} else if gqlerr.Source != nil {
+		for _, pos := range gqlerr.Positions {
+			out += "\n\n" + p.printLocation(*gqlerr.Source, pos, pos)
+		}
+	}
+
+	return out
+}
+
+// mergePrintOptions returns the defaults with the options that are set in
+// options replaced.
+func mergePrintOptions(defaults, options PrintOptions) PrintOptions {
+	merged := defaults
+	if options.ContextLines != 0 {
+		merged.ContextLines = options.ContextLines
+	}
+	if options.NoContext {
+		merged.NoContext = true
+	}
+	if options.Color {
+		merged.Color = true
+	}
+	if options.Locale != "" {
+		merged.Locale = options.Locale
+	}
+
+	return merged
+}
+
+type printer struct {
+	options PrintOptions
+}
+
+func (p printer) style(code string, str string) string {
+	if !p.options.Color {
+		return str
+	}
+
+	return code + str + ansiReset
+}
+
+// printLocation renders the lines around start, underlining the range from
+// start to end. Ranges spanning several lines are underlined to the end of
+// the first line.
+func (p printer) printLocation(source language.Source, start, end int) string {
+	location := language.GetLocation(source, start)
+	lines := lineTerminator.Split(source.Body, -1)
+
+	contextLines := p.options.ContextLines
+	if p.options.NoContext {
+		contextLines = 0
+	}
+
+	first := location.Line - contextLines
+	if first < 1 {
+		first = 1
+	}
+
+	last := location.Line + contextLines
+	if last > len(lines) {
+		last = len(lines)
+	}
+
+	padLen := len(fmt.Sprintf("%d", last))
+	gutter := func(label string) string {
+		return p.style(ansiDim, lpad(padLen, label)+" | ")
+	}
+
+	out := p.style(ansiBold+ansiCyan, fmt.Sprintf(
+		"%s (%d:%d)",
+		source.GetName(),
+		location.Line,
+		location.Column,
+	)) + "\n"
+
+	for num := first; num <= last; num++ {
+		line := lines[num-1]
+		out += gutter(fmt.Sprintf("%d", num)) + line + "\n"
+
+		if num == location.Line {
+			out += gutter("") + caretPrefix(line, location.Column) +
+				p.style(ansiBold+ansiRed, strings.Repeat("^", caretWidth(source, line, location, end))) + "\n"
+		}
+	}
+
+	return strings.TrimRight(out, "\n")
+}
+
+// caretPrefix returns the whitespace that aligns the carets with column,
+// preserving tabs so the alignment holds however the terminal renders them.
+func caretPrefix(line string, column int) string {
+	var b strings.Builder
+	idx := 0
+	for _, r := range line {
+		if idx >= column-1 {
+			break
+		}
+		if r == '\t' {
+			b.WriteRune('\t')
+		} else {
+			b.WriteRune(' ')
+		}
+		idx++
+	}
+
+	return b.String() + strings.Repeat(" ", column-1-idx)
+}
+
+// caretWidth returns the number of carets needed to underline from location
+// to end, clamped to the line the location is on.
+func caretWidth(source language.Source, line string, location language.SourceLocation, end int) int {
+	lineLen := utf8.RuneCountInString(line)
+
+	width := 1
+	endLocation := language.GetLocation(source, end)
+	if endLocation.Line == location.Line {
+		width = endLocation.Column - location.Column
+	} else if endLocation.Line > location.Line {
+		width = lineLen - location.Column + 1
+	}
+
+	if width < 1 {
+		width = 1
+	}
+
+	return width
+}
@@ -0,0 +1,96 @@
+package errors
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ijsnow/goql/internal/language"
+)
+
+type testNode struct {
+	language.Node
+}
+
+func nodeAt(source language.Source, start, end int) language.ASTNode {
+	return testNode{language.Node{Loc: &language.Location{Start: start, End: end, Source: source}}}
+}
+
+func TestPrintsSyntaxErrorWithContext(t *testing.T) {
+	source := language.NewSource("query {\n  field\n  ?\n}")
+	e := NewSyntaxError(source, 18, Message{MsgUnexpectedCharacter, map[string]interface{}{"char": `"?"`}})
+
+	want := `Syntax Error GraphQL request (3:3) Cannot parse the unexpected character "?".
+
+GraphQL request (3:3)
+2 |   field
+3 |   ?
+  |   ^
+4 | }`
+
+	if got := PrintError(e); got != want {
+		t.Errorf("unexpected output; got\n%v\nwanted\n%v", got, want)
+	}
+}
+
+func TestPrintsEveryLocationWithNodeRange(t *testing.T) {
+	source := language.NewSource("{\n  dog: name\n  dog: barkVolume\n}", "query.graphql")
+	e := NewValidationError(
+		Message{ID: MsgUnknownError},
+		nodeAt(source, 4, 13),
+		nodeAt(source, 16, 31),
+	)
+
+	want := `An unknown error occurred.
+
+query.graphql (2:3)
+2 |   dog: name
+  |   ^^^^^^^^^
+
+query.graphql (3:3)
+3 |   dog: barkVolume
+  |   ^^^^^^^^^^^^^^^`
+
+	if got := PrintError(e, PrintOptions{NoContext: true}); got != want {
+		t.Errorf("unexpected output; got\n%v\nwanted\n%v", got, want)
+	}
+}
+
+func TestPrintsMultiLineNodeToEndOfLine(t *testing.T) {
+	source := language.NewSource("type Query {\n  a: Int\n}")
+	e := NewValidationError(Message{ID: MsgUnknownError}, nodeAt(source, 0, len(source.Body)))
+
+	want := "An unknown error occurred.\n\nGraphQL request (1:1)\n1 | type Query {\n  | ^^^^^^^^^^^^"
+
+	if got := PrintError(e, PrintOptions{NoContext: true}); got != want {
+		t.Errorf("unexpected output; got\n%q\nwanted\n%q", got, want)
+	}
+}
+
+func TestPrintsWithDefaultsForUnsetOptions(t *testing.T) {
+	source := language.NewSource("query {\n  field\n  ?\n}")
+	e := NewSyntaxError(source, 18, Message{MsgUnexpectedCharacter, map[string]interface{}{"char": `"?"`}})
+
+	want := "\x1b[1m\x1b[36mGraphQL request (3:3)\x1b[0m\n" +
+		"\x1b[2m2 | \x1b[0m  field\n" +
+		"\x1b[2m3 | \x1b[0m  ?\n" +
+		"\x1b[2m  | \x1b[0m  \x1b[1m\x1b[31m^\x1b[0m\n" +
+		"\x1b[2m4 | \x1b[0m}"
+
+	if got := PrintError(e, PrintOptions{Color: true}); !strings.HasSuffix(got, want) {
+		t.Errorf("unexpected output; got\n%q\nwanted it to end with\n%q", got, want)
+	}
+}
+
+func TestPrintsWithColor(t *testing.T) {
+	source := language.NewSource("{ ? }")
+	e := NewSyntaxError(source, 2, Message{MsgUnexpectedCharacter, map[string]interface{}{"char": `"?"`}})
+
+	want := "\x1b[1m\x1b[31mSyntax Error GraphQL request (1:3) Cannot parse the unexpected character \"?\".\x1b[0m\n\n" +
+		"\x1b[1m\x1b[36mGraphQL request (1:3)\x1b[0m\n" +
+		"\x1b[2m1 | \x1b[0m{ ? }\n" +
+		"\x1b[2m  | \x1b[0m  \x1b[1m\x1b[31m^\x1b[0m"
+
+	if got := PrintError(e, PrintOptions{ContextLines: 1, Color: true}); got != want {
+		t.Errorf("unexpected output; got\n%q\nwanted\n%q", got, want)
+	}
+}
@@ -0,0 +1,132 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// maxStackDepth is the maximum number of frames captured for a GraphQLError.
+const maxStackDepth = 64
+
+// Stack is a call stack captured when an error was created, represented as
+// program counters in the same format as runtime.Callers.
+type Stack []uintptr
+
+// StackTracer is implemented by errors that carry the call stack from where
+// they were created. GraphQLError implements it, and NewGraphQLError reuses the
+// stack of any error in the original error's chain that implements it.
+type StackTracer interface {
+	StackTrace() Stack
+}
+
+// Frames resolves the program counters of the stack into runtime frames.
+func (s Stack) Frames() []runtime.Frame {
+	if len(s) == 0 {
+		return nil
+	}
+
+	var out []runtime.Frame
+	frames := runtime.CallersFrames(s)
+	for {
+		frame, more := frames.Next()
+		out = append(out, frame)
+		if !more {
+			break
+		}
+	}
+
+	return out
+}
+
+// Lines formats each frame of the stack as "function (file:line)".
+func (s Stack) Lines() []string {
+	frames := s.Frames()
+	lines := make([]string, len(frames))
+	for idx, frame := range frames {
+		lines[idx] = fmt.Sprintf("%s (%s:%d)", frame.Function, frame.File, frame.Line)
+	}
+
+	return lines
+}
+
+// String formats the stack in the same layout as a goroutine trace.
+func (s Stack) String() string {
+	var b strings.Builder
+	for _, frame := range s.Frames() {
+		fmt.Fprintf(&b, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+	}
+
+	return b.String()
+}
+
+// stackError attaches a captured Stack to an arbitrary error.
+type stackError struct {
+	err   error
+	stack Stack
+}
+
+func (e stackError) Error() string {
+	return e.err.Error()
+}
+
+func (e stackError) Unwrap() error {
+	return e.err
+}
+
+func (e stackError) StackTrace() Stack {
+	return e.stack
+}
+
+// WithStack annotates err with the call stack at the point it is called.
+// Resolvers can use it so that the GraphQLError built from their error
+// reports where the failure happened rather than where it was located.
+func WithStack(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return stackError{err: err, stack: callers()}
+}
+
+// stackOf finds the first non-empty Stack in err's chain.
+func stackOf(err error) Stack {
+	for ; err != nil; err = errors.Unwrap(err) {
+		if st, ok := err.(StackTracer); ok && len(st.StackTrace()) > 0 {
+			return st.StackTrace()
+		}
+	}
+
+	return nil
+}
+
+// callers captures the current call stack, dropping the leading frames that
+// belong to this package so the stack starts at the caller that created the
+// error.
+func callers() Stack {
+	pcs := make([]uintptr, maxStackDepth)
+	n := runtime.Callers(2, pcs)
+	pcs = pcs[:n]
+
+	skip := 0
+	for skip < len(pcs)-1 {
+		fn := runtime.FuncForPC(pcs[skip] - 1)
+		if fn == nil || !strings.HasPrefix(fn.Name(), thisPackage+".") {
+			break
+		}
+		skip++
+	}
+
+	return Stack(pcs[skip:])
+}
+
+// thisPackage is the import path of this package, used to trim its frames
+// from captured stacks.
+var thisPackage = func() string {
+	pc, _, _, _ := runtime.Caller(0)
+	name := runtime.FuncForPC(pc).Name()
+	slash := strings.LastIndex(name, "/")
+
+	return name[:slash+strings.Index(name[slash:], ".")]
+}()
@@ -0,0 +1,70 @@
+package errors_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	gqlerrors "github.com/ijsnow/goql/internal/errors"
+)
+
+func failingResolver() error {
+	return gqlerrors.WithStack(errors.New("boom"))
+}
+
+func TestCapturesStackAtCreation(t *testing.T) {
+	e := gqlerrors.NewGraphQLError("msg", nil, nil, nil, nil, nil, nil).(*gqlerrors.GraphQLError)
+
+	frames := e.StackTrace().Frames()
+	if len(frames) == 0 {
+		t.Fatal("expected a captured stack")
+	}
+
+	if !strings.HasSuffix(frames[0].Function, "TestCapturesStackAtCreation") {
+		t.Errorf("expected stack to start at the caller; got %v", frames[0].Function)
+	}
+}
+
+func TestLocatedErrorReusesStackOfOriginalError(t *testing.T) {
+	e := gqlerrors.NewLocatedError(failingResolver(), nil, []interface{}{"field"}).(*gqlerrors.GraphQLError)
+
+	frames := e.StackTrace().Frames()
+	if len(frames) == 0 {
+		t.Fatal("expected a captured stack")
+	}
+
+	if !strings.HasSuffix(frames[0].Function, "failingResolver") {
+		t.Errorf("expected stack to start in the resolver; got %v", frames[0].Function)
+	}
+}
+
+func TestFormatIncludesStackTraceInDebugMode(t *testing.T) {
+	e := gqlerrors.NewLocatedError(failingResolver(), nil, []interface{}{"field"}).(*gqlerrors.GraphQLError)
+
+	formatted, err := gqlerrors.NewFormatError(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := formatted.Extensions["stacktrace"]; ok {
+		t.Errorf("did not expect a stack trace outside of debug mode")
+	}
+
+	formatted, err = gqlerrors.NewFormatError(e, gqlerrors.FormatOptions{Debug: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines, ok := formatted.Extensions["stacktrace"].([]string)
+	if !ok || len(lines) == 0 {
+		t.Fatalf("expected a stack trace in debug mode; got %v", formatted.Extensions)
+	}
+
+	if !strings.Contains(lines[0], "failingResolver") {
+		t.Errorf("unexpected first stack line; got %v", lines[0])
+	}
+
+	if _, ok := e.Extensions["stacktrace"]; ok {
+		t.Errorf("formatting must not modify the error's extensions")
+	}
+}
@@ -0,0 +1,127 @@
+package errors
+
+import (
+	"fmt"
+	"sort"
+)
+
+// maxSuggestions is the maximum number of items QuotedOrList includes.
+const maxSuggestions = 5
+
+// SuggestionList , given an invalid input string and a list of valid options, returns a filtered
+// list of valid options sorted based on their similarity with the input.
+func SuggestionList(input string, options []string) []string {
+	type suggestion struct {
+		option   string
+		distance int
+	}
+
+	var suggestions []suggestion
+	inputThreshold := float64(len([]rune(input))) / 2
+
+	for _, option := range options {
+		distance := lexicalDistance(input, option)
+		threshold := inputThreshold
+		if optionThreshold := float64(len([]rune(option))) / 2; optionThreshold > threshold {
+			threshold = optionThreshold
+		}
+		if threshold < 1 {
+			threshold = 1
+		}
+
+		if float64(distance) <= threshold {
+			suggestions = append(suggestions, suggestion{option, distance})
+		}
+	}
+
+	// Stable so options at the same distance keep the order they were given in.
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].distance < suggestions[j].distance
+	})
+
+	out := make([]string, len(suggestions))
+	for idx, s := range suggestions {
+		out[idx] = s.option
+	}
+
+	return out
+}
+
+/**
+ * lexicalDistance computes the lexical distance between strings A and B.
+ *
+ * The "distance" between two strings is given by counting the minimum number
+ * of edits needed to transform string A into string B. An edit can be an
+ * insertion, deletion, or substitution of a single character, or a swap of two
+ * adjacent characters.
+ *
+ * This distance can be useful for detecting typos in input or sorting
+ */
+func lexicalDistance(aStr, bStr string) int {
+	a := []rune(aStr)
+	b := []rune(bStr)
+
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+
+	for j := 1; j <= len(b); j++ {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			d[i][j] = min(
+				d[i-1][j]+1,
+				d[i][j-1]+1,
+				d[i-1][j-1]+cost,
+			)
+
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+cost)
+			}
+		}
+	}
+
+	return d[len(a)][len(b)]
+}
+
+// QuotedOrList , given [ A, B, C ] return '"A", "B" or "C"'.
+func QuotedOrList(items []string) string {
+	return quotedOrList(items).Localize(DefaultLocale)
+}
+
+// quotedOrList renders as a quoted list joined by the word for "or" in the
+// requested locale.
+type quotedOrList []string
+
+func (q quotedOrList) Localize(locale string) string {
+	selected := q
+	if len(selected) > maxSuggestions {
+		selected = selected[:maxSuggestions]
+	}
+
+	or := Message{ID: MsgOr}.Localize(locale)
+
+	out := ""
+	for idx, item := range selected {
+		switch {
+		case idx == 0:
+		case idx == len(selected)-1:
+			out += " " + or + " "
+		default:
+			out += ", "
+		}
+
+		out += fmt.Sprintf(`"%s"`, item)
+	}
+
+	return out
+}
@@ -0,0 +1,88 @@
+package errors
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSuggestionList(t *testing.T) {
+	tests := []struct {
+		input   string
+		options []string
+		want    []string
+	}{
+		{"", []string{"a"}, []string{"a"}},
+		{"a", []string{"a", "b"}, []string{"a", "b"}},
+		{"abc", []string{"a", "ab", "abc"}, []string{"abc", "ab"}},
+		{"nmae", []string{"name", "id", "friends"}, []string{"name"}},
+		{"frends", []string{"name", "friends", "friendsConnection"}, []string{"friends"}},
+		{"xyz", []string{"name", "id"}, []string{}},
+	}
+
+	for _, test := range tests {
+		got := SuggestionList(test.input, test.options)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("SuggestionList(%q, %v); got %v wanted %v", test.input, test.options, got, test.want)
+		}
+	}
+}
+
+func TestQuotedOrList(t *testing.T) {
+	tests := []struct {
+		items []string
+		want  string
+	}{
+		{[]string{"A"}, `"A"`},
+		{[]string{"A", "B"}, `"A" or "B"`},
+		{[]string{"A", "B", "C"}, `"A", "B" or "C"`},
+		{[]string{"A", "B", "C", "D", "E", "F"}, `"A", "B", "C", "D" or "E"`},
+	}
+
+	for _, test := range tests {
+		if got := QuotedOrList(test.items); got != test.want {
+			t.Errorf("QuotedOrList(%v); got %v wanted %v", test.items, got, test.want)
+		}
+	}
+}
+
+func TestMessagesAppendSuggestions(t *testing.T) {
+	tests := []struct {
+		got  Message
+		want string
+	}{
+		{
+			UndefinedFieldMessage("nmae", "Dog", nil, SuggestionList("nmae", []string{"name", "nickname"})),
+			`Cannot query field "nmae" on type "Dog". Did you mean "name"?`,
+		},
+		{
+			UndefinedFieldMessage("meowVolume", "Pet", []string{"Cat"}, []string{"barkVolume"}),
+			`Cannot query field "meowVolume" on type "Pet". Did you mean to use an inline fragment on "Cat"?`,
+		},
+		{
+			UnknownTypeMessage("Strnig", []string{"String"}),
+			`Unknown type "Strnig". Did you mean "String"?`,
+		},
+		{
+			UnknownArgMessage("dogCommand", "doesKnowCommand", "Dog", []string{"dogCommand"}),
+			`Unknown argument "dogCommand" on field "doesKnowCommand" of type "Dog". Did you mean "dogCommand"?`,
+		},
+		{
+			UnknownDirectiveArgMessage("iff", "skip", []string{"if"}),
+			`Unknown argument "iff" on directive "@skip". Did you mean "if"?`,
+		},
+		{
+			UnknownEnumValueMessage("SITT", "DogCommand", []string{"SIT"}),
+			`Value "SITT" does not exist in "DogCommand" enum. Did you mean "SIT"?`,
+		},
+		{
+			UnknownFragmentMessage("UnknownFragment", nil),
+			`Unknown fragment "UnknownFragment".`,
+		},
+	}
+
+	for _, test := range tests {
+		if test.got.String() != test.want {
+			t.Errorf("unexpected message; got\n%v\nwanted\n%v", test.got, test.want)
+		}
+	}
+}
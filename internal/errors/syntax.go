@@ -11,22 +11,36 @@ import (
 
 // NewSyntaxError returns a GraphQLError representing a syntax error, containing useful
 // descriptive information about the syntax error's position in the source.
+// The returned error matches ErrSyntax. PrintError renders it with more
+// context than the message does.
 func NewSyntaxError(
 	source language.Source,
 	position int,
-	description string,
+	description Message,
 ) error {
 	location := language.GetLocation(source, position)
+	message := Message{MsgSyntaxError, map[string]interface{}{
+		"source":      source.GetName(),
+		"line":        location.Line,
+		"column":      location.Column,
+		"description": description,
+	}}
+	excerpt := highlightSourceAtLocation(source, location)
 
-	return NewGraphQLError(
-		fmt.Sprintf("Syntax Error GraphQL request (%v:%v) ", location.Line, location.Column)+
-			description+"\n\n"+highlightSourceAtLocation(source, location),
+	gqlerr := NewGraphQLError(
+		message.String()+"\n\n"+excerpt,
 		nil,
 		&source,
 		[]int{position},
 		nil,
 		nil,
-	)
+		map[string]interface{}{"code": CodeParseFailed},
+	).(*GraphQLError)
+	gqlerr.kind = ErrSyntax
+	gqlerr.Localizable = &message
+	gqlerr.excerpt = excerpt
+
+	return gqlerr
 }
 
 // highlightSourceAtLocation is a helpful description
@@ -0,0 +1,22 @@
+package errors
+
+import "github.com/ijsnow/goql/internal/language"
+
+// NewValidationError returns a GraphQLError describing a violation of the
+// validation rules, located at the given nodes. The returned error matches
+// ErrValidation.
+func NewValidationError(message Message, nodes ...language.ASTNode) error {
+	gqlerr := NewGraphQLError(
+		message.String(),
+		nodes,
+		nil,
+		nil,
+		nil,
+		nil,
+		map[string]interface{}{"code": CodeValidationFailed},
+	).(*GraphQLError)
+	gqlerr.kind = ErrValidation
+	gqlerr.Localizable = &message
+
+	return gqlerr
+}
@@ -49,8 +49,8 @@ func (n *NodeList) GetStarts() []int {
 		}
 	}
 
-	starts := make([]int, len(*n))
-	for idx, s := range *n {
+	starts := make([]int, len(list))
+	for idx, s := range list {
 		starts[idx] = s.GetLoc().Start
 	}
 	return starts
@@ -112,7 +112,7 @@ type VariableDefinitionNode struct {
 	Node
 	Variable     VariableNode
 	Type         TypeNode
-	DefaultValue *ValueNode
+	DefaultValue ValueNode
 }
 
 // VariableNode ...
@@ -182,11 +182,12 @@ type FragmentDefinitionNode struct {
 
 // Values
 
-// ValueNode ...
-type ValueNode struct {
-	Node
-	Type  string
-	Value interface{}
+// ValueNode is implemented by the nodes of values. GetKind returns one of
+// the value kinds, KindVariable, KindInt, KindFloat, KindString, KindBoolean,
+// KindNull, KindEnum, KindList or KindObject.
+type ValueNode interface {
+	ASTNode
+	GetKind() string
 }
 
 // export type ValueNode =
@@ -202,7 +203,7 @@ type ValueNode struct {
 
 // IntValueNode ...
 type IntValueNode struct {
-	Loc   Location
+	Node
 	Value string
 }
 
@@ -216,6 +217,12 @@ type FloatValueNode struct {
 type StringValueNode struct {
 	Node
 	Value string
+
+	/**
+	 * Block is set for block strings, which are written between triple
+	 * quotes.
+	 */
+	Block bool
 }
 
 // BooleanValueNode ...
@@ -226,7 +233,7 @@ type BooleanValueNode struct {
 
 // NullValueNode ...
 type NullValueNode struct {
-	Loc *Location
+	Node
 }
 
 // EnumValueNode ...
@@ -238,7 +245,7 @@ type EnumValueNode struct {
 // ListValueNode ...
 type ListValueNode struct {
 	Node
-	Values []ASTNode
+	Values []ValueNode
 }
 
 // ObjectValueNode ...
@@ -254,6 +261,33 @@ type ObjectFieldNode struct {
 	Value ValueNode
 }
 
+// GetKind returns KindVariable.
+func (n *VariableNode) GetKind() string { return KindVariable }
+
+// GetKind returns KindInt.
+func (n *IntValueNode) GetKind() string { return KindInt }
+
+// GetKind returns KindFloat.
+func (n *FloatValueNode) GetKind() string { return KindFloat }
+
+// GetKind returns KindString.
+func (n *StringValueNode) GetKind() string { return KindString }
+
+// GetKind returns KindBoolean.
+func (n *BooleanValueNode) GetKind() string { return KindBoolean }
+
+// GetKind returns KindNull.
+func (n *NullValueNode) GetKind() string { return KindNull }
+
+// GetKind returns KindEnum.
+func (n *EnumValueNode) GetKind() string { return KindEnum }
+
+// GetKind returns KindList.
+func (n *ListValueNode) GetKind() string { return KindList }
+
+// GetKind returns KindObject.
+func (n *ObjectValueNode) GetKind() string { return KindObject }
+
 // Directives
 
 // DirectiveNode ...
@@ -330,74 +364,83 @@ type TypeDefinitionNode interface{}
 // ScalarTypeDefinitionNode ...
 type ScalarTypeDefinitionNode struct {
 	Node
-	Name       NameNode
-	Directives *[]DirectiveNode
+	Description *StringValueNode
+	Name        NameNode
+	Directives  *[]DirectiveNode
 }
 
 // ObjectTypeDefinitionNode ...
 type ObjectTypeDefinitionNode struct {
 	Node
-	Name       NameNode
-	Interfaces *[]NamedTypeNode
-	Directives *[]DirectiveNode
-	Fields     []FieldDefinitionNode
+	Description *StringValueNode
+	Name        NameNode
+	Interfaces  *[]NamedTypeNode
+	Directives  *[]DirectiveNode
+	Fields      []FieldDefinitionNode
 }
 
 // FieldDefinitionNode ...
 type FieldDefinitionNode struct {
 	Node
-	Name       NameNode
-	Arguments  []InputValueDefinitionNode
-	Type       TypeNode
-	Directives *[]DirectiveNode
+	Description *StringValueNode
+	Name        NameNode
+	Arguments   []InputValueDefinitionNode
+	Type        TypeNode
+	Directives  *[]DirectiveNode
 }
 
 // InputValueDefinitionNode ...
 type InputValueDefinitionNode struct {
 	Node
+	Description  *StringValueNode
 	Name         NameNode
 	Type         TypeNode
-	DefaultValue *ValueNode
+	DefaultValue ValueNode
 	Directives   *[]DirectiveNode
 }
 
 // InterfaceTypeDefinitionNode ...
 type InterfaceTypeDefinitionNode struct {
 	Node
-	Name       NameNode
-	Directives *[]DirectiveNode
-	Fields     []FieldDefinitionNode
+	Description *StringValueNode
+	Name        NameNode
+	Directives  *[]DirectiveNode
+	Fields      []FieldDefinitionNode
 }
 
 // UnionTypeDefinitionNode ...
 type UnionTypeDefinitionNode struct {
 	Node
-	Name       NameNode
-	Directives *[]DirectiveNode
-	Types      []NamedTypeNode
+	Description *StringValueNode
+	Name        NameNode
+	Directives  *[]DirectiveNode
+	Types       []NamedTypeNode
 }
 
 // EnumTypeDefinitionNode ...
 type EnumTypeDefinitionNode struct {
 	Node
-	Name       NameNode
-	Directives *[]DirectiveNode
-	Values     []EnumValueDefinitionNode
+	Description *StringValueNode
+	Name        NameNode
+	Directives  *[]DirectiveNode
+	Values      []EnumValueDefinitionNode
 }
 
 // EnumValueDefinitionNode ...
 type EnumValueDefinitionNode struct {
 	Node
-	Name       NameNode
-	Directives *[]DirectiveNode
+	Description *StringValueNode
+	Name        NameNode
+	Directives  *[]DirectiveNode
 }
 
 // InputObjectTypeDefinitionNode ...
 type InputObjectTypeDefinitionNode struct {
-	Loc        Location
-	Name       NameNode
-	Directives *[]DirectiveNode
-	Fields     []InputValueDefinitionNode
+	Node
+	Description *StringValueNode
+	Name        NameNode
+	Directives  *[]DirectiveNode
+	Fields      []InputValueDefinitionNode
 }
 
 // TypeExtensionDefinitionNode ...
@@ -409,7 +452,9 @@ type TypeExtensionDefinitionNode struct {
 // DirectiveDefinitionNode ...
 type DirectiveDefinitionNode struct {
 	Node
-	Name      NameNode
-	Arguments *[]InputValueDefinitionNode
-	Locations []NameNode
+	Description *StringValueNode
+	Name        NameNode
+	Arguments   *[]InputValueDefinitionNode
+	Repeatable  bool
+	Locations   []NameNode
 }
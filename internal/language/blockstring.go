@@ -0,0 +1,85 @@
+package language
+
+import "strings"
+
+// BlockStringValue produces the value of a block string from its raw
+// content, as written between the triple quotes.
+//
+// This implements the GraphQL spec's BlockStringValue() static algorithm: the
+// indentation common to all lines but the first is removed, along with the
+// blank lines at the start and at the end.
+func BlockStringValue(raw string) string {
+	// Expand a block string's raw value into independent lines.
+	lines := strings.Split(strings.NewReplacer("\r\n", "\n", "\r", "\n").Replace(raw), "\n")
+
+	// Remove common indentation from all lines but first.
+	commonIndent := -1
+	for _, line := range lines[1:] {
+		indent := leadingWhitespace(line)
+		if indent < len(line) && (commonIndent == -1 || indent < commonIndent) {
+			commonIndent = indent
+			if commonIndent == 0 {
+				break
+			}
+		}
+	}
+
+	if commonIndent > 0 {
+		for idx := 1; idx < len(lines); idx++ {
+			if len(lines[idx]) > commonIndent {
+				lines[idx] = lines[idx][commonIndent:]
+			} else {
+				lines[idx] = ""
+			}
+		}
+	}
+
+	// Remove leading and trailing blank lines.
+	for len(lines) > 0 && isBlank(lines[0]) {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && isBlank(lines[len(lines)-1]) {
+		lines = lines[:len(lines)-1]
+	}
+
+	// Return a string of the lines joined with U+000A.
+	return strings.Join(lines, "\n")
+}
+
+func leadingWhitespace(str string) int {
+	idx := 0
+	for idx < len(str) && (str[idx] == ' ' || str[idx] == '\t') {
+		idx++
+	}
+
+	return idx
+}
+
+func isBlank(str string) bool {
+	return leadingWhitespace(str) == len(str)
+}
+
+// PrintBlockString prints a string as a block string, the inverse of
+// BlockStringValue. The string is printed on lines of its own when it spans
+// several lines, when it could not be read back otherwise, or when
+// preferMultipleLines is set.
+func PrintBlockString(value string, preferMultipleLines bool) string {
+	isSingleLine := !strings.Contains(value, "\n")
+	hasLeadingSpace := strings.HasPrefix(value, " ") || strings.HasPrefix(value, "\t")
+	hasTrailingQuote := strings.HasSuffix(value, `"`)
+	hasTrailingSlash := strings.HasSuffix(value, `\`)
+	printAsMultipleLines := !isSingleLine || hasTrailingQuote || hasTrailingSlash || preferMultipleLines
+
+	var result string
+
+	// Format a multi-line block quote to account for leading space.
+	if printAsMultipleLines && !(isSingleLine && hasLeadingSpace) {
+		result += "\n"
+	}
+	result += value
+	if printAsMultipleLines {
+		result += "\n"
+	}
+
+	return `"""` + strings.Replace(result, `"""`, `\"""`, -1) + `"""`
+}
@@ -29,7 +29,7 @@ const (
 const (
 	KindInt         = "IntValue"
 	KindFloat       = "FloatValue"
-	FloatString     = "StringValue"
+	KindString      = "StringValue"
 	KindBoolean     = "BooleanValue"
 	KindNull        = "NullValue"
 	KindEnum        = "EnumValue"
@@ -0,0 +1,69 @@
+package language
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// Print converts an AST node into a string, using a standard set of
+// formatting rules.
+func Print(node ASTNode) string {
+	switch node := node.(type) {
+	case *NameNode:
+		return node.Value
+	case *VariableNode:
+		return "$" + node.Name.Value
+
+	// Value
+	case *IntValueNode:
+		return node.Value
+	case *FloatValueNode:
+		return node.Value
+	case *StringValueNode:
+		return printString(node.Value)
+	case *BooleanValueNode:
+		if node.Value {
+			return "true"
+		}
+		return "false"
+	case *NullValueNode:
+		return "null"
+	case *EnumValueNode:
+		return node.Value
+	case *ListValueNode:
+		values := make([]string, len(node.Values))
+		for idx, value := range node.Values {
+			values[idx] = Print(value)
+		}
+		return "[" + strings.Join(values, ", ") + "]"
+	case *ObjectValueNode:
+		fields := make([]string, len(node.Fields))
+		for idx := range node.Fields {
+			fields[idx] = Print(&node.Fields[idx])
+		}
+		return "{" + strings.Join(fields, ", ") + "}"
+	case *ObjectFieldNode:
+		return node.Name.Value + ": " + Print(node.Value)
+
+	// Type
+	case *NamedTypeNode:
+		return node.Name.Value
+	case *ListTypeNode:
+		return "[" + Print(node.Type.(ASTNode)) + "]"
+	case *NonNullTypeNode:
+		return Print(node.Type.(ASTNode)) + "!"
+	}
+
+	return ""
+}
+
+// printString prints a string as a GraphQL string literal.
+func printString(value string) string {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	enc.Encode(value)
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
@@ -1,13 +1,36 @@
 package language
 
+// DefaultSourceName is the name given to a Source when none is provided.
+const DefaultSourceName = "GraphQL request"
+
 // Source is a representation of source input to GraphQL
 type Source struct {
 	Body string
+
+	// Name identifies the source in error messages, for example a file name.
+	Name string
 }
 
-// NewSource creates a new Source struct
-func NewSource(body string) Source {
+// NewSource creates a new Source struct. The name is optional and defaults
+// to DefaultSourceName.
+func NewSource(body string, name ...string) Source {
+	sourceName := DefaultSourceName
+	if len(name) > 0 && name[0] != "" {
+		sourceName = name[0]
+	}
+
 	return Source{
 		Body: body,
+		Name: sourceName,
 	}
 }
+
+// GetName returns the name of the source, falling back to DefaultSourceName
+// for sources that were not created with NewSource.
+func (s Source) GetName() string {
+	if s.Name == "" {
+		return DefaultSourceName
+	}
+
+	return s.Name
+}
@@ -24,6 +24,7 @@ const (
 	TokenInt          TokenKind = "Int"
 	TokenFloat        TokenKind = "Float"
 	TokenString       TokenKind = "String"
+	TokenBlockString  TokenKind = "BlockString"
 	TokenComment      TokenKind = "Comment"
 )
 
@@ -79,24 +79,41 @@ func (l *Lexer) Advance() (*language.Token, error) {
 	token := l.Token
 
 	if token.Kind != language.TokenEOF {
-		for {
+		next, err := l.Lookahead()
+		if err != nil {
+			return nil, err
+		}
+
+		token = next
+		l.Token = token
+	}
+
+	return token, nil
+}
+
+// Lookahead returns the token after the current one, skipping comments,
+// without advancing the lexer.
+func (l *Lexer) Lookahead() (*language.Token, error) {
+	token := l.Token
+	if token.Kind == language.TokenEOF {
+		return token, nil
+	}
+
+	for {
+		// Tokens already read when looking ahead are kept in the list.
+		if token.Next == nil {
 			nt, err := readToken(l, token)
 			if err != nil {
 				return nil, err
 			}
-
 			token.Next = nt
-			token = nt
-
-			if token.Kind != language.TokenComment {
-				break
-			}
 		}
+		token = token.Next
 
-		l.Token = token
+		if token.Kind != language.TokenComment {
+			return token, nil
+		}
 	}
-
-	return token, nil
 }
 
 // getTokenDesc is a helper function to describe a token as a string for debugging
@@ -135,7 +152,9 @@ func readToken(lexer *Lexer, prev *language.Token) (*language.Token, error) {
 		return nil, errors.NewSyntaxError(
 			source,
 			position,
-			fmt.Sprintf("Cannot contain the invalid character %s.", printCharCode(code)),
+			errors.Message{ID: errors.MsgInvalidCharacter, Args: map[string]interface{}{
+				"char": printCharCode(code),
+			}},
 		)
 	}
 
@@ -255,6 +274,9 @@ func readToken(lexer *Lexer, prev *language.Token) (*language.Token, error) {
 		return readNumber(source, position, code, line, col, prev)
 	// "
 	case 34:
+		if charCodeAt(body, position+1) == 34 && charCodeAt(body, position+2) == 34 {
+			return readBlockString(lexer, position, line, col, prev)
+		}
 		return readString(source, position, line, col, prev)
 	}
 
@@ -307,15 +329,16 @@ func printChar(code rune) string {
 }
 
 // unexpectedCharacterMessage reports a message that an unexpected character was encountered.
-func unexpectedCharacterMessage(code rune) string {
+func unexpectedCharacterMessage(code rune) errors.Message {
 	if code == 39 { // '
-		return "Unexpected single quote character ('), did you mean to use a double quote (\")?"
+		return errors.Message{ID: errors.MsgUnexpectedSingleQuote}
 	}
 
 	q := fmt.Sprintf("%+q", code)
 
-	//return fmt.Sprintf("Cannot parse the unexpected character %s.", printChar(code))
-	return fmt.Sprintf("Cannot parse the unexpected character \"%s\".", q[1:len(q)-1])
+	return errors.Message{ID: errors.MsgUnexpectedCharacter, Args: map[string]interface{}{
+		"char": fmt.Sprintf("\"%s\"", q[1:len(q)-1]),
+	}}
 }
 
 /**
@@ -415,7 +438,9 @@ func readNumber(
 			return nil, errors.NewSyntaxError(
 				source,
 				position,
-				fmt.Sprintf("Invalid number, unexpected digit after 0: \"%c\".", code),
+				errors.Message{ID: errors.MsgUnexpectedDigitAfterZero, Args: map[string]interface{}{
+					"digit": fmt.Sprintf("\"%c\"", code),
+				}},
 			)
 		}
 	} else {
@@ -490,7 +515,9 @@ func readDigits(source language.Source, start int, firstCode rune) (int, error)
 	return position, errors.NewSyntaxError(
 		source,
 		position,
-		fmt.Sprintf("Invalid number, expected digit but got: %s.", printChar(code)),
+		errors.Message{ID: errors.MsgExpectedDigit, Args: map[string]interface{}{
+			"char": printChar(code),
+		}},
 	)
 }
 
@@ -519,7 +546,9 @@ func readString(source language.Source, start, line, col int, prev *language.Tok
 				return nil, errors.NewSyntaxError(
 					source,
 					position,
-					fmt.Sprintf("Invalid character within String: %s.", printCharCode(code)),
+					errors.Message{ID: errors.MsgInvalidStringCharacter, Args: map[string]interface{}{
+						"char": printCharCode(code),
+					}},
 				)
 			}
 
@@ -556,8 +585,9 @@ func readString(source language.Source, start, line, col int, prev *language.Tok
 						return nil, errors.NewSyntaxError(
 							source,
 							position,
-							fmt.Sprintf("Invalid character escape sequence: \\u%s.",
-								sliceStr(body, position+1, position+5)),
+							errors.Message{ID: errors.MsgInvalidEscapeSequence, Args: map[string]interface{}{
+								"sequence": "\\u" + sliceStr(body, position+1, position+5),
+							}},
 						)
 					}
 					value += fmt.Sprintf("%c", charCode)
@@ -566,7 +596,9 @@ func readString(source language.Source, start, line, col int, prev *language.Tok
 					return nil, errors.NewSyntaxError(
 						source,
 						position,
-						fmt.Sprintf("Invalid character escape sequence: \\%c.", code),
+						errors.Message{ID: errors.MsgInvalidEscapeSequence, Args: map[string]interface{}{
+							"sequence": fmt.Sprintf("\\%c", code),
+						}},
 					)
 				}
 
@@ -579,13 +611,82 @@ func readString(source language.Source, start, line, col int, prev *language.Tok
 	}
 
 	if code != 34 { // quote (")
-		return nil, errors.NewSyntaxError(source, position, "Unterminated string.")
+		return nil, errors.NewSyntaxError(source, position, errors.Message{ID: errors.MsgUnterminatedString})
 	}
 
 	value += sliceStr(body, chunkStart, position)
 	return language.NewToken(language.TokenString, start, position+1, line, col, prev, value), nil
 }
 
+/**
+ * Reads a block string token from the source file.
+ *
+ * """("?"?(\\"""|\\(?!=""")|[^"\\]))*"""
+ */
+func readBlockString(lexer *Lexer, start, line, col int, prev *language.Token) (*language.Token, error) {
+	source := lexer.Source
+	body := source.Body
+	position := start + 3
+	chunkStart := position
+	rawValue := ""
+
+	for position < len(body) {
+		code := charCodeAt(body, position)
+
+		// Closing Triple-Quote (""")
+		if code == 34 && charCodeAt(body, position+1) == 34 && charCodeAt(body, position+2) == 34 {
+			rawValue += sliceStr(body, chunkStart, position)
+			return language.NewToken(
+				language.TokenBlockString,
+				start,
+				position+3,
+				line,
+				col,
+				prev,
+				language.BlockStringValue(rawValue),
+			), nil
+		}
+
+		// SourceCharacter
+		if code < 0x0020 && code != 0x0009 && code != 0x000A && code != 0x000D {
+			return nil, errors.NewSyntaxError(
+				source,
+				position,
+				errors.Message{ID: errors.MsgInvalidStringCharacter, Args: map[string]interface{}{
+					"char": printCharCode(code),
+				}},
+			)
+		}
+
+		switch {
+		case code == 0x000A:
+			position++
+			lexer.Line++
+			lexer.LineStart = position
+		case code == 0x000D:
+			if charCodeAt(body, position+1) == 0x000A {
+				position += 2
+			} else {
+				position++
+			}
+			lexer.Line++
+			lexer.LineStart = position
+		case code == 92 &&
+			charCodeAt(body, position+1) == 34 &&
+			charCodeAt(body, position+2) == 34 &&
+			charCodeAt(body, position+3) == 34:
+			// Escape Triple-Quote (\""")
+			rawValue += sliceStr(body, chunkStart, position) + `"""`
+			position += 4
+			chunkStart = position
+		default:
+			position++
+		}
+	}
+
+	return nil, errors.NewSyntaxError(source, position, errors.Message{ID: errors.MsgUnterminatedString})
+}
+
 /**
  * Converts four hexidecimal chars to the integer that the
  * string represents. For example, uniCharCode('0','0','0','f')
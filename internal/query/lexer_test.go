@@ -369,6 +369,115 @@ func TestLexReportsUsefulStringErrors(t *testing.T) {
 
 }
 
+func TestLexesBlockStrings(t *testing.T) {
+	set := []tokenTest{
+		tokenTest{
+			lex: `"""simple"""`,
+			want: &language.Token{
+				Kind:  language.TokenBlockString,
+				Start: 0,
+				End:   12,
+				Value: "simple",
+			},
+		},
+		tokenTest{
+			lex: `"""contains " quote"""`,
+			want: &language.Token{
+				Kind:  language.TokenBlockString,
+				Start: 0,
+				End:   22,
+				Value: `contains " quote`,
+			},
+		},
+		tokenTest{
+			lex: `"""contains \""" triplequote"""`,
+			want: &language.Token{
+				Kind:  language.TokenBlockString,
+				Start: 0,
+				End:   31,
+				Value: `contains """ triplequote`,
+			},
+		},
+		tokenTest{
+			lex: "\"\"\"multi\nline\"\"\"",
+			want: &language.Token{
+				Kind:  language.TokenBlockString,
+				Start: 0,
+				End:   16,
+				Value: "multi\nline",
+			},
+		},
+		tokenTest{
+			lex: "\"\"\"unescaped \\n\\r\\b\\t\\f\\u1234\"\"\"",
+			want: &language.Token{
+				Kind:  language.TokenBlockString,
+				Start: 0,
+				End:   32,
+				Value: "unescaped \\n\\r\\b\\t\\f\\u1234",
+			},
+		},
+		tokenTest{
+			lex: "\"\"\"\n\n        spans\n          multiple\n            lines\n\n        \"\"\"",
+			want: &language.Token{
+				Kind:  language.TokenBlockString,
+				Start: 0,
+				End:   68,
+				Value: "spans\n  multiple\n    lines",
+			},
+		},
+	}
+
+	for _, test := range set {
+		checkToken(t, test.lex, test.want)
+	}
+}
+
+func TestLexReportsUsefulBlockStringErrors(t *testing.T) {
+	set := [][]string{
+		[]string{
+			`"""`,
+			"Syntax Error GraphQL request (1:4) Unterminated string.",
+		},
+		[]string{
+			`"""no end quote`,
+			"Syntax Error GraphQL request (1:16) Unterminated string.",
+		},
+		[]string{
+			"\"\"\"contains unescaped \u0007 control char\"\"\"",
+			"Syntax Error GraphQL request (1:23) Invalid character within String: \"\\u0007\".",
+		},
+	}
+
+	for _, test := range set {
+		_, err := lexOne(test[0])
+		testErr(t, err, test[1])
+	}
+}
+
+func TestLookaheadDoesNotAdvance(t *testing.T) {
+	lexer := CreateLexer(language.NewSource("foo # comment\nbar baz"))
+
+	if _, err := lexer.Advance(); err != nil {
+		t.Fatal(err)
+	}
+
+	next, err := lexer.Lookahead()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next.Value != "bar" || lexer.Token.Value != "foo" {
+		t.Errorf("Lookahead; got %q at %q wanted \"bar\" at \"foo\"", next.Value, lexer.Token.Value)
+	}
+
+	got, err := lexer.Advance()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != next || got.Line != 2 {
+		t.Errorf("Advance after Lookahead; got %q on line %d wanted \"bar\" on line 2", got.Value, got.Line)
+	}
+}
+
 func TestLexesNumbers(t *testing.T) {
 	set := []tokenTest{
 		tokenTest{
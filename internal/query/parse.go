@@ -1,985 +1,1618 @@
 package query
 
 import (
-	"fmt"
+	"github.com/ijsnow/goql/internal/errors"
+	"github.com/ijsnow/goql/internal/language"
+)
+
+type parser func(*Lexer) (language.ASTNode, error)
+
+/**
+ * Given a GraphQL source, parses it into a Document.
+ * Returns a GraphQLError if a syntax error is encountered.
+ */
+func Parse(source language.Source, options ...ParseOptions) (*language.DocumentNode, error) {
+	lexer := CreateLexer(source, options...)
+
+	return parseDocument(lexer)
+}
+
+/**
+ * Given a string containing a GraphQL value (ex. `[42]`), parse the AST for
+ * that value.
+ * Returns a GraphQLError if a syntax error is encountered.
+ *
+ * This is useful within tools that operate upon GraphQL Values directly and
+ * in isolation of complete GraphQL documents.
+ *
+ * Consider providing the results to the utility function: valueFromAST().
+ */
+func ParseValue(source language.Source, options ...ParseOptions) (language.ValueNode, error) {
+	lexer := CreateLexer(source, options...)
+
+	if _, err := expect(lexer, language.TokenSOF); err != nil {
+		return nil, err
+	}
+
+	value, err := parseValueLiteral(lexer, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := expect(lexer, language.TokenEOF); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+/**
+ * Given a string containing a GraphQL Type (ex. `[Int!]`), parse the AST for
+ * that type.
+ * Returns a GraphQLError if a syntax error is encountered.
+ *
+ * This is useful within tools that operate upon GraphQL Types directly and
+ * in isolation of complete GraphQL documents.
+ *
+ * Consider providing the results to the utility function: typeFromAST().
+ */
+func ParseType(source language.Source, options ...ParseOptions) (language.TypeNode, error) {
+	lexer := CreateLexer(source, options...)
+
+	if _, err := expect(lexer, language.TokenSOF); err != nil {
+		return nil, err
+	}
+
+	t, err := parseTypeReference(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := expect(lexer, language.TokenEOF); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+/**
+ * Converts a name lex token into a name parse node.
+ */
+func parseName(lexer *Lexer) (language.NameNode, error) {
+	token, err := expect(lexer, language.TokenName)
+	if err != nil {
+		return language.NameNode{}, err
+	}
+
+	return language.NameNode{
+		Node:  language.Node{Loc: loc(lexer, token)},
+		Value: token.Value,
+	}, nil
+}
+
+// Implements the parsing rules in the Document section.
+
+/**
+ * Document : Definition+
+ */
+func parseDocument(lexer *Lexer) (*language.DocumentNode, error) {
+	start := lexer.Token
+
+	if _, err := expect(lexer, language.TokenSOF); err != nil {
+		return nil, err
+	}
+
+	var definitions []language.DefinitionNode
+	for {
+		definition, err := parseDefinition(lexer)
+		if err != nil {
+			return nil, err
+		}
+		definitions = append(definitions, definition)
+
+		done, err := skip(lexer, language.TokenEOF)
+		if err != nil {
+			return nil, err
+		}
+		if done {
+			break
+		}
+	}
+
+	return &language.DocumentNode{
+		Node:        language.Node{Loc: loc(lexer, start)},
+		Definitions: definitions,
+	}, nil
+}
+
+/**
+ * Definition :
+ *   - OperationDefinition
+ *   - FragmentDefinition
+ *   - TypeSystemDefinition
+ */
+func parseDefinition(lexer *Lexer) (language.DefinitionNode, error) {
+	if peek(lexer, language.TokenBraceLeft) {
+		return parseOperationDefinition(lexer)
+	}
+
+	// Only the definitions of the type system have descriptions.
+	if peekDescription(lexer) {
+		return parseTypeSystemDefinition(lexer)
+	}
+
+	if peek(lexer, language.TokenName) {
+		switch lexer.Token.Value {
+		// Note: subscription is an experimental non-spec addition.
+		case "query", "mutation", "subscription":
+			return parseOperationDefinition(lexer)
+
+		case "fragment":
+			return parseFragmentDefinition(lexer)
+
+		// Note: the Type System IDL is an experimental non-spec addition.
+		case "schema", "scalar", "type", "interface", "union", "enum", "input", "extend", "directive":
+			return parseTypeSystemDefinition(lexer)
+		}
+	}
+
+	return nil, unexpected(lexer, nil)
+}
+
+// Implements the parsing rules in the Operations section.
+
+/**
+ * OperationDefinition :
+ *  - SelectionSet
+ *  - OperationType Name? VariableDefinitions? Directives? SelectionSet
+ */
+func parseOperationDefinition(lexer *Lexer) (*language.OperationDefinitionNode, error) {
+	start := lexer.Token
+
+	if peek(lexer, language.TokenBraceLeft) {
+		selectionSet, err := parseSelectionSet(lexer)
+		if err != nil {
+			return nil, err
+		}
+
+		return &language.OperationDefinitionNode{
+			Node:         language.Node{Loc: loc(lexer, start)},
+			Operation:    language.OperationTypeQuery,
+			Directives:   &[]language.DirectiveNode{},
+			SelectionSet: selectionSet,
+		}, nil
+	}
+
+	operation, err := parseOperationType(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	var name language.NameNode
+	if peek(lexer, language.TokenName) {
+		if name, err = parseName(lexer); err != nil {
+			return nil, err
+		}
+	}
+
+	variableDefinitions, err := parseVariableDefinitions(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	directives, err := parseDirectives(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	selectionSet, err := parseSelectionSet(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &language.OperationDefinitionNode{
+		Node:                language.Node{Loc: loc(lexer, start)},
+		Operation:           operation,
+		Name:                name,
+		VariableDefinitions: &variableDefinitions,
+		Directives:          &directives,
+		SelectionSet:        selectionSet,
+	}, nil
+}
+
+/**
+ * OperationType : one of query mutation subscription
+ */
+func parseOperationType(lexer *Lexer) (language.OperationTypeNode, error) {
+	operationToken, err := expect(lexer, language.TokenName)
+	if err != nil {
+		return "", err
+	}
+
+	switch operationToken.Value {
+	case language.OperationTypeQuery, language.OperationTypeMutation:
+		return language.OperationTypeNode(operationToken.Value), nil
+	// Note: subscription is an experimental non-spec addition.
+	case language.OperationTypeSubscription:
+		return language.OperationTypeNode(operationToken.Value), nil
+	}
+
+	return "", unexpected(lexer, operationToken)
+}
+
+/**
+ * VariableDefinitions : ( VariableDefinition+ )
+ */
+func parseVariableDefinitions(lexer *Lexer) ([]language.VariableDefinitionNode, error) {
+	definitions := []language.VariableDefinitionNode{}
+	if !peek(lexer, language.TokenParenLeft) {
+		return definitions, nil
+	}
+
+	nodes, err := many(lexer, language.TokenParenLeft, func(lexer *Lexer) (language.ASTNode, error) {
+		return parseVariableDefinition(lexer)
+	}, language.TokenParenRight)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, node := range nodes {
+		definitions = append(definitions, *node.(*language.VariableDefinitionNode))
+	}
+
+	return definitions, nil
+}
+
+/**
+ * VariableDefinition : Variable : Type DefaultValue?
+ */
+func parseVariableDefinition(lexer *Lexer) (*language.VariableDefinitionNode, error) {
+	start := lexer.Token
+
+	variable, err := parseVariable(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := expect(lexer, language.TokenColon); err != nil {
+		return nil, err
+	}
+
+	t, err := parseTypeReference(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	defaultValue, err := parseDefaultValue(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &language.VariableDefinitionNode{
+		Node:         language.Node{Loc: loc(lexer, start)},
+		Variable:     *variable,
+		Type:         t,
+		DefaultValue: defaultValue,
+	}, nil
+}
+
+/**
+ * DefaultValue : = Value[Const]
+ */
+func parseDefaultValue(lexer *Lexer) (language.ValueNode, error) {
+	hasDefault, err := skip(lexer, language.TokenEqual)
+	if err != nil || !hasDefault {
+		return nil, err
+	}
+
+	return parseValueLiteral(lexer, true)
+}
+
+/**
+ * SelectionSet : { Selection+ }
+ */
+func parseSelectionSet(lexer *Lexer) (language.SelectionSetNode, error) {
+	start := lexer.Token
+
+	nodes, err := many(lexer, language.TokenBraceLeft, func(lexer *Lexer) (language.ASTNode, error) {
+		return parseSelection(lexer)
+	}, language.TokenBraceRight)
+	if err != nil {
+		return language.SelectionSetNode{}, err
+	}
+
+	selections := make([]language.SelectionNode, len(nodes))
+	for idx, node := range nodes {
+		selections[idx] = node
+	}
+
+	return language.SelectionSetNode{
+		Node:       language.Node{Loc: loc(lexer, start)},
+		Selections: selections,
+	}, nil
+}
+
+/**
+ * Selection :
+ *   - Field
+ *   - FragmentSpread
+ *   - InlineFragment
+ */
+func parseSelection(lexer *Lexer) (language.ASTNode, error) {
+	if peek(lexer, language.TokenSpread) {
+		return parseFragment(lexer)
+	}
+
+	return parseField(lexer)
+}
+
+/**
+ * Field : Alias? Name Arguments? Directives? SelectionSet?
+ *
+ * Alias : Name :
+ */
+func parseField(lexer *Lexer) (*language.FieldNode, error) {
+	start := lexer.Token
+
+	nameOrAlias, err := parseName(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	var alias *language.NameNode
+	name := nameOrAlias
+
+	hasAlias, err := skip(lexer, language.TokenColon)
+	if err != nil {
+		return nil, err
+	}
+	if hasAlias {
+		alias = &nameOrAlias
+		if name, err = parseName(lexer); err != nil {
+			return nil, err
+		}
+	}
+
+	args, err := parseArguments(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	directives, err := parseDirectives(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	var selectionSet *language.SelectionSetNode
+	if peek(lexer, language.TokenBraceLeft) {
+		set, err := parseSelectionSet(lexer)
+		if err != nil {
+			return nil, err
+		}
+		selectionSet = &set
+	}
+
+	return &language.FieldNode{
+		Node:         language.Node{Loc: loc(lexer, start)},
+		Alias:        alias,
+		Name:         name,
+		Arguments:    &args,
+		Directives:   &directives,
+		SelectionSet: selectionSet,
+	}, nil
+}
+
+/**
+ * Arguments : ( Argument+ )
+ */
+func parseArguments(lexer *Lexer) ([]language.ArgumentNode, error) {
+	args := []language.ArgumentNode{}
+	if !peek(lexer, language.TokenParenLeft) {
+		return args, nil
+	}
+
+	nodes, err := many(lexer, language.TokenParenLeft, func(lexer *Lexer) (language.ASTNode, error) {
+		return parseArgument(lexer)
+	}, language.TokenParenRight)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, node := range nodes {
+		args = append(args, *node.(*language.ArgumentNode))
+	}
+
+	return args, nil
+}
+
+/**
+ * Argument : Name : Value
+ */
+func parseArgument(lexer *Lexer) (*language.ArgumentNode, error) {
+	start := lexer.Token
+
+	name, err := parseName(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := expect(lexer, language.TokenColon); err != nil {
+		return nil, err
+	}
+
+	value, err := parseValueLiteral(lexer, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &language.ArgumentNode{
+		Node:  language.Node{Loc: loc(lexer, start)},
+		Name:  name,
+		Value: value,
+	}, nil
+}
+
+// Implements the parsing rules in the Fragments section.
+
+/**
+ * Corresponds to both FragmentSpread and InlineFragment in the spec.
+ *
+ * FragmentSpread : ... FragmentName Directives?
+ *
+ * InlineFragment : ... TypeCondition? Directives? SelectionSet
+ */
+func parseFragment(lexer *Lexer) (language.ASTNode, error) {
+	start := lexer.Token
+
+	if _, err := expect(lexer, language.TokenSpread); err != nil {
+		return nil, err
+	}
+
+	if peek(lexer, language.TokenName) && lexer.Token.Value != "on" {
+		name, err := parseFragmentName(lexer)
+		if err != nil {
+			return nil, err
+		}
+
+		directives, err := parseDirectives(lexer)
+		if err != nil {
+			return nil, err
+		}
+
+		return &language.FragmentSpreadNode{
+			Node:       language.Node{Loc: loc(lexer, start)},
+			Name:       name,
+			Directives: &directives,
+		}, nil
+	}
+
+	var typeCondition *language.NamedTypeNode
+	if lexer.Token.Value == "on" {
+		if err := advance(lexer); err != nil {
+			return nil, err
+		}
+
+		namedType, err := parseNamedType(lexer)
+		if err != nil {
+			return nil, err
+		}
+		typeCondition = namedType
+	}
+
+	directives, err := parseDirectives(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	selectionSet, err := parseSelectionSet(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &language.InlineFragmentNode{
+		Node:          language.Node{Loc: loc(lexer, start)},
+		TypeCondition: typeCondition,
+		Directives:    &directives,
+		SelectionSet:  &selectionSet,
+	}, nil
+}
+
+/**
+ * FragmentDefinition :
+ *   - fragment FragmentName on TypeCondition Directives? SelectionSet
+ *
+ * TypeCondition : NamedType
+ */
+func parseFragmentDefinition(lexer *Lexer) (*language.FragmentDefinitionNode, error) {
+	start := lexer.Token
+
+	if _, err := expectKeyword(lexer, "fragment"); err != nil {
+		return nil, err
+	}
+
+	name, err := parseFragmentName(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := expectKeyword(lexer, "on"); err != nil {
+		return nil, err
+	}
+
+	typeCondition, err := parseNamedType(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	directives, err := parseDirectives(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	selectionSet, err := parseSelectionSet(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &language.FragmentDefinitionNode{
+		Node:          language.Node{Loc: loc(lexer, start)},
+		Name:          name,
+		TypeCondition: *typeCondition,
+		Directives:    &directives,
+		SelectionSet:  selectionSet,
+	}, nil
+}
+
+/**
+ * FragmentName : Name but not `on`
+ */
+func parseFragmentName(lexer *Lexer) (language.NameNode, error) {
+	if lexer.Token.Value == "on" {
+		return language.NameNode{}, unexpected(lexer, nil)
+	}
+
+	return parseName(lexer)
+}
+
+// Implements the parsing rules in the Values section.
+
+/**
+ * Value[Const] :
+ *   - [~Const] Variable
+ *   - IntValue
+ *   - FloatValue
+ *   - StringValue
+ *   - BooleanValue
+ *   - NullValue
+ *   - EnumValue
+ *   - ListValue[?Const]
+ *   - ObjectValue[?Const]
+ *
+ * BooleanValue : one of `true` `false`
+ *
+ * NullValue : `null`
+ *
+ * EnumValue : Name but not `true`, `false` or `null`
+ */
+func parseValueLiteral(lexer *Lexer, isConst bool) (language.ValueNode, error) {
+	token := lexer.Token
+
+	switch token.Kind {
+	case language.TokenBracketLeft:
+		return parseList(lexer, isConst)
+	case language.TokenBraceLeft:
+		return parseObject(lexer, isConst)
+	case language.TokenInt:
+		if err := advance(lexer); err != nil {
+			return nil, err
+		}
+		return &language.IntValueNode{
+			Node:  language.Node{Loc: loc(lexer, token)},
+			Value: token.Value,
+		}, nil
+	case language.TokenFloat:
+		if err := advance(lexer); err != nil {
+			return nil, err
+		}
+		return &language.FloatValueNode{
+			Node:  language.Node{Loc: loc(lexer, token)},
+			Value: token.Value,
+		}, nil
+	case language.TokenString, language.TokenBlockString:
+		return parseStringLiteral(lexer)
+	case language.TokenName:
+		if err := advance(lexer); err != nil {
+			return nil, err
+		}
+
+		switch token.Value {
+		case "true", "false":
+			return &language.BooleanValueNode{
+				Node:  language.Node{Loc: loc(lexer, token)},
+				Value: token.Value == "true",
+			}, nil
+		case "null":
+			return &language.NullValueNode{
+				Node: language.Node{Loc: loc(lexer, token)},
+			}, nil
+		}
+
+		return &language.EnumValueNode{
+			Node:  language.Node{Loc: loc(lexer, token)},
+			Value: token.Value,
+		}, nil
+	case language.TokenDollar:
+		if !isConst {
+			return parseVariable(lexer)
+		}
+	}
+
+	return nil, unexpected(lexer, token)
+}
+
+/**
+ * StringValue : String or BlockString
+ */
+func parseStringLiteral(lexer *Lexer) (*language.StringValueNode, error) {
+	token := lexer.Token
+
+	if err := advance(lexer); err != nil {
+		return nil, err
+	}
+
+	return &language.StringValueNode{
+		Node:  language.Node{Loc: loc(lexer, token)},
+		Value: token.Value,
+		Block: token.Kind == language.TokenBlockString,
+	}, nil
+}
+
+func parseConstValue(lexer *Lexer) (language.ASTNode, error) {
+	return parseValueLiteral(lexer, true)
+}
+
+func parseValueValue(lexer *Lexer) (language.ASTNode, error) {
+	return parseValueLiteral(lexer, false)
+}
+
+/**
+ * Variable : $ Name
+ */
+func parseVariable(lexer *Lexer) (*language.VariableNode, error) {
+	start := lexer.Token
+
+	if _, err := expect(lexer, language.TokenDollar); err != nil {
+		return nil, err
+	}
+
+	name, err := parseName(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &language.VariableNode{
+		Node: language.Node{Loc: loc(lexer, start)},
+		Name: name,
+	}, nil
+}
+
+/**
+ * ListValue[Const] :
+ *   - [ ]
+ *   - [ Value[?Const]+ ]
+ */
+func parseList(lexer *Lexer, isConst bool) (*language.ListValueNode, error) {
+	start := lexer.Token
+	var item parser
+	if isConst {
+		item = parseConstValue
+	} else {
+		item = parseValueValue
+	}
+
+	nodes, err := any(lexer, language.TokenBracketLeft, item, language.TokenBracketRight)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]language.ValueNode, len(nodes))
+	for idx, node := range nodes {
+		values[idx] = node.(language.ValueNode)
+	}
+
+	return &language.ListValueNode{
+		Node:   language.Node{Loc: loc(lexer, start)},
+		Values: values,
+	}, nil
+}
+
+/**
+ * ObjectValue[Const] :
+ *   - { }
+ *   - { ObjectField[?Const]+ }
+ */
+func parseObject(lexer *Lexer, isConst bool) (*language.ObjectValueNode, error) {
+	start := lexer.Token
+
+	if _, err := expect(lexer, language.TokenBraceLeft); err != nil {
+		return nil, err
+	}
+
+	fields := make([]language.ObjectFieldNode, 0)
+
+	for {
+		done, err := skip(lexer, language.TokenBraceRight)
+		if err != nil {
+			return nil, err
+		}
+		if done {
+			break
+		}
+
+		field, err := parseObjectField(lexer, isConst)
+		if err != nil {
+			return nil, err
+		}
+
+		fields = append(fields, field)
+	}
+
+	return &language.ObjectValueNode{
+		Node:   language.Node{Loc: loc(lexer, start)},
+		Fields: fields,
+	}, nil
+}
+
+/**
+ * ObjectField[Const] : Name : Value[?Const]
+ */
+func parseObjectField(lexer *Lexer, isConst bool) (language.ObjectFieldNode, error) {
+	start := lexer.Token
+
+	name, err := parseName(lexer)
+	if err != nil {
+		return language.ObjectFieldNode{}, err
+	}
+
+	if _, err := expect(lexer, language.TokenColon); err != nil {
+		return language.ObjectFieldNode{}, err
+	}
+
+	value, err := parseValueLiteral(lexer, isConst)
+	if err != nil {
+		return language.ObjectFieldNode{}, err
+	}
+
+	return language.ObjectFieldNode{
+		Node:  language.Node{Loc: loc(lexer, start)},
+		Name:  name,
+		Value: value,
+	}, nil
+}
+
+// Implements the parsing rules in the Directives section.
+
+/**
+ * Directives : Directive+
+ */
+func parseDirectives(lexer *Lexer) ([]language.DirectiveNode, error) {
+	directives := []language.DirectiveNode{}
+	for peek(lexer, language.TokenAt) {
+		directive, err := parseDirective(lexer)
+		if err != nil {
+			return nil, err
+		}
+		directives = append(directives, *directive)
+	}
+
+	return directives, nil
+}
+
+/**
+ * Directive : @ Name Arguments?
+ */
+func parseDirective(lexer *Lexer) (*language.DirectiveNode, error) {
+	start := lexer.Token
+
+	if _, err := expect(lexer, language.TokenAt); err != nil {
+		return nil, err
+	}
+
+	name, err := parseName(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	args, err := parseArguments(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &language.DirectiveNode{
+		Node:      language.Node{Loc: loc(lexer, start)},
+		Name:      name,
+		Arguments: &args,
+	}, nil
+}
+
+// Implements the parsing rules in the Types section.
+
+/**
+ * Type :
+ *   - NamedType
+ *   - ListType
+ *   - NonNullType
+ */
+func parseTypeReference(lexer *Lexer) (language.TypeNode, error) {
+	start := lexer.Token
+
+	var t language.TypeNode
+
+	isList, err := skip(lexer, language.TokenBracketLeft)
+	if err != nil {
+		return nil, err
+	}
+	if isList {
+		itemType, err := parseTypeReference(lexer)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := expect(lexer, language.TokenBracketRight); err != nil {
+			return nil, err
+		}
+
+		t = &language.ListTypeNode{
+			Node: language.Node{Loc: loc(lexer, start)},
+			Type: itemType,
+		}
+	} else {
+		if t, err = parseNamedType(lexer); err != nil {
+			return nil, err
+		}
+	}
+
+	isNonNull, err := skip(lexer, language.TokenBang)
+	if err != nil {
+		return nil, err
+	}
+	if isNonNull {
+		return &language.NonNullTypeNode{
+			Node: language.Node{Loc: loc(lexer, start)},
+			Type: t,
+		}, nil
+	}
+
+	return t, nil
+}
+
+/**
+ * NamedType : Name
+ */
+func parseNamedType(lexer *Lexer) (*language.NamedTypeNode, error) {
+	start := lexer.Token
+
+	name, err := parseName(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &language.NamedTypeNode{
+		Node: language.Node{Loc: loc(lexer, start)},
+		Name: name,
+	}, nil
+}
+
+// Implements the parsing rules in the Type Definition section.
+
+/**
+ * TypeSystemDefinition :
+ *   - SchemaDefinition
+ *   - TypeDefinition
+ *   - TypeExtensionDefinition
+ *   - DirectiveDefinition
+ *
+ * TypeDefinition :
+ *   - ScalarTypeDefinition
+ *   - ObjectTypeDefinition
+ *   - InterfaceTypeDefinition
+ *   - UnionTypeDefinition
+ *   - EnumTypeDefinition
+ *   - InputObjectTypeDefinition
+ */
+func parseTypeSystemDefinition(lexer *Lexer) (language.TypeSystemDefinitionNode, error) {
+	// Many definitions begin with a description and require a lookahead.
+	keywordToken := lexer.Token
+	if peekDescription(lexer) {
+		token, err := lexer.Lookahead()
+		if err != nil {
+			return nil, err
+		}
+		keywordToken = token
+	}
+
+	if keywordToken.Kind == language.TokenName {
+		switch keywordToken.Value {
+		case "schema":
+			return parseSchemaDefinition(lexer)
+		case "scalar":
+			return parseScalarTypeDefinition(lexer)
+		case "type":
+			return parseObjectTypeDefinition(lexer)
+		case "interface":
+			return parseInterfaceTypeDefinition(lexer)
+		case "union":
+			return parseUnionTypeDefinition(lexer)
+		case "enum":
+			return parseEnumTypeDefinition(lexer)
+		case "input":
+			return parseInputObjectTypeDefinition(lexer)
+		case "extend":
+			return parseTypeExtensionDefinition(lexer)
+		case "directive":
+			return parseDirectiveDefinition(lexer)
+		}
+	}
+
+	return nil, unexpected(lexer, keywordToken)
+}
+
+/**
+ * Determines if the next token starts a description.
+ */
+func peekDescription(lexer *Lexer) bool {
+	return peek(lexer, language.TokenString) || peek(lexer, language.TokenBlockString)
+}
+
+/**
+ * Description : StringValue
+ */
+func parseDescription(lexer *Lexer) (*language.StringValueNode, error) {
+	if !peekDescription(lexer) {
+		return nil, nil
+	}
+
+	return parseStringLiteral(lexer)
+}
+
+/**
+ * SchemaDefinition : schema Directives? { OperationTypeDefinition+ }
+ *
+ * OperationTypeDefinition : OperationType : NamedType
+ */
+func parseSchemaDefinition(lexer *Lexer) (*language.SchemaDefinitionNode, error) {
+	start := lexer.Token
+
+	if _, err := expectKeyword(lexer, "schema"); err != nil {
+		return nil, err
+	}
+
+	directives, err := parseDirectives(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes, err := many(lexer, language.TokenBraceLeft, func(lexer *Lexer) (language.ASTNode, error) {
+		return parseOperationTypeDefinition(lexer)
+	}, language.TokenBraceRight)
+	if err != nil {
+		return nil, err
+	}
+
+	operationTypes := make([]language.OperationTypeDefinitionNode, len(nodes))
+	for idx, node := range nodes {
+		operationTypes[idx] = *node.(*language.OperationTypeDefinitionNode)
+	}
+
+	return &language.SchemaDefinitionNode{
+		Node:           language.Node{Loc: loc(lexer, start)},
+		Directives:     directives,
+		OperationTypes: operationTypes,
+	}, nil
+}
+
+func parseOperationTypeDefinition(lexer *Lexer) (*language.OperationTypeDefinitionNode, error) {
+	start := lexer.Token
+
+	operation, err := parseOperationType(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := expect(lexer, language.TokenColon); err != nil {
+		return nil, err
+	}
+
+	t, err := parseNamedType(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &language.OperationTypeDefinitionNode{
+		Node:      language.Node{Loc: loc(lexer, start)},
+		Operation: operation,
+		Type:      *t,
+	}, nil
+}
+
+/**
+ * ScalarTypeDefinition : Description? scalar Name Directives?
+ */
+func parseScalarTypeDefinition(lexer *Lexer) (*language.ScalarTypeDefinitionNode, error) {
+	start := lexer.Token
+
+	description, err := parseDescription(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := expectKeyword(lexer, "scalar"); err != nil {
+		return nil, err
+	}
+
+	name, err := parseName(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	directives, err := parseDirectives(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &language.ScalarTypeDefinitionNode{
+		Node:        language.Node{Loc: loc(lexer, start)},
+		Description: description,
+		Name:        name,
+		Directives:  &directives,
+	}, nil
+}
+
+/**
+ * ObjectTypeDefinition :
+ *   - Description? type Name ImplementsInterfaces? Directives? { FieldDefinition+ }
+ */
+func parseObjectTypeDefinition(lexer *Lexer) (*language.ObjectTypeDefinitionNode, error) {
+	start := lexer.Token
+
+	description, err := parseDescription(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := expectKeyword(lexer, "type"); err != nil {
+		return nil, err
+	}
+
+	name, err := parseName(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	interfaces, err := parseImplementsInterfaces(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	directives, err := parseDirectives(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := parseFieldDefinitions(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &language.ObjectTypeDefinitionNode{
+		Node:        language.Node{Loc: loc(lexer, start)},
+		Description: description,
+		Name:        name,
+		Interfaces:  &interfaces,
+		Directives:  &directives,
+		Fields:      fields,
+	}, nil
+}
+
+/**
+ * ImplementsInterfaces : implements NamedType+
+ */
+func parseImplementsInterfaces(lexer *Lexer) ([]language.NamedTypeNode, error) {
+	types := []language.NamedTypeNode{}
+	if lexer.Token.Value != "implements" {
+		return types, nil
+	}
+
+	if err := advance(lexer); err != nil {
+		return nil, err
+	}
+
+	for {
+		t, err := parseNamedType(lexer)
+		if err != nil {
+			return nil, err
+		}
+		types = append(types, *t)
+
+		if !peek(lexer, language.TokenName) {
+			return types, nil
+		}
+	}
+}
+
+/**
+ * FieldsDefinition : { FieldDefinition+ }
+ */
+func parseFieldDefinitions(lexer *Lexer) ([]language.FieldDefinitionNode, error) {
+	nodes, err := any(lexer, language.TokenBraceLeft, func(lexer *Lexer) (language.ASTNode, error) {
+		return parseFieldDefinition(lexer)
+	}, language.TokenBraceRight)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make([]language.FieldDefinitionNode, len(nodes))
+	for idx, node := range nodes {
+		fields[idx] = *node.(*language.FieldDefinitionNode)
+	}
+
+	return fields, nil
+}
+
+/**
+ * FieldDefinition : Description? Name ArgumentsDefinition? : Type Directives?
+ */
+func parseFieldDefinition(lexer *Lexer) (*language.FieldDefinitionNode, error) {
+	start := lexer.Token
+
+	description, err := parseDescription(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := parseName(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	args, err := parseArgumentDefs(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := expect(lexer, language.TokenColon); err != nil {
+		return nil, err
+	}
+
+	t, err := parseTypeReference(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	directives, err := parseDirectives(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &language.FieldDefinitionNode{
+		Node:        language.Node{Loc: loc(lexer, start)},
+		Description: description,
+		Name:        name,
+		Arguments:   args,
+		Type:        t,
+		Directives:  &directives,
+	}, nil
+}
+
+/**
+ * ArgumentsDefinition : ( InputValueDefinition+ )
+ */
+func parseArgumentDefs(lexer *Lexer) ([]language.InputValueDefinitionNode, error) {
+	args := []language.InputValueDefinitionNode{}
+	if !peek(lexer, language.TokenParenLeft) {
+		return args, nil
+	}
+
+	nodes, err := many(lexer, language.TokenParenLeft, func(lexer *Lexer) (language.ASTNode, error) {
+		return parseInputValueDef(lexer)
+	}, language.TokenParenRight)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, node := range nodes {
+		args = append(args, *node.(*language.InputValueDefinitionNode))
+	}
+
+	return args, nil
+}
 
-	"github.com/ijsnow/goql/internal/errors"
-	"github.com/ijsnow/goql/internal/language"
-)
+/**
+ * InputValueDefinition : Description? Name : Type DefaultValue? Directives?
+ */
+func parseInputValueDef(lexer *Lexer) (*language.InputValueDefinitionNode, error) {
+	start := lexer.Token
 
-type parser func(*Lexer) (language.ASTNode, error)
+	description, err := parseDescription(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := parseName(lexer)
+	if err != nil {
+		return nil, err
+	}
 
-// Parse , Given a GraphQL source, parses it into a Document.
-// Returns a GraphQLError if a syntax error is encountered.
-// func Parse(src language.Source, options ...ParseOptions) (language.DocumentNode, error) {
-// 	lexer := CreateLexer(src, options...)
+	if _, err := expect(lexer, language.TokenColon); err != nil {
+		return nil, err
+	}
+
+	t, err := parseTypeReference(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	defaultValue, err := parseDefaultValue(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	directives, err := parseDirectives(lexer)
+	if err != nil {
+		return nil, err
+	}
 
-// 	return parseDocument(lexer)
-// }
+	return &language.InputValueDefinitionNode{
+		Node:         language.Node{Loc: loc(lexer, start)},
+		Description:  description,
+		Name:         name,
+		Type:         t,
+		DefaultValue: defaultValue,
+		Directives:   &directives,
+	}, nil
+}
 
 /**
- * Given a string containing a GraphQL value (ex. `[42]`), parse the AST for
- * that value.
- * Throws GraphQLError if a syntax error is encountered.
- *
- * This is useful within tools that operate upon GraphQL Values directly and
- * in isolation of complete GraphQL documents.
- *
- * Consider providing the results to the utility function: valueFromAST().
+ * InterfaceTypeDefinition :
+ *   - Description? interface Name Directives? { FieldDefinition+ }
  */
-// func ParseValue(
-// 	source language.Source,
-// 	options ...ParseOptions,
-// ) (*language.ASTNode, error) {
-// 	lexer := CreateLexer(source, options...)
-
-// 	_, err := expect(lexer, language.TokenSOF)
-// 	if err != nil {
-// 		return nil, err
-// 	}
-
-// 	value, err := parseValueLiteral(lexer, false)
-
-// 	_, err = expect(lexer, language.TokenEOF)
-// 	if err != nil {
-// 		return nil, err
-// 	}
-
-// 	return value, nil
-// }
-
-// /**
-//  * Given a string containing a GraphQL Type (ex. `[Int!]`), parse the AST for
-//  * that type.
-//  * Throws GraphQLError if a syntax error is encountered.
-//  *
-//  * This is useful within tools that operate upon GraphQL Types directly and
-//  * in isolation of complete GraphQL documents.
-//  *
-//  * Consider providing the results to the utility function: typeFromAST().
-//  */
-// export function parseType(
-//   source: string | Source,
-//   options?: ParseOptions
-// ): TypeNode {
-//   const sourceObj = typeof source === 'string' ? new Source(source) : source;
-//   const lexer = createLexer(sourceObj, options || {});
-//   expect(lexer, TokenKind.SOF);
-//   const type = parseTypeReference(lexer);
-//   expect(lexer, TokenKind.EOF);
-//   return type;
-// }
-
-// /**
-//  * Converts a name lex token into a name parse node.
-//  */
-// function parseName(lexer: Lexer<*>): NameNode {
-//   const token = expect(lexer, TokenKind.NAME);
-//   return {
-//     kind: NAME,
-//     value: ((token.value: any): string),
-//     loc: loc(lexer, token)
-//   };
-// }
+func parseInterfaceTypeDefinition(lexer *Lexer) (*language.InterfaceTypeDefinitionNode, error) {
+	start := lexer.Token
 
-// Implements the parsing rules in the Document section.
+	description, err := parseDescription(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := expectKeyword(lexer, "interface"); err != nil {
+		return nil, err
+	}
+
+	name, err := parseName(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	directives, err := parseDirectives(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := parseFieldDefinitions(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &language.InterfaceTypeDefinitionNode{
+		Node:        language.Node{Loc: loc(lexer, start)},
+		Description: description,
+		Name:        name,
+		Directives:  &directives,
+		Fields:      fields,
+	}, nil
+}
 
 /**
- * Document : Definition+
+ * UnionTypeDefinition : Description? union Name Directives? = UnionMembers
  */
-// func parseDocument(lexer Lexer) (language.DocumentNode, error) {
-// 	start := lexer.Token
-// 	_, err := expect(lexer, language.TokenSOF)
-// 	if err != nil {
-// 		return nil, err
-// 	}
-
-// 	  definitions := []language.DefinitionNode
-
-// 	  for {
-// 		definitions = append(parseDefinition(lexer))
-
-// 		if skip(lexer, TokenKind.EOF) {
-// 			break
-// 		}
-// 	  }
-
-// 	  return language.DocumentNode{
-// 	    Definitions: definitions,
-// 	    Loc: loc(lexer, start),
-// 	  }
-// }
-
-// /**
-//  * Definition :
-//  *   - OperationDefinition
-//  *   - FragmentDefinition
-//  *   - TypeSystemDefinition
-//  */
-// function parseDefinition(lexer: Lexer<*>): DefinitionNode {
-//   if (peek(lexer, TokenKind.BRACE_L)) {
-//     return parseOperationDefinition(lexer);
-//   }
-
-//   if (peek(lexer, TokenKind.NAME)) {
-//     switch (lexer.token.value) {
-//       // Note: subscription is an experimental non-spec addition.
-//       case 'query':
-//       case 'mutation':
-//       case 'subscription':
-//         return parseOperationDefinition(lexer);
-
-//       case 'fragment': return parseFragmentDefinition(lexer);
-
-//       // Note: the Type System IDL is an experimental non-spec addition.
-//       case 'schema':
-//       case 'scalar':
-//       case 'type':
-//       case 'interface':
-//       case 'union':
-//       case 'enum':
-//       case 'input':
-//       case 'extend':
-//       case 'directive': return parseTypeSystemDefinition(lexer);
-//     }
-//   }
-
-//   throw unexpected(lexer);
-// }
-
-// // Implements the parsing rules in the Operations section.
-
-// /**
-//  * OperationDefinition :
-//  *  - SelectionSet
-//  *  - OperationType Name? VariableDefinitions? Directives? SelectionSet
-//  */
-// function parseOperationDefinition(lexer: Lexer<*>): OperationDefinitionNode {
-//   const start = lexer.token;
-//   if (peek(lexer, TokenKind.BRACE_L)) {
-//     return {
-//       kind: OPERATION_DEFINITION,
-//       operation: 'query',
-//       name: null,
-//       variableDefinitions: null,
-//       directives: [],
-//       selectionSet: parseSelectionSet(lexer),
-//       loc: loc(lexer, start)
-//     };
-//   }
-//   const operation = parseOperationType(lexer);
-//   let name;
-//   if (peek(lexer, TokenKind.NAME)) {
-//     name = parseName(lexer);
-//   }
-//   return {
-//     kind: OPERATION_DEFINITION,
-//     operation,
-//     name,
-//     variableDefinitions: parseVariableDefinitions(lexer),
-//     directives: parseDirectives(lexer),
-//     selectionSet: parseSelectionSet(lexer),
-//     loc: loc(lexer, start)
-//   };
-// }
-
-// /**
-//  * OperationType : one of query mutation subscription
-//  */
-// function parseOperationType(lexer: Lexer<*>): OperationTypeNode {
-//   const operationToken = expect(lexer, TokenKind.NAME);
-//   switch (operationToken.value) {
-//     case 'query': return 'query';
-//     case 'mutation': return 'mutation';
-//     // Note: subscription is an experimental non-spec addition.
-//     case 'subscription': return 'subscription';
-//   }
-
-//   throw unexpected(lexer, operationToken);
-// }
-
-// /**
-//  * VariableDefinitions : ( VariableDefinition+ )
-//  */
-// function parseVariableDefinitions(
-//   lexer: Lexer<*>
-// ): Array<VariableDefinitionNode> {
-//   return peek(lexer, TokenKind.PAREN_L) ?
-//     many(
-//       lexer,
-//       TokenKind.PAREN_L,
-//       parseVariableDefinition,
-//       TokenKind.PAREN_R
-//     ) :
-//     [];
-// }
-
-// /**
-//  * VariableDefinition : Variable : Type DefaultValue?
-//  */
-// function parseVariableDefinition(lexer: Lexer<*>): VariableDefinitionNode {
-//   const start = lexer.token;
-//   return {
-//     kind: VARIABLE_DEFINITION,
-//     variable: parseVariable(lexer),
-//     type: (expect(lexer, TokenKind.COLON), parseTypeReference(lexer)),
-//     defaultValue:
-//       skip(lexer, TokenKind.EQUALS) ? parseValueLiteral(lexer, true) : null,
-//     loc: loc(lexer, start)
-//   };
-// }
-
-// /**
-//  * Variable : $ Name
-//  */
-// function parseVariable(lexer: Lexer<*>): VariableNode {
-//   const start = lexer.token;
-//   expect(lexer, TokenKind.DOLLAR);
-//   return {
-//     kind: VARIABLE,
-//     name: parseName(lexer),
-//     loc: loc(lexer, start)
-//   };
-// }
-
-// /**
-//  * SelectionSet : { Selection+ }
-//  */
-// function parseSelectionSet(lexer: Lexer<*>): SelectionSetNode {
-//   const start = lexer.token;
-//   return {
-//     kind: SELECTION_SET,
-//     selections:
-//       many(lexer, TokenKind.BRACE_L, parseSelection, TokenKind.BRACE_R),
-//     loc: loc(lexer, start)
-//   };
-// }
-
-// /**
-//  * Selection :
-//  *   - Field
-//  *   - FragmentSpread
-//  *   - InlineFragment
-//  */
-// function parseSelection(lexer: Lexer<*>): SelectionNode {
-//   return peek(lexer, TokenKind.SPREAD) ?
-//     parseFragment(lexer) :
-//     parseField(lexer);
-// }
-
-// /**
-//  * Field : Alias? Name Arguments? Directives? SelectionSet?
-//  *
-//  * Alias : Name :
-//  */
-// function parseField(lexer: Lexer<*>): FieldNode {
-//   const start = lexer.token;
-
-//   const nameOrAlias = parseName(lexer);
-//   let alias;
-//   let name;
-//   if (skip(lexer, TokenKind.COLON)) {
-//     alias = nameOrAlias;
-//     name = parseName(lexer);
-//   } else {
-//     alias = null;
-//     name = nameOrAlias;
-//   }
-
-//   return {
-//     kind: FIELD,
-//     alias,
-//     name,
-//     arguments: parseArguments(lexer),
-//     directives: parseDirectives(lexer),
-//     selectionSet:
-//       peek(lexer, TokenKind.BRACE_L) ? parseSelectionSet(lexer) : null,
-//     loc: loc(lexer, start)
-//   };
-// }
-
-// /**
-//  * Arguments : ( Argument+ )
-//  */
-// function parseArguments(lexer: Lexer<*>): Array<ArgumentNode> {
-//   return peek(lexer, TokenKind.PAREN_L) ?
-//     many(lexer, TokenKind.PAREN_L, parseArgument, TokenKind.PAREN_R) :
-//     [];
-// }
-
-// /**
-//  * Argument : Name : Value
-//  */
-// function parseArgument(lexer: Lexer<*>): ArgumentNode {
-//   const start = lexer.token;
-//   return {
-//     kind: ARGUMENT,
-//     name: parseName(lexer),
-//     value: (expect(lexer, TokenKind.COLON), parseValueLiteral(lexer, false)),
-//     loc: loc(lexer, start)
-//   };
-// }
-
-// // Implements the parsing rules in the Fragments section.
-
-// /**
-//  * Corresponds to both FragmentSpread and InlineFragment in the spec.
-//  *
-//  * FragmentSpread : ... FragmentName Directives?
-//  *
-//  * InlineFragment : ... TypeCondition? Directives? SelectionSet
-//  */
-// function parseFragment(
-//   lexer: Lexer<*>
-// ): FragmentSpreadNode | InlineFragmentNode {
-//   const start = lexer.token;
-//   expect(lexer, TokenKind.SPREAD);
-//   if (peek(lexer, TokenKind.NAME) && lexer.token.value !== 'on') {
-//     return {
-//       kind: FRAGMENT_SPREAD,
-//       name: parseFragmentName(lexer),
-//       directives: parseDirectives(lexer),
-//       loc: loc(lexer, start)
-//     };
-//   }
-//   let typeCondition = null;
-//   if (lexer.token.value === 'on') {
-//     lexer.advance();
-//     typeCondition = parseNamedType(lexer);
-//   }
-//   return {
-//     kind: INLINE_FRAGMENT,
-//     typeCondition,
-//     directives: parseDirectives(lexer),
-//     selectionSet: parseSelectionSet(lexer),
-//     loc: loc(lexer, start)
-//   };
-// }
-
-// /**
-//  * FragmentDefinition :
-//  *   - fragment FragmentName on TypeCondition Directives? SelectionSet
-//  *
-//  * TypeCondition : NamedType
-//  */
-// function parseFragmentDefinition(lexer: Lexer<*>): FragmentDefinitionNode {
-//   const start = lexer.token;
-//   expectKeyword(lexer, 'fragment');
-//   return {
-//     kind: FRAGMENT_DEFINITION,
-//     name: parseFragmentName(lexer),
-//     typeCondition: (expectKeyword(lexer, 'on'), parseNamedType(lexer)),
-//     directives: parseDirectives(lexer),
-//     selectionSet: parseSelectionSet(lexer),
-//     loc: loc(lexer, start)
-//   };
-// }
-
-// /**
-//  * FragmentName : Name but not `on`
-//  */
-// function parseFragmentName(lexer: Lexer<*>): NameNode {
-//   if (lexer.token.value === 'on') {
-//     throw unexpected(lexer);
-//   }
-//   return parseName(lexer);
-// }
-
-// // Implements the parsing rules in the Values section.
+func parseUnionTypeDefinition(lexer *Lexer) (*language.UnionTypeDefinitionNode, error) {
+	start := lexer.Token
+
+	description, err := parseDescription(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := expectKeyword(lexer, "union"); err != nil {
+		return nil, err
+	}
+
+	name, err := parseName(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	directives, err := parseDirectives(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := expect(lexer, language.TokenEqual); err != nil {
+		return nil, err
+	}
+
+	types, err := parseUnionMembers(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &language.UnionTypeDefinitionNode{
+		Node:        language.Node{Loc: loc(lexer, start)},
+		Description: description,
+		Name:        name,
+		Directives:  &directives,
+		Types:       types,
+	}, nil
+}
 
 /**
- * Value[Const] :
- *   - [~Const] Variable
- *   - IntValue
- *   - FloatValue
- *   - StringValue
- *   - BooleanValue
- *   - NullValue
- *   - EnumValue
- *   - ListValue[?Const]
- *   - ObjectValue[?Const]
- *
- * BooleanValue : one of `true` `false`
- *
- * NullValue : `null`
- *
- * EnumValue : Name but not `true`, `false` or `null`
+ * UnionMembers :
+ *   - `|`? NamedType
+ *   - UnionMembers | NamedType
  */
-func parseValueLiteral(lexer *Lexer, isConst bool) (language.ASTNode, error) {
-	token := lexer.Token
+func parseUnionMembers(lexer *Lexer) ([]language.NamedTypeNode, error) {
+	// Optional leading pipe
+	if _, err := skip(lexer, language.TokenPipe); err != nil {
+		return nil, err
+	}
 
-	// switch token.Kind {
-	// case language.TokenBracketLeft:
-	// 	return parseList(lexer, isConst)
-	// case language.TokenBraceLeft:
-	// 	return parseObject(lexer, isConst)
-	// case language.TokenInt:
-	// 	lexer.Advance()
-
-	// 	return language.IntValueNode{
-	// 		Value: token.Value,
-	// 		Loc:   loc(lexer, token),
-	// 	}
-	// case language.TokenFloat:
-	// 	lexer.Advance()
-	// 	return language.FloatValueNode{
-	// 		Value: token.Value,
-	// 		loc:   loc(lexer, token),
-	// 	}
-	//     case TokenKind.STRING:
-	//       lexer.advance();
-	//       return {
-	//         kind: (STRING: 'StringValue'),
-	//         value: ((token.value: any): string),
-	//         loc: loc(lexer, token)
-	//       };
-	//     case TokenKind.NAME:
-	//       if (token.value === 'true' || token.value === 'false') {
-	//         lexer.advance();
-	//         return {
-	//           kind: (BOOLEAN: 'BooleanValue'),
-	//           value: token.value === 'true',
-	//           loc: loc(lexer, token)
-	//         };
-	//       } else if (token.value === 'null') {
-	//         lexer.advance();
-	//         return {
-	//           kind: (NULL: 'NullValue'),
-	//           loc: loc(lexer, token)
-	//         };
-	//       }
-	//       lexer.advance();
-	//       return {
-	//         kind: (ENUM: 'EnumValue'),
-	//         value: ((token.value: any): string),
-	//         loc: loc(lexer, token)
-	//       };
-	//     case TokenKind.DOLLAR:
-	//       if (!isConst) {
-	//         return parseVariable(lexer);
-	//       }
-	//       break;
-	//}
+	var members []language.NamedTypeNode
+	for {
+		member, err := parseNamedType(lexer)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, *member)
 
-	return nil, unexpected(lexer, token)
+		more, err := skip(lexer, language.TokenPipe)
+		if err != nil {
+			return nil, err
+		}
+		if !more {
+			return members, nil
+		}
+	}
 }
 
-func parseConstValue(lexer *Lexer) (language.ASTNode, error) {
-	return parseValueLiteral(lexer, true)
-}
+/**
+ * EnumTypeDefinition : Description? enum Name Directives? { EnumValueDefinition+ }
+ */
+func parseEnumTypeDefinition(lexer *Lexer) (*language.EnumTypeDefinitionNode, error) {
+	start := lexer.Token
 
-func parseValueValue(lexer *Lexer) (language.ASTNode, error) {
-	return parseValueLiteral(lexer, false)
+	description, err := parseDescription(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := expectKeyword(lexer, "enum"); err != nil {
+		return nil, err
+	}
+
+	name, err := parseName(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	directives, err := parseDirectives(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes, err := many(lexer, language.TokenBraceLeft, func(lexer *Lexer) (language.ASTNode, error) {
+		return parseEnumValueDefinition(lexer)
+	}, language.TokenBraceRight)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]language.EnumValueDefinitionNode, len(nodes))
+	for idx, node := range nodes {
+		values[idx] = *node.(*language.EnumValueDefinitionNode)
+	}
+
+	return &language.EnumTypeDefinitionNode{
+		Node:        language.Node{Loc: loc(lexer, start)},
+		Description: description,
+		Name:        name,
+		Directives:  &directives,
+		Values:      values,
+	}, nil
 }
 
 /**
- * ListValue[Const] :
- *   - [ ]
- *   - [ Value[?Const]+ ]
+ * EnumValueDefinition : Description? EnumValue Directives?
+ *
+ * EnumValue : Name
  */
-func parseList(lexer *Lexer, isConst bool) (language.ASTNode, error) {
+func parseEnumValueDefinition(lexer *Lexer) (*language.EnumValueDefinitionNode, error) {
 	start := lexer.Token
-	var item parser
-	if isConst {
-		item = parseConstValue
-	} else {
-		item = parseValueValue
+
+	description, err := parseDescription(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := parseName(lexer)
+	if err != nil {
+		return nil, err
 	}
 
-	vals, err := any(lexer, language.TokenBracketLeft, item, language.TokenBracketRight)
+	directives, err := parseDirectives(lexer)
 	if err != nil {
 		return nil, err
 	}
 
-	return language.ListValueNode{language.Node{Loc: loc(lexer, start)}, vals}, nil
+	return &language.EnumValueDefinitionNode{
+		Node:        language.Node{Loc: loc(lexer, start)},
+		Description: description,
+		Name:        name,
+		Directives:  &directives,
+	}, nil
 }
 
 /**
- * ObjectValue[Const] :
- *   - { }
- *   - { ObjectField[?Const]+ }
+ * InputObjectTypeDefinition :
+ *   - Description? input Name Directives? { InputValueDefinition+ }
  */
-func parseObject(lexer *Lexer, isConst bool) (*language.ASTNode, error) {
+func parseInputObjectTypeDefinition(lexer *Lexer) (*language.InputObjectTypeDefinitionNode, error) {
 	start := lexer.Token
 
-	_, err := expect(lexer, language.TokenBraceLeft)
+	description, err := parseDescription(lexer)
 	if err != nil {
 		return nil, err
 	}
 
-	fields := make([]language.ObjectFieldNode, 0)
+	if _, err := expectKeyword(lexer, "input"); err != nil {
+		return nil, err
+	}
 
-	for !skip(lexer, language.TokenBraceRight) {
-		f, err := parseObjectField(lexer, isConst)
-		if err != nil {
-			return nil, err
-		}
+	name, err := parseName(lexer)
+	if err != nil {
+		return nil, err
+	}
 
-		fields := append(fields, f)
+	directives, err := parseDirectives(lexer)
+	if err != nil {
+		return nil, err
 	}
 
-	return &language.ObjectValueNode{
-		language.Node{Loc: loc(lexer, start)},
-		fields,
+	nodes, err := any(lexer, language.TokenBraceLeft, func(lexer *Lexer) (language.ASTNode, error) {
+		return parseInputValueDef(lexer)
+	}, language.TokenBraceRight)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make([]language.InputValueDefinitionNode, len(nodes))
+	for idx, node := range nodes {
+		fields[idx] = *node.(*language.InputValueDefinitionNode)
+	}
+
+	return &language.InputObjectTypeDefinitionNode{
+		Node:        language.Node{Loc: loc(lexer, start)},
+		Description: description,
+		Name:        name,
+		Directives:  &directives,
+		Fields:      fields,
 	}, nil
 }
 
 /**
- * ObjectField[Const] : Name : Value[?Const]
+ * TypeExtensionDefinition : extend ObjectTypeDefinition
+ */
+func parseTypeExtensionDefinition(lexer *Lexer) (*language.TypeExtensionDefinitionNode, error) {
+	start := lexer.Token
+
+	if _, err := expectKeyword(lexer, "extend"); err != nil {
+		return nil, err
+	}
+
+	definition, err := parseObjectTypeDefinition(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &language.TypeExtensionDefinitionNode{
+		Node:       language.Node{Loc: loc(lexer, start)},
+		Definition: *definition,
+	}, nil
+}
+
+/**
+ * DirectiveDefinition :
+ *   - Description? directive @ Name ArgumentsDefinition? `repeatable`? on DirectiveLocations
  */
-func parseObjectField(lexer *Lexer, isConst bool) (*language.ASTNode, error) {
+func parseDirectiveDefinition(lexer *Lexer) (*language.DirectiveDefinitionNode, error) {
 	start := lexer.Token
 
-	_, err := expect(lexer, language.TokenColon)
+	description, err := parseDescription(lexer)
 	if err != nil {
 		return nil, err
 	}
 
+	if _, err := expectKeyword(lexer, "directive"); err != nil {
+		return nil, err
+	}
+
+	if _, err := expect(lexer, language.TokenAt); err != nil {
+		return nil, err
+	}
+
 	name, err := parseName(lexer)
 	if err != nil {
 		return nil, err
 	}
 
-	val, err := parseValueLiteral(lexer, isConst)
+	args, err := parseArgumentDefs(lexer)
+	if err != nil {
+		return nil, err
+	}
+
+	repeatable := peek(lexer, language.TokenName) && lexer.Token.Value == "repeatable"
+	if repeatable {
+		if err := advance(lexer); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := expectKeyword(lexer, "on"); err != nil {
+		return nil, err
+	}
+
+	locations, err := parseDirectiveLocations(lexer)
 	if err != nil {
 		return nil, err
 	}
 
-	return &language.ObjectFieldNode{
-		language.Node{Loc: loc(lexer, start)},
-		name,
-		val,
+	return &language.DirectiveDefinitionNode{
+		Node:        language.Node{Loc: loc(lexer, start)},
+		Description: description,
+		Name:        name,
+		Arguments:   &args,
+		Repeatable:  repeatable,
+		Locations:   locations,
 	}, nil
 }
 
-// // Implements the parsing rules in the Directives section.
-
-// /**
-//  * Directives : Directive+
-//  */
-// function parseDirectives(lexer: Lexer<*>): Array<DirectiveNode> {
-//   const directives = [];
-//   while (peek(lexer, TokenKind.AT)) {
-//     directives.push(parseDirective(lexer));
-//   }
-//   return directives;
-// }
-
-// /**
-//  * Directive : @ Name Arguments?
-//  */
-// function parseDirective(lexer: Lexer<*>): DirectiveNode {
-//   const start = lexer.token;
-//   expect(lexer, TokenKind.AT);
-//   return {
-//     kind: DIRECTIVE,
-//     name: parseName(lexer),
-//     arguments: parseArguments(lexer),
-//     loc: loc(lexer, start)
-//   };
-// }
-
-// // Implements the parsing rules in the Types section.
-
-// /**
-//  * Type :
-//  *   - NamedType
-//  *   - ListType
-//  *   - NonNullType
-//  */
-// export function parseTypeReference(lexer: Lexer<*>): TypeNode {
-//   const start = lexer.token;
-//   let type;
-//   if (skip(lexer, TokenKind.BRACKET_L)) {
-//     type = parseTypeReference(lexer);
-//     expect(lexer, TokenKind.BRACKET_R);
-//     type = ({
-//       kind: LIST_TYPE,
-//       type,
-//       loc: loc(lexer, start)
-//     }: ListTypeNode);
-//   } else {
-//     type = parseNamedType(lexer);
-//   }
-//   if (skip(lexer, TokenKind.BANG)) {
-//     return ({
-//       kind: NON_NULL_TYPE,
-//       type,
-//       loc: loc(lexer, start)
-//     }: NonNullTypeNode);
-//   }
-//   return type;
-// }
-
-// /**
-//  * NamedType : Name
-//  */
-// export function parseNamedType(lexer: Lexer<*>): NamedTypeNode {
-//   const start = lexer.token;
-//   return {
-//     kind: NAMED_TYPE,
-//     name: parseName(lexer),
-//     loc: loc(lexer, start)
-//   };
-// }
-
-// // Implements the parsing rules in the Type Definition section.
-
-// /**
-//  * TypeSystemDefinition :
-//  *   - SchemaDefinition
-//  *   - TypeDefinition
-//  *   - TypeExtensionDefinition
-//  *   - DirectiveDefinition
-//  *
-//  * TypeDefinition :
-//  *   - ScalarTypeDefinition
-//  *   - ObjectTypeDefinition
-//  *   - InterfaceTypeDefinition
-//  *   - UnionTypeDefinition
-//  *   - EnumTypeDefinition
-//  *   - InputObjectTypeDefinition
-//  */
-// function parseTypeSystemDefinition(lexer: Lexer<*>): TypeSystemDefinitionNode {
-//   if (peek(lexer, TokenKind.NAME)) {
-//     switch (lexer.token.value) {
-//       case 'schema': return parseSchemaDefinition(lexer);
-//       case 'scalar': return parseScalarTypeDefinition(lexer);
-//       case 'type': return parseObjectTypeDefinition(lexer);
-//       case 'interface': return parseInterfaceTypeDefinition(lexer);
-//       case 'union': return parseUnionTypeDefinition(lexer);
-//       case 'enum': return parseEnumTypeDefinition(lexer);
-//       case 'input': return parseInputObjectTypeDefinition(lexer);
-//       case 'extend': return parseTypeExtensionDefinition(lexer);
-//       case 'directive': return parseDirectiveDefinition(lexer);
-//     }
-//   }
-
-//   throw unexpected(lexer);
-// }
-
-// /**
-//  * SchemaDefinition : schema Directives? { OperationTypeDefinition+ }
-//  *
-//  * OperationTypeDefinition : OperationType : NamedType
-//  */
-// function parseSchemaDefinition(lexer: Lexer<*>): SchemaDefinitionNode {
-//   const start = lexer.token;
-//   expectKeyword(lexer, 'schema');
-//   const directives = parseDirectives(lexer);
-//   const operationTypes = many(
-//     lexer,
-//     TokenKind.BRACE_L,
-//     parseOperationTypeDefinition,
-//     TokenKind.BRACE_R
-//   );
-//   return {
-//     kind: SCHEMA_DEFINITION,
-//     directives,
-//     operationTypes,
-//     loc: loc(lexer, start),
-//   };
-// }
-
-// function parseOperationTypeDefinition(
-//   lexer: Lexer<*>
-// ): OperationTypeDefinitionNode {
-//   const start = lexer.token;
-//   const operation = parseOperationType(lexer);
-//   expect(lexer, TokenKind.COLON);
-//   const type = parseNamedType(lexer);
-//   return {
-//     kind: OPERATION_TYPE_DEFINITION,
-//     operation,
-//     type,
-//     loc: loc(lexer, start),
-//   };
-// }
-
-// /**
-//  * ScalarTypeDefinition : scalar Name Directives?
-//  */
-// function parseScalarTypeDefinition(lexer: Lexer<*>): ScalarTypeDefinitionNode {
-//   const start = lexer.token;
-//   expectKeyword(lexer, 'scalar');
-//   const name = parseName(lexer);
-//   const directives = parseDirectives(lexer);
-//   return {
-//     kind: SCALAR_TYPE_DEFINITION,
-//     name,
-//     directives,
-//     loc: loc(lexer, start),
-//   };
-// }
-
-// /**
-//  * ObjectTypeDefinition :
-//  *   - type Name ImplementsInterfaces? Directives? { FieldDefinition+ }
-//  */
-// function parseObjectTypeDefinition(lexer: Lexer<*>): ObjectTypeDefinitionNode {
-//   const start = lexer.token;
-//   expectKeyword(lexer, 'type');
-//   const name = parseName(lexer);
-//   const interfaces = parseImplementsInterfaces(lexer);
-//   const directives = parseDirectives(lexer);
-//   const fields = any(
-//     lexer,
-//     TokenKind.BRACE_L,
-//     parseFieldDefinition,
-//     TokenKind.BRACE_R
-//   );
-//   return {
-//     kind: OBJECT_TYPE_DEFINITION,
-//     name,
-//     interfaces,
-//     directives,
-//     fields,
-//     loc: loc(lexer, start),
-//   };
-// }
-
-// /**
-//  * ImplementsInterfaces : implements NamedType+
-//  */
-// function parseImplementsInterfaces(lexer: Lexer<*>): Array<NamedTypeNode> {
-//   const types = [];
-//   if (lexer.token.value === 'implements') {
-//     lexer.advance();
-//     do {
-//       types.push(parseNamedType(lexer));
-//     } while (peek(lexer, TokenKind.NAME));
-//   }
-//   return types;
-// }
-
-// /**
-//  * FieldDefinition : Name ArgumentsDefinition? : Type Directives?
-//  */
-// function parseFieldDefinition(lexer: Lexer<*>): FieldDefinitionNode {
-//   const start = lexer.token;
-//   const name = parseName(lexer);
-//   const args = parseArgumentDefs(lexer);
-//   expect(lexer, TokenKind.COLON);
-//   const type = parseTypeReference(lexer);
-//   const directives = parseDirectives(lexer);
-//   return {
-//     kind: FIELD_DEFINITION,
-//     name,
-//     arguments: args,
-//     type,
-//     directives,
-//     loc: loc(lexer, start),
-//   };
-// }
-
-// /**
-//  * ArgumentsDefinition : ( InputValueDefinition+ )
-//  */
-// function parseArgumentDefs(lexer: Lexer<*>): Array<InputValueDefinitionNode> {
-//   if (!peek(lexer, TokenKind.PAREN_L)) {
-//     return [];
-//   }
-//   return many(lexer, TokenKind.PAREN_L, parseInputValueDef, TokenKind.PAREN_R);
-// }
-
-// /**
-//  * InputValueDefinition : Name : Type DefaultValue? Directives?
-//  */
-// function parseInputValueDef(lexer: Lexer<*>): InputValueDefinitionNode {
-//   const start = lexer.token;
-//   const name = parseName(lexer);
-//   expect(lexer, TokenKind.COLON);
-//   const type = parseTypeReference(lexer);
-//   let defaultValue = null;
-//   if (skip(lexer, TokenKind.EQUALS)) {
-//     defaultValue = parseConstValue(lexer);
-//   }
-//   const directives = parseDirectives(lexer);
-//   return {
-//     kind: INPUT_VALUE_DEFINITION,
-//     name,
-//     type,
-//     defaultValue,
-//     directives,
-//     loc: loc(lexer, start),
-//   };
-// }
-
-// /**
-//  * InterfaceTypeDefinition : interface Name Directives? { FieldDefinition+ }
-//  */
-// function parseInterfaceTypeDefinition(
-//   lexer: Lexer<*>
-// ): InterfaceTypeDefinitionNode {
-//   const start = lexer.token;
-//   expectKeyword(lexer, 'interface');
-//   const name = parseName(lexer);
-//   const directives = parseDirectives(lexer);
-//   const fields = any(
-//     lexer,
-//     TokenKind.BRACE_L,
-//     parseFieldDefinition,
-//     TokenKind.BRACE_R
-//   );
-//   return {
-//     kind: INTERFACE_TYPE_DEFINITION,
-//     name,
-//     directives,
-//     fields,
-//     loc: loc(lexer, start),
-//   };
-// }
-
-// /**
-//  * UnionTypeDefinition : union Name Directives? = UnionMembers
-//  */
-// function parseUnionTypeDefinition(lexer: Lexer<*>): UnionTypeDefinitionNode {
-//   const start = lexer.token;
-//   expectKeyword(lexer, 'union');
-//   const name = parseName(lexer);
-//   const directives = parseDirectives(lexer);
-//   expect(lexer, TokenKind.EQUALS);
-//   const types = parseUnionMembers(lexer);
-//   return {
-//     kind: UNION_TYPE_DEFINITION,
-//     name,
-//     directives,
-//     types,
-//     loc: loc(lexer, start),
-//   };
-// }
-
-// /**
-//  * UnionMembers :
-//  *   - `|`? NamedType
-//  *   - UnionMembers | NamedType
-//  */
-// function parseUnionMembers(lexer: Lexer<*>): Array<NamedTypeNode> {
-//   // Optional leading pipe
-//   skip(lexer, TokenKind.PIPE);
-//   const members = [];
-//   do {
-//     members.push(parseNamedType(lexer));
-//   } while (skip(lexer, TokenKind.PIPE));
-//   return members;
-// }
-
-// /**
-//  * EnumTypeDefinition : enum Name Directives? { EnumValueDefinition+ }
-//  */
-// function parseEnumTypeDefinition(lexer: Lexer<*>): EnumTypeDefinitionNode {
-//   const start = lexer.token;
-//   expectKeyword(lexer, 'enum');
-//   const name = parseName(lexer);
-//   const directives = parseDirectives(lexer);
-//   const values = many(
-//     lexer,
-//     TokenKind.BRACE_L,
-//     parseEnumValueDefinition,
-//     TokenKind.BRACE_R
-//   );
-//   return {
-//     kind: ENUM_TYPE_DEFINITION,
-//     name,
-//     directives,
-//     values,
-//     loc: loc(lexer, start),
-//   };
-// }
-
-// /**
-//  * EnumValueDefinition : EnumValue Directives?
-//  *
-//  * EnumValue : Name
-//  */
-// function parseEnumValueDefinition(lexer: Lexer<*>): EnumValueDefinitionNode {
-//   const start = lexer.token;
-//   const name = parseName(lexer);
-//   const directives = parseDirectives(lexer);
-//   return {
-//     kind: ENUM_VALUE_DEFINITION,
-//     name,
-//     directives,
-//     loc: loc(lexer, start),
-//   };
-// }
-
-// /**
-//  * InputObjectTypeDefinition : input Name Directives? { InputValueDefinition+ }
-//  */
-// function parseInputObjectTypeDefinition(
-//   lexer: Lexer<*>
-// ): InputObjectTypeDefinitionNode {
-//   const start = lexer.token;
-//   expectKeyword(lexer, 'input');
-//   const name = parseName(lexer);
-//   const directives = parseDirectives(lexer);
-//   const fields = any(
-//     lexer,
-//     TokenKind.BRACE_L,
-//     parseInputValueDef,
-//     TokenKind.BRACE_R
-//   );
-//   return {
-//     kind: INPUT_OBJECT_TYPE_DEFINITION,
-//     name,
-//     directives,
-//     fields,
-//     loc: loc(lexer, start),
-//   };
-// }
-
-// /**
-//  * TypeExtensionDefinition : extend ObjectTypeDefinition
-//  */
-// function parseTypeExtensionDefinition(
-//   lexer: Lexer<*>
-// ): TypeExtensionDefinitionNode {
-//   const start = lexer.token;
-//   expectKeyword(lexer, 'extend');
-//   const definition = parseObjectTypeDefinition(lexer);
-//   return {
-//     kind: TYPE_EXTENSION_DEFINITION,
-//     definition,
-//     loc: loc(lexer, start),
-//   };
-// }
-
-// /**
-//  * DirectiveDefinition :
-//  *   - directive @ Name ArgumentsDefinition? on DirectiveLocations
-//  */
-// function parseDirectiveDefinition(lexer: Lexer<*>): DirectiveDefinitionNode {
-//   const start = lexer.token;
-//   expectKeyword(lexer, 'directive');
-//   expect(lexer, TokenKind.AT);
-//   const name = parseName(lexer);
-//   const args = parseArgumentDefs(lexer);
-//   expectKeyword(lexer, 'on');
-//   const locations = parseDirectiveLocations(lexer);
-//   return {
-//     kind: DIRECTIVE_DEFINITION,
-//     name,
-//     arguments: args,
-//     locations,
-//     loc: loc(lexer, start)
-//   };
-// }
-
-// /**
-//  * DirectiveLocations :
-//  *   - `|`? Name
-//  *   - DirectiveLocations | Name
-//  */
-// function parseDirectiveLocations(lexer: Lexer<*>): Array<NameNode> {
-//   // Optional leading pipe
-//   skip(lexer, TokenKind.PIPE);
-//   const locations = [];
-//   do {
-//     locations.push(parseName(lexer));
-//   } while (skip(lexer, TokenKind.PIPE));
-//   return locations;
-// }
+/**
+ * DirectiveLocations :
+ *   - `|`? Name
+ *   - DirectiveLocations | Name
+ */
+func parseDirectiveLocations(lexer *Lexer) ([]language.NameNode, error) {
+	// Optional leading pipe
+	if _, err := skip(lexer, language.TokenPipe); err != nil {
+		return nil, err
+	}
+
+	var locations []language.NameNode
+	for {
+		location, err := parseName(lexer)
+		if err != nil {
+			return nil, err
+		}
+		locations = append(locations, location)
+
+		more, err := skip(lexer, language.TokenPipe)
+		if err != nil {
+			return nil, err
+		}
+		if !more {
+			return locations, nil
+		}
+	}
+}
 
 // Core parsing utility functions
 
@@ -1012,16 +1645,25 @@ func peek(lexer *Lexer, kind language.TokenKind) bool {
 	return lexer.Token.Kind == kind
 }
 
+/**
+ * Moves the lexer to the next token, returning the syntax error of a token
+ * that cannot be lexed.
+ */
+func advance(lexer *Lexer) error {
+	_, err := lexer.Advance()
+	return err
+}
+
 /**
  * If the next token is of the given kind, return true after advancing
  * the lexer. Otherwise, do not change the parser state and return false.
  */
-func skip(lexer *Lexer, kind language.TokenKind) bool {
-	match := lexer.Token.Kind == kind
-	if match {
-		lexer.Advance()
+func skip(lexer *Lexer, kind language.TokenKind) (bool, error) {
+	if lexer.Token.Kind != kind {
+		return false, nil
 	}
-	return match
+
+	return true, advance(lexer)
 }
 
 /**
@@ -1031,14 +1673,16 @@ func skip(lexer *Lexer, kind language.TokenKind) bool {
 func expect(lexer *Lexer, kind language.TokenKind) (*language.Token, error) {
 	token := lexer.Token
 	if token.Kind == kind {
-		lexer.Advance()
-		return token, nil
+		return token, advance(lexer)
 	}
 
 	return nil, errors.NewSyntaxError(
 		lexer.Source,
 		token.Start,
-		fmt.Sprintf("Expected %s, found %s", string(kind), getTokenDesc(*token)),
+		errors.Message{ID: errors.MsgExpectedToken, Args: map[string]interface{}{
+			"expected": string(kind),
+			"found":    getTokenDesc(*token),
+		}},
 	)
 }
 
@@ -1051,14 +1695,16 @@ func expectKeyword(lexer *Lexer, value string) (*language.Token, error) {
 	token := lexer.Token
 
 	if token.Kind == language.TokenName && token.Value == value {
-		lexer.Advance()
-		return token, nil
+		return token, advance(lexer)
 	}
 
 	return nil, errors.NewSyntaxError(
 		lexer.Source,
 		token.Start,
-		fmt.Sprintf("Expected \"%s\", found %s", value, getTokenDesc(*token)),
+		errors.Message{ID: errors.MsgExpectedKeyword, Args: map[string]interface{}{
+			"keyword": value,
+			"found":   getTokenDesc(*token),
+		}},
 	)
 }
 
@@ -1077,7 +1723,9 @@ func unexpected(lexer *Lexer, atToken *language.Token) error {
 	return errors.NewSyntaxError(
 		lexer.Source,
 		token.Start,
-		fmt.Sprintf("Unexpected %s", getTokenDesc(*token)),
+		errors.Message{ID: errors.MsgUnexpectedToken, Args: map[string]interface{}{
+			"token": getTokenDesc(*token),
+		}},
 	)
 }
 
@@ -1093,22 +1741,27 @@ func any(
 	parseFn parser,
 	closeKind language.TokenKind,
 ) ([]language.ASTNode, error) {
-	_, err := expect(lexer, openKind)
-	if err != nil {
+	if _, err := expect(lexer, openKind); err != nil {
 		return nil, err
 	}
 
 	nodes := make([]language.ASTNode, 0)
 
-	for !skip(lexer, closeKind) {
+	for {
+		done, err := skip(lexer, closeKind)
+		if err != nil {
+			return nil, err
+		}
+		if done {
+			return nodes, nil
+		}
+
 		n, err := parseFn(lexer)
 		if err != nil {
 			return nil, err
 		}
 		nodes = append(nodes, n)
 	}
-
-	return nodes, nil
 }
 
 /**
@@ -1123,8 +1776,7 @@ func many(
 	parseFn parser,
 	closeKind language.TokenKind,
 ) ([]language.ASTNode, error) {
-	_, err := expect(lexer, openKind)
-	if err != nil {
+	if _, err := expect(lexer, openKind); err != nil {
 		return nil, err
 	}
 
@@ -1134,13 +1786,19 @@ func many(
 	}
 
 	nodes := []language.ASTNode{n}
-	for !skip(lexer, closeKind) {
+	for {
+		done, err := skip(lexer, closeKind)
+		if err != nil {
+			return nil, err
+		}
+		if done {
+			return nodes, nil
+		}
+
 		n, err := parseFn(lexer)
 		if err != nil {
 			return nil, err
 		}
 		nodes = append(nodes, n)
 	}
-
-	return nodes, nil
 }
@@ -0,0 +1,181 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/ijsnow/goql/internal/language"
+)
+
+func parseString(t *testing.T, body string) *language.DocumentNode {
+	t.Helper()
+
+	doc, err := Parse(language.NewSource(body))
+	if err != nil {
+		t.Fatalf("Parse; got error %v", err)
+	}
+
+	return doc
+}
+
+func TestParsesOperations(t *testing.T) {
+	doc := parseString(t, `
+query Hero($episode: Episode = JEDI, $withFriends: Boolean!) {
+  hero(episode: $episode) {
+    heroName: name
+    ... on Droid @include(if: $withFriends) {
+      primaryFunction
+    }
+    ...HeroFriends
+  }
+}
+
+fragment HeroFriends on Character {
+  friends { name }
+}
+
+{ unnamed }
+`)
+
+	if len(doc.Definitions) != 3 {
+		t.Fatalf("Definitions; got %d wanted 3", len(doc.Definitions))
+	}
+
+	op, ok := doc.Definitions[0].(*language.OperationDefinitionNode)
+	if !ok {
+		t.Fatalf("Definitions[0]; got %T wanted *OperationDefinitionNode", doc.Definitions[0])
+	}
+	if op.Operation != language.OperationTypeQuery || op.Name.Value != "Hero" {
+		t.Errorf("operation; got %s %s wanted query Hero", op.Operation, op.Name.Value)
+	}
+	if len(*op.VariableDefinitions) != 2 {
+		t.Fatalf("VariableDefinitions; got %d wanted 2", len(*op.VariableDefinitions))
+	}
+	if _, ok := (*op.VariableDefinitions)[1].Type.(*language.NonNullTypeNode); !ok {
+		t.Errorf("$withFriends type; got %T wanted *NonNullTypeNode", (*op.VariableDefinitions)[1].Type)
+	}
+
+	hero := op.SelectionSet.Selections[0].(*language.FieldNode)
+	if len(hero.SelectionSet.Selections) != 3 {
+		t.Fatalf("hero selections; got %d wanted 3", len(hero.SelectionSet.Selections))
+	}
+	if alias := hero.SelectionSet.Selections[0].(*language.FieldNode).Alias; alias == nil || alias.Value != "heroName" {
+		t.Errorf("alias; got %v wanted heroName", alias)
+	}
+	inline := hero.SelectionSet.Selections[1].(*language.InlineFragmentNode)
+	if inline.TypeCondition.Name.Value != "Droid" || len(*inline.Directives) != 1 {
+		t.Errorf("inline fragment; got %s with %d directives", inline.TypeCondition.Name.Value, len(*inline.Directives))
+	}
+	if _, ok := hero.SelectionSet.Selections[2].(*language.FragmentSpreadNode); !ok {
+		t.Errorf("spread; got %T wanted *FragmentSpreadNode", hero.SelectionSet.Selections[2])
+	}
+
+	if _, ok := doc.Definitions[1].(*language.FragmentDefinitionNode); !ok {
+		t.Errorf("Definitions[1]; got %T wanted *FragmentDefinitionNode", doc.Definitions[1])
+	}
+	if short := doc.Definitions[2].(*language.OperationDefinitionNode); short.Operation != language.OperationTypeQuery {
+		t.Errorf("shorthand operation; got %s wanted query", short.Operation)
+	}
+}
+
+func TestParsesTypeSystemDefinitions(t *testing.T) {
+	doc := parseString(t, `
+"""
+The query root.
+"""
+type Query implements Node {
+  "The id."
+  id: ID!
+  search(
+    """The text."""
+    text: String = "all"
+  ): [Result] @deprecated
+}
+
+union Result = | Query | Other
+
+"An enum."
+enum Color { "Red." RED GREEN }
+
+"""Repeatable directive."""
+directive @tag(name: String!) repeatable on FIELD_DEFINITION | OBJECT
+`)
+
+	if len(doc.Definitions) != 4 {
+		t.Fatalf("Definitions; got %d wanted 4", len(doc.Definitions))
+	}
+
+	query := doc.Definitions[0].(*language.ObjectTypeDefinitionNode)
+	if query.Description == nil || query.Description.Value != "The query root." || !query.Description.Block {
+		t.Errorf("type description; got %+v wanted block \"The query root.\"", query.Description)
+	}
+	if d := query.Fields[0].Description; d == nil || d.Value != "The id." || d.Block {
+		t.Errorf("field description; got %+v wanted \"The id.\"", d)
+	}
+	arg := query.Fields[1].Arguments[0]
+	if arg.Description == nil || arg.Description.Value != "The text." {
+		t.Errorf("argument description; got %+v wanted \"The text.\"", arg.Description)
+	}
+	if value, ok := arg.DefaultValue.(*language.StringValueNode); !ok || value.Value != "all" {
+		t.Errorf("default value; got %#v wanted \"all\"", arg.DefaultValue)
+	}
+
+	union := doc.Definitions[1].(*language.UnionTypeDefinitionNode)
+	if len(union.Types) != 2 {
+		t.Errorf("union members; got %d wanted 2", len(union.Types))
+	}
+
+	enum := doc.Definitions[2].(*language.EnumTypeDefinitionNode)
+	if enum.Description.Value != "An enum." || enum.Values[0].Description.Value != "Red." || enum.Values[1].Description != nil {
+		t.Errorf("enum descriptions; got %+v", enum)
+	}
+
+	directive := doc.Definitions[3].(*language.DirectiveDefinitionNode)
+	if !directive.Repeatable || len(directive.Locations) != 2 || directive.Description.Value != "Repeatable directive." {
+		t.Errorf("directive; got %+v", directive)
+	}
+}
+
+func TestParsesType(t *testing.T) {
+	typ, err := ParseType(language.NewSource("[String!]!"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nonNull, ok := typ.(*language.NonNullTypeNode)
+	if !ok {
+		t.Fatalf("ParseType; got %T wanted *NonNullTypeNode", typ)
+	}
+	list, ok := nonNull.Type.(*language.ListTypeNode)
+	if !ok {
+		t.Fatalf("ParseType; got %T wanted *ListTypeNode", nonNull.Type)
+	}
+	if _, ok := list.Type.(*language.NonNullTypeNode); !ok {
+		t.Fatalf("ParseType; got %T wanted *NonNullTypeNode", list.Type)
+	}
+}
+
+func TestParseReportsSyntaxErrors(t *testing.T) {
+	set := [][]string{
+		[]string{
+			"{",
+			"Syntax Error GraphQL request (1:2) Expected Name, found <EOF>",
+		},
+		[]string{
+			"notanoperation Foo { field }",
+			`Syntax Error GraphQL request (1:1) Unexpected Name "notanoperation"`,
+		},
+		[]string{
+			`"description" query { field }`,
+			`Syntax Error GraphQL request (1:15) Unexpected Name "query"`,
+		},
+		[]string{
+			"type Query { field: String",
+			"Syntax Error GraphQL request (1:27) Expected Name, found <EOF>",
+		},
+	}
+
+	for _, test := range set {
+		_, err := Parse(language.NewSource(test[0]))
+		testErr(t, err, test[1])
+	}
+}
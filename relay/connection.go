@@ -0,0 +1,288 @@
+package relay
+
+import (
+	"fmt"
+
+	"github.com/ijsnow/goql/schema"
+)
+
+// Connection is the value of a connection type: a page of edges and the
+// information to fetch the pages around it.
+type Connection struct {
+	Edges    []*Edge
+	PageInfo PageInfo
+}
+
+// Edge is the value of an edge type: a node of a connection and the cursor
+// locating it.
+type Edge struct {
+	Node   interface{}
+	Cursor string
+}
+
+// PageInfo is the value of the PageInfo type. An empty StartCursor or
+// EndCursor is null.
+type PageInfo struct {
+	StartCursor     string
+	EndCursor       string
+	HasPreviousPage bool
+	HasNextPage     bool
+}
+
+// ConnectionArguments are the arguments of a connection field, which can be
+// read from ResolveParams.Args by schema.DecodeArgs or
+// NewConnectionArguments. Empty cursors are not set.
+type ConnectionArguments struct {
+	First  *int   `graphql:"first"`
+	After  string `graphql:"after"`
+	Last   *int   `graphql:"last"`
+	Before string `graphql:"before"`
+}
+
+// NewConnectionArguments reads the connection arguments from the arguments
+// of a field.
+func NewConnectionArguments(args map[string]interface{}) (ConnectionArguments, error) {
+	var connArgs ConnectionArguments
+	err := schema.DecodeArgs(args, &connArgs)
+
+	return connArgs, err
+}
+
+// ForwardConnectionArgs returns the arguments of a connection field paging
+// forward, first and after, followed by the given arguments.
+func ForwardConnectionArgs(args ...*schema.Argument) []*schema.Argument {
+	return append([]*schema.Argument{
+		{Name: "after", Type: schema.String, Description: "Returns the items in the list that come after the specified cursor."},
+		{Name: "first", Type: schema.Int, Description: "Returns the first n items from the list."},
+	}, args...)
+}
+
+// BackwardConnectionArgs returns the arguments of a connection field paging
+// backward, last and before, followed by the given arguments.
+func BackwardConnectionArgs(args ...*schema.Argument) []*schema.Argument {
+	return append([]*schema.Argument{
+		{Name: "before", Type: schema.String, Description: "Returns the items in the list that come before the specified cursor."},
+		{Name: "last", Type: schema.Int, Description: "Returns the last n items from the list."},
+	}, args...)
+}
+
+// ConnectionArgs returns the arguments of a connection field paging in both
+// directions, followed by the given arguments.
+func ConnectionArgs(args ...*schema.Argument) []*schema.Argument {
+	return append(ForwardConnectionArgs(), BackwardConnectionArgs(args...)...)
+}
+
+// PageInfoType is the PageInfo type of the connections.
+var PageInfoType = schema.NewObject(schema.ObjectConfig{
+	Name:        "PageInfo",
+	Description: "Information about pagination in a connection.",
+	Fields: schema.Fields{
+		{
+			Name:        "hasNextPage",
+			Description: "When paginating forwards, are there more items?",
+			Type:        schema.NewNonNull(schema.Boolean),
+			Resolve: func(p schema.ResolveParams) (interface{}, error) {
+				info, err := pageInfoOf(p.Source)
+				if err != nil {
+					return nil, err
+				}
+				return info.HasNextPage, nil
+			},
+		},
+		{
+			Name:        "hasPreviousPage",
+			Description: "When paginating backwards, are there more items?",
+			Type:        schema.NewNonNull(schema.Boolean),
+			Resolve: func(p schema.ResolveParams) (interface{}, error) {
+				info, err := pageInfoOf(p.Source)
+				if err != nil {
+					return nil, err
+				}
+				return info.HasPreviousPage, nil
+			},
+		},
+		{
+			Name:        "startCursor",
+			Description: "When paginating backwards, the cursor to continue.",
+			Type:        schema.String,
+			Resolve: func(p schema.ResolveParams) (interface{}, error) {
+				info, err := pageInfoOf(p.Source)
+				if err != nil || info.StartCursor == "" {
+					return nil, err
+				}
+				return info.StartCursor, nil
+			},
+		},
+		{
+			Name:        "endCursor",
+			Description: "When paginating forwards, the cursor to continue.",
+			Type:        schema.String,
+			Resolve: func(p schema.ResolveParams) (interface{}, error) {
+				info, err := pageInfoOf(p.Source)
+				if err != nil || info.EndCursor == "" {
+					return nil, err
+				}
+				return info.EndCursor, nil
+			},
+		},
+	},
+})
+
+// ConnectionConfig configures NewConnectionDefinitions.
+type ConnectionConfig struct {
+	/**
+	 * Name is the prefix of the names of the types, which are <Name>Edge
+	 * and <Name>Connection. It defaults to the name of the node type.
+	 */
+	Name string
+
+	/**
+	 * NodeType is the type of the nodes of the connection.
+	 */
+	NodeType schema.Type
+
+	/**
+	 * ResolveNode and ResolveCursor resolve the node and cursor fields of
+	 * the edges. By default, they read the fields of an Edge.
+	 */
+	ResolveNode   schema.FieldResolveFn
+	ResolveCursor schema.FieldResolveFn
+
+	/**
+	 * EdgeFields and ConnectionFields are added to the fields of the edge
+	 * and connection types.
+	 */
+	EdgeFields       schema.FieldsThunk
+	ConnectionFields schema.FieldsThunk
+}
+
+// ConnectionDefinitions are the edge and connection types of a connection.
+type ConnectionDefinitions struct {
+	EdgeType       *schema.Object
+	ConnectionType *schema.Object
+}
+
+// NewConnectionDefinitions creates the edge and connection types of a
+// connection. Their values are an Edge and a Connection, or pointers to
+// them, unless the fields resolve other values.
+func NewConnectionDefinitions(config ConnectionConfig) *ConnectionDefinitions {
+	name := config.Name
+	if name == "" {
+		if named := schema.GetNamedType(config.NodeType); named != nil {
+			name = named.GetName()
+		}
+	}
+
+	resolveNode := config.ResolveNode
+	if resolveNode == nil {
+		resolveNode = func(p schema.ResolveParams) (interface{}, error) {
+			edge, err := edgeOf(p.Source)
+			if err != nil {
+				return nil, err
+			}
+			return edge.Node, nil
+		}
+	}
+	resolveCursor := config.ResolveCursor
+	if resolveCursor == nil {
+		resolveCursor = func(p schema.ResolveParams) (interface{}, error) {
+			edge, err := edgeOf(p.Source)
+			if err != nil {
+				return nil, err
+			}
+			return edge.Cursor, nil
+		}
+	}
+
+	edgeType := schema.NewObject(schema.ObjectConfig{
+		Name:        name + "Edge",
+		Description: "An edge in a connection.",
+		Fields: schema.FieldsFunc(func() schema.Fields {
+			return append(schema.Fields{
+				{
+					Name:        "node",
+					Description: "The item at the end of the edge",
+					Type:        config.NodeType,
+					Resolve:     resolveNode,
+				},
+				{
+					Name:        "cursor",
+					Description: "A cursor for use in pagination",
+					Type:        schema.NewNonNull(schema.String),
+					Resolve:     resolveCursor,
+				},
+			}, fieldsOf(config.EdgeFields)...)
+		}),
+	})
+
+	connectionType := schema.NewObject(schema.ObjectConfig{
+		Name:        name + "Connection",
+		Description: "A connection to a list of items.",
+		Fields: schema.FieldsFunc(func() schema.Fields {
+			return append(schema.Fields{
+				{
+					Name:        "pageInfo",
+					Description: "Information to aid in pagination.",
+					Type:        schema.NewNonNull(PageInfoType),
+					Resolve: func(p schema.ResolveParams) (interface{}, error) {
+						conn, err := connectionOf(p.Source)
+						if err != nil {
+							return nil, err
+						}
+						return &conn.PageInfo, nil
+					},
+				},
+				{
+					Name:        "edges",
+					Description: "A list of edges.",
+					Type:        schema.NewList(edgeType),
+					Resolve: func(p schema.ResolveParams) (interface{}, error) {
+						conn, err := connectionOf(p.Source)
+						if err != nil {
+							return nil, err
+						}
+						return conn.Edges, nil
+					},
+				},
+			}, fieldsOf(config.ConnectionFields)...)
+		}),
+	})
+
+	return &ConnectionDefinitions{EdgeType: edgeType, ConnectionType: connectionType}
+}
+
+// connectionOf returns the Connection of a source value.
+func connectionOf(source interface{}) (*Connection, error) {
+	switch source := source.(type) {
+	case *Connection:
+		return source, nil
+	case Connection:
+		return &source, nil
+	}
+
+	return nil, fmt.Errorf("Expected a relay.Connection, but received: %T.", source)
+}
+
+// edgeOf returns the Edge of a source value.
+func edgeOf(source interface{}) (*Edge, error) {
+	switch source := source.(type) {
+	case *Edge:
+		return source, nil
+	case Edge:
+		return &source, nil
+	}
+
+	return nil, fmt.Errorf("Expected a relay.Edge, but received: %T.", source)
+}
+
+// pageInfoOf returns the PageInfo of a source value.
+func pageInfoOf(source interface{}) (*PageInfo, error) {
+	switch source := source.(type) {
+	case *PageInfo:
+		return source, nil
+	case PageInfo:
+		return &source, nil
+	}
+
+	return nil, fmt.Errorf("Expected a relay.PageInfo, but received: %T.", source)
+}
@@ -0,0 +1,136 @@
+package relay
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ijsnow/goql/schema"
+)
+
+func TestConnectionArgs(t *testing.T) {
+	names := func(args []*schema.Argument) []string {
+		var names []string
+		for _, arg := range args {
+			names = append(names, arg.Name)
+		}
+		return names
+	}
+
+	tests := []struct {
+		args []*schema.Argument
+		want []string
+	}{
+		{ForwardConnectionArgs(), []string{"after", "first"}},
+		{BackwardConnectionArgs(), []string{"before", "last"}},
+		{ConnectionArgs(), []string{"after", "first", "before", "last"}},
+		{ConnectionArgs(&schema.Argument{Name: "orderBy", Type: schema.String}), []string{"after", "first", "before", "last", "orderBy"}},
+	}
+
+	for _, test := range tests {
+		if got := names(test.args); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("arguments; got %v wanted %v", got, test.want)
+		}
+	}
+}
+
+func TestNewConnectionArguments(t *testing.T) {
+	args, err := NewConnectionArguments(map[string]interface{}{
+		"first": 2,
+		"after": OffsetToCursor(1),
+		"other": true,
+	})
+	if err != nil {
+		t.Fatalf("NewConnectionArguments; got error %v", err)
+	}
+
+	want := ConnectionArguments{First: intPtr(2), After: OffsetToCursor(1)}
+	if !reflect.DeepEqual(args, want) {
+		t.Errorf("NewConnectionArguments; got %+v wanted %+v", args, want)
+	}
+}
+
+func TestConnectionResolvers(t *testing.T) {
+	s := testSchema(t)
+
+	conn, err := s.GetQueryType().GetField("ships").Resolve(schema.ResolveParams{
+		Args: map[string]interface{}{"first": 2},
+	})
+	if err != nil {
+		t.Fatalf("ships; got error %v", err)
+	}
+
+	connectionType := testShipConnection.ConnectionType
+	edges, _ := connectionType.GetField("edges").Resolve(schema.ResolveParams{Source: conn})
+	if len(edges.([]*Edge)) != 2 {
+		t.Fatalf("edges; got %v wanted 2 edges", edges)
+	}
+	edge := edges.([]*Edge)[1]
+
+	if node, _ := testShipConnection.EdgeType.GetField("node").Resolve(schema.ResolveParams{Source: edge}); node != testShips[1] {
+		t.Errorf("node; got %v wanted %v", node, testShips[1])
+	}
+	if cursor, _ := testShipConnection.EdgeType.GetField("cursor").Resolve(schema.ResolveParams{Source: *edge}); cursor != OffsetToCursor(1) {
+		t.Errorf("cursor; got %v wanted %s", cursor, OffsetToCursor(1))
+	}
+
+	pageInfo, _ := connectionType.GetField("pageInfo").Resolve(schema.ResolveParams{Source: conn})
+	tests := []struct {
+		field string
+		want  interface{}
+	}{
+		{"hasNextPage", true},
+		{"hasPreviousPage", false},
+		{"startCursor", OffsetToCursor(0)},
+		{"endCursor", OffsetToCursor(1)},
+	}
+	for _, test := range tests {
+		if got, _ := PageInfoType.GetField(test.field).Resolve(schema.ResolveParams{Source: pageInfo}); got != test.want {
+			t.Errorf("%s; got %v wanted %v", test.field, got, test.want)
+		}
+	}
+
+	if got, _ := PageInfoType.GetField("startCursor").Resolve(schema.ResolveParams{Source: PageInfo{}}); got != nil {
+		t.Errorf("startCursor of an empty page; got %v wanted <nil>", got)
+	}
+	if _, err := connectionType.GetField("edges").Resolve(schema.ResolveParams{Source: testShips}); err == nil {
+		t.Errorf("edges of a slice; got no error")
+	}
+}
+
+func TestConnectionDefinitions(t *testing.T) {
+	defs := NewConnectionDefinitions(ConnectionConfig{
+		Name:     "Friend",
+		NodeType: schema.NewNonNull(shipType),
+		ResolveCursor: func(p schema.ResolveParams) (interface{}, error) {
+			return "custom", nil
+		},
+		EdgeFields: schema.FieldsFunc(func() schema.Fields {
+			return schema.Fields{{Name: "since", Type: schema.Int}}
+		}),
+	})
+
+	if defs.EdgeType.Name != "FriendEdge" || defs.ConnectionType.Name != "FriendConnection" {
+		t.Errorf("names; got %s and %s wanted FriendEdge and FriendConnection", defs.EdgeType.Name, defs.ConnectionType.Name)
+	}
+
+	want := `"""An edge in a connection."""
+type FriendEdge {
+  """The item at the end of the edge"""
+  node: Ship!
+
+  """A cursor for use in pagination"""
+  cursor: String!
+  since: Int
+}`
+	got, err := schema.PrintType(defs.EdgeType)
+	if err != nil {
+		t.Fatalf("PrintType; got error %v", err)
+	}
+	if got != want {
+		t.Errorf("PrintType; got\n%s\nwanted\n%s", got, want)
+	}
+
+	if cursor, _ := defs.EdgeType.GetField("cursor").Resolve(schema.ResolveParams{Source: &Edge{}}); cursor != "custom" {
+		t.Errorf("cursor; got %v wanted custom", cursor)
+	}
+}
@@ -0,0 +1,118 @@
+package relay
+
+import (
+	"fmt"
+
+	"github.com/ijsnow/goql/schema"
+)
+
+// MutateAndGetPayloadFn performs a mutation given the fields of its input,
+// which can be read by schema.DecodeArgs, and returns the fields of its
+// payload.
+type MutateAndGetPayloadFn func(input map[string]interface{}, p schema.ResolveParams) (map[string]interface{}, error)
+
+// MutationConfig configures MutationWithClientMutationID.
+type MutationConfig struct {
+	/**
+	 * Name is the name of the mutation, such as IntroduceShip, after which
+	 * the field and its input and payload types are named.
+	 */
+	Name              string
+	Description       string
+	DeprecationReason string
+
+	/**
+	 * InputFields and OutputFields are the fields of the input and payload
+	 * types, besides clientMutationId.
+	 */
+	InputFields  schema.InputFieldsThunk
+	OutputFields schema.FieldsThunk
+
+	/**
+	 * MutateAndGetPayload performs the mutation.
+	 */
+	MutateAndGetPayload MutateAndGetPayloadFn
+}
+
+// MutationWithClientMutationID creates a field of the mutation root type
+// taking a single input argument of the type <Name>Input and returning the
+// type <Name>Payload. The field is named after the mutation, starting with a
+// lowercase letter. The clientMutationId given in the input is returned in
+// the payload, so that clients can match the responses of mutations to
+// their requests.
+//
+// The payload returned by MutateAndGetPayload is copied with the
+// clientMutationId added, and the copy is the source value of the output
+// fields, which are read from it when they have no resolvers. The
+// MutateAndGetPayload function of the configuration is required.
+func MutationWithClientMutationID(config MutationConfig) (*schema.Field, error) {
+	if config.MutateAndGetPayload == nil {
+		return nil, fmt.Errorf("Mutation %s requires a MutateAndGetPayload function.", config.Name)
+	}
+
+	inputType := schema.NewInputObject(schema.InputObjectConfig{
+		Name: config.Name + "Input",
+		Fields: schema.InputFieldsFunc(func() schema.InputFields {
+			return append(schema.InputFields{
+				{Name: "clientMutationId", Type: schema.String},
+			}, inputFieldsOf(config.InputFields)...)
+		}),
+	})
+
+	payloadType := schema.NewObject(schema.ObjectConfig{
+		Name: config.Name + "Payload",
+		Fields: schema.FieldsFunc(func() schema.Fields {
+			fields := schema.Fields{
+				{Name: "clientMutationId", Type: schema.String, Resolve: payloadResolver("clientMutationId")},
+			}
+			for _, field := range fieldsOf(config.OutputFields) {
+				if field.Resolve == nil {
+					withResolver := *field
+					withResolver.Resolve = payloadResolver(field.Name)
+					field = &withResolver
+				}
+				fields = append(fields, field)
+			}
+			return fields
+		}),
+	})
+
+	return &schema.Field{
+		Name:              lowerFirst(config.Name),
+		Description:       config.Description,
+		DeprecationReason: config.DeprecationReason,
+		Type:              payloadType,
+		Args: []*schema.Argument{
+			{Name: "input", Type: schema.NewNonNull(inputType)},
+		},
+		Resolve: func(p schema.ResolveParams) (interface{}, error) {
+			input, _ := p.Args["input"].(map[string]interface{})
+
+			result, err := config.MutateAndGetPayload(input, p)
+			if err != nil {
+				return nil, err
+			}
+
+			payload := make(map[string]interface{}, len(result)+1)
+			for key, value := range result {
+				payload[key] = value
+			}
+			payload["clientMutationId"] = input["clientMutationId"]
+
+			return payload, nil
+		},
+	}, nil
+}
+
+// payloadResolver resolves a field of a payload by reading the key of the
+// map.
+func payloadResolver(key string) schema.FieldResolveFn {
+	return func(p schema.ResolveParams) (interface{}, error) {
+		payload, ok := p.Source.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("Expected a mutation payload, but received: %T.", p.Source)
+		}
+
+		return payload[key], nil
+	}
+}
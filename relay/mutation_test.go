@@ -0,0 +1,98 @@
+package relay
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ijsnow/goql/schema"
+)
+
+func TestMutationWithClientMutationID(t *testing.T) {
+	if introduceShip.Name != "introduceShip" {
+		t.Errorf("name; got %s wanted introduceShip", introduceShip.Name)
+	}
+
+	payload, err := introduceShip.Resolve(schema.ResolveParams{
+		Args: map[string]interface{}{
+			"input": map[string]interface{}{"clientMutationId": "abc", "shipName": "B-Wing"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("introduceShip; got error %v", err)
+	}
+
+	payloadType := introduceShip.Type.(*schema.Object)
+	if got, _ := payloadType.GetField("clientMutationId").Resolve(schema.ResolveParams{Source: payload}); got != "abc" {
+		t.Errorf("clientMutationId; got %v wanted abc", got)
+	}
+	s, _ := payloadType.GetField("ship").Resolve(schema.ResolveParams{Source: payload})
+	if want := (&ship{ID: "4", Name: "B-Wing"}); !reflect.DeepEqual(s, want) {
+		t.Errorf("ship; got %v wanted %v", s, want)
+	}
+}
+
+func TestMutationWithoutMutateAndGetPayload(t *testing.T) {
+	want := "Mutation RemoveShip requires a MutateAndGetPayload function."
+	if _, err := MutationWithClientMutationID(MutationConfig{Name: "RemoveShip"}); err == nil || err.Error() != want {
+		t.Errorf("MutationWithClientMutationID; got error %v wanted %s", err, want)
+	}
+}
+
+func TestMutationCopiesPayload(t *testing.T) {
+	result := map[string]interface{}{"removed": true}
+	field := mustMutation(MutationConfig{
+		Name: "RemoveShip",
+		MutateAndGetPayload: func(input map[string]interface{}, p schema.ResolveParams) (map[string]interface{}, error) {
+			return result, nil
+		},
+	})
+
+	payload, err := field.Resolve(schema.ResolveParams{
+		Args: map[string]interface{}{"input": map[string]interface{}{"clientMutationId": "abc"}},
+	})
+	if err != nil {
+		t.Fatalf("removeShip; got error %v", err)
+	}
+	if want := map[string]interface{}{"removed": true, "clientMutationId": "abc"}; !reflect.DeepEqual(payload, want) {
+		t.Errorf("removeShip; got %v wanted %v", payload, want)
+	}
+	if want := map[string]interface{}{"removed": true}; !reflect.DeepEqual(result, want) {
+		t.Errorf("payload of MutateAndGetPayload; got %v wanted it unchanged", result)
+	}
+}
+
+func TestMutationWithoutPayload(t *testing.T) {
+	field := mustMutation(MutationConfig{
+		Name: "RemoveShip",
+		OutputFields: schema.Fields{
+			{
+				Name: "removed",
+				Type: schema.Boolean,
+				Resolve: func(p schema.ResolveParams) (interface{}, error) {
+					return true, nil
+				},
+			},
+		},
+		MutateAndGetPayload: func(input map[string]interface{}, p schema.ResolveParams) (map[string]interface{}, error) {
+			return nil, nil
+		},
+	})
+
+	payload, err := field.Resolve(schema.ResolveParams{
+		Args: map[string]interface{}{"input": map[string]interface{}{}},
+	})
+	if err != nil {
+		t.Fatalf("removeShip; got error %v", err)
+	}
+	if want := map[string]interface{}{"clientMutationId": nil}; !reflect.DeepEqual(payload, want) {
+		t.Errorf("removeShip; got %v wanted %v", payload, want)
+	}
+
+	payloadType := field.Type.(*schema.Object)
+	if got, _ := payloadType.GetField("removed").Resolve(schema.ResolveParams{Source: payload}); got != true {
+		t.Errorf("removed; got %v wanted true", got)
+	}
+	if _, err := payloadType.GetField("clientMutationId").Resolve(schema.ResolveParams{Source: 1}); err == nil {
+		t.Errorf("clientMutationId of 1; got no error")
+	}
+}
@@ -0,0 +1,198 @@
+package relay
+
+import (
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/ijsnow/goql/schema"
+)
+
+// IDFetcherFn returns the object with the given global ID, or nil if there
+// is none.
+type IDFetcherFn func(id string, p schema.ResolveParams) (interface{}, error)
+
+// GlobalIDFetcherFn returns the ID of an object, unique among the objects
+// of its type, from which its global ID is made.
+type GlobalIDFetcherFn func(obj interface{}, p schema.ResolveParams) (string, error)
+
+// NodeDefinitionsConfig configures NewNodeDefinitions.
+type NodeDefinitionsConfig struct {
+	/**
+	 * IDFetcher returns the object of a global ID, for the node and nodes
+	 * fields.
+	 */
+	IDFetcher IDFetcherFn
+
+	/**
+	 * ResolveType determines the Object type of the objects returned by
+	 * IDFetcher. Without it, the IsTypeOf functions of the types
+	 * implementing Node are used.
+	 */
+	ResolveType schema.TypeResolveFn
+}
+
+// NodeDefinitions are the Node interface and the root fields fetching
+// objects by their global IDs.
+type NodeDefinitions struct {
+	/**
+	 * NodeInterface is the Node interface, implemented by the types of
+	 * objects which can be fetched by global ID.
+	 */
+	NodeInterface *schema.Interface
+
+	/**
+	 * NodeField is the node(id: ID!): Node field of the query root type.
+	 */
+	NodeField *schema.Field
+
+	/**
+	 * NodesField is the nodes(ids: [ID!]!): [Node]! field of the query root
+	 * type.
+	 */
+	NodesField *schema.Field
+}
+
+// NewNodeDefinitions creates the Node interface and the node and nodes
+// fields, which are to be added to the query root type. The IDFetcher of
+// the configuration is required.
+func NewNodeDefinitions(config NodeDefinitionsConfig) (*NodeDefinitions, error) {
+	if config.IDFetcher == nil {
+		return nil, fmt.Errorf("Node definitions require an IDFetcher.")
+	}
+
+	nodeInterface := schema.NewInterface(schema.InterfaceConfig{
+		Name:        "Node",
+		Description: "An object with an ID",
+		Fields: schema.Fields{
+			{
+				Name:        "id",
+				Description: "The id of the object.",
+				Type:        schema.NewNonNull(schema.ID),
+			},
+		},
+		ResolveType: config.ResolveType,
+	})
+
+	return &NodeDefinitions{
+		NodeInterface: nodeInterface,
+		NodeField: &schema.Field{
+			Name:        "node",
+			Description: "Fetches an object given its ID",
+			Type:        nodeInterface,
+			Args: []*schema.Argument{
+				{
+					Name:        "id",
+					Description: "The ID of an object",
+					Type:        schema.NewNonNull(schema.ID),
+				},
+			},
+			Resolve: func(p schema.ResolveParams) (interface{}, error) {
+				id, _ := p.Args["id"].(string)
+				return config.IDFetcher(id, p)
+			},
+		},
+		NodesField: &schema.Field{
+			Name:        "nodes",
+			Description: "Fetches objects given their IDs",
+			Type:        schema.NewNonNull(schema.NewList(nodeInterface)),
+			Args: []*schema.Argument{
+				{
+					Name:        "ids",
+					Description: "The IDs of objects",
+					Type:        schema.NewNonNull(schema.NewList(schema.NewNonNull(schema.ID))),
+				},
+			},
+			Resolve: func(p schema.ResolveParams) (interface{}, error) {
+				ids, _ := p.Args["ids"].([]interface{})
+
+				objects := make([]interface{}, len(ids))
+				for idx, id := range ids {
+					id, _ := id.(string)
+
+					obj, err := config.IDFetcher(id, p)
+					if err != nil {
+						return nil, err
+					}
+					objects[idx] = obj
+				}
+				return objects, nil
+			},
+		},
+	}, nil
+}
+
+// ResolvedGlobalID is a global ID decoded into the name of the type of the
+// object and its ID within that type.
+type ResolvedGlobalID struct {
+	Type string
+	ID   string
+}
+
+// ToGlobalID returns the global ID of the object of a type with the given
+// ID, which is opaque to clients.
+func ToGlobalID(typeName string, id string) string {
+	return base64.StdEncoding.EncodeToString([]byte(typeName + ":" + id))
+}
+
+// FromGlobalID decodes a global ID created by ToGlobalID.
+func FromGlobalID(globalID string) (ResolvedGlobalID, error) {
+	data, err := base64.StdEncoding.DecodeString(globalID)
+	if err != nil {
+		return ResolvedGlobalID{}, fmt.Errorf("Invalid global ID: %q.", globalID)
+	}
+
+	parts := strings.SplitN(string(data), ":", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return ResolvedGlobalID{}, fmt.Errorf("Invalid global ID: %q.", globalID)
+	}
+
+	return ResolvedGlobalID{Type: parts[0], ID: parts[1]}, nil
+}
+
+// GlobalIDField creates the id: ID! field of a type implementing Node,
+// resolving to the global ID of the source object. The ID of the object is
+// returned by idFetcher, or else read from the id key of a map or the ID
+// field of a struct. The type name defaults to the name of the parent type.
+func GlobalIDField(typeName string, idFetcher GlobalIDFetcherFn) *schema.Field {
+	if idFetcher == nil {
+		idFetcher = defaultIDFetcher
+	}
+
+	return &schema.Field{
+		Name:        "id",
+		Description: "The ID of an object",
+		Type:        schema.NewNonNull(schema.ID),
+		Resolve: func(p schema.ResolveParams) (interface{}, error) {
+			id, err := idFetcher(p.Source, p)
+			if err != nil {
+				return nil, err
+			}
+
+			name := typeName
+			if name == "" && p.Info.ParentType != nil {
+				name = p.Info.ParentType.GetName()
+			}
+			return ToGlobalID(name, id), nil
+		},
+	}
+}
+
+// defaultIDFetcher reads the ID of a map or a struct.
+func defaultIDFetcher(obj interface{}, p schema.ResolveParams) (string, error) {
+	var id interface{}
+	if m, ok := obj.(map[string]interface{}); ok {
+		id = m["id"]
+	} else if v := indirect(obj); v.Kind() == reflect.Struct {
+		if field := v.FieldByName("ID"); field.IsValid() && field.CanInterface() {
+			id = field.Interface()
+		}
+	}
+
+	if id == nil {
+		return "", fmt.Errorf("Cannot read the ID of %T.", obj)
+	}
+
+	return fmt.Sprint(id), nil
+}
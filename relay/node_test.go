@@ -0,0 +1,106 @@
+package relay
+
+import (
+	"testing"
+
+	"github.com/ijsnow/goql/schema"
+)
+
+func TestToGlobalID(t *testing.T) {
+	if got := ToGlobalID("Ship", "1"); got != "U2hpcDox" {
+		t.Errorf("ToGlobalID; got %s wanted U2hpcDox", got)
+	}
+
+	global, err := FromGlobalID(ToGlobalID("Ship", "a:b"))
+	if err != nil {
+		t.Fatalf("FromGlobalID; got error %v", err)
+	}
+	if global.Type != "Ship" || global.ID != "a:b" {
+		t.Errorf("FromGlobalID; got %+v wanted {Type:Ship ID:a:b}", global)
+	}
+}
+
+func TestFromGlobalIDErrors(t *testing.T) {
+	for _, globalID := range []string{"", "not base64!", "U2hpcA==", "OjE="} {
+		if _, err := FromGlobalID(globalID); err == nil {
+			t.Errorf("FromGlobalID(%q); got no error", globalID)
+		}
+	}
+}
+
+func TestNodeField(t *testing.T) {
+	node, err := testNodes.NodeField.Resolve(schema.ResolveParams{
+		Args: map[string]interface{}{"id": ToGlobalID("Ship", "2")},
+	})
+	if err != nil {
+		t.Fatalf("node; got error %v", err)
+	}
+	if node != testShips[1] {
+		t.Errorf("node; got %v wanted %v", node, testShips[1])
+	}
+
+	if _, err := testNodes.NodeField.Resolve(schema.ResolveParams{
+		Args: map[string]interface{}{"id": "invalid"},
+	}); err == nil {
+		t.Errorf("node with an invalid ID; got no error")
+	}
+}
+
+func TestNodeDefinitionsWithoutFetcher(t *testing.T) {
+	want := "Node definitions require an IDFetcher."
+	if _, err := NewNodeDefinitions(NodeDefinitionsConfig{}); err == nil || err.Error() != want {
+		t.Errorf("NewNodeDefinitions; got error %v wanted %s", err, want)
+	}
+}
+
+func TestNodesField(t *testing.T) {
+	nodes, err := testNodes.NodesField.Resolve(schema.ResolveParams{
+		Args: map[string]interface{}{
+			"ids": []interface{}{ToGlobalID("Ship", "3"), ToGlobalID("Ship", "9"), ToGlobalID("Ship", "1")},
+		},
+	})
+	if err != nil {
+		t.Fatalf("nodes; got error %v", err)
+	}
+
+	list := nodes.([]interface{})
+	if len(list) != 3 || list[0] != testShips[2] || list[1] != nil || list[2] != testShips[0] {
+		t.Errorf("nodes; got %v wanted [%v <nil> %v]", list, testShips[2], testShips[0])
+	}
+}
+
+func TestGlobalIDField(t *testing.T) {
+	info := schema.ResolveInfo{ParentType: schema.NewObject(schema.ObjectConfig{Name: "Faction"})}
+
+	tests := []struct {
+		field  *schema.Field
+		source interface{}
+		want   string
+	}{
+		{GlobalIDField("Ship", nil), testShips[0], ToGlobalID("Ship", "1")},
+		{GlobalIDField("Ship", nil), ship{ID: "5"}, ToGlobalID("Ship", "5")},
+		{GlobalIDField("", nil), map[string]interface{}{"id": 7}, ToGlobalID("Faction", "7")},
+		{
+			GlobalIDField("Ship", func(obj interface{}, p schema.ResolveParams) (string, error) {
+				return obj.(*ship).Name, nil
+			}),
+			testShips[1],
+			ToGlobalID("Ship", "Y-Wing"),
+		},
+	}
+
+	for _, test := range tests {
+		got, err := test.field.Resolve(schema.ResolveParams{Source: test.source, Info: info})
+		if err != nil {
+			t.Errorf("id of %v; got error %v", test.source, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("id of %v; got %v wanted %s", test.source, got, test.want)
+		}
+	}
+
+	if _, err := GlobalIDField("Ship", nil).Resolve(schema.ResolveParams{Source: 1}); err == nil {
+		t.Errorf("id of 1; got no error")
+	}
+}
@@ -0,0 +1,94 @@
+// Package relay helps building schemas that follow the Relay server
+// specification: objects refetched by global IDs through the Node interface,
+// paginated connections, and mutations taking a single input argument with a
+// client mutation ID.
+//
+// A schema exposing ships by global ID and paginating them could be defined
+// as:
+//
+//	nodes, err := relay.NewNodeDefinitions(relay.NodeDefinitionsConfig{
+//		IDFetcher: func(id string, p schema.ResolveParams) (interface{}, error) {
+//			global, err := relay.FromGlobalID(id)
+//			if err != nil {
+//				return nil, err
+//			}
+//			return getShip(global.ID), nil
+//		},
+//		ResolveType: func(p schema.ResolveTypeParams) *schema.Object {
+//			return p.Info.Schema.GetType("Ship").(*schema.Object)
+//		},
+//	})
+//	if err != nil {
+//		return nil, err
+//	}
+//
+//	shipType := schema.NewObject(schema.ObjectConfig{
+//		Name:       "Ship",
+//		Interfaces: schema.Interfaces{nodes.NodeInterface},
+//		Fields: schema.Fields{
+//			relay.GlobalIDField("Ship", nil),
+//			{Name: "name", Type: schema.String},
+//		},
+//	})
+//
+//	ships := relay.NewConnectionDefinitions(relay.ConnectionConfig{
+//		NodeType: shipType,
+//	})
+package relay
+
+import (
+	"reflect"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ijsnow/goql/schema"
+)
+
+// fieldsOf returns the fields provided by a thunk.
+func fieldsOf(thunk schema.FieldsThunk) schema.Fields {
+	switch thunk := thunk.(type) {
+	case schema.Fields:
+		return thunk
+	case schema.FieldsFunc:
+		return thunk()
+	}
+
+	return nil
+}
+
+// inputFieldsOf returns the input fields provided by a thunk.
+func inputFieldsOf(thunk schema.InputFieldsThunk) schema.InputFields {
+	switch thunk := thunk.(type) {
+	case schema.InputFields:
+		return thunk
+	case schema.InputFieldsFunc:
+		return thunk()
+	}
+
+	return nil
+}
+
+// indirect dereferences pointers until it reaches a value, returning the
+// invalid Value for a nil pointer.
+func indirect(value interface{}) reflect.Value {
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+
+	return v
+}
+
+// lowerFirst returns the name starting with a lowercase letter.
+func lowerFirst(name string) string {
+	if name == "" {
+		return name
+	}
+
+	r, size := utf8.DecodeRuneInString(name)
+
+	return string(unicode.ToLower(r)) + name[size:]
+}
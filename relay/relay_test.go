@@ -0,0 +1,232 @@
+package relay
+
+import (
+	"testing"
+
+	"github.com/ijsnow/goql/schema"
+)
+
+type ship struct {
+	ID   string
+	Name string
+}
+
+var testShips = []*ship{
+	{ID: "1", Name: "X-Wing"},
+	{ID: "2", Name: "Y-Wing"},
+	{ID: "3", Name: "A-Wing"},
+}
+
+func fetchShip(id string, p schema.ResolveParams) (interface{}, error) {
+	global, err := FromGlobalID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range testShips {
+		if global.Type == "Ship" && s.ID == global.ID {
+			return s, nil
+		}
+	}
+	return nil, nil
+}
+
+var testNodes = mustNodeDefinitions(NodeDefinitionsConfig{
+	IDFetcher: fetchShip,
+	ResolveType: func(p schema.ResolveTypeParams) *schema.Object {
+		return p.Info.Schema.GetType("Ship").(*schema.Object)
+	},
+})
+
+func mustNodeDefinitions(config NodeDefinitionsConfig) *NodeDefinitions {
+	defs, err := NewNodeDefinitions(config)
+	if err != nil {
+		panic(err)
+	}
+	return defs
+}
+
+var shipType = schema.NewObject(schema.ObjectConfig{
+	Name:       "Ship",
+	Interfaces: schema.Interfaces{testNodes.NodeInterface},
+	Fields: schema.Fields{
+		GlobalIDField("Ship", nil),
+		{
+			Name: "name",
+			Type: schema.String,
+			Resolve: func(p schema.ResolveParams) (interface{}, error) {
+				return p.Source.(*ship).Name, nil
+			},
+		},
+	},
+})
+
+var testShipConnection = NewConnectionDefinitions(ConnectionConfig{
+	NodeType: shipType,
+	ConnectionFields: schema.Fields{
+		{
+			Name: "totalCount",
+			Type: schema.Int,
+			Resolve: func(p schema.ResolveParams) (interface{}, error) {
+				return len(testShips), nil
+			},
+		},
+	},
+})
+
+var introduceShip = mustMutation(MutationConfig{
+	Name: "IntroduceShip",
+	InputFields: schema.InputFields{
+		{Name: "shipName", Type: schema.NewNonNull(schema.String)},
+	},
+	OutputFields: schema.FieldsFunc(func() schema.Fields {
+		return schema.Fields{
+			{Name: "ship", Type: shipType},
+		}
+	}),
+	MutateAndGetPayload: func(input map[string]interface{}, p schema.ResolveParams) (map[string]interface{}, error) {
+		s := &ship{ID: "4", Name: input["shipName"].(string)}
+		return map[string]interface{}{"ship": s}, nil
+	},
+})
+
+func mustMutation(config MutationConfig) *schema.Field {
+	field, err := MutationWithClientMutationID(config)
+	if err != nil {
+		panic(err)
+	}
+	return field
+}
+
+func testSchema(t *testing.T) *schema.Schema {
+	s, err := schema.NewSchema(schema.SchemaConfig{
+		Query: schema.NewObject(schema.ObjectConfig{
+			Name: "Query",
+			Fields: schema.Fields{
+				testNodes.NodeField,
+				testNodes.NodesField,
+				{
+					Name: "ships",
+					Type: testShipConnection.ConnectionType,
+					Args: ConnectionArgs(),
+					Resolve: func(p schema.ResolveParams) (interface{}, error) {
+						args, err := NewConnectionArguments(p.Args)
+						if err != nil {
+							return nil, err
+						}
+						return ConnectionFromSlice(testShips, args)
+					},
+				},
+			},
+		}),
+		Mutation: schema.NewObject(schema.ObjectConfig{
+			Name:   "Mutation",
+			Fields: schema.Fields{introduceShip},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("NewSchema; got error %v", err)
+	}
+	if err := schema.AssertValidSchema(s); err != nil {
+		t.Fatalf("AssertValidSchema; got error %v", err)
+	}
+
+	return s
+}
+
+func TestSchema(t *testing.T) {
+	want := `input IntroduceShipInput {
+  clientMutationId: String
+  shipName: String!
+}
+
+type IntroduceShipPayload {
+  clientMutationId: String
+  ship: Ship
+}
+
+type Mutation {
+  introduceShip(input: IntroduceShipInput!): IntroduceShipPayload
+}
+
+"""An object with an ID"""
+interface Node {
+  """The id of the object."""
+  id: ID!
+}
+
+"""Information about pagination in a connection."""
+type PageInfo {
+  """When paginating forwards, are there more items?"""
+  hasNextPage: Boolean!
+
+  """When paginating backwards, are there more items?"""
+  hasPreviousPage: Boolean!
+
+  """When paginating backwards, the cursor to continue."""
+  startCursor: String
+
+  """When paginating forwards, the cursor to continue."""
+  endCursor: String
+}
+
+type Query {
+  """Fetches an object given its ID"""
+  node(
+    """The ID of an object"""
+    id: ID!
+  ): Node
+
+  """Fetches objects given their IDs"""
+  nodes(
+    """The IDs of objects"""
+    ids: [ID!]!
+  ): [Node]!
+  ships(
+    """Returns the items in the list that come after the specified cursor."""
+    after: String
+
+    """Returns the first n items from the list."""
+    first: Int
+
+    """Returns the items in the list that come before the specified cursor."""
+    before: String
+
+    """Returns the last n items from the list."""
+    last: Int
+  ): ShipConnection
+}
+
+type Ship implements Node {
+  """The ID of an object"""
+  id: ID!
+  name: String
+}
+
+"""A connection to a list of items."""
+type ShipConnection {
+  """Information to aid in pagination."""
+  pageInfo: PageInfo!
+
+  """A list of edges."""
+  edges: [ShipEdge]
+  totalCount: Int
+}
+
+"""An edge in a connection."""
+type ShipEdge {
+  """The item at the end of the edge"""
+  node: Ship
+
+  """A cursor for use in pagination"""
+  cursor: String!
+}
+`
+	got, err := schema.PrintSchema(testSchema(t))
+	if err != nil {
+		t.Fatalf("PrintSchema; got error %v", err)
+	}
+	if got != want {
+		t.Errorf("PrintSchema; got\n%s\nwanted\n%s", got, want)
+	}
+}
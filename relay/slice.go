@@ -0,0 +1,167 @@
+package relay
+
+import (
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const cursorPrefix = "arrayconnection:"
+
+// SliceInfo locates a slice within the full list of a connection.
+type SliceInfo struct {
+	/**
+	 * SliceStart is the offset of the first item of the slice in the list.
+	 */
+	SliceStart int
+
+	/**
+	 * ListLength is the number of items in the list.
+	 */
+	ListLength int
+}
+
+// ConnectionFromSlice returns the page of a connection over all the items
+// of a slice or an array selected by the arguments. The cursors of the
+// edges are the offsets of their nodes.
+func ConnectionFromSlice(data interface{}, args ConnectionArguments) (*Connection, error) {
+	items, err := sliceOf(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return ConnectionFromSlicePart(data, args, SliceInfo{SliceStart: 0, ListLength: items.Len()})
+}
+
+// ConnectionFromSlicePart returns the page of a connection over a list
+// selected by the arguments, given a part of the list which contains at
+// least the items of the page, such as the rows fetched with an offset and
+// a limit.
+func ConnectionFromSlicePart(part interface{}, args ConnectionArguments, info SliceInfo) (*Connection, error) {
+	items, err := sliceOf(part)
+	if err != nil {
+		return nil, err
+	}
+	if info.SliceStart < 0 {
+		return nil, fmt.Errorf("The slice start must be a non-negative integer, but is %d.", info.SliceStart)
+	}
+	if info.ListLength < 0 {
+		return nil, fmt.Errorf("The list length must be a non-negative integer, but is %d.", info.ListLength)
+	}
+
+	sliceEnd := info.SliceStart + items.Len()
+	startOffset := max(info.SliceStart, 0)
+	endOffset := min(sliceEnd, info.ListLength)
+
+	afterOffset := GetOffsetWithDefault(args.After, -1)
+	if afterOffset >= 0 && afterOffset < info.ListLength {
+		startOffset = max(startOffset, afterOffset+1)
+	}
+
+	beforeOffset := GetOffsetWithDefault(args.Before, info.ListLength)
+	if beforeOffset >= 0 && beforeOffset < info.ListLength {
+		endOffset = min(endOffset, beforeOffset)
+	}
+
+	if args.First != nil {
+		if *args.First < 0 {
+			return nil, fmt.Errorf("Argument \"first\" must be a non-negative integer.")
+		}
+		endOffset = min(endOffset, startOffset+*args.First)
+	}
+	if args.Last != nil {
+		if *args.Last < 0 {
+			return nil, fmt.Errorf("Argument \"last\" must be a non-negative integer.")
+		}
+		startOffset = max(startOffset, endOffset-*args.Last)
+	}
+
+	conn := &Connection{Edges: []*Edge{}}
+	for offset := startOffset; offset < endOffset; offset++ {
+		conn.Edges = append(conn.Edges, &Edge{
+			Node:   items.Index(offset - info.SliceStart).Interface(),
+			Cursor: OffsetToCursor(offset),
+		})
+	}
+
+	lowerBound := 0
+	if args.After != "" {
+		lowerBound = afterOffset + 1
+	}
+	upperBound := info.ListLength
+	if args.Before != "" {
+		upperBound = beforeOffset
+	}
+
+	if len(conn.Edges) > 0 {
+		conn.PageInfo.StartCursor = conn.Edges[0].Cursor
+		conn.PageInfo.EndCursor = conn.Edges[len(conn.Edges)-1].Cursor
+	}
+	conn.PageInfo.HasPreviousPage = args.Last != nil && startOffset > lowerBound
+	conn.PageInfo.HasNextPage = args.First != nil && endOffset < upperBound
+
+	return conn, nil
+}
+
+// OffsetToCursor returns the cursor of the item at an offset of a list.
+func OffsetToCursor(offset int) string {
+	return base64.StdEncoding.EncodeToString([]byte(cursorPrefix + strconv.Itoa(offset)))
+}
+
+// CursorToOffset returns the offset of the item a cursor created by
+// OffsetToCursor points to.
+func CursorToOffset(cursor string) (int, error) {
+	data, err := base64.StdEncoding.DecodeString(cursor)
+	if err == nil && strings.HasPrefix(string(data), cursorPrefix) {
+		if offset, err := strconv.Atoi(strings.TrimPrefix(string(data), cursorPrefix)); err == nil {
+			return offset, nil
+		}
+	}
+
+	return 0, fmt.Errorf("Invalid cursor: %q.", cursor)
+}
+
+// GetOffsetWithDefault returns the offset a cursor points to, or the default
+// offset if the cursor is empty or invalid.
+func GetOffsetWithDefault(cursor string, defaultOffset int) int {
+	if cursor == "" {
+		return defaultOffset
+	}
+
+	offset, err := CursorToOffset(cursor)
+	if err != nil {
+		return defaultOffset
+	}
+
+	return offset
+}
+
+// CursorForObjectInConnection returns the cursor of the first item of a
+// slice or an array equal to object, or an empty string if there is none or
+// data is not a slice.
+func CursorForObjectInConnection(data interface{}, object interface{}) string {
+	items, err := sliceOf(data)
+	if err != nil {
+		return ""
+	}
+
+	for idx := 0; idx < items.Len(); idx++ {
+		if reflect.DeepEqual(items.Index(idx).Interface(), object) {
+			return OffsetToCursor(idx)
+		}
+	}
+
+	return ""
+}
+
+// sliceOf returns the value of a slice or an array.
+func sliceOf(data interface{}) (reflect.Value, error) {
+	items := reflect.ValueOf(data)
+	if items.Kind() != reflect.Slice && items.Kind() != reflect.Array {
+		return reflect.Value{}, fmt.Errorf("Expected a slice or an array, but received: %T.", data)
+	}
+
+	return items, nil
+}
@@ -0,0 +1,251 @@
+package relay
+
+import (
+	"reflect"
+	"testing"
+)
+
+var letters = []string{"A", "B", "C", "D", "E"}
+
+func intPtr(n int) *int { return &n }
+
+// page describes a connection by its nodes and page info.
+type page struct {
+	nodes           []interface{}
+	startCursor     string
+	endCursor       string
+	hasPreviousPage bool
+	hasNextPage     bool
+}
+
+func pageOf(conn *Connection) page {
+	p := page{
+		startCursor:     conn.PageInfo.StartCursor,
+		endCursor:       conn.PageInfo.EndCursor,
+		hasPreviousPage: conn.PageInfo.HasPreviousPage,
+		hasNextPage:     conn.PageInfo.HasNextPage,
+	}
+	for _, edge := range conn.Edges {
+		p.nodes = append(p.nodes, edge.Node)
+	}
+
+	return p
+}
+
+func TestConnectionFromSlice(t *testing.T) {
+	tests := []struct {
+		name string
+		args ConnectionArguments
+		want page
+	}{
+		{
+			"all",
+			ConnectionArguments{},
+			page{[]interface{}{"A", "B", "C", "D", "E"}, OffsetToCursor(0), OffsetToCursor(4), false, false},
+		},
+		{
+			"first",
+			ConnectionArguments{First: intPtr(2)},
+			page{[]interface{}{"A", "B"}, OffsetToCursor(0), OffsetToCursor(1), false, true},
+		},
+		{
+			"first with more than enough",
+			ConnectionArguments{First: intPtr(10)},
+			page{[]interface{}{"A", "B", "C", "D", "E"}, OffsetToCursor(0), OffsetToCursor(4), false, false},
+		},
+		{
+			"last",
+			ConnectionArguments{Last: intPtr(2)},
+			page{[]interface{}{"D", "E"}, OffsetToCursor(3), OffsetToCursor(4), true, false},
+		},
+		{
+			"first after",
+			ConnectionArguments{First: intPtr(2), After: OffsetToCursor(1)},
+			page{[]interface{}{"C", "D"}, OffsetToCursor(2), OffsetToCursor(3), false, true},
+		},
+		{
+			"first after with more than enough",
+			ConnectionArguments{First: intPtr(10), After: OffsetToCursor(1)},
+			page{[]interface{}{"C", "D", "E"}, OffsetToCursor(2), OffsetToCursor(4), false, false},
+		},
+		{
+			"last before",
+			ConnectionArguments{Last: intPtr(2), Before: OffsetToCursor(3)},
+			page{[]interface{}{"B", "C"}, OffsetToCursor(1), OffsetToCursor(2), true, false},
+		},
+		{
+			"last before with more than enough",
+			ConnectionArguments{Last: intPtr(10), Before: OffsetToCursor(3)},
+			page{[]interface{}{"A", "B", "C"}, OffsetToCursor(0), OffsetToCursor(2), false, false},
+		},
+		{
+			"first and last",
+			ConnectionArguments{First: intPtr(4), Last: intPtr(2)},
+			page{[]interface{}{"C", "D"}, OffsetToCursor(2), OffsetToCursor(3), true, true},
+		},
+		{
+			"after and before",
+			ConnectionArguments{After: OffsetToCursor(0), Before: OffsetToCursor(4)},
+			page{[]interface{}{"B", "C", "D"}, OffsetToCursor(1), OffsetToCursor(3), false, false},
+		},
+		{
+			"invalid cursors",
+			ConnectionArguments{First: intPtr(2), After: "invalid", Before: "invalid"},
+			page{[]interface{}{"A", "B"}, OffsetToCursor(0), OffsetToCursor(1), false, true},
+		},
+		{
+			"cursors out of range",
+			ConnectionArguments{After: OffsetToCursor(-1), Before: OffsetToCursor(10)},
+			page{[]interface{}{"A", "B", "C", "D", "E"}, OffsetToCursor(0), OffsetToCursor(4), false, false},
+		},
+		{
+			"no items",
+			ConnectionArguments{First: intPtr(0)},
+			page{nil, "", "", false, true},
+		},
+		{
+			"before after",
+			ConnectionArguments{After: OffsetToCursor(3), Before: OffsetToCursor(1)},
+			page{nil, "", "", false, false},
+		},
+	}
+
+	for _, test := range tests {
+		conn, err := ConnectionFromSlice(letters, test.args)
+		if err != nil {
+			t.Errorf("%s; got error %v", test.name, err)
+			continue
+		}
+		if got := pageOf(conn); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s; got %+v wanted %+v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestConnectionFromArray(t *testing.T) {
+	conn, err := ConnectionFromSlice([3]int{1, 2, 3}, ConnectionArguments{Last: intPtr(1)})
+	if err != nil {
+		t.Fatalf("ConnectionFromSlice of an array; got error %v", err)
+	}
+
+	want := page{[]interface{}{3}, OffsetToCursor(2), OffsetToCursor(2), true, false}
+	if got := pageOf(conn); !reflect.DeepEqual(got, want) {
+		t.Errorf("ConnectionFromSlice of an array; got %+v wanted %+v", got, want)
+	}
+}
+
+func TestConnectionFromSliceErrors(t *testing.T) {
+	tests := []struct {
+		args ConnectionArguments
+		want string
+	}{
+		{ConnectionArguments{First: intPtr(-1)}, `Argument "first" must be a non-negative integer.`},
+		{ConnectionArguments{Last: intPtr(-1)}, `Argument "last" must be a non-negative integer.`},
+	}
+
+	for _, test := range tests {
+		_, err := ConnectionFromSlice(letters, test.args)
+		if err == nil || err.Error() != test.want {
+			t.Errorf("ConnectionFromSlice(%+v); got error %v wanted %s", test.args, err, test.want)
+		}
+	}
+
+	want := "Expected a slice or an array, but received: map[string]int."
+	if _, err := ConnectionFromSlice(map[string]int{}, ConnectionArguments{}); err == nil || err.Error() != want {
+		t.Errorf("ConnectionFromSlice of a map; got error %v wanted %s", err, want)
+	}
+}
+
+func TestConnectionFromSlicePartErrors(t *testing.T) {
+	tests := []struct {
+		data interface{}
+		info SliceInfo
+		want string
+	}{
+		{nil, SliceInfo{}, "Expected a slice or an array, but received: <nil>."},
+		{letters, SliceInfo{SliceStart: -1, ListLength: 5}, "The slice start must be a non-negative integer, but is -1."},
+		{letters, SliceInfo{SliceStart: 0, ListLength: -1}, "The list length must be a non-negative integer, but is -1."},
+	}
+
+	for _, test := range tests {
+		_, err := ConnectionFromSlicePart(test.data, ConnectionArguments{}, test.info)
+		if err == nil || err.Error() != test.want {
+			t.Errorf("ConnectionFromSlicePart(%v, %+v); got error %v wanted %s", test.data, test.info, err, test.want)
+		}
+	}
+}
+
+func TestConnectionFromSlicePart(t *testing.T) {
+	tests := []struct {
+		name  string
+		slice []string
+		args  ConnectionArguments
+		info  SliceInfo
+		want  page
+	}{
+		{
+			"exact slice",
+			[]string{"B", "C"},
+			ConnectionArguments{First: intPtr(2), After: OffsetToCursor(0)},
+			SliceInfo{SliceStart: 1, ListLength: 5},
+			page{[]interface{}{"B", "C"}, OffsetToCursor(1), OffsetToCursor(2), false, true},
+		},
+		{
+			"oversized slice",
+			[]string{"A", "B", "C"},
+			ConnectionArguments{First: intPtr(1), After: OffsetToCursor(0)},
+			SliceInfo{SliceStart: 0, ListLength: 5},
+			page{[]interface{}{"B"}, OffsetToCursor(1), OffsetToCursor(1), false, true},
+		},
+		{
+			"undersized slice",
+			[]string{"D", "E"},
+			ConnectionArguments{First: intPtr(3), After: OffsetToCursor(1)},
+			SliceInfo{SliceStart: 3, ListLength: 5},
+			page{[]interface{}{"D", "E"}, OffsetToCursor(3), OffsetToCursor(4), false, false},
+		},
+	}
+
+	for _, test := range tests {
+		conn, err := ConnectionFromSlicePart(test.slice, test.args, test.info)
+		if err != nil {
+			t.Errorf("%s; got error %v", test.name, err)
+			continue
+		}
+		if got := pageOf(conn); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s; got %+v wanted %+v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestCursors(t *testing.T) {
+	if got := OffsetToCursor(3); got != "YXJyYXljb25uZWN0aW9uOjM=" {
+		t.Errorf("OffsetToCursor; got %s wanted YXJyYXljb25uZWN0aW9uOjM=", got)
+	}
+	if offset, err := CursorToOffset(OffsetToCursor(3)); err != nil || offset != 3 {
+		t.Errorf("CursorToOffset; got %d, %v wanted 3", offset, err)
+	}
+	if _, err := CursorToOffset(ToGlobalID("Ship", "1")); err == nil {
+		t.Errorf("CursorToOffset of a global ID; got no error")
+	}
+
+	if got := GetOffsetWithDefault("", 7); got != 7 {
+		t.Errorf("GetOffsetWithDefault of no cursor; got %d wanted 7", got)
+	}
+	if got := GetOffsetWithDefault("invalid", 7); got != 7 {
+		t.Errorf("GetOffsetWithDefault of an invalid cursor; got %d wanted 7", got)
+	}
+
+	if got := CursorForObjectInConnection(letters, "C"); got != OffsetToCursor(2) {
+		t.Errorf("CursorForObjectInConnection; got %s wanted %s", got, OffsetToCursor(2))
+	}
+	if got := CursorForObjectInConnection(letters, "F"); got != "" {
+		t.Errorf("CursorForObjectInConnection of a missing object; got %s wanted an empty cursor", got)
+	}
+	if got := CursorForObjectInConnection([2]string{"A", "B"}, "B"); got != OffsetToCursor(1) {
+		t.Errorf("CursorForObjectInConnection of an array; got %s wanted %s", got, OffsetToCursor(1))
+	}
+	if got := CursorForObjectInConnection("ABC", "B"); got != "" {
+		t.Errorf("CursorForObjectInConnection of a string; got %s wanted an empty cursor", got)
+	}
+}
@@ -0,0 +1,31 @@
+package scalars
+
+import "github.com/ijsnow/goql/schema"
+
+// JSON is an arbitrary JSON value. Values are serialized as they are, so
+// they must be encodable by encoding/json. Input is parsed into the values
+// encoding/json decodes into an interface{}, except that literal integers
+// become ints.
+var JSON = schema.DefineScalar(
+	"JSON",
+	"An arbitrary JSON value.",
+	jsonScalar{},
+)
+
+type jsonScalar struct{}
+
+func (jsonScalar) Serialize(value interface{}) (interface{}, error) {
+	return value, nil
+}
+
+func (jsonScalar) ParseValue(value interface{}) (interface{}, error) {
+	return value, nil
+}
+
+func (jsonScalar) ParseLiteral(valueNode schema.ValueNode) (interface{}, error) {
+	if _, ok := valueNode.(*schema.VariableNode); ok {
+		return nil, schema.NewLiteralError("JSON", valueNode)
+	}
+
+	return schema.ValueFromASTUntyped(valueNode), nil
+}
@@ -0,0 +1,233 @@
+package scalars
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ijsnow/goql/schema"
+)
+
+// maxSafeInteger is the largest integer a float64 holds exactly, so the
+// largest accepted from a float64 decoded from JSON.
+const maxSafeInteger = 1<<53 - 1
+
+// BigInt is an integer of any size. It is serialized as a JSON number with
+// every digit kept, so clients must decode it without loss of precision.
+// It serializes *big.Int, Go integers and numeric strings, and parses input
+// from numbers and strings into a *big.Int.
+var BigInt = schema.DefineScalar(
+	"BigInt",
+	"An integer of arbitrary size.",
+	bigInt{},
+)
+
+// Decimal is an exact decimal number, such as an amount of money. It is
+// serialized as a string so that no precision is lost. It serializes
+// *big.Rat, Go numbers and numeric strings, and parses input from numbers and
+// strings into a *big.Rat.
+var Decimal = schema.DefineScalar(
+	"Decimal",
+	"An exact decimal number, as a string such as `\"19.99\"`.",
+	decimal{},
+)
+
+var (
+	integerPattern = regexp.MustCompile(`^[+-]?\d+$`)
+	decimalPattern = regexp.MustCompile(`^[+-]?(?:\d+(?:\.\d*)?|\.\d+)(?:[eE][+-]?\d+)?$`)
+)
+
+type bigInt struct{}
+
+func (bigInt) Serialize(value interface{}) (interface{}, error) {
+	if i, ok := bigIntOf(value); ok {
+		return json.Number(i.String()), nil
+	}
+
+	return nil, schema.NewScalarError("BigInt", value)
+}
+
+func (bigInt) ParseValue(value interface{}) (interface{}, error) {
+	if i, ok := bigIntOf(value); ok {
+		return i, nil
+	}
+
+	return nil, schema.NewScalarError("BigInt", value)
+}
+
+func (bigInt) ParseLiteral(valueNode schema.ValueNode) (interface{}, error) {
+	var raw string
+	switch node := valueNode.(type) {
+	case *schema.IntValueNode:
+		raw = node.Value
+	case *schema.StringValueNode:
+		raw = node.Value
+	}
+
+	if i, ok := parseBigInt(raw); ok {
+		return i, nil
+	}
+
+	return nil, schema.NewLiteralError("BigInt", valueNode)
+}
+
+func bigIntOf(value interface{}) (*big.Int, bool) {
+	switch v := indirect(value).(type) {
+	case big.Int:
+		return new(big.Int).Set(&v), true
+	case json.Number:
+		return parseBigInt(v.String())
+	}
+
+	v := reflect.ValueOf(indirect(value))
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewInt(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return new(big.Int).SetUint64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		if f := v.Float(); math.Trunc(f) == f && math.Abs(f) <= maxSafeInteger {
+			return big.NewInt(int64(f)), true
+		}
+	case reflect.String:
+		return parseBigInt(v.String())
+	}
+
+	return nil, false
+}
+
+func parseBigInt(str string) (*big.Int, bool) {
+	if !integerPattern.MatchString(str) {
+		return nil, false
+	}
+
+	return new(big.Int).SetString(str, 10)
+}
+
+type decimal struct{}
+
+func (decimal) Serialize(value interface{}) (interface{}, error) {
+	if r, ok := ratOf(value); ok {
+		if str, ok := decimalString(r); ok {
+			return str, nil
+		}
+	}
+
+	return nil, schema.NewScalarError("Decimal", value)
+}
+
+func (decimal) ParseValue(value interface{}) (interface{}, error) {
+	if r, ok := ratOf(value); ok {
+		return r, nil
+	}
+
+	return nil, schema.NewScalarError("Decimal", value)
+}
+
+func (decimal) ParseLiteral(valueNode schema.ValueNode) (interface{}, error) {
+	var raw string
+	switch node := valueNode.(type) {
+	case *schema.IntValueNode:
+		raw = node.Value
+	case *schema.FloatValueNode:
+		raw = node.Value
+	case *schema.StringValueNode:
+		raw = node.Value
+	}
+
+	if r, ok := parseDecimal(raw); ok {
+		return r, nil
+	}
+
+	return nil, schema.NewLiteralError("Decimal", valueNode)
+}
+
+func ratOf(value interface{}) (*big.Rat, bool) {
+	switch v := indirect(value).(type) {
+	case big.Rat:
+		return new(big.Rat).Set(&v), true
+	case big.Float:
+		if v.IsInf() {
+			return nil, false
+		}
+		r, _ := v.Rat(nil)
+		return r, true
+	case big.Int:
+		return new(big.Rat).SetInt(&v), true
+	case json.Number:
+		return parseDecimal(v.String())
+	}
+
+	v := reflect.ValueOf(indirect(value))
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return new(big.Rat).SetInt64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return new(big.Rat).SetInt(new(big.Int).SetUint64(v.Uint())), true
+	case reflect.Float32, reflect.Float64:
+		// Use the shortest decimal that identifies the float, so that 0.1
+		// is read as one tenth rather than its binary approximation.
+		f := v.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, false
+		}
+		bits := 64
+		if v.Kind() == reflect.Float32 {
+			bits = 32
+		}
+		return parseDecimal(strconv.FormatFloat(f, 'g', -1, bits))
+	case reflect.String:
+		return parseDecimal(v.String())
+	}
+
+	return nil, false
+}
+
+func parseDecimal(str string) (*big.Rat, bool) {
+	if !decimalPattern.MatchString(str) {
+		return nil, false
+	}
+
+	return new(big.Rat).SetString(str)
+}
+
+// decimalString writes a rational number in decimal notation, if it has a
+// finite decimal expansion.
+func decimalString(r *big.Rat) (string, bool) {
+	denom := new(big.Int).Set(r.Denom())
+
+	// A fraction in lowest terms has a finite decimal expansion when its
+	// denominator is of the form 2^a * 5^b, and needs max(a, b) digits.
+	digits := 0
+	for _, factor := range []int64{2, 5} {
+		count := 0
+		f := big.NewInt(factor)
+		mod := new(big.Int)
+		for {
+			q, m := new(big.Int).QuoRem(denom, f, mod)
+			if m.Sign() != 0 {
+				break
+			}
+			denom = q
+			count++
+		}
+		if count > digits {
+			digits = count
+		}
+	}
+
+	if denom.Cmp(big.NewInt(1)) != 0 {
+		return "", false
+	}
+
+	str := r.FloatString(digits)
+	if strings.Contains(str, ".") {
+		str = strings.TrimRight(strings.TrimRight(str, "0"), ".")
+	}
+
+	return str, true
+}
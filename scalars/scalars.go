@@ -0,0 +1,71 @@
+// Package scalars provides custom scalars commonly needed by schemas, beyond
+// the scalars built into the specification. Each scalar documents the Go
+// values it serializes and the internal value it parses input into.
+//
+// Add them to a schema like any other type:
+//
+//	Fields: schema.Fields{
+//		{Name: "createdAt", Type: scalars.DateTime},
+//	}
+package scalars
+
+import (
+	"reflect"
+
+	"github.com/ijsnow/goql/schema"
+)
+
+// indirect dereferences pointers until it reaches a value, returning nil for
+// a nil pointer.
+func indirect(value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	return v.Interface()
+}
+
+// stringOf returns the value as a string if it is one, or a pointer to one.
+func stringOf(value interface{}) (string, bool) {
+	v := reflect.ValueOf(indirect(value))
+	if v.Kind() != reflect.String {
+		return "", false
+	}
+
+	return v.String(), true
+}
+
+// stringScalar implements ParseValue and ParseLiteral for scalars that are
+// represented as strings, given the function parsing the string.
+type stringScalar struct {
+	name  string
+	parse func(string) (interface{}, bool)
+}
+
+func (s stringScalar) ParseValue(value interface{}) (interface{}, error) {
+	if str, ok := stringOf(value); ok {
+		if parsed, ok := s.parse(str); ok {
+			return parsed, nil
+		}
+	}
+
+	return nil, schema.NewScalarError(s.name, value)
+}
+
+func (s stringScalar) ParseLiteral(valueNode schema.ValueNode) (interface{}, error) {
+	if node, ok := valueNode.(*schema.StringValueNode); ok {
+		if parsed, ok := s.parse(node.Value); ok {
+			return parsed, nil
+		}
+	}
+
+	return nil, schema.NewLiteralError(s.name, valueNode)
+}
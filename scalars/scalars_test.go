@@ -0,0 +1,219 @@
+package scalars
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ijsnow/goql/schema"
+)
+
+// roundTrips checks that each serialized value is parsed from variables and
+// from a string literal into a value that serializes back to the same string.
+func roundTrips(t *testing.T, scalar *schema.Scalar, serialized ...string) {
+	for _, str := range serialized {
+		parsed, err := scalar.ParseValue(str)
+		if err != nil {
+			t.Errorf("%s.ParseValue(%q); got error %v", scalar, str, err)
+			continue
+		}
+
+		literal, err := scalar.ParseLiteral(&schema.StringValueNode{Value: str})
+		if err != nil {
+			t.Errorf("%s.ParseLiteral(%q); got error %v", scalar, str, err)
+			continue
+		}
+
+		for _, value := range []interface{}{parsed, literal} {
+			got, err := scalar.Serialize(value)
+			if err != nil || got != str {
+				t.Errorf("%s.Serialize(%v); got %#v, %v wanted %q", scalar, value, got, err, str)
+			}
+		}
+	}
+}
+
+// rejects checks that each value fails to parse from variables and to
+// serialize.
+func rejects(t *testing.T, scalar *schema.Scalar, values ...interface{}) {
+	for _, value := range values {
+		if got, err := scalar.ParseValue(value); err == nil {
+			t.Errorf("%s.ParseValue(%#v); got %v wanted an error", scalar, value, got)
+		}
+		if got, err := scalar.Serialize(value); err == nil {
+			t.Errorf("%s.Serialize(%#v); got %v wanted an error", scalar, value, got)
+		}
+	}
+}
+
+func TestDateTime(t *testing.T) {
+	roundTrips(t, DateTime,
+		"2017-07-04T15:30:00Z",
+		"2017-07-04T15:30:00.123456789+02:00",
+		"1999-12-31T23:59:59-08:00",
+	)
+	rejects(t, DateTime, "2017-07-04", "2017-07-04T15:30:00", "yesterday", 1499182200, true)
+
+	moment := time.Date(2017, 7, 4, 15, 30, 0, 0, time.UTC)
+	if got, err := DateTime.Serialize(&moment); err != nil || got != "2017-07-04T15:30:00Z" {
+		t.Errorf("DateTime.Serialize(*time.Time); got %v, %v", got, err)
+	}
+	if got, err := DateTime.ParseValue("2017-07-04T15:30:00Z"); err != nil || !got.(time.Time).Equal(moment) {
+		t.Errorf("DateTime.ParseValue; got %v, %v wanted %v", got, err, moment)
+	}
+}
+
+func TestDate(t *testing.T) {
+	roundTrips(t, Date, "2017-07-04", "2000-02-29")
+	rejects(t, Date, "2017-02-29", "2017-7-4", "2017-07-04T00:00:00Z", 20170704)
+}
+
+func TestTime(t *testing.T) {
+	roundTrips(t, Time, "15:30:00Z", "08:00:00.5-05:00", "23:59:59.999999999+14:00")
+	rejects(t, Time, "15:30", "25:00:00Z", "15:30:00", 1530)
+}
+
+func TestDuration(t *testing.T) {
+	roundTrips(t, Duration, "PT0S", "PT1H30M", "PT90H", "PT0.5S", "-PT1M2.000000003S")
+	rejects(t, Duration, "P", "PT", "P1Y", "P1M", "1h30m", "PT1.0000000001S", 90, "P99999999999999999999W")
+
+	tests := []struct {
+		input string
+		want  time.Duration
+	}{
+		{"P1W", 7 * 24 * time.Hour},
+		{"P1DT12H", 36 * time.Hour},
+		{"PT1,5S", 1500 * time.Millisecond},
+	}
+	for _, test := range tests {
+		if got, err := Duration.ParseValue(test.input); err != nil || got != test.want {
+			t.Errorf("Duration.ParseValue(%q); got %v, %v wanted %v", test.input, got, err, test.want)
+		}
+	}
+
+	if got, err := Duration.Serialize(36 * time.Hour); err != nil || got != "PT36H" {
+		t.Errorf("Duration.Serialize(36h); got %v, %v wanted PT36H", got, err)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	literal := &schema.ObjectValueNode{Fields: []schema.ObjectFieldNode{
+		{Name: schema.NameNode{Value: "a"}, Value: &schema.IntValueNode{Value: "1"}},
+		{Name: schema.NameNode{Value: "b"}, Value: &schema.ListValueNode{Values: []schema.ValueNode{
+			&schema.StringValueNode{Value: "x"},
+			&schema.BooleanValueNode{Value: true},
+			&schema.NullValueNode{},
+		}}},
+	}}
+
+	got, err := JSON.ParseLiteral(literal)
+	want := map[string]interface{}{"a": 1, "b": []interface{}{"x", true, nil}}
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("JSON.ParseLiteral; got %#v, %v wanted %#v", got, err, want)
+	}
+
+	var decoded interface{}
+	json.Unmarshal([]byte(`{"a":[1,"x",null]}`), &decoded)
+	parsed, _ := JSON.ParseValue(decoded)
+	serialized, _ := JSON.Serialize(parsed)
+	if out, _ := json.Marshal(serialized); string(out) != `{"a":[1,"x",null]}` {
+		t.Errorf("JSON round trip; got %s", out)
+	}
+}
+
+func TestBigInt(t *testing.T) {
+	const huge = "123456789012345678901234567890"
+
+	for _, input := range []interface{}{huge, json.Number(huge)} {
+		parsed, err := BigInt.ParseValue(input)
+		if err != nil {
+			t.Fatalf("BigInt.ParseValue(%v); got error %v", input, err)
+		}
+
+		got, err := BigInt.Serialize(parsed)
+		if err != nil || got != json.Number(huge) {
+			t.Errorf("BigInt.Serialize; got %#v, %v wanted %s", got, err, huge)
+		}
+	}
+
+	literal, err := BigInt.ParseLiteral(&schema.IntValueNode{Value: "-" + huge})
+	if err != nil || literal.(*big.Int).String() != "-"+huge {
+		t.Errorf("BigInt.ParseLiteral; got %v, %v", literal, err)
+	}
+
+	if got, err := BigInt.Serialize(int64(42)); err != nil || got != json.Number("42") {
+		t.Errorf("BigInt.Serialize(42); got %#v, %v", got, err)
+	}
+
+	rejects(t, BigInt, "1.5", 1.5, 1e300, "", "0x10", true)
+}
+
+func TestDecimal(t *testing.T) {
+	roundTrips(t, Decimal, "19.99", "0", "-0.001", "123456789012345678901234567890.123456789")
+	rejects(t, Decimal, "1/3", "abc", "Inf", "", true)
+
+	tests := []struct {
+		input interface{}
+		want  string
+	}{
+		{0.1, "0.1"},
+		{float32(0.1), "0.1"},
+		{json.Number("1e3"), "1000"},
+		{"2.50", "2.5"},
+		{7, "7"},
+	}
+	for _, test := range tests {
+		parsed, err := Decimal.ParseValue(test.input)
+		if err != nil {
+			t.Errorf("Decimal.ParseValue(%#v); got error %v", test.input, err)
+			continue
+		}
+		if got, err := Decimal.Serialize(parsed); err != nil || got != test.want {
+			t.Errorf("Decimal.Serialize(%#v); got %v, %v wanted %s", test.input, got, err, test.want)
+		}
+	}
+
+	if got, err := Decimal.Serialize(big.NewRat(1, 3)); err == nil {
+		t.Errorf("Decimal.Serialize(1/3); got %v wanted an error", got)
+	}
+}
+
+func TestUUID(t *testing.T) {
+	roundTrips(t, UUID, "123e4567-e89b-12d3-a456-426614174000")
+	rejects(t, UUID, "123e4567e89b12d3a456426614174000", "not-a-uuid", 42)
+
+	if got, _ := UUID.ParseValue("123E4567-E89B-12D3-A456-426614174000"); got != "123e4567-e89b-12d3-a456-426614174000" {
+		t.Errorf("UUID.ParseValue; got %v wanted lowercase", got)
+	}
+
+	type libraryUUID [16]byte
+	id := libraryUUID{0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3, 0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00}
+	if got, err := UUID.Serialize(id); err != nil || got != "123e4567-e89b-12d3-a456-426614174000" {
+		t.Errorf("UUID.Serialize([16]byte); got %v, %v", got, err)
+	}
+}
+
+func TestURL(t *testing.T) {
+	roundTrips(t, URL, "https://example.com/", "https://example.com/a?b=c#d", "mailto:someone@example.com")
+	rejects(t, URL, "/relative/path", "example.com", "%zz", 42)
+
+	parsed, _ := URL.ParseValue("https://example.com/path")
+	if u, ok := parsed.(*url.URL); !ok || u.Host != "example.com" {
+		t.Errorf("URL.ParseValue; got %#v wanted a *url.URL", parsed)
+	}
+}
+
+func TestEmail(t *testing.T) {
+	roundTrips(t, Email, "someone@example.com", "first.last+tag@sub.example.org")
+	rejects(t, Email, "someone", "Someone <someone@example.com>", "@example.com", 42)
+}
+
+func TestReportsLocatedErrorsForLiterals(t *testing.T) {
+	_, err := Date.ParseLiteral(&schema.IntValueNode{Value: "20170704"})
+	if err == nil || err.Error() != "Date cannot represent value: 20170704" {
+		t.Errorf("Date.ParseLiteral(20170704); got %v", err)
+	}
+}
@@ -0,0 +1,142 @@
+package scalars
+
+import (
+	"encoding/hex"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/ijsnow/goql/schema"
+)
+
+// UUID is a universally unique identifier, written in the canonical
+// hyphenated form such as "123e4567-e89b-12d3-a456-426614174000". It
+// serializes strings and 16-byte arrays, such as the UUID types of common
+// libraries, and parses input into a lowercase string.
+var UUID = schema.DefineScalar(
+	"UUID",
+	"A universally unique identifier, as a string such as "+
+		"`123e4567-e89b-12d3-a456-426614174000`.",
+	uuid{stringScalar{"UUID", func(str string) (interface{}, bool) {
+		return parseUUID(str)
+	}}},
+)
+
+// URL is an absolute URL, such as "https://example.com/". It serializes
+// *url.URL and strings, and parses input into a *url.URL.
+var URL = schema.DefineScalar(
+	"URL",
+	"An absolute URL, as a string such as `https://example.com/`.",
+	urlScalar{stringScalar{"URL", func(str string) (interface{}, bool) {
+		return parseURL(str)
+	}}},
+)
+
+// Email is an email address, such as "someone@example.com", without a
+// display name. It serializes strings and parses input into a string.
+var Email = schema.DefineScalar(
+	"Email",
+	"An email address, as a string such as `someone@example.com`.",
+	email{stringScalar{"Email", func(str string) (interface{}, bool) {
+		return parseEmail(str)
+	}}},
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+type uuid struct {
+	stringScalar
+}
+
+func (u uuid) Serialize(value interface{}) (interface{}, error) {
+	if str, ok := stringOf(value); ok {
+		if parsed, ok := parseUUID(str); ok {
+			return parsed, nil
+		}
+	} else if v := reflect.ValueOf(indirect(value)); v.Kind() == reflect.Array &&
+		v.Len() == 16 && v.Type().Elem().Kind() == reflect.Uint8 {
+		b := make([]byte, 16)
+		for idx := range b {
+			b[idx] = byte(v.Index(idx).Uint())
+		}
+		return formatUUID(b), nil
+	}
+
+	return nil, schema.NewScalarError(u.name, value)
+}
+
+func parseUUID(str string) (string, bool) {
+	if !uuidPattern.MatchString(str) {
+		return "", false
+	}
+
+	return strings.ToLower(str), true
+}
+
+func formatUUID(b []byte) string {
+	str := hex.EncodeToString(b)
+	return str[0:8] + "-" + str[8:12] + "-" + str[12:16] + "-" + str[16:20] + "-" + str[20:]
+}
+
+type urlScalar struct {
+	stringScalar
+}
+
+func (u urlScalar) Serialize(value interface{}) (interface{}, error) {
+	switch v := indirect(value).(type) {
+	case url.URL:
+		if v.IsAbs() {
+			return v.String(), nil
+		}
+	case string:
+		if parsed, ok := parseURL(v); ok {
+			return parsed.String(), nil
+		}
+	}
+
+	return nil, schema.NewScalarError(u.name, value)
+}
+
+// ParseValue also accepts a *url.URL, for values given programmatically
+// rather than decoded from JSON.
+func (u urlScalar) ParseValue(value interface{}) (interface{}, error) {
+	if v, ok := indirect(value).(url.URL); ok && v.IsAbs() {
+		return &v, nil
+	}
+
+	return u.stringScalar.ParseValue(value)
+}
+
+func parseURL(str string) (*url.URL, bool) {
+	parsed, err := url.Parse(str)
+	if err != nil || !parsed.IsAbs() {
+		return nil, false
+	}
+
+	return parsed, true
+}
+
+type email struct {
+	stringScalar
+}
+
+func (e email) Serialize(value interface{}) (interface{}, error) {
+	if str, ok := stringOf(value); ok {
+		if parsed, ok := parseEmail(str); ok {
+			return parsed, nil
+		}
+	}
+
+	return nil, schema.NewScalarError(e.name, value)
+}
+
+func parseEmail(str string) (string, bool) {
+	address, err := mail.ParseAddress(str)
+	if err != nil || address.Name != "" || address.Address != str {
+		return "", false
+	}
+
+	return str, true
+}
@@ -0,0 +1,226 @@
+package scalars
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ijsnow/goql/schema"
+)
+
+// Layouts of the RFC 3339 full-date and full-time. Fractional seconds are
+// accepted when parsing with any layout.
+const (
+	dateLayout     = "2006-01-02"
+	timeLayout     = "15:04:05Z07:00"
+	timeNanoLayout = "15:04:05.999999999Z07:00"
+)
+
+// DateTime is a date and time with an offset, written as an RFC 3339
+// date-time string such as "2017-07-04T15:30:00Z". It serializes time.Time
+// and RFC 3339 strings, and parses input into a time.Time.
+var DateTime = schema.DefineScalar(
+	"DateTime",
+	"A date and time with an offset from UTC, as an RFC 3339 date-time string "+
+		"such as `2017-07-04T15:30:00Z`.",
+	timeScalar{stringScalar{"DateTime", parseLayout(time.RFC3339)}, time.RFC3339Nano},
+)
+
+// Date is a calendar date, written as an RFC 3339 full-date string such as
+// "2017-07-04". It serializes time.Time and date strings, and parses input
+// into a time.Time at midnight UTC.
+var Date = schema.DefineScalar(
+	"Date",
+	"A calendar date, as an RFC 3339 full-date string such as `2017-07-04`.",
+	timeScalar{stringScalar{"Date", parseLayout(dateLayout)}, dateLayout},
+)
+
+// Time is a time of day with an offset, written as an RFC 3339 full-time
+// string such as "15:30:00Z". It serializes time.Time and time strings, and
+// parses input into a time.Time on January 1 of year 0.
+var Time = schema.DefineScalar(
+	"Time",
+	"A time of day with an offset from UTC, as an RFC 3339 full-time string "+
+		"such as `15:30:00Z`.",
+	timeScalar{stringScalar{"Time", parseLayout(timeLayout)}, timeNanoLayout},
+)
+
+// Duration is a length of time, written as an ISO 8601 duration string such
+// as "PT1H30M". Days are 24 hours and weeks 7 days; years and months are
+// rejected since their length varies. It serializes time.Duration and
+// duration strings, and parses input into a time.Duration.
+var Duration = schema.DefineScalar(
+	"Duration",
+	"A length of time, as an ISO 8601 duration string such as `PT1H30M`.",
+	duration{stringScalar{"Duration", func(str string) (interface{}, bool) {
+		return parseDuration(str)
+	}}},
+)
+
+func parseLayout(layout string) func(string) (interface{}, bool) {
+	return func(str string) (interface{}, bool) {
+		t, err := time.Parse(layout, str)
+		if err != nil {
+			return nil, false
+		}
+
+		return t, true
+	}
+}
+
+// timeScalar is a scalar represented by a time.Time formatted with a layout.
+type timeScalar struct {
+	stringScalar
+	layout string
+}
+
+func (s timeScalar) Serialize(value interface{}) (interface{}, error) {
+	switch v := indirect(value).(type) {
+	case time.Time:
+		return v.Format(s.layout), nil
+	case string:
+		if parsed, ok := s.parse(v); ok {
+			return parsed.(time.Time).Format(s.layout), nil
+		}
+	}
+
+	return nil, schema.NewScalarError(s.name, value)
+}
+
+// ParseValue also accepts a time.Time, for values given programmatically
+// rather than decoded from JSON.
+func (s timeScalar) ParseValue(value interface{}) (interface{}, error) {
+	if t, ok := indirect(value).(time.Time); ok {
+		return t, nil
+	}
+
+	return s.stringScalar.ParseValue(value)
+}
+
+type duration struct {
+	stringScalar
+}
+
+func (d duration) Serialize(value interface{}) (interface{}, error) {
+	switch v := indirect(value).(type) {
+	case time.Duration:
+		return formatDuration(v), nil
+	case string:
+		if parsed, ok := parseDuration(v); ok {
+			return formatDuration(parsed), nil
+		}
+	}
+
+	return nil, schema.NewScalarError(d.name, value)
+}
+
+// ParseValue also accepts a time.Duration, for values given
+// programmatically rather than decoded from JSON.
+func (d duration) ParseValue(value interface{}) (interface{}, error) {
+	if v, ok := indirect(value).(time.Duration); ok {
+		return v, nil
+	}
+
+	return d.stringScalar.ParseValue(value)
+}
+
+var durationPattern = regexp.MustCompile(
+	`^(-)?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)(?:[.,](\d{1,9}))?S)?)?$`,
+)
+
+// parseDuration parses an ISO 8601 duration made of weeks, days, hours,
+// minutes and seconds.
+func parseDuration(str string) (time.Duration, bool) {
+	match := durationPattern.FindStringSubmatch(str)
+	if match == nil || strings.HasSuffix(str, "P") || strings.HasSuffix(str, "T") {
+		return 0, false
+	}
+
+	units := []struct {
+		digits string
+		unit   time.Duration
+	}{
+		{match[2], 7 * 24 * time.Hour},
+		{match[3], 24 * time.Hour},
+		{match[4], time.Hour},
+		{match[5], time.Minute},
+		{match[6], time.Second},
+	}
+
+	var total time.Duration
+	for _, u := range units {
+		if u.digits == "" {
+			continue
+		}
+
+		n, err := strconv.ParseInt(u.digits, 10, 64)
+		if err != nil || n > int64(math.MaxInt64/u.unit) {
+			return 0, false
+		}
+
+		part := time.Duration(n) * u.unit
+		if total > math.MaxInt64-part {
+			return 0, false
+		}
+		total += part
+	}
+
+	if fraction := match[7]; fraction != "" {
+		nanos, _ := strconv.ParseInt(fraction+strings.Repeat("0", 9-len(fraction)), 10, 64)
+		if total > math.MaxInt64-time.Duration(nanos) {
+			return 0, false
+		}
+		total += time.Duration(nanos)
+	}
+
+	if match[1] == "-" {
+		total = -total
+	}
+
+	return total, true
+}
+
+// formatDuration formats a duration as an ISO 8601 duration in hours,
+// minutes and seconds.
+func formatDuration(d time.Duration) string {
+	if d == 0 {
+		return "PT0S"
+	}
+
+	var b strings.Builder
+
+	// Work with the magnitude as unsigned so the minimum duration does not
+	// overflow when negated.
+	magnitude := uint64(d)
+	if d < 0 {
+		b.WriteString("-")
+		magnitude = uint64(-(d + 1)) + 1
+	}
+	b.WriteString("PT")
+
+	hours := magnitude / uint64(time.Hour)
+	magnitude -= hours * uint64(time.Hour)
+	minutes := magnitude / uint64(time.Minute)
+	magnitude -= minutes * uint64(time.Minute)
+	seconds := magnitude / uint64(time.Second)
+	nanos := magnitude - seconds*uint64(time.Second)
+
+	if hours > 0 {
+		b.WriteString(strconv.FormatUint(hours, 10) + "H")
+	}
+	if minutes > 0 {
+		b.WriteString(strconv.FormatUint(minutes, 10) + "M")
+	}
+	if seconds > 0 || nanos > 0 {
+		b.WriteString(strconv.FormatUint(seconds, 10))
+		if nanos > 0 {
+			fraction := strconv.FormatUint(nanos+uint64(time.Second), 10)[1:]
+			b.WriteString("." + strings.TrimRight(fraction, "0"))
+		}
+		b.WriteString("S")
+	}
+
+	return b.String()
+}
@@ -0,0 +1,55 @@
+package schema
+
+import (
+	"github.com/ijsnow/goql/internal/language"
+	"github.com/ijsnow/goql/internal/query"
+)
+
+// The nodes of literal values, as received by ParseLiteral. They are aliases
+// of the nodes produced by the parser.
+type (
+	ValueNode        = language.ValueNode
+	VariableNode     = language.VariableNode
+	IntValueNode     = language.IntValueNode
+	FloatValueNode   = language.FloatValueNode
+	StringValueNode  = language.StringValueNode
+	BooleanValueNode = language.BooleanValueNode
+	NullValueNode    = language.NullValueNode
+	EnumValueNode    = language.EnumValueNode
+	ListValueNode    = language.ListValueNode
+	ObjectValueNode  = language.ObjectValueNode
+	ObjectFieldNode  = language.ObjectFieldNode
+	NameNode         = language.NameNode
+)
+
+// PrintValue prints a literal the way it is written in a document.
+func PrintValue(valueNode ValueNode) string {
+	return language.Print(valueNode)
+}
+
+// The nodes of documents, as produced by the parser. Types built from a
+// document keep the nodes that define them as their AstNode.
+type (
+	DocumentNode                  = language.DocumentNode
+	SchemaDefinitionNode          = language.SchemaDefinitionNode
+	ScalarTypeDefinitionNode      = language.ScalarTypeDefinitionNode
+	ObjectTypeDefinitionNode      = language.ObjectTypeDefinitionNode
+	FieldDefinitionNode           = language.FieldDefinitionNode
+	InputValueDefinitionNode      = language.InputValueDefinitionNode
+	InterfaceTypeDefinitionNode   = language.InterfaceTypeDefinitionNode
+	UnionTypeDefinitionNode       = language.UnionTypeDefinitionNode
+	EnumTypeDefinitionNode        = language.EnumTypeDefinitionNode
+	EnumValueDefinitionNode       = language.EnumValueDefinitionNode
+	InputObjectTypeDefinitionNode = language.InputObjectTypeDefinitionNode
+	TypeExtensionDefinitionNode   = language.TypeExtensionDefinitionNode
+	DirectiveDefinitionNode       = language.DirectiveDefinitionNode
+)
+
+// SourceLocation is a line and column within a document, counted from 1.
+type SourceLocation = language.SourceLocation
+
+// Parse parses a document, such as a schema written in the schema
+// definition language. Its errors are syntax errors located in the source.
+func Parse(source string) (*DocumentNode, error) {
+	return query.Parse(language.NewSource(source))
+}
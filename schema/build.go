@@ -0,0 +1,688 @@
+package schema
+
+import (
+	"strings"
+
+	"github.com/ijsnow/goql/internal/errors"
+	"github.com/ijsnow/goql/internal/language"
+)
+
+// BuildOptions configuration for BuildFromAST and BuildFromSDL
+type BuildOptions struct {
+	/**
+	 * CommentDescriptions reads the description of a definition without a
+	 * description string from the block of `#` comments preceding it, as
+	 * earlier versions of the SDL did.
+	 */
+	CommentDescriptions bool
+}
+
+// BuildFromSDL parses a document written in the schema definition language
+// and builds a Schema from it. See BuildFromAST.
+//
+//	s, err := schema.BuildFromSDL(`
+//		type Query {
+//			"The greeting of the day."
+//			hello: String
+//		}
+//	`)
+func BuildFromSDL(sdl string, options ...BuildOptions) (*Schema, error) {
+	document, err := Parse(sdl)
+	if err != nil {
+		return nil, err
+	}
+
+	return BuildFromAST(document, options...)
+}
+
+// BuildFromAST builds a Schema from the type definitions of a parsed
+// document. Its root types are given by the schema definition of the
+// document, or else are the types named Query, Mutation and Subscription.
+// The fields and interfaces of `extend type` definitions are added to the
+// Object types they extend, as ExtendSchema does for an existing schema.
+//
+// Every type, field, argument, enum value and directive keeps the node it
+// was defined by as its AstNode, so that the errors found when validating
+// the schema point at the document. Fields have no resolvers, so values are
+// resolved with the default resolver, and abstract types have no ResolveType
+// function.
+func BuildFromAST(document *DocumentNode, options ...BuildOptions) (*Schema, error) {
+	b := newASTBuilder(nil, options)
+
+	var schemaDef *language.SchemaDefinitionNode
+	var typeDefs []language.ASTNode
+	var directiveDefs []*language.DirectiveDefinitionNode
+
+	for _, definition := range document.Definitions {
+		switch definition := definition.(type) {
+		case *language.SchemaDefinitionNode:
+			if schemaDef != nil {
+				return nil, errors.NewValidationError(errors.Message{ID: errors.MsgDuplicateSchemaDefinition}, schemaDef, definition)
+			}
+			schemaDef = definition
+		case *language.ScalarTypeDefinitionNode,
+			*language.ObjectTypeDefinitionNode,
+			*language.InterfaceTypeDefinitionNode,
+			*language.UnionTypeDefinitionNode,
+			*language.EnumTypeDefinitionNode,
+			*language.InputObjectTypeDefinitionNode:
+			node := definition.(language.ASTNode)
+			typeName := typeDefinitionName(node)
+			if existing, ok := b.nodeMap[typeName]; ok {
+				return nil, errors.NewValidationError(errors.Message{
+					ID:   errors.MsgDuplicateTypeDefinition,
+					Args: map[string]interface{}{"type": typeName},
+				}, existing, node)
+			}
+			typeDefs = append(typeDefs, node)
+			b.nodeMap[typeName] = node
+		case *language.TypeExtensionDefinitionNode:
+			typeName := definition.Definition.Name.Value
+			b.extensions[typeName] = append(b.extensions[typeName], definition)
+		case *language.DirectiveDefinitionNode:
+			directiveDefs = append(directiveDefs, definition)
+		}
+	}
+
+	// Extensions apply to the Object types defined in the same document.
+	for _, extension := range document.Definitions {
+		extension, ok := extension.(*language.TypeExtensionDefinitionNode)
+		if !ok {
+			continue
+		}
+
+		typeName := extension.Definition.Name.Value
+		def, ok := b.nodeMap[typeName]
+		if !ok {
+			return nil, errors.NewValidationError(errors.Message{
+				ID:   errors.MsgUnknownTypeInDocument,
+				Args: map[string]interface{}{"type": typeName},
+			}, &extension.Definition)
+		}
+		if _, ok := def.(*language.ObjectTypeDefinitionNode); !ok {
+			return nil, errors.NewValidationError(errors.Message{
+				ID:   errors.MsgExtendNonObjectType,
+				Args: map[string]interface{}{"type": typeName},
+			}, &extension.Definition)
+		}
+	}
+
+	operationTypes := map[language.OperationTypeNode]*language.NamedTypeNode{}
+	if schemaDef != nil {
+		for idx := range schemaDef.OperationTypes {
+			operationType := &schemaDef.OperationTypes[idx]
+			if _, ok := operationTypes[operationType.Operation]; ok {
+				return nil, errors.NewValidationError(errors.Message{
+					ID:   errors.MsgDuplicateOperationType,
+					Args: map[string]interface{}{"operation": string(operationType.Operation)},
+				}, operationType)
+			}
+			if _, ok := b.nodeMap[operationType.Type.Name.Value]; !ok {
+				return nil, errors.NewValidationError(errors.Message{
+					ID: errors.MsgUnknownOperationType,
+					Args: map[string]interface{}{
+						"operation": string(operationType.Operation),
+						"type":      operationType.Type.Name.Value,
+					},
+				}, &operationType.Type)
+			}
+			operationTypes[operationType.Operation] = &operationType.Type
+		}
+	} else {
+		for operation, typeName := range map[language.OperationTypeNode]string{
+			language.OperationTypeQuery:        "Query",
+			language.OperationTypeMutation:     "Mutation",
+			language.OperationTypeSubscription: "Subscription",
+		} {
+			if _, ok := b.nodeMap[typeName]; ok {
+				operationTypes[operation] = &language.NamedTypeNode{Name: language.NameNode{Value: typeName}}
+			}
+		}
+	}
+
+	if operationTypes[language.OperationTypeQuery] == nil {
+		var nodes []language.ASTNode
+		if schemaDef != nil {
+			nodes = append(nodes, schemaDef)
+		}
+		return nil, errors.NewValidationError(errors.Message{ID: errors.MsgQueryTypeNotDefined}, nodes...)
+	}
+
+	config := SchemaConfig{AstNode: schemaDef}
+
+	for _, typeDef := range typeDefs {
+		t, err := b.typeDefNamed(typeDefinitionName(typeDef), typeDef)
+		if err != nil {
+			return nil, err
+		}
+		config.Types = append(config.Types, t)
+	}
+
+	var err error
+	if config.Query, err = b.getObjectType(language.OperationTypeQuery, operationTypes); err != nil {
+		return nil, err
+	}
+	if config.Mutation, err = b.getObjectType(language.OperationTypeMutation, operationTypes); err != nil {
+		return nil, err
+	}
+	if config.Subscription, err = b.getObjectType(language.OperationTypeSubscription, operationTypes); err != nil {
+		return nil, err
+	}
+
+	for _, directiveDef := range directiveDefs {
+		directive, err := b.getDirective(directiveDef)
+		if err != nil {
+			return nil, err
+		}
+		config.Directives = append(config.Directives, directive)
+	}
+
+	schema, err := NewSchema(config)
+
+	// Fields, interfaces and union members are built when the schema
+	// collects its types, so errors in them are only known now.
+	if b.err != nil {
+		return nil, b.err
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return schema, nil
+}
+
+// astBuilder builds the types defined in a document. The fields of types
+// are built lazily, so that types may refer to each other, and the first
+// error found while building them is kept in err.
+//
+// When extending a schema, the types of the existing schema are copied as
+// they are referred to, with the extensions of the document applied.
+type astBuilder struct {
+	options      BuildOptions
+	schema       *Schema
+	nodeMap      map[string]language.ASTNode
+	extensions   map[string][]*language.TypeExtensionDefinitionNode
+	typeDefCache map[string]NamedType
+	err          error
+}
+
+func newASTBuilder(schema *Schema, options []BuildOptions) *astBuilder {
+	b := &astBuilder{
+		schema:       schema,
+		nodeMap:      map[string]language.ASTNode{},
+		extensions:   map[string][]*language.TypeExtensionDefinitionNode{},
+		typeDefCache: map[string]NamedType{},
+	}
+	if len(options) > 0 {
+		b.options = options[0]
+	}
+
+	return b
+}
+
+func (b *astBuilder) fail(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+func (b *astBuilder) getObjectType(operation language.OperationTypeNode, operationTypes map[language.OperationTypeNode]*language.NamedTypeNode) (*Object, error) {
+	typeNode := operationTypes[operation]
+	if typeNode == nil {
+		return nil, nil
+	}
+
+	t, err := b.typeDefNamed(typeNode.Name.Value, typeNode)
+	if err != nil {
+		return nil, err
+	}
+
+	object, ok := t.(*Object)
+	if !ok {
+		return nil, errors.NewValidationError(errors.Message{
+			ID:   errors.MsgNonObjectOperationType,
+			Args: map[string]interface{}{"operation": string(operation), "type": t.GetName()},
+		}, astNodes(typeNode)...)
+	}
+
+	return object, nil
+}
+
+// typeDefNamed returns the type of the given name, building it the first
+// time. node is the reference to the type, which locates the error of an
+// unknown type.
+func (b *astBuilder) typeDefNamed(typeName string, node language.ASTNode) (NamedType, error) {
+	if t, ok := b.typeDefCache[typeName]; ok {
+		return t, nil
+	}
+
+	// The specified scalars and the introspection types may be used without
+	// being defined.
+	for _, t := range SpecifiedScalarTypes {
+		if t.Name == typeName {
+			return t, nil
+		}
+	}
+	for _, t := range IntrospectionTypes {
+		if t.GetName() == typeName {
+			return t, nil
+		}
+	}
+
+	if b.schema != nil {
+		if existing := b.schema.GetType(typeName); existing != nil {
+			t := b.extendType(existing)
+			b.typeDefCache[typeName] = t
+			return t, nil
+		}
+	}
+
+	def, ok := b.nodeMap[typeName]
+	if !ok {
+		id := errors.MsgUnknownTypeInDocument
+		if b.schema != nil {
+			id = errors.MsgUnknownTypeInExtension
+		}
+		return nil, errors.NewValidationError(errors.Message{
+			ID:   id,
+			Args: map[string]interface{}{"type": typeName},
+		}, astNodes(node)...)
+	}
+
+	t, err := b.makeSchemaDef(def)
+	if err != nil {
+		return nil, err
+	}
+	b.typeDefCache[typeName] = t
+
+	return t, nil
+}
+
+// produceType returns the type a type reference refers to.
+func (b *astBuilder) produceType(typeNode language.TypeNode) (Type, error) {
+	switch typeNode := typeNode.(type) {
+	case *language.ListTypeNode:
+		itemType, err := b.produceType(typeNode.Type)
+		if err != nil {
+			return nil, err
+		}
+		return NewList(itemType), nil
+	case *language.NonNullTypeNode:
+		nullableType, err := b.produceType(typeNode.Type)
+		if err != nil {
+			return nil, err
+		}
+		return NewNonNull(nullableType), nil
+	case *language.NamedTypeNode:
+		return b.typeDefNamed(typeNode.Name.Value, typeNode)
+	}
+
+	return nil, nil
+}
+
+func (b *astBuilder) makeSchemaDef(def language.ASTNode) (NamedType, error) {
+	switch def := def.(type) {
+	case *language.ObjectTypeDefinitionNode:
+		return b.makeTypeDef(def), nil
+	case *language.InterfaceTypeDefinitionNode:
+		return b.makeInterfaceDef(def), nil
+	case *language.EnumTypeDefinitionNode:
+		return b.makeEnumDef(def)
+	case *language.UnionTypeDefinitionNode:
+		return b.makeUnionDef(def), nil
+	case *language.ScalarTypeDefinitionNode:
+		return b.makeScalarDef(def)
+	case *language.InputObjectTypeDefinitionNode:
+		return b.makeInputObjectDef(def), nil
+	}
+
+	return nil, nil
+}
+
+func (b *astBuilder) makeTypeDef(def *language.ObjectTypeDefinitionNode) *Object {
+	return NewObject(ObjectConfig{
+		Name:        def.Name.Value,
+		Description: b.description(def.Description, def),
+		Fields: FieldsFunc(func() Fields {
+			return b.extendFields(def.Name.Value, b.makeFieldDefMap(def.Fields))
+		}),
+		Interfaces: InterfacesFunc(func() Interfaces {
+			return b.extendInterfaces(def.Name.Value, b.makeImplementedInterfaces(def))
+		}),
+		AstNode:           def,
+		ExtensionASTNodes: b.extensions[def.Name.Value],
+	})
+}
+
+func (b *astBuilder) makeFieldDefMap(defs []language.FieldDefinitionNode) Fields {
+	fields := make(Fields, 0, len(defs))
+	for idx := range defs {
+		def := &defs[idx]
+
+		t, err := b.produceType(def.Type)
+		if err != nil {
+			b.fail(err)
+			continue
+		}
+
+		args, err := b.makeInputValues(def.Arguments)
+		if err != nil {
+			b.fail(err)
+			continue
+		}
+
+		deprecationReason, err := b.deprecationReason(def.Directives)
+		if err != nil {
+			b.fail(err)
+			continue
+		}
+
+		fields = append(fields, &Field{
+			Name:              def.Name.Value,
+			Description:       b.description(def.Description, def),
+			Type:              t,
+			Args:              args,
+			DeprecationReason: deprecationReason,
+			AstNode:           def,
+		})
+	}
+
+	return fields
+}
+
+func (b *astBuilder) makeImplementedInterfaces(def *language.ObjectTypeDefinitionNode) Interfaces {
+	if def.Interfaces == nil {
+		return nil
+	}
+
+	var interfaces Interfaces
+	for idx := range *def.Interfaces {
+		ifaceNode := &(*def.Interfaces)[idx]
+
+		t, err := b.produceType(ifaceNode)
+		if err != nil {
+			b.fail(err)
+			continue
+		}
+
+		iface, ok := t.(*Interface)
+		if !ok {
+			b.fail(errors.NewValidationError(errors.Message{
+				ID:   errors.MsgNonInterfaceImplemented,
+				Args: map[string]interface{}{"type": def.Name.Value, "interface": t.String()},
+			}, ifaceNode))
+			continue
+		}
+		interfaces = append(interfaces, iface)
+	}
+
+	return interfaces
+}
+
+func (b *astBuilder) makeInputValues(defs []language.InputValueDefinitionNode) ([]*Argument, error) {
+	args := make([]*Argument, 0, len(defs))
+	for idx := range defs {
+		def := &defs[idx]
+
+		t, err := b.produceType(def.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		var defaultValue interface{}
+		if def.DefaultValue != nil {
+			var ok bool
+			if defaultValue, ok = ValueFromAST(def.DefaultValue, t, nil); !ok {
+				return nil, errors.NewValidationError(errors.Message{
+					ID: errors.MsgInvalidDefaultValue,
+					Args: map[string]interface{}{
+						"name":  def.Name.Value,
+						"value": language.Print(def.DefaultValue),
+						"type":  t.String(),
+					},
+				}, def.DefaultValue)
+			}
+		}
+
+		deprecationReason, err := b.deprecationReason(def.Directives)
+		if err != nil {
+			return nil, err
+		}
+
+		args = append(args, &Argument{
+			Name:              def.Name.Value,
+			Description:       b.description(def.Description, def),
+			Type:              t,
+			DefaultValue:      defaultValue,
+			DeprecationReason: deprecationReason,
+			AstNode:           def,
+		})
+	}
+
+	return args, nil
+}
+
+func (b *astBuilder) makeInterfaceDef(def *language.InterfaceTypeDefinitionNode) *Interface {
+	return NewInterface(InterfaceConfig{
+		Name:        def.Name.Value,
+		Description: b.description(def.Description, def),
+		Fields: FieldsFunc(func() Fields {
+			return b.makeFieldDefMap(def.Fields)
+		}),
+		AstNode: def,
+	})
+}
+
+func (b *astBuilder) makeEnumDef(def *language.EnumTypeDefinitionNode) (*Enum, error) {
+	values := make([]*EnumValue, 0, len(def.Values))
+	for idx := range def.Values {
+		valueDef := &def.Values[idx]
+
+		deprecationReason, err := b.deprecationReason(valueDef.Directives)
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, &EnumValue{
+			Name:              valueDef.Name.Value,
+			Description:       b.description(valueDef.Description, valueDef),
+			DeprecationReason: deprecationReason,
+			AstNode:           valueDef,
+		})
+	}
+
+	return NewEnum(EnumConfig{
+		Name:        def.Name.Value,
+		Description: b.description(def.Description, def),
+		Values:      values,
+		AstNode:     def,
+	}), nil
+}
+
+func (b *astBuilder) makeUnionDef(def *language.UnionTypeDefinitionNode) *Union {
+	return NewUnion(UnionConfig{
+		Name:        def.Name.Value,
+		Description: b.description(def.Description, def),
+		Types: ObjectsFunc(func() Objects {
+			var objects Objects
+			for idx := range def.Types {
+				memberNode := &def.Types[idx]
+
+				t, err := b.produceType(memberNode)
+				if err != nil {
+					b.fail(err)
+					continue
+				}
+
+				object, ok := t.(*Object)
+				if !ok {
+					b.fail(errors.NewValidationError(errors.Message{
+						ID:   errors.MsgNonObjectUnionMember,
+						Args: map[string]interface{}{"union": def.Name.Value, "type": t.String()},
+					}, memberNode))
+					continue
+				}
+				objects = append(objects, object)
+			}
+			return objects
+		}),
+		AstNode: def,
+	})
+}
+
+func (b *astBuilder) makeScalarDef(def *language.ScalarTypeDefinitionNode) (*Scalar, error) {
+	var specifiedByURL string
+	if def.Directives != nil {
+		values, err := GetDirectiveValues(SpecifiedByDirective, *def.Directives, nil)
+		if err != nil {
+			return nil, err
+		}
+		if url, ok := values["url"].(string); ok {
+			specifiedByURL = url
+		}
+	}
+
+	// Values of custom scalars are used as they are, until the scalar is
+	// given its coercion functions.
+	return NewScalar(ScalarConfig{
+		Name:           def.Name.Value,
+		Description:    b.description(def.Description, def),
+		SpecifiedByURL: specifiedByURL,
+		AstNode:        def,
+	}), nil
+}
+
+func (b *astBuilder) makeInputObjectDef(def *language.InputObjectTypeDefinitionNode) *InputObject {
+	return NewInputObject(InputObjectConfig{
+		Name:        def.Name.Value,
+		Description: b.description(def.Description, def),
+		Fields: InputFieldsFunc(func() InputFields {
+			args, err := b.makeInputValues(def.Fields)
+			if err != nil {
+				b.fail(err)
+				return nil
+			}
+
+			fields := make(InputFields, len(args))
+			for idx, arg := range args {
+				fields[idx] = &InputField{
+					Name:              arg.Name,
+					Description:       arg.Description,
+					Type:              arg.Type,
+					DefaultValue:      arg.DefaultValue,
+					DeprecationReason: arg.DeprecationReason,
+					AstNode:           arg.AstNode,
+				}
+			}
+			return fields
+		}),
+		AstNode: def,
+	})
+}
+
+func (b *astBuilder) getDirective(def *language.DirectiveDefinitionNode) (*Directive, error) {
+	var args []*Argument
+	if def.Arguments != nil {
+		var err error
+		if args, err = b.makeInputValues(*def.Arguments); err != nil {
+			return nil, err
+		}
+	}
+
+	locations := make([]DirectiveLocation, len(def.Locations))
+	for idx, location := range def.Locations {
+		locations[idx] = DirectiveLocation(location.Value)
+	}
+
+	return NewDirective(DirectiveConfig{
+		Name:         def.Name.Value,
+		Description:  b.description(def.Description, def),
+		Locations:    locations,
+		Args:         args,
+		IsRepeatable: def.Repeatable,
+		AstNode:      def,
+	}), nil
+}
+
+// deprecationReason returns the reason given to @deprecated among the
+// directives of a definition, or "" if it is not deprecated.
+func (b *astBuilder) deprecationReason(directives *[]language.DirectiveNode) (string, error) {
+	if directives == nil {
+		return "", nil
+	}
+
+	values, err := GetDirectiveValues(DeprecatedDirective, *directives, nil)
+	if err != nil || values == nil {
+		return "", err
+	}
+
+	reason, _ := values["reason"].(string)
+	return reason, nil
+}
+
+// description returns the description of a definition, read from its
+// description string or, with CommentDescriptions, from the comments
+// preceding it.
+func (b *astBuilder) description(description *language.StringValueNode, node language.ASTNode) string {
+	if description != nil {
+		return description.Value
+	}
+	if b.options.CommentDescriptions {
+		return commentDescription(node.GetLoc())
+	}
+
+	return ""
+}
+
+// commentDescription returns the description given by a contiguous block of
+// full-line comments preceding a node.
+func commentDescription(loc *language.Location) string {
+	if loc == nil {
+		return ""
+	}
+
+	var comments []string
+	minSpaces := -1
+
+	token := loc.StartToken.Prev
+	for token != nil &&
+		token.Kind == language.TokenComment &&
+		token.Next != nil && token.Prev != nil &&
+		token.Line+1 == token.Next.Line &&
+		token.Line != token.Prev.Line {
+		value := token.Value
+		spaces := len(value) - len(strings.TrimLeft(value, " "))
+		if minSpaces == -1 || spaces < minSpaces {
+			minSpaces = spaces
+		}
+		comments = append(comments, value)
+		token = token.Prev
+	}
+
+	lines := make([]string, len(comments))
+	for idx, comment := range comments {
+		lines[len(comments)-1-idx] = comment[minSpaces:]
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// typeDefinitionName returns the name of the type a definition defines.
+func typeDefinitionName(node language.ASTNode) string {
+	switch node := node.(type) {
+	case *language.ScalarTypeDefinitionNode:
+		return node.Name.Value
+	case *language.ObjectTypeDefinitionNode:
+		return node.Name.Value
+	case *language.InterfaceTypeDefinitionNode:
+		return node.Name.Value
+	case *language.UnionTypeDefinitionNode:
+		return node.Name.Value
+	case *language.EnumTypeDefinitionNode:
+		return node.Name.Value
+	case *language.InputObjectTypeDefinitionNode:
+		return node.Name.Value
+	}
+
+	return ""
+}
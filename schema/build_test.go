@@ -0,0 +1,273 @@
+package schema
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ijsnow/goql/internal/errors"
+)
+
+const petsSDL = `
+schema {
+  query: Root
+  mutation: Mutation
+}
+
+"""
+The query root.
+"""
+type Root {
+  "All the pets."
+  pets(first: Int = 10, kind: Kind = DOG): [Pet]!
+  search(filter: Filter = {name: "Rex"}): [SearchResult]
+  oldPets: [Pet] @deprecated
+  date: Date
+}
+
+type Mutation {
+  adopt(id: ID!, reason: String @deprecated(reason: "Unused.")): Pet
+}
+
+interface Pet {
+  name: String
+}
+
+type Dog implements Pet {
+  name: String
+  barks: Boolean
+}
+
+type Cat implements Pet {
+  name: String
+}
+
+union SearchResult = Dog | Cat
+
+enum Kind {
+  DOG
+  CAT @deprecated(reason: "Cats are out.")
+}
+
+input Filter {
+  name: String
+  kind: Kind = CAT
+}
+
+scalar Date @specifiedBy(url: "https://tools.ietf.org/html/rfc3339")
+
+"Tags a field."
+directive @tag(name: String!) repeatable on FIELD_DEFINITION | OBJECT
+`
+
+func TestBuildFromSDL(t *testing.T) {
+	schema, err := BuildFromSDL(petsSDL)
+	if err != nil {
+		t.Fatalf("BuildFromSDL; got error %v", err)
+	}
+	if errs := Validate(schema); len(errs) != 0 {
+		t.Fatalf("Validate; got %v", errs)
+	}
+
+	root := schema.GetQueryType()
+	if root == nil || root.Name != "Root" || root.Description != "The query root." {
+		t.Fatalf("query type; got %+v", root)
+	}
+	if schema.GetMutationType().Name != "Mutation" || schema.GetSubscriptionType() != nil {
+		t.Errorf("root types; got %v and %v", schema.GetMutationType(), schema.GetSubscriptionType())
+	}
+
+	pets := root.GetField("pets")
+	if pets.Description != "All the pets." || pets.Type.String() != "[Pet]!" {
+		t.Errorf("pets; got %q of type %s", pets.Description, pets.Type)
+	}
+	if got := pets.GetArg("first").DefaultValue; got != 10 {
+		t.Errorf("first default; got %#v wanted 10", got)
+	}
+	if got := pets.GetArg("kind").DefaultValue; got != "DOG" {
+		t.Errorf("kind default; got %#v wanted DOG", got)
+	}
+	want := map[string]interface{}{"name": "Rex", "kind": "CAT"}
+	if got := root.GetField("search").GetArg("filter").DefaultValue; !reflect.DeepEqual(got, want) {
+		t.Errorf("filter default; got %#v wanted %#v", got, want)
+	}
+
+	if old := root.GetField("oldPets"); !old.IsDeprecated || old.DeprecationReason != DefaultDeprecationReason {
+		t.Errorf("oldPets; got deprecated %v with %q", old.IsDeprecated, old.DeprecationReason)
+	}
+	if reason := schema.GetMutationType().GetField("adopt").GetArg("reason"); reason.DeprecationReason != "Unused." {
+		t.Errorf("reason; got %q wanted \"Unused.\"", reason.DeprecationReason)
+	}
+	if cat := schema.GetType("Kind").(*Enum).GetValue("CAT"); !cat.IsDeprecated || cat.DeprecationReason != "Cats are out." {
+		t.Errorf("CAT; got deprecated %v with %q", cat.IsDeprecated, cat.DeprecationReason)
+	}
+
+	pet := schema.GetType("Pet").(*Interface)
+	if got := len(schema.Implementations(pet)); got != 2 {
+		t.Errorf("Implementations(Pet); got %d wanted 2", got)
+	}
+	if got := len(schema.GetType("SearchResult").(*Union).GetTypes()); got != 2 {
+		t.Errorf("SearchResult types; got %d wanted 2", got)
+	}
+
+	date := schema.GetType("Date").(*Scalar)
+	if date.SpecifiedByURL != "https://tools.ietf.org/html/rfc3339" {
+		t.Errorf("Date; got specifiedByURL %q", date.SpecifiedByURL)
+	}
+	if date.AstNode == nil || date.AstNode.Name.Value != "Date" {
+		t.Errorf("Date; got AstNode %+v", date.AstNode)
+	}
+
+	tag := schema.GetDirective("tag")
+	if tag == nil || !tag.IsRepeatable || tag.Description != "Tags a field." || len(tag.Locations) != 2 {
+		t.Fatalf("@tag; got %+v", tag)
+	}
+	if schema.GetDirective("deprecated") == nil {
+		t.Errorf("@deprecated; got nil wanted the specified directive")
+	}
+}
+
+func TestBuildFromSDLDefaultRootTypes(t *testing.T) {
+	schema, err := BuildFromSDL(`
+type Query { a: String }
+type Mutation { b: String }
+type Subscription { c: String }
+`)
+	if err != nil {
+		t.Fatalf("BuildFromSDL; got error %v", err)
+	}
+
+	if schema.GetQueryType().Name != "Query" ||
+		schema.GetMutationType().Name != "Mutation" ||
+		schema.GetSubscriptionType().Name != "Subscription" {
+		t.Errorf("root types; got %v, %v and %v", schema.GetQueryType(), schema.GetMutationType(), schema.GetSubscriptionType())
+	}
+}
+
+func TestBuildFromSDLCommentDescriptions(t *testing.T) {
+	sdl := `
+# The query root.
+# Has one field.
+type Query {
+  # A string.
+  str: String
+}
+`
+
+	schema, err := BuildFromSDL(sdl, BuildOptions{CommentDescriptions: true})
+	if err != nil {
+		t.Fatalf("BuildFromSDL; got error %v", err)
+	}
+	if got := schema.GetQueryType().Description; got != "The query root.\nHas one field." {
+		t.Errorf("Query description; got %q", got)
+	}
+	if got := schema.GetQueryType().GetField("str").Description; got != "A string." {
+		t.Errorf("str description; got %q", got)
+	}
+
+	schema, err = BuildFromSDL(sdl)
+	if err != nil {
+		t.Fatalf("BuildFromSDL; got error %v", err)
+	}
+	if got := schema.GetQueryType().Description; got != "" {
+		t.Errorf("Query description without CommentDescriptions; got %q", got)
+	}
+}
+
+func TestBuildFromSDLErrors(t *testing.T) {
+	set := [][]string{
+		[]string{
+			"type Query { a: String } type Query { b: String }",
+			`Type "Query" was defined more than once.`,
+		},
+		[]string{
+			"type Query { a: Missing }",
+			`Type "Missing" not found in document.`,
+		},
+		[]string{
+			"type Hello { a: String }",
+			"Must provide schema definition with query type or a type named Query.",
+		},
+		[]string{
+			"schema { query: Hello } scalar Hello",
+			`Specified query type "Hello" must be an Object type.`,
+		},
+		[]string{
+			"schema { query: Hello }",
+			`Specified query type "Hello" not found in document.`,
+		},
+		[]string{
+			"schema { query: Query } schema { query: Query } type Query { a: String }",
+			"Must provide only one schema definition.",
+		},
+		[]string{
+			`type Query { f(a: Int = "abc"): Int }`,
+			`Default value "abc" of "a" is not a valid value of type "Int".`,
+		},
+		[]string{
+			"type Query { f(a: Filter): Int } input Filter { limit: Int! = null }",
+			`Default value null of "limit" is not a valid value of type "Int!".`,
+		},
+		[]string{
+			"type Query {",
+			"Syntax Error GraphQL request (1:13) Expected Name, found <EOF>",
+		},
+	}
+
+	for _, test := range set {
+		_, err := BuildFromSDL(test[0])
+		if err == nil {
+			t.Errorf("BuildFromSDL(%q); got no error wanted %s", test[0], test[1])
+			continue
+		}
+		if got := err.Error(); !strings.HasPrefix(got, test[1]) {
+			t.Errorf("BuildFromSDL(%q); got %s wanted %s", test[0], got, test[1])
+		}
+	}
+}
+
+func TestBuiltSchemaValidationErrorsHaveLocations(t *testing.T) {
+	schema, err := BuildFromSDL(`
+type Query {
+  pet: Pet
+}
+
+interface Pet {
+  name: String
+}
+
+type Dog implements Pet {
+  name: Int
+}
+`)
+	if err != nil {
+		t.Fatalf("BuildFromSDL; got error %v", err)
+	}
+
+	errs := Validate(schema)
+	if len(errs) != 1 {
+		t.Fatalf("Validate; got %v wanted one error", errs)
+	}
+
+	gqlerr, ok := errs[0].(*errors.GraphQLError)
+	if !ok {
+		t.Fatalf("Validate; got %T wanted *errors.GraphQLError", errs[0])
+	}
+	locations := gqlerr.Locations
+	if len(locations) != 2 || locations[0].Line != 7 || locations[1].Line != 11 {
+		t.Errorf("Validate; got locations %+v wanted lines 7 and 11", locations)
+	}
+}
+
+func TestBuildFromSDLInvalidDefaultValueLocation(t *testing.T) {
+	_, err := BuildFromSDL(`type Query { f(a: Int = "abc"): Int }`)
+
+	gqlerr, ok := err.(*errors.GraphQLError)
+	if !ok {
+		t.Fatalf("BuildFromSDL; got %T wanted *errors.GraphQLError", err)
+	}
+	locations := gqlerr.Locations
+	if len(locations) != 1 || locations[0].Line != 1 || locations[0].Column != 25 {
+		t.Errorf("BuildFromSDL; got locations %+v wanted 1:25", locations)
+	}
+}
@@ -0,0 +1,652 @@
+package schema
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/ijsnow/goql/internal/language"
+)
+
+// BreakingChangeType is the kind of a BreakingChange.
+type BreakingChangeType string
+
+// The kinds of changes which break clients of a schema.
+const (
+	BreakingChangeFieldChangedKind           BreakingChangeType = "FIELD_CHANGED_KIND"
+	BreakingChangeFieldRemoved               BreakingChangeType = "FIELD_REMOVED"
+	BreakingChangeTypeChangedKind            BreakingChangeType = "TYPE_CHANGED_KIND"
+	BreakingChangeTypeRemoved                BreakingChangeType = "TYPE_REMOVED"
+	BreakingChangeTypeRemovedFromUnion       BreakingChangeType = "TYPE_REMOVED_FROM_UNION"
+	BreakingChangeValueRemovedFromEnum       BreakingChangeType = "VALUE_REMOVED_FROM_ENUM"
+	BreakingChangeArgRemoved                 BreakingChangeType = "ARG_REMOVED"
+	BreakingChangeArgChangedKind             BreakingChangeType = "ARG_CHANGED_KIND"
+	BreakingChangeNonNullArgAdded            BreakingChangeType = "NON_NULL_ARG_ADDED"
+	BreakingChangeNonNullInputFieldAdded     BreakingChangeType = "NON_NULL_INPUT_FIELD_ADDED"
+	BreakingChangeInterfaceRemovedFromObject BreakingChangeType = "INTERFACE_REMOVED_FROM_OBJECT"
+)
+
+// DangerousChangeType is the kind of a DangerousChange.
+type DangerousChangeType string
+
+// The kinds of changes which may change the behavior of clients of a
+// schema without breaking their queries.
+const (
+	DangerousChangeArgDefaultValueChange   DangerousChangeType = "ARG_DEFAULT_VALUE_CHANGE"
+	DangerousChangeValueAddedToEnum        DangerousChangeType = "VALUE_ADDED_TO_ENUM"
+	DangerousChangeTypeAddedToUnion        DangerousChangeType = "TYPE_ADDED_TO_UNION"
+	DangerousChangeOptionalInputFieldAdded DangerousChangeType = "OPTIONAL_INPUT_FIELD_ADDED"
+	DangerousChangeOptionalArgAdded        DangerousChangeType = "OPTIONAL_ARG_ADDED"
+	DangerousChangeInterfaceAddedToObject  DangerousChangeType = "INTERFACE_ADDED_TO_OBJECT"
+)
+
+// SafeChangeType is the kind of a SafeChange.
+type SafeChangeType string
+
+// The kinds of changes which neither break nor endanger clients of a schema.
+const (
+	SafeChangeTypeAdded          SafeChangeType = "TYPE_ADDED"
+	SafeChangeFieldAdded         SafeChangeType = "FIELD_ADDED"
+	SafeChangeDirectiveAdded     SafeChangeType = "DIRECTIVE_ADDED"
+	SafeChangeDeprecationAdded   SafeChangeType = "DEPRECATION_ADDED"
+	SafeChangeDeprecationRemoved SafeChangeType = "DEPRECATION_REMOVED"
+	SafeChangeDescriptionChanged SafeChangeType = "DESCRIPTION_CHANGED"
+)
+
+// BreakingChange is a change between two schemas which breaks queries that
+// were valid against the old schema.
+type BreakingChange struct {
+	Type        BreakingChangeType `json:"type"`
+	Description string             `json:"description"`
+
+	/**
+	 * Coordinate names the changed type, field, argument, input field or
+	 * enum value, such as `Query.user(id:)`.
+	 */
+	Coordinate string `json:"coordinate"`
+
+	/**
+	 * Locations locate the changed element in the document the new schema
+	 * was built from, or in the document of the old schema when it was
+	 * removed. It is empty for schemas that were not built from a document.
+	 */
+	Locations []SourceLocation `json:"locations,omitempty"`
+}
+
+// DangerousChange is a change between two schemas which keeps queries valid
+// but may change their results, such as a new enum value that clients do
+// not know how to handle.
+type DangerousChange struct {
+	Type        DangerousChangeType `json:"type"`
+	Description string              `json:"description"`
+
+	/**
+	 * Coordinate names the changed type, field, argument, input field or
+	 * enum value, such as `Query.user(id:)`.
+	 */
+	Coordinate string `json:"coordinate"`
+
+	/**
+	 * Locations locate the changed element in the document the new schema
+	 * was built from. It is empty for schemas that were not built from a
+	 * document.
+	 */
+	Locations []SourceLocation `json:"locations,omitempty"`
+}
+
+// SafeChange is a change between two schemas which neither breaks queries
+// nor changes their results, such as an added type or a deprecated field.
+type SafeChange struct {
+	Type        SafeChangeType `json:"type"`
+	Description string         `json:"description"`
+
+	/**
+	 * Coordinate names the changed type, field, enum value or directive,
+	 * such as `Query.user`.
+	 */
+	Coordinate string `json:"coordinate"`
+
+	/**
+	 * Locations locate the changed element in the document the new schema
+	 * was built from. It is empty for schemas that were not built from a
+	 * document.
+	 */
+	Locations []SourceLocation `json:"locations,omitempty"`
+}
+
+// FindBreakingChanges returns the changes from oldSchema to newSchema that
+// break queries which were valid against oldSchema: removed types, fields,
+// arguments, enum values, union members and interfaces, types and fields
+// whose type changed incompatibly, and added required arguments and input
+// fields. Changes are sorted by the name of the type they are found in.
+func FindBreakingChanges(oldSchema, newSchema *Schema) []BreakingChange {
+	return findChanges(oldSchema, newSchema).breaking
+}
+
+// FindDangerousChanges returns the changes from oldSchema to newSchema that
+// may change the results of queries without making them invalid: changed
+// argument default values, added enum values, union members and interfaces,
+// and added optional arguments and input fields.
+func FindDangerousChanges(oldSchema, newSchema *Schema) []DangerousChange {
+	return findChanges(oldSchema, newSchema).dangerous
+}
+
+// FindSafeChanges returns the changes from oldSchema to newSchema that
+// neither break queries nor change their results: added types, fields and
+// directives, added and removed deprecations of fields and enum values, and
+// changed descriptions of types and fields. Changes are sorted by the name of
+// the type they are found in, followed by the added directives.
+func FindSafeChanges(oldSchema, newSchema *Schema) []SafeChange {
+	c := &changeFinder{}
+	c.findSafeChanges(oldSchema, newSchema)
+
+	return c.safe
+}
+
+type changeFinder struct {
+	breaking  []BreakingChange
+	dangerous []DangerousChange
+	safe      []SafeChange
+}
+
+func (c *changeFinder) addBreaking(t BreakingChangeType, description, coordinate string, node language.ASTNode) {
+	c.breaking = append(c.breaking, BreakingChange{
+		Type:        t,
+		Description: description,
+		Coordinate:  coordinate,
+		Locations:   nodeLocations(node),
+	})
+}
+
+func (c *changeFinder) addDangerous(t DangerousChangeType, description, coordinate string, node language.ASTNode) {
+	c.dangerous = append(c.dangerous, DangerousChange{
+		Type:        t,
+		Description: description,
+		Coordinate:  coordinate,
+		Locations:   nodeLocations(node),
+	})
+}
+
+func (c *changeFinder) addSafe(t SafeChangeType, description, coordinate string, node language.ASTNode) {
+	c.safe = append(c.safe, SafeChange{
+		Type:        t,
+		Description: description,
+		Coordinate:  coordinate,
+		Locations:   nodeLocations(node),
+	})
+}
+
+func findChanges(oldSchema, newSchema *Schema) *changeFinder {
+	c := &changeFinder{}
+
+	oldTypeMap := oldSchema.GetTypeMap()
+	newTypeMap := newSchema.GetTypeMap()
+
+	typeNames := make([]string, 0, len(oldTypeMap))
+	for typeName := range oldTypeMap {
+		typeNames = append(typeNames, typeName)
+	}
+	sort.Strings(typeNames)
+
+	for _, typeName := range typeNames {
+		oldType := oldTypeMap[typeName]
+		newType, ok := newTypeMap[typeName]
+		if !ok {
+			c.addBreaking(BreakingChangeTypeRemoved, typeName+" was removed.", typeName, typeASTNode(oldType))
+			continue
+		}
+
+		switch oldType := oldType.(type) {
+		case *Object:
+			if newType, ok := newType.(*Object); ok {
+				c.findFieldChanges(typeName, oldType.GetFields(), newType.GetFields())
+				c.findInterfaceChanges(oldType, newType)
+				continue
+			}
+		case *Interface:
+			if newType, ok := newType.(*Interface); ok {
+				c.findFieldChanges(typeName, oldType.GetFields(), newType.GetFields())
+				continue
+			}
+		case *InputObject:
+			if newType, ok := newType.(*InputObject); ok {
+				c.findInputFieldChanges(oldType, newType)
+				continue
+			}
+		case *Union:
+			if newType, ok := newType.(*Union); ok {
+				c.findUnionChanges(oldType, newType)
+				continue
+			}
+		case *Enum:
+			if newType, ok := newType.(*Enum); ok {
+				c.findEnumChanges(oldType, newType)
+				continue
+			}
+		case *Scalar:
+			if _, ok := newType.(*Scalar); ok {
+				continue
+			}
+		}
+
+		c.addBreaking(BreakingChangeTypeChangedKind,
+			typeName+" changed from "+typeKindName(oldType)+" to "+typeKindName(newType)+".",
+			typeName, typeASTNode(newType))
+	}
+
+	return c
+}
+
+// findFieldChanges finds the changes to the fields of an Object or
+// Interface, and to their arguments.
+func (c *changeFinder) findFieldChanges(typeName string, oldFields, newFields []*Field) {
+	newFieldMap := make(map[string]*Field, len(newFields))
+	for _, field := range newFields {
+		newFieldMap[field.Name] = field
+	}
+
+	for _, oldField := range oldFields {
+		coordinate := typeName + "." + oldField.Name
+
+		newField, ok := newFieldMap[oldField.Name]
+		if !ok {
+			c.addBreaking(BreakingChangeFieldRemoved, coordinate+" was removed.", coordinate, oldField.AstNode)
+			continue
+		}
+
+		if !isChangeSafeForObjectOrInterfaceField(oldField.Type, newField.Type) {
+			c.addBreaking(BreakingChangeFieldChangedKind,
+				coordinate+" changed type from "+oldField.Type.String()+" to "+newField.Type.String()+".",
+				coordinate, newField.AstNode)
+		}
+
+		c.findArgChanges(typeName, oldField.Name, oldField.Args, newField.Args)
+	}
+}
+
+func (c *changeFinder) findArgChanges(typeName, fieldName string, oldArgs, newArgs []*Argument) {
+	field := typeName + "." + fieldName
+
+	for _, oldArg := range oldArgs {
+		coordinate := field + "(" + oldArg.Name + ":)"
+
+		newArg := findArg(newArgs, oldArg.Name)
+		if newArg == nil {
+			c.addBreaking(BreakingChangeArgRemoved,
+				field+" arg "+oldArg.Name+" was removed",
+				coordinate, oldArg.AstNode)
+			continue
+		}
+
+		if !isChangeSafeForInputObjectFieldOrFieldArg(oldArg.Type, newArg.Type) {
+			c.addBreaking(BreakingChangeArgChangedKind,
+				field+" arg "+oldArg.Name+" has changed type from "+oldArg.Type.String()+" to "+newArg.Type.String(),
+				coordinate, newArg.AstNode)
+		} else if oldArg.DefaultValue != nil && !sameDefaultValue(oldArg, newArg) {
+			c.addDangerous(DangerousChangeArgDefaultValueChange,
+				field+" arg "+oldArg.Name+" has changed defaultValue",
+				coordinate, newArg.AstNode)
+		}
+	}
+
+	// Check if an arg was added to the field.
+	for _, newArg := range newArgs {
+		if findArg(oldArgs, newArg.Name) != nil {
+			continue
+		}
+
+		coordinate := field + "(" + newArg.Name + ":)"
+		if isRequiredInput(newArg.Type, newArg.DefaultValue) {
+			c.addBreaking(BreakingChangeNonNullArgAdded,
+				"A non-null arg "+newArg.Name+" on "+field+" was added",
+				coordinate, newArg.AstNode)
+		} else {
+			c.addDangerous(DangerousChangeOptionalArgAdded,
+				"An optional arg "+newArg.Name+" on "+field+" was added",
+				coordinate, newArg.AstNode)
+		}
+	}
+}
+
+func (c *changeFinder) findInputFieldChanges(oldType, newType *InputObject) {
+	for _, oldField := range oldType.GetFields() {
+		coordinate := oldType.Name + "." + oldField.Name
+
+		newField := newType.GetField(oldField.Name)
+		if newField == nil {
+			c.addBreaking(BreakingChangeFieldRemoved, coordinate+" was removed.", coordinate, oldField.AstNode)
+			continue
+		}
+
+		if !isChangeSafeForInputObjectFieldOrFieldArg(oldField.Type, newField.Type) {
+			c.addBreaking(BreakingChangeFieldChangedKind,
+				coordinate+" changed type from "+oldField.Type.String()+" to "+newField.Type.String()+".",
+				coordinate, newField.AstNode)
+		}
+	}
+
+	// Check if a field was added to the input object type.
+	for _, newField := range newType.GetFields() {
+		if oldType.GetField(newField.Name) != nil {
+			continue
+		}
+
+		coordinate := newType.Name + "." + newField.Name
+		if isRequiredInput(newField.Type, newField.DefaultValue) {
+			c.addBreaking(BreakingChangeNonNullInputFieldAdded,
+				"A non-null field "+newField.Name+" on input type "+newType.Name+" was added.",
+				coordinate, newField.AstNode)
+		} else {
+			c.addDangerous(DangerousChangeOptionalInputFieldAdded,
+				"An optional field "+newField.Name+" on input type "+newType.Name+" was added.",
+				coordinate, newField.AstNode)
+		}
+	}
+}
+
+func (c *changeFinder) findUnionChanges(oldType, newType *Union) {
+	oldMembers := map[string]bool{}
+	for _, member := range oldType.GetTypes() {
+		oldMembers[member.Name] = true
+	}
+	newMembers := map[string]bool{}
+	for _, member := range newType.GetTypes() {
+		newMembers[member.Name] = true
+	}
+
+	for _, member := range oldType.GetTypes() {
+		if !newMembers[member.Name] {
+			c.addBreaking(BreakingChangeTypeRemovedFromUnion,
+				member.Name+" was removed from union type "+oldType.Name+".",
+				oldType.Name, newType.AstNode)
+		}
+	}
+	for _, member := range newType.GetTypes() {
+		if !oldMembers[member.Name] {
+			c.addDangerous(DangerousChangeTypeAddedToUnion,
+				member.Name+" was added to union type "+newType.Name+".",
+				newType.Name, newType.AstNode)
+		}
+	}
+}
+
+func (c *changeFinder) findEnumChanges(oldType, newType *Enum) {
+	for _, value := range oldType.GetValues() {
+		if newType.GetValue(value.Name) == nil {
+			c.addBreaking(BreakingChangeValueRemovedFromEnum,
+				value.Name+" was removed from enum type "+oldType.Name+".",
+				oldType.Name+"."+value.Name, value.AstNode)
+		}
+	}
+	for _, value := range newType.GetValues() {
+		if oldType.GetValue(value.Name) == nil {
+			c.addDangerous(DangerousChangeValueAddedToEnum,
+				value.Name+" was added to enum type "+newType.Name+".",
+				newType.Name+"."+value.Name, value.AstNode)
+		}
+	}
+}
+
+func (c *changeFinder) findInterfaceChanges(oldType, newType *Object) {
+	implements := func(interfaces []*Interface, name string) bool {
+		for _, iface := range interfaces {
+			if iface.Name == name {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, oldInterface := range oldType.GetInterfaces() {
+		if !implements(newType.GetInterfaces(), oldInterface.Name) {
+			c.addBreaking(BreakingChangeInterfaceRemovedFromObject,
+				oldType.Name+" no longer implements interface "+oldInterface.Name+".",
+				oldType.Name, newType.AstNode)
+		}
+	}
+	for _, newInterface := range newType.GetInterfaces() {
+		if !implements(oldType.GetInterfaces(), newInterface.Name) {
+			c.addDangerous(DangerousChangeInterfaceAddedToObject,
+				newInterface.Name+" added to interfaces implemented by "+newType.Name+".",
+				newType.Name, newType.AstNode)
+		}
+	}
+}
+
+func isChangeSafeForObjectOrInterfaceField(oldType, newType Type) bool {
+	switch oldType := oldType.(type) {
+	case NamedType:
+		// If they're both named types, see if their names are equivalent.
+		if newType, ok := newType.(NamedType); ok {
+			return oldType.GetName() == newType.GetName()
+		}
+	case *List:
+		// If they're both lists, make sure the underlying types are
+		// compatible.
+		if newType, ok := newType.(*List); ok {
+			return isChangeSafeForObjectOrInterfaceField(oldType.OfType, newType.OfType)
+		}
+	case *NonNull:
+		// If they're both non-null, make sure the underlying types are
+		// compatible.
+		if newType, ok := newType.(*NonNull); ok {
+			return isChangeSafeForObjectOrInterfaceField(oldType.OfType, newType.OfType)
+		}
+		return false
+	}
+
+	// Moving from nullable to non-null of the same underlying type is safe.
+	if newType, ok := newType.(*NonNull); ok {
+		return isChangeSafeForObjectOrInterfaceField(oldType, newType.OfType)
+	}
+
+	return false
+}
+
+func isChangeSafeForInputObjectFieldOrFieldArg(oldType, newType Type) bool {
+	switch oldType := oldType.(type) {
+	case NamedType:
+		// If they're both named types, see if their names are equivalent.
+		newType, ok := newType.(NamedType)
+		return ok && oldType.GetName() == newType.GetName()
+	case *List:
+		// If they're both lists, make sure the underlying types are
+		// compatible.
+		newType, ok := newType.(*List)
+		return ok && isChangeSafeForInputObjectFieldOrFieldArg(oldType.OfType, newType.OfType)
+	case *NonNull:
+		// If they're both non-null, make sure the underlying types are
+		// compatible.
+		if newType, ok := newType.(*NonNull); ok {
+			return isChangeSafeForInputObjectFieldOrFieldArg(oldType.OfType, newType.OfType)
+		}
+
+		// Moving from non-null to nullable of the same underlying type is
+		// safe.
+		return isChangeSafeForInputObjectFieldOrFieldArg(oldType.OfType, newType)
+	}
+
+	return false
+}
+
+func findArg(args []*Argument, name string) *Argument {
+	for _, arg := range args {
+		if arg.Name == name {
+			return arg
+		}
+	}
+
+	return nil
+}
+
+// sameDefaultValue reports whether two arguments have the same default
+// value. Default values are compared by the literals they are written as,
+// or else, when either cannot be printed as a literal of its type, as Go
+// values.
+func sameDefaultValue(oldArg, newArg *Argument) bool {
+	oldValue, oldErr := printDefaultValue(oldArg.DefaultValue, oldArg.Type)
+	newValue, newErr := printDefaultValue(newArg.DefaultValue, newArg.Type)
+	if oldErr != nil || newErr != nil {
+		return reflect.DeepEqual(oldArg.DefaultValue, newArg.DefaultValue)
+	}
+
+	return oldValue == newValue
+}
+
+// printDefaultValue prints a default value as a literal.
+func printDefaultValue(value interface{}, t Type) (string, error) {
+	if value == nil {
+		return "", nil
+	}
+
+	valueNode, err := AstFromValue(value, t)
+	if err != nil || valueNode == nil {
+		return "", err
+	}
+
+	return PrintValue(valueNode), nil
+}
+
+// isRequiredInput reports whether an argument or input field must be given
+// a value.
+func isRequiredInput(t Type, defaultValue interface{}) bool {
+	_, nonNull := t.(*NonNull)
+	return nonNull && defaultValue == nil
+}
+
+func typeKindName(t NamedType) string {
+	switch t.(type) {
+	case *Scalar:
+		return "a Scalar type"
+	case *Object:
+		return "an Object type"
+	case *Interface:
+		return "an Interface type"
+	case *Union:
+		return "a Union type"
+	case *Enum:
+		return "an Enum type"
+	case *InputObject:
+		return "an Input type"
+	}
+
+	return t.String()
+}
+
+// findSafeChanges finds the additions, deprecations and description changes
+// which neither break nor endanger clients.
+func (c *changeFinder) findSafeChanges(oldSchema, newSchema *Schema) {
+	oldTypeMap := oldSchema.GetTypeMap()
+	newTypeMap := newSchema.GetTypeMap()
+
+	typeNames := make([]string, 0, len(newTypeMap))
+	for typeName := range newTypeMap {
+		typeNames = append(typeNames, typeName)
+	}
+	sort.Strings(typeNames)
+
+	for _, typeName := range typeNames {
+		newType := newTypeMap[typeName]
+		oldType, ok := oldTypeMap[typeName]
+		if !ok {
+			c.addSafe(SafeChangeTypeAdded, typeName+" was added.", typeName, typeASTNode(newType))
+			continue
+		}
+
+		if oldType.GetDescription() != newType.GetDescription() {
+			c.addSafe(SafeChangeDescriptionChanged, "Description of "+typeName+" changed.", typeName, typeASTNode(newType))
+		}
+
+		if oldType, ok := oldType.(*Enum); ok {
+			if newType, ok := newType.(*Enum); ok {
+				c.findSafeEnumChanges(oldType, newType)
+			}
+			continue
+		}
+
+		oldFields, newFields := fieldsOf(oldType), fieldsOf(newType)
+		if oldFields == nil || newFields == nil {
+			continue
+		}
+
+		oldFieldMap := map[string]*Field{}
+		for _, field := range oldFields {
+			oldFieldMap[field.Name] = field
+		}
+
+		for _, newField := range newFields {
+			coordinate := typeName + "." + newField.Name
+
+			oldField, ok := oldFieldMap[newField.Name]
+			if !ok {
+				c.addSafe(SafeChangeFieldAdded, coordinate+" was added.", coordinate, newField.AstNode)
+				continue
+			}
+
+			c.findDeprecationChange(coordinate, oldField.IsDeprecated, newField.IsDeprecated, newField.AstNode)
+			if oldField.Description != newField.Description {
+				c.addSafe(SafeChangeDescriptionChanged, "Description of "+coordinate+" changed.", coordinate, newField.AstNode)
+			}
+		}
+	}
+
+	for _, directive := range newSchema.GetDirectives() {
+		if oldSchema.GetDirective(directive.Name) == nil {
+			c.addSafe(SafeChangeDirectiveAdded, "@"+directive.Name+" was added.", "@"+directive.Name, directive.AstNode)
+		}
+	}
+}
+
+// findSafeEnumChanges finds the deprecations added to and removed from the
+// values of an Enum.
+func (c *changeFinder) findSafeEnumChanges(oldType, newType *Enum) {
+	for _, newValue := range newType.GetValues() {
+		oldValue := oldType.GetValue(newValue.Name)
+		if oldValue == nil {
+			continue
+		}
+
+		coordinate := newType.Name + "." + newValue.Name
+		c.findDeprecationChange(coordinate, oldValue.IsDeprecated, newValue.IsDeprecated, newValue.AstNode)
+	}
+}
+
+// findDeprecationChange finds whether an element was deprecated, or is no
+// longer deprecated.
+func (c *changeFinder) findDeprecationChange(coordinate string, wasDeprecated, isDeprecated bool, node language.ASTNode) {
+	if !wasDeprecated && isDeprecated {
+		c.addSafe(SafeChangeDeprecationAdded, coordinate+" was deprecated.", coordinate, node)
+	} else if wasDeprecated && !isDeprecated {
+		c.addSafe(SafeChangeDeprecationRemoved, coordinate+" is no longer deprecated.", coordinate, node)
+	}
+}
+
+// typeASTNode returns the node a type was defined by, if any.
+func typeASTNode(t NamedType) language.ASTNode {
+	switch t := t.(type) {
+	case *Scalar:
+		return t.AstNode
+	case *Object:
+		return t.AstNode
+	case *Interface:
+		return t.AstNode
+	case *Union:
+		return t.AstNode
+	case *Enum:
+		return t.AstNode
+	case *InputObject:
+		return t.AstNode
+	}
+
+	return nil
+}
+
+// nodeLocations returns the location of a node in its document.
+func nodeLocations(node language.ASTNode) []SourceLocation {
+	nodes := astNodes(node)
+	if len(nodes) == 0 || nodes[0].GetLoc() == nil {
+		return nil
+	}
+
+	loc := nodes[0].GetLoc()
+	return []SourceLocation{language.GetLocation(loc.Source, loc.Start)}
+}
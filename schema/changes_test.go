@@ -0,0 +1,269 @@
+package schema
+
+import (
+	"reflect"
+	"testing"
+)
+
+func buildSDL(t *testing.T, sdl string) *Schema {
+	t.Helper()
+
+	schema, err := BuildFromSDL(sdl)
+	if err != nil {
+		t.Fatalf("BuildFromSDL; got error %v", err)
+	}
+
+	return schema
+}
+
+const oldChangesSDL = `
+type Query {
+  pets(first: Int = 10, kind: Kind): [Pet]
+  pet(id: ID!): Pet
+  removed: String
+  list: [String]
+  named: String!
+  search(filter: Filter): Result
+}
+
+interface Pet { name: String }
+
+type Dog implements Pet {
+  name: String
+}
+
+type Cat { name: String }
+
+union Result = Dog | Cat
+
+enum Kind { DOG CAT }
+
+input Filter {
+  name: String
+  kind: Kind!
+}
+
+scalar Removed
+
+type ChangedKind { a: String }
+`
+
+const newChangesSDL = `
+type Query {
+  pets(first: Int = 20, kind: Kind, after: String): [Pet]
+  pet(id: ID!, owner: ID!): Pet
+  list: String
+  named: String
+  search(filter: Filter, order: String = "name"): Result
+}
+
+interface Pet { name: String }
+
+type Dog {
+  name: String!
+}
+
+type Cat implements Pet { name: String }
+
+union Result = Cat | Bird
+
+type Bird { name: String }
+
+enum Kind { DOG BIRD }
+
+input Filter {
+  name: [String]
+  kind: Kind
+  owner: ID!
+  age: Int
+}
+
+interface ChangedKind { a: String }
+`
+
+func TestFindBreakingChanges(t *testing.T) {
+	changes := FindBreakingChanges(buildSDL(t, oldChangesSDL), buildSDL(t, newChangesSDL))
+
+	want := []BreakingChange{
+		{Type: BreakingChangeTypeChangedKind, Description: "ChangedKind changed from an Object type to an Interface type.", Coordinate: "ChangedKind"},
+		{Type: BreakingChangeInterfaceRemovedFromObject, Description: "Dog no longer implements interface Pet.", Coordinate: "Dog"},
+		{Type: BreakingChangeFieldChangedKind, Description: "Filter.name changed type from String to [String].", Coordinate: "Filter.name"},
+		{Type: BreakingChangeNonNullInputFieldAdded, Description: "A non-null field owner on input type Filter was added.", Coordinate: "Filter.owner"},
+		{Type: BreakingChangeValueRemovedFromEnum, Description: "CAT was removed from enum type Kind.", Coordinate: "Kind.CAT"},
+		{Type: BreakingChangeNonNullArgAdded, Description: "A non-null arg owner on Query.pet was added", Coordinate: "Query.pet(owner:)"},
+		{Type: BreakingChangeFieldRemoved, Description: "Query.removed was removed.", Coordinate: "Query.removed"},
+		{Type: BreakingChangeFieldChangedKind, Description: "Query.list changed type from [String] to String.", Coordinate: "Query.list"},
+		{Type: BreakingChangeFieldChangedKind, Description: "Query.named changed type from String! to String.", Coordinate: "Query.named"},
+		{Type: BreakingChangeTypeRemoved, Description: "Removed was removed.", Coordinate: "Removed"},
+		{Type: BreakingChangeTypeRemovedFromUnion, Description: "Dog was removed from union type Result.", Coordinate: "Result"},
+	}
+
+	if len(changes) != len(want) {
+		t.Fatalf("FindBreakingChanges; got %d changes %+v wanted %d", len(changes), changes, len(want))
+	}
+	for idx, change := range changes {
+		change.Locations = nil
+		if !reflect.DeepEqual(change, want[idx]) {
+			t.Errorf("FindBreakingChanges[%d]; got %+v wanted %+v", idx, change, want[idx])
+		}
+	}
+}
+
+func TestFindDangerousChanges(t *testing.T) {
+	changes := FindDangerousChanges(buildSDL(t, oldChangesSDL), buildSDL(t, newChangesSDL))
+
+	want := []DangerousChange{
+		{Type: DangerousChangeInterfaceAddedToObject, Description: "Pet added to interfaces implemented by Cat.", Coordinate: "Cat"},
+		{Type: DangerousChangeOptionalInputFieldAdded, Description: "An optional field age on input type Filter was added.", Coordinate: "Filter.age"},
+		{Type: DangerousChangeValueAddedToEnum, Description: "BIRD was added to enum type Kind.", Coordinate: "Kind.BIRD"},
+		{Type: DangerousChangeArgDefaultValueChange, Description: "Query.pets arg first has changed defaultValue", Coordinate: "Query.pets(first:)"},
+		{Type: DangerousChangeOptionalArgAdded, Description: "An optional arg after on Query.pets was added", Coordinate: "Query.pets(after:)"},
+		{Type: DangerousChangeOptionalArgAdded, Description: "An optional arg order on Query.search was added", Coordinate: "Query.search(order:)"},
+		{Type: DangerousChangeTypeAddedToUnion, Description: "Bird was added to union type Result.", Coordinate: "Result"},
+	}
+
+	if len(changes) != len(want) {
+		t.Fatalf("FindDangerousChanges; got %d changes %+v wanted %d", len(changes), changes, len(want))
+	}
+	for idx, change := range changes {
+		change.Locations = nil
+		if !reflect.DeepEqual(change, want[idx]) {
+			t.Errorf("FindDangerousChanges[%d]; got %+v wanted %+v", idx, change, want[idx])
+		}
+	}
+}
+
+func TestFindSafeChanges(t *testing.T) {
+	oldSchema := buildSDL(t, `
+type Query {
+  a: String
+  b: String @deprecated
+  c: String
+}
+
+enum Kind { DOG CAT @deprecated }
+`)
+	newSchema := buildSDL(t, `
+"""The root."""
+type Query {
+  a: String @deprecated
+  b: String
+  """C."""
+  c: String
+  d: String
+}
+
+enum Kind { DOG @deprecated CAT }
+
+type Added { a: String }
+
+directive @added on FIELD
+`)
+
+	changes := FindSafeChanges(oldSchema, newSchema)
+
+	want := []SafeChange{
+		{Type: SafeChangeTypeAdded, Description: "Added was added.", Coordinate: "Added"},
+		{Type: SafeChangeDeprecationAdded, Description: "Kind.DOG was deprecated.", Coordinate: "Kind.DOG"},
+		{Type: SafeChangeDeprecationRemoved, Description: "Kind.CAT is no longer deprecated.", Coordinate: "Kind.CAT"},
+		{Type: SafeChangeDescriptionChanged, Description: "Description of Query changed.", Coordinate: "Query"},
+		{Type: SafeChangeDeprecationAdded, Description: "Query.a was deprecated.", Coordinate: "Query.a"},
+		{Type: SafeChangeDeprecationRemoved, Description: "Query.b is no longer deprecated.", Coordinate: "Query.b"},
+		{Type: SafeChangeDescriptionChanged, Description: "Description of Query.c changed.", Coordinate: "Query.c"},
+		{Type: SafeChangeFieldAdded, Description: "Query.d was added.", Coordinate: "Query.d"},
+		{Type: SafeChangeDirectiveAdded, Description: "@added was added.", Coordinate: "@added"},
+	}
+
+	if len(changes) != len(want) {
+		t.Fatalf("FindSafeChanges; got %d changes %+v wanted %d", len(changes), changes, len(want))
+	}
+	for idx, change := range changes {
+		if len(change.Locations) != 1 {
+			t.Errorf("FindSafeChanges[%d]; got locations %v wanted one", idx, change.Locations)
+		}
+		change.Locations = nil
+		if !reflect.DeepEqual(change, want[idx]) {
+			t.Errorf("FindSafeChanges[%d]; got %+v wanted %+v", idx, change, want[idx])
+		}
+	}
+}
+
+func TestFindChangesUnprintableDefaultValue(t *testing.T) {
+	schemaWithDefault := func(defaultValue interface{}) *Schema {
+		schema, err := NewSchema(SchemaConfig{
+			Query: NewObject(ObjectConfig{
+				Name: "Query",
+				Fields: Fields{{
+					Name: "f",
+					Type: String,
+					Args: []*Argument{{Name: "o", Type: opaqueScalar, DefaultValue: defaultValue}},
+				}},
+			}),
+		})
+		if err != nil {
+			t.Fatalf("NewSchema; got error %v", err)
+		}
+		return schema
+	}
+
+	changes := FindDangerousChanges(schemaWithDefault(1), schemaWithDefault(2))
+	if len(changes) != 1 || changes[0].Type != DangerousChangeArgDefaultValueChange {
+		t.Errorf("FindDangerousChanges; got %+v wanted a changed default value", changes)
+	}
+	if changes := FindDangerousChanges(schemaWithDefault(1), schemaWithDefault(1)); len(changes) != 0 {
+		t.Errorf("FindDangerousChanges of the same default value; got %+v wanted none", changes)
+	}
+}
+
+func TestFindChangesSafeTypeChanges(t *testing.T) {
+	oldSchema := buildSDL(t, `
+type Query {
+  a: String
+  b: [String]
+  c(arg: String!, list: [Int!]!): Int
+}
+`)
+	newSchema := buildSDL(t, `
+type Query {
+  a: String!
+  b: [String!]!
+  c(arg: String, list: [Int!]): Int
+}
+`)
+
+	if changes := FindBreakingChanges(oldSchema, newSchema); len(changes) != 0 {
+		t.Errorf("FindBreakingChanges; got %+v wanted none", changes)
+	}
+	if changes := FindDangerousChanges(oldSchema, newSchema); len(changes) != 0 {
+		t.Errorf("FindDangerousChanges; got %+v wanted none", changes)
+	}
+
+	// The reverse changes break clients.
+	if changes := FindBreakingChanges(newSchema, oldSchema); len(changes) != 4 {
+		t.Errorf("FindBreakingChanges reversed; got %+v wanted 4 changes", changes)
+	}
+}
+
+func TestFindChangesLocations(t *testing.T) {
+	changes := FindBreakingChanges(buildSDL(t, oldChangesSDL), buildSDL(t, newChangesSDL))
+
+	locations := map[string][]SourceLocation{}
+	for _, change := range changes {
+		locations[change.Coordinate] = change.Locations
+	}
+
+	// Removed elements are located in the old document.
+	if got := locations["Query.removed"]; !reflect.DeepEqual(got, []SourceLocation{{Line: 5, Column: 3}}) {
+		t.Errorf("Query.removed; got %+v wanted 5:3", got)
+	}
+	// Changed and added elements are located in the new document.
+	if got := locations["Query.pet(owner:)"]; !reflect.DeepEqual(got, []SourceLocation{{Line: 4, Column: 16}}) {
+		t.Errorf("Query.pet(owner:); got %+v wanted 4:16", got)
+	}
+
+	// Schemas which were not built from a document have no locations.
+	changes = FindBreakingChanges(schemaWithField(t, String), schemaWithField(t, Int))
+	if len(changes) != 1 || changes[0].Locations != nil {
+		t.Errorf("FindBreakingChanges; got %+v wanted one change without locations", changes)
+	}
+}
@@ -0,0 +1,472 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ijsnow/goql/internal/language"
+	"github.com/ijsnow/goql/internal/query"
+)
+
+// BuildClientSchema builds a Schema from the JSON result of the
+// introspection query, given either as the data of the response or as the
+// whole response.
+//
+// The schema describes the remote API, so that tools can validate queries
+// against it, but it cannot execute them: its fields have no resolvers and
+// its custom scalars accept any value.
+func BuildClientSchema(introspectionJSON []byte) (*Schema, error) {
+	var response struct {
+		IntrospectionResult
+		Data *IntrospectionResult `json:"data"`
+	}
+	if err := json.Unmarshal(introspectionJSON, &response); err != nil {
+		return nil, err
+	}
+
+	result := response.IntrospectionResult
+	if response.Data != nil {
+		result = *response.Data
+	}
+
+	return BuildClientSchemaFromResult(result)
+}
+
+// BuildClientSchemaFromResult builds a Schema from a decoded result of the
+// introspection query. See BuildClientSchema.
+func BuildClientSchemaFromResult(result IntrospectionResult) (*Schema, error) {
+	schemaIntrospection := result.Schema
+	if schemaIntrospection.Types == nil {
+		return nil, fmt.Errorf("Invalid or incomplete introspection result. Ensure that you are passing \"data\" property of introspection response and no \"errors\" was returned alongside.")
+	}
+
+	b := &clientSchemaBuilder{
+		introspectionByName: make(map[string]IntrospectionType, len(schemaIntrospection.Types)),
+		typeDefCache:        map[string]NamedType{},
+	}
+
+	// The specified scalars and the introspection types are the same in
+	// every schema, so they are used as they are.
+	for _, t := range SpecifiedScalarTypes {
+		b.typeDefCache[t.Name] = t
+	}
+	for _, t := range IntrospectionTypes {
+		b.typeDefCache[t.GetName()] = t
+	}
+
+	types := make([]NamedType, 0, len(schemaIntrospection.Types))
+	for _, typeIntrospection := range schemaIntrospection.Types {
+		b.introspectionByName[typeIntrospection.Name] = typeIntrospection
+	}
+	for _, typeIntrospection := range schemaIntrospection.Types {
+		t, err := b.getNamedType(typeIntrospection.Name)
+		if err != nil {
+			return nil, err
+		}
+		types = append(types, t)
+	}
+
+	config := SchemaConfig{Types: types}
+
+	var err error
+	if config.Query, err = b.getRootType(schemaIntrospection.QueryType, "queryType"); err != nil {
+		return nil, err
+	}
+	if config.Mutation, err = b.getRootType(schemaIntrospection.MutationType, "mutationType"); err != nil {
+		return nil, err
+	}
+	if config.Subscription, err = b.getRootType(schemaIntrospection.SubscriptionType, "subscriptionType"); err != nil {
+		return nil, err
+	}
+
+	for _, directiveIntrospection := range schemaIntrospection.Directives {
+		directive, err := b.buildDirective(directiveIntrospection)
+		if err != nil {
+			return nil, err
+		}
+		config.Directives = append(config.Directives, directive)
+	}
+
+	schema, err := NewSchema(config)
+
+	// Fields, interfaces and union members are built when the schema
+	// collects its types, so errors in them are only known now.
+	if b.err != nil {
+		return nil, b.err
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return schema, nil
+}
+
+// clientSchemaBuilder builds the types of a client schema. The fields of
+// types are built lazily, so that types may refer to each other, and the
+// first error found while building them is kept in err.
+type clientSchemaBuilder struct {
+	introspectionByName map[string]IntrospectionType
+	typeDefCache        map[string]NamedType
+	err                 error
+}
+
+func (b *clientSchemaBuilder) fail(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+func (b *clientSchemaBuilder) getRootType(ref *IntrospectionTypeRef, name string) (*Object, error) {
+	if ref == nil {
+		return nil, nil
+	}
+
+	t, err := b.getNamedType(ref.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	object, ok := t.(*Object)
+	if !ok {
+		return nil, fmt.Errorf("Introspection must provide object type for %s, but received: %s.", name, t)
+	}
+
+	return object, nil
+}
+
+func (b *clientSchemaBuilder) getType(ref IntrospectionTypeRef) (Type, error) {
+	switch ref.Kind {
+	case TypeKindList:
+		if ref.OfType == nil {
+			return nil, fmt.Errorf("Decorated type deeper than introspection query.")
+		}
+		itemType, err := b.getType(*ref.OfType)
+		if err != nil {
+			return nil, err
+		}
+		return NewList(itemType), nil
+	case TypeKindNonNull:
+		if ref.OfType == nil {
+			return nil, fmt.Errorf("Decorated type deeper than introspection query.")
+		}
+		nullableType, err := b.getType(*ref.OfType)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := nullableType.(*NonNull); ok {
+			return nil, fmt.Errorf("Introspection must provide a nullable type for NON_NULL, but received: %s.", nullableType)
+		}
+		return NewNonNull(nullableType), nil
+	}
+
+	if ref.Name == "" {
+		return nil, fmt.Errorf("Unknown type reference: %s.", inspectTypeRef(ref))
+	}
+
+	return b.getNamedType(ref.Name)
+}
+
+func (b *clientSchemaBuilder) getNamedType(typeName string) (NamedType, error) {
+	if t, ok := b.typeDefCache[typeName]; ok {
+		return t, nil
+	}
+
+	typeIntrospection, ok := b.introspectionByName[typeName]
+	if !ok {
+		return nil, fmt.Errorf("Invalid or incomplete schema, unknown type: %s. Ensure that a full introspection query is used in order to build a client schema.", typeName)
+	}
+
+	t, err := b.buildType(typeIntrospection)
+	if err != nil {
+		return nil, err
+	}
+	b.typeDefCache[typeName] = t
+
+	return t, nil
+}
+
+func (b *clientSchemaBuilder) getInputType(ref IntrospectionTypeRef) (Type, error) {
+	t, err := b.getType(ref)
+	if err != nil {
+		return nil, err
+	}
+	if !IsInputType(t) {
+		return nil, fmt.Errorf("Introspection must provide input type for arguments, but received: %s.", t)
+	}
+
+	return t, nil
+}
+
+func (b *clientSchemaBuilder) getOutputType(ref IntrospectionTypeRef) (Type, error) {
+	t, err := b.getType(ref)
+	if err != nil {
+		return nil, err
+	}
+	if !IsOutputType(t) {
+		return nil, fmt.Errorf("Introspection must provide output type for fields, but received: %s.", t)
+	}
+
+	return t, nil
+}
+
+func (b *clientSchemaBuilder) getObjectType(ref IntrospectionTypeRef) (*Object, error) {
+	t, err := b.getType(ref)
+	if err != nil {
+		return nil, err
+	}
+	object, ok := t.(*Object)
+	if !ok {
+		return nil, fmt.Errorf("Introspection must provide object type for possibleTypes, but received: %s.", t)
+	}
+
+	return object, nil
+}
+
+func (b *clientSchemaBuilder) getInterfaceType(ref IntrospectionTypeRef) (*Interface, error) {
+	t, err := b.getType(ref)
+	if err != nil {
+		return nil, err
+	}
+	iface, ok := t.(*Interface)
+	if !ok {
+		return nil, fmt.Errorf("Introspection must provide interface type for interfaces, but received: %s.", t)
+	}
+
+	return iface, nil
+}
+
+func (b *clientSchemaBuilder) buildType(t IntrospectionType) (NamedType, error) {
+	switch t.Kind {
+	case TypeKindScalar:
+		return NewScalar(ScalarConfig{
+			Name:           t.Name,
+			Description:    t.Description,
+			SpecifiedByURL: t.SpecifiedByURL,
+		}), nil
+	case TypeKindObject:
+		if t.Interfaces == nil {
+			return nil, fmt.Errorf("Introspection result missing interfaces: %s.", inspectIntrospectionType(t))
+		}
+		if t.Fields == nil {
+			return nil, fmt.Errorf("Introspection result missing fields: %s.", inspectIntrospectionType(t))
+		}
+		return NewObject(ObjectConfig{
+			Name:        t.Name,
+			Description: t.Description,
+			Interfaces: InterfacesFunc(func() Interfaces {
+				var interfaces Interfaces
+				for _, ref := range t.Interfaces {
+					iface, err := b.getInterfaceType(ref)
+					if err != nil {
+						b.fail(err)
+						continue
+					}
+					interfaces = append(interfaces, iface)
+				}
+				return interfaces
+			}),
+			Fields: FieldsFunc(func() Fields {
+				return b.buildFields(t)
+			}),
+		}), nil
+	case TypeKindInterface:
+		if t.Fields == nil {
+			return nil, fmt.Errorf("Introspection result missing fields: %s.", inspectIntrospectionType(t))
+		}
+		return NewInterface(InterfaceConfig{
+			Name:        t.Name,
+			Description: t.Description,
+			Fields: FieldsFunc(func() Fields {
+				return b.buildFields(t)
+			}),
+		}), nil
+	case TypeKindUnion:
+		if t.PossibleTypes == nil {
+			return nil, fmt.Errorf("Introspection result missing possibleTypes: %s.", inspectIntrospectionType(t))
+		}
+		return NewUnion(UnionConfig{
+			Name:        t.Name,
+			Description: t.Description,
+			Types: ObjectsFunc(func() Objects {
+				var objects Objects
+				for _, ref := range t.PossibleTypes {
+					object, err := b.getObjectType(ref)
+					if err != nil {
+						b.fail(err)
+						continue
+					}
+					objects = append(objects, object)
+				}
+				return objects
+			}),
+		}), nil
+	case TypeKindEnum:
+		if t.EnumValues == nil {
+			return nil, fmt.Errorf("Introspection result missing enumValues: %s.", inspectIntrospectionType(t))
+		}
+		values := make([]*EnumValue, 0, len(t.EnumValues))
+		for _, value := range t.EnumValues {
+			values = append(values, &EnumValue{
+				Name:              value.Name,
+				Description:       value.Description,
+				DeprecationReason: deprecationReasonOf(value.IsDeprecated, value.DeprecationReason),
+			})
+		}
+		return NewEnum(EnumConfig{
+			Name:        t.Name,
+			Description: t.Description,
+			Values:      values,
+		}), nil
+	case TypeKindInputObject:
+		if t.InputFields == nil {
+			return nil, fmt.Errorf("Introspection result missing inputFields: %s.", inspectIntrospectionType(t))
+		}
+		return NewInputObject(InputObjectConfig{
+			Name:        t.Name,
+			Description: t.Description,
+			Fields: InputFieldsFunc(func() InputFields {
+				var fields InputFields
+				for _, inputValue := range t.InputFields {
+					arg, err := b.buildInputValue(inputValue)
+					if err != nil {
+						b.fail(err)
+						continue
+					}
+					fields = append(fields, &InputField{
+						Name:              arg.Name,
+						Description:       arg.Description,
+						Type:              arg.Type,
+						DefaultValue:      arg.DefaultValue,
+						DeprecationReason: arg.DeprecationReason,
+					})
+				}
+				return fields
+			}),
+		}), nil
+	}
+
+	return nil, fmt.Errorf("Invalid or incomplete introspection result. Ensure that a full introspection query is used in order to build a client schema: %s.", inspectIntrospectionType(t))
+}
+
+func (b *clientSchemaBuilder) buildFields(t IntrospectionType) Fields {
+	fields := make(Fields, 0, len(t.Fields))
+	for _, fieldIntrospection := range t.Fields {
+		field, err := b.buildField(fieldIntrospection)
+		if err != nil {
+			b.fail(err)
+			continue
+		}
+		fields = append(fields, field)
+	}
+
+	return fields
+}
+
+func (b *clientSchemaBuilder) buildField(f IntrospectionField) (*Field, error) {
+	t, err := b.getOutputType(f.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	if f.Args == nil {
+		return nil, fmt.Errorf("Introspection result missing field args: %s.", f.Name)
+	}
+	args, err := b.buildArgs(f.Args)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Field{
+		Name:              f.Name,
+		Description:       f.Description,
+		Type:              t,
+		Args:              args,
+		DeprecationReason: deprecationReasonOf(f.IsDeprecated, f.DeprecationReason),
+	}, nil
+}
+
+func (b *clientSchemaBuilder) buildArgs(inputValues []IntrospectionInputValue) ([]*Argument, error) {
+	args := make([]*Argument, 0, len(inputValues))
+	for _, inputValue := range inputValues {
+		arg, err := b.buildInputValue(inputValue)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+
+	return args, nil
+}
+
+func (b *clientSchemaBuilder) buildInputValue(inputValue IntrospectionInputValue) (*Argument, error) {
+	t, err := b.getInputType(inputValue.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	var defaultValue interface{}
+	if inputValue.DefaultValue != nil {
+		valueNode, err := query.ParseValue(language.NewSource(*inputValue.DefaultValue))
+		if err != nil {
+			return nil, err
+		}
+		var ok bool
+		if defaultValue, ok = ValueFromAST(valueNode, t, nil); !ok {
+			return nil, fmt.Errorf("Introspection must provide a valid default value for %s, but received: %s.", inputValue.Name, *inputValue.DefaultValue)
+		}
+	}
+
+	return &Argument{
+		Name:              inputValue.Name,
+		Description:       inputValue.Description,
+		Type:              t,
+		DefaultValue:      defaultValue,
+		DeprecationReason: deprecationReasonOf(inputValue.IsDeprecated, inputValue.DeprecationReason),
+	}, nil
+}
+
+func (b *clientSchemaBuilder) buildDirective(d IntrospectionDirective) (*Directive, error) {
+	if d.Args == nil {
+		return nil, fmt.Errorf("Introspection result missing directive args: %s.", d.Name)
+	}
+	if d.Locations == nil {
+		return nil, fmt.Errorf("Introspection result missing directive locations: %s.", d.Name)
+	}
+
+	args, err := b.buildArgs(d.Args)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewDirective(DirectiveConfig{
+		Name:         d.Name,
+		Description:  d.Description,
+		Locations:    append([]DirectiveLocation(nil), d.Locations...),
+		Args:         args,
+		IsRepeatable: d.IsRepeatable,
+	}), nil
+}
+
+// deprecationReasonOf returns the reason to deprecate an element. An
+// element deprecated without a reason gets the default reason, since
+// elements are deprecated by giving a reason.
+func deprecationReasonOf(isDeprecated bool, reason string) string {
+	if !isDeprecated {
+		return ""
+	}
+	if reason == "" {
+		return DefaultDeprecationReason
+	}
+
+	return reason
+}
+
+func inspectTypeRef(ref IntrospectionTypeRef) string {
+	data, _ := json.Marshal(ref)
+	return string(data)
+}
+
+func inspectIntrospectionType(t IntrospectionType) string {
+	data, _ := json.Marshal(t)
+	return string(data)
+}
@@ -0,0 +1,413 @@
+package schema
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const petsIntrospection = `{
+  "data": {
+    "__schema": {
+      "queryType": { "name": "Query" },
+      "mutationType": null,
+      "subscriptionType": null,
+      "types": [
+        {
+          "kind": "OBJECT",
+          "name": "Query",
+          "description": null,
+          "fields": [
+            {
+              "name": "pets",
+              "description": "All the pets.",
+              "args": [
+                {
+                  "name": "first",
+                  "description": null,
+                  "type": { "kind": "SCALAR", "name": "Int", "ofType": null },
+                  "defaultValue": "10"
+                },
+                {
+                  "name": "kind",
+                  "description": null,
+                  "type": { "kind": "ENUM", "name": "Kind", "ofType": null },
+                  "defaultValue": "DOG"
+                }
+              ],
+              "type": {
+                "kind": "NON_NULL",
+                "name": null,
+                "ofType": {
+                  "kind": "LIST",
+                  "name": null,
+                  "ofType": { "kind": "INTERFACE", "name": "Pet", "ofType": null }
+                }
+              },
+              "isDeprecated": false,
+              "deprecationReason": null
+            },
+            {
+              "name": "search",
+              "description": null,
+              "args": [
+                {
+                  "name": "filter",
+                  "description": null,
+                  "type": { "kind": "INPUT_OBJECT", "name": "Filter", "ofType": null },
+                  "defaultValue": "{name: \"Rex\"}"
+                }
+              ],
+              "type": { "kind": "UNION", "name": "Result", "ofType": null },
+              "isDeprecated": true,
+              "deprecationReason": "Use pets."
+            }
+          ],
+          "inputFields": null,
+          "interfaces": [],
+          "enumValues": null,
+          "possibleTypes": null
+        },
+        {
+          "kind": "INTERFACE",
+          "name": "Pet",
+          "description": null,
+          "fields": [
+            {
+              "name": "name",
+              "description": null,
+              "args": [],
+              "type": { "kind": "SCALAR", "name": "String", "ofType": null },
+              "isDeprecated": false,
+              "deprecationReason": null
+            }
+          ],
+          "inputFields": null,
+          "interfaces": null,
+          "enumValues": null,
+          "possibleTypes": [{ "kind": "OBJECT", "name": "Dog", "ofType": null }]
+        },
+        {
+          "kind": "OBJECT",
+          "name": "Dog",
+          "description": "A good boy.",
+          "fields": [
+            {
+              "name": "name",
+              "description": null,
+              "args": [],
+              "type": { "kind": "SCALAR", "name": "String", "ofType": null },
+              "isDeprecated": false,
+              "deprecationReason": null
+            },
+            {
+              "name": "born",
+              "description": null,
+              "args": [],
+              "type": { "kind": "SCALAR", "name": "Date", "ofType": null },
+              "isDeprecated": false,
+              "deprecationReason": null
+            }
+          ],
+          "inputFields": null,
+          "interfaces": [{ "kind": "INTERFACE", "name": "Pet", "ofType": null }],
+          "enumValues": null,
+          "possibleTypes": null
+        },
+        {
+          "kind": "UNION",
+          "name": "Result",
+          "description": null,
+          "fields": null,
+          "inputFields": null,
+          "interfaces": null,
+          "enumValues": null,
+          "possibleTypes": [{ "kind": "OBJECT", "name": "Dog", "ofType": null }]
+        },
+        {
+          "kind": "ENUM",
+          "name": "Kind",
+          "description": null,
+          "fields": null,
+          "inputFields": null,
+          "interfaces": null,
+          "enumValues": [
+            { "name": "DOG", "description": null, "isDeprecated": false, "deprecationReason": null },
+            { "name": "CAT", "description": null, "isDeprecated": true, "deprecationReason": null }
+          ],
+          "possibleTypes": null
+        },
+        {
+          "kind": "INPUT_OBJECT",
+          "name": "Filter",
+          "description": null,
+          "fields": null,
+          "inputFields": [
+            {
+              "name": "name",
+              "description": null,
+              "type": { "kind": "SCALAR", "name": "String", "ofType": null },
+              "defaultValue": null
+            }
+          ],
+          "interfaces": null,
+          "enumValues": null,
+          "possibleTypes": null
+        },
+        {
+          "kind": "SCALAR",
+          "name": "Date",
+          "description": "A calendar date.",
+          "fields": null,
+          "inputFields": null,
+          "interfaces": null,
+          "enumValues": null,
+          "possibleTypes": null
+        },
+        {
+          "kind": "SCALAR",
+          "name": "String",
+          "description": null,
+          "fields": null,
+          "inputFields": null,
+          "interfaces": null,
+          "enumValues": null,
+          "possibleTypes": null
+        }
+      ],
+      "directives": [
+        {
+          "name": "cached",
+          "description": null,
+          "locations": ["FIELD", "QUERY"],
+          "args": [
+            {
+              "name": "ttl",
+              "description": null,
+              "type": { "kind": "NON_NULL", "name": null, "ofType": { "kind": "SCALAR", "name": "Int", "ofType": null } },
+              "defaultValue": null
+            }
+          ]
+        }
+      ]
+    }
+  }
+}`
+
+func TestGetIntrospectionQuery(t *testing.T) {
+	if got := GetIntrospectionQuery(DefaultIntrospectionOptions); got != IntrospectionQuery {
+		t.Errorf("GetIntrospectionQuery(DefaultIntrospectionOptions); got\n%s\nwanted IntrospectionQuery", got)
+	}
+
+	withoutDescriptions := GetIntrospectionQuery(IntrospectionOptions{})
+	if strings.Contains(withoutDescriptions, "description") {
+		t.Errorf("GetIntrospectionQuery without descriptions; got\n%s", withoutDescriptions)
+	}
+
+	all := GetIntrospectionQuery(IntrospectionOptions{
+		Descriptions:          true,
+		SpecifiedByURL:        true,
+		DirectiveIsRepeatable: true,
+		InputValueDeprecation: true,
+	})
+	for _, part := range []string{
+		"specifiedByURL",
+		"isRepeatable",
+		"args(includeDeprecated: true) {",
+		"inputFields(includeDeprecated: true) {",
+		"  defaultValue\n  isDeprecated\n  deprecationReason\n}",
+	} {
+		if !strings.Contains(all, part) {
+			t.Errorf("GetIntrospectionQuery with all options; missing %q in\n%s", part, all)
+		}
+	}
+}
+
+func TestBuildClientSchema(t *testing.T) {
+	schema, err := BuildClientSchema([]byte(petsIntrospection))
+	if err != nil {
+		t.Fatalf("BuildClientSchema; got error %v", err)
+	}
+
+	if errs := Validate(schema); len(errs) != 0 {
+		t.Errorf("Validate; got %v", errs)
+	}
+
+	query := schema.GetQueryType()
+	if query == nil || query.Name != "Query" {
+		t.Fatalf("GetQueryType; got %v", query)
+	}
+	if schema.GetMutationType() != nil {
+		t.Errorf("GetMutationType; got %v wanted nil", schema.GetMutationType())
+	}
+
+	pets := query.GetField("pets")
+	if got := pets.Type.String(); got != "[Pet]!" {
+		t.Errorf("Query.pets type; got %s wanted [Pet]!", got)
+	}
+	if pets.Description != "All the pets." {
+		t.Errorf("Query.pets description; got %q", pets.Description)
+	}
+	if got := pets.GetArg("first").DefaultValue; got != 10 {
+		t.Errorf("Query.pets(first:) default value; got %#v wanted 10", got)
+	}
+	if got := pets.GetArg("kind").DefaultValue; got != "DOG" {
+		t.Errorf("Query.pets(kind:) default value; got %#v wanted DOG", got)
+	}
+	if pets.GetArg("first").Type != Int {
+		t.Errorf("Query.pets(first:) is not the specified Int")
+	}
+
+	search := query.GetField("search")
+	if !search.IsDeprecated || search.DeprecationReason != "Use pets." {
+		t.Errorf("Query.search deprecation; got %v %q", search.IsDeprecated, search.DeprecationReason)
+	}
+	wantFilter := map[string]interface{}{"name": "Rex"}
+	if got := search.GetArg("filter").DefaultValue; !reflect.DeepEqual(got, wantFilter) {
+		t.Errorf("Query.search(filter:) default value; got %#v wanted %#v", got, wantFilter)
+	}
+
+	pet, _ := schema.GetType("Pet").(*Interface)
+	dog, _ := schema.GetType("Dog").(*Object)
+	if pet == nil || dog == nil {
+		t.Fatalf("GetType(Pet), GetType(Dog); got %v, %v", schema.GetType("Pet"), schema.GetType("Dog"))
+	}
+	if !schema.IsPossibleType(pet, dog) {
+		t.Errorf("IsPossibleType(Pet, Dog); got false")
+	}
+	if dog.Description != "A good boy." {
+		t.Errorf("Dog description; got %q", dog.Description)
+	}
+
+	result, _ := schema.GetType("Result").(*Union)
+	if result == nil || !reflect.DeepEqual(result.GetTypes(), []*Object{dog}) {
+		t.Errorf("Result types; got %v", result)
+	}
+
+	kind, _ := schema.GetType("Kind").(*Enum)
+	if cat := kind.GetValue("CAT"); !cat.IsDeprecated || cat.DeprecationReason != DefaultDeprecationReason {
+		t.Errorf("Kind.CAT deprecation; got %v %q", cat.IsDeprecated, cat.DeprecationReason)
+	}
+
+	date, _ := schema.GetType("Date").(*Scalar)
+	if date == nil || date.Description != "A calendar date." {
+		t.Fatalf("GetType(Date); got %v", schema.GetType("Date"))
+	}
+	if got, err := date.ParseValue("2017-07-04"); err != nil || got != "2017-07-04" {
+		t.Errorf("Date.ParseValue; got %v, %v", got, err)
+	}
+
+	if schema.GetType("String") != String {
+		t.Errorf("GetType(String) is not the specified String")
+	}
+	if schema.GetType("__Schema") != SchemaType {
+		t.Errorf("GetType(__Schema) is not the introspection __Schema")
+	}
+
+	cached := schema.GetDirective("cached")
+	if cached == nil || !reflect.DeepEqual(cached.Locations, []DirectiveLocation{DirectiveLocationField, DirectiveLocationQuery}) {
+		t.Fatalf("GetDirective(cached); got %v", cached)
+	}
+	if got := cached.GetArg("ttl").Type.String(); got != "Int!" {
+		t.Errorf("@cached(ttl:) type; got %s", got)
+	}
+	if schema.GetDirective("skip") != SkipDirective {
+		t.Errorf("GetDirective(skip) is not the specified @skip")
+	}
+}
+
+func TestBuildClientSchemaAcceptsData(t *testing.T) {
+	data := `{"__schema": {
+		"queryType": {"name": "Query"},
+		"types": [{
+			"kind": "OBJECT", "name": "Query", "interfaces": [],
+			"fields": [{"name": "hello", "args": [], "type": {"kind": "SCALAR", "name": "String"}}]
+		}],
+		"directives": []
+	}}`
+
+	schema, err := BuildClientSchema([]byte(data))
+	if err != nil {
+		t.Fatalf("BuildClientSchema; got error %v", err)
+	}
+	if schema.GetQueryType().GetField("hello").Type != String {
+		t.Errorf("Query.hello; got %v", schema.GetQueryType().GetField("hello"))
+	}
+}
+
+func TestBuildClientSchemaErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want string
+	}{
+		{
+			name: "missing schema",
+			json: `{"errors": [{"message": "Not allowed."}]}`,
+			want: `Invalid or incomplete introspection result.`,
+		},
+		{
+			name: "unknown type",
+			json: `{"__schema": {"queryType": {"name": "Query"}, "directives": [], "types": [
+				{"kind": "OBJECT", "name": "Query", "interfaces": [],
+				 "fields": [{"name": "pet", "args": [], "type": {"kind": "OBJECT", "name": "Pet"}}]}
+			]}}`,
+			want: "Invalid or incomplete schema, unknown type: Pet. Ensure that a full introspection query is used in order to build a client schema.",
+		},
+		{
+			name: "deep type",
+			json: `{"__schema": {"queryType": {"name": "Query"}, "directives": [], "types": [
+				{"kind": "OBJECT", "name": "Query", "interfaces": [],
+				 "fields": [{"name": "pets", "args": [], "type": {"kind": "LIST", "ofType": {"kind": "NON_NULL"}}}]}
+			]}}`,
+			want: "Decorated type deeper than introspection query.",
+		},
+		{
+			name: "missing fields",
+			json: `{"__schema": {"queryType": {"name": "Query"}, "directives": [], "types": [
+				{"kind": "OBJECT", "name": "Query", "interfaces": []}
+			]}}`,
+			want: "Introspection result missing fields:",
+		},
+		{
+			name: "input type for field",
+			json: `{"__schema": {"queryType": {"name": "Query"}, "directives": [], "types": [
+				{"kind": "OBJECT", "name": "Query", "interfaces": [],
+				 "fields": [{"name": "filter", "args": [], "type": {"kind": "INPUT_OBJECT", "name": "Filter"}}]},
+				{"kind": "INPUT_OBJECT", "name": "Filter", "inputFields": []}
+			]}}`,
+			want: "Introspection must provide output type for fields, but received: Filter.",
+		},
+		{
+			name: "query type not an object",
+			json: `{"__schema": {"queryType": {"name": "Kind"}, "directives": [], "types": [
+				{"kind": "ENUM", "name": "Kind", "enumValues": [{"name": "DOG"}]}
+			]}}`,
+			want: "Introspection must provide object type for queryType, but received: Kind.",
+		},
+		{
+			name: "invalid default value",
+			json: `{"__schema": {"queryType": {"name": "Query"}, "directives": [], "types": [
+				{"kind": "OBJECT", "name": "Query", "interfaces": [],
+				 "fields": [{"name": "a", "type": {"kind": "SCALAR", "name": "Int"},
+				  "args": [{"name": "x", "type": {"kind": "SCALAR", "name": "Int"}, "defaultValue": "\"abc\""}]}]},
+				{"kind": "SCALAR", "name": "Int"}
+			]}}`,
+			want: `Introspection must provide a valid default value for x, but received: "abc".`,
+		},
+		{
+			name: "unknown kind",
+			json: `{"__schema": {"queryType": {"name": "Query"}, "directives": [], "types": [
+				{"kind": "THING", "name": "Query"}
+			]}}`,
+			want: "Invalid or incomplete introspection result. Ensure that a full introspection query is used in order to build a client schema:",
+		},
+	}
+
+	for _, test := range tests {
+		_, err := BuildClientSchema([]byte(test.json))
+		if err == nil || !strings.HasPrefix(err.Error(), test.want) {
+			t.Errorf("%s: BuildClientSchema; got error %v wanted %q", test.name, err, test.want)
+		}
+	}
+}
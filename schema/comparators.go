@@ -0,0 +1,110 @@
+package schema
+
+// IsEqualType reports whether two types are the same, wrapped in the same
+// lists and non-nulls.
+func IsEqualType(typeA, typeB Type) bool {
+	// Equivalent types are equal.
+	if typeA == typeB {
+		return true
+	}
+
+	// If either type is non-null, the other must also be non-null.
+	if nonNullA, ok := typeA.(*NonNull); ok {
+		if nonNullB, ok := typeB.(*NonNull); ok {
+			return IsEqualType(nonNullA.OfType, nonNullB.OfType)
+		}
+		return false
+	}
+
+	// If either type is a list, the other must also be a list.
+	if listA, ok := typeA.(*List); ok {
+		if listB, ok := typeB.(*List); ok {
+			return IsEqualType(listA.OfType, listB.OfType)
+		}
+		return false
+	}
+
+	// Otherwise the types are not equal.
+	return false
+}
+
+// IsTypeSubTypeOf reports whether a value of maybeSubType is always a valid
+// value of superType in the schema, which is the case when it is the same
+// type, a non-null version of it, or an object type possible for it.
+func IsTypeSubTypeOf(schema *Schema, maybeSubType, superType Type) bool {
+	// Equivalent type is a valid subtype
+	if maybeSubType == superType {
+		return true
+	}
+
+	// If superType is non-null, maybeSubType must also be non-null.
+	if superNonNull, ok := superType.(*NonNull); ok {
+		if subNonNull, ok := maybeSubType.(*NonNull); ok {
+			return IsTypeSubTypeOf(schema, subNonNull.OfType, superNonNull.OfType)
+		}
+		return false
+	} else if subNonNull, ok := maybeSubType.(*NonNull); ok {
+		// If superType is nullable, maybeSubType may be non-null or nullable.
+		return IsTypeSubTypeOf(schema, subNonNull.OfType, superType)
+	}
+
+	// If superType type is a list, maybeSubType type must also be a list.
+	if superList, ok := superType.(*List); ok {
+		if subList, ok := maybeSubType.(*List); ok {
+			return IsTypeSubTypeOf(schema, subList.OfType, superList.OfType)
+		}
+		return false
+	} else if _, ok := maybeSubType.(*List); ok {
+		// If superType is not a list, maybeSubType must also be not a list.
+		return false
+	}
+
+	// If superType type is an abstract type, maybeSubType type may be a
+	// currently possible object type.
+	if abstract, ok := superType.(AbstractType); ok {
+		if object, ok := maybeSubType.(*Object); ok {
+			return schema.IsPossibleType(abstract, object)
+		}
+	}
+
+	// Otherwise, the child type is not a valid subtype of the parent type.
+	return false
+}
+
+// DoTypesOverlap reports whether the two composite types have an object type
+// in common, which is when a selection on one may apply to a value of the
+// other.
+func DoTypesOverlap(schema *Schema, typeA, typeB CompositeType) bool {
+	// Equivalent types overlap
+	if typeA == typeB {
+		return true
+	}
+
+	if abstractA, ok := typeA.(AbstractType); ok {
+		if abstractB, ok := typeB.(AbstractType); ok {
+			// If both types are abstract, then determine if there is any
+			// intersection between possible concrete types of each.
+			for _, t := range schema.PossibleTypes(abstractA) {
+				if schema.IsPossibleType(abstractB, t) {
+					return true
+				}
+			}
+			return false
+		}
+
+		// Determine if the latter type is a possible concrete type of the
+		// former.
+		object, _ := typeB.(*Object)
+		return schema.IsPossibleType(abstractA, object)
+	}
+
+	if abstractB, ok := typeB.(AbstractType); ok {
+		// Determine if the former type is a possible concrete type of the
+		// latter.
+		object, _ := typeA.(*Object)
+		return schema.IsPossibleType(abstractB, object)
+	}
+
+	// Otherwise the types do not overlap.
+	return false
+}
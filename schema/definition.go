@@ -0,0 +1,988 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/ijsnow/goql/internal/errors"
+	"github.com/ijsnow/goql/internal/language"
+)
+
+// Predicates & Assertions
+
+// Type is implemented by every GraphQL type: the named types and the List
+// and NonNull wrappers around them.
+type Type interface {
+	String() string
+	isType()
+}
+
+// NamedType is a type that is not a List or NonNull wrapper.
+type NamedType interface {
+	Type
+	GetName() string
+	GetDescription() string
+}
+
+// LeafType is a type that may be a leaf value in a response: a Scalar or an
+// Enum.
+type LeafType interface {
+	NamedType
+	Serialize(value interface{}) (interface{}, error)
+	ParseValue(value interface{}) (interface{}, error)
+	ParseLiteral(valueNode language.ValueNode) (interface{}, error)
+}
+
+// CompositeType is a type that may be the parent context of a selection set:
+// an Object, an Interface or a Union.
+type CompositeType interface {
+	NamedType
+	isCompositeType()
+}
+
+// AbstractType is a type whose concrete type is determined at runtime: an
+// Interface or a Union.
+type AbstractType interface {
+	CompositeType
+	GetResolveType() TypeResolveFn
+}
+
+// WrappingType is a List or a NonNull.
+type WrappingType interface {
+	Type
+	GetOfType() Type
+}
+
+// IsInputType reports whether the type may be used as the type of an
+// argument, a variable or an input object field.
+func IsInputType(t Type) bool {
+	switch t := t.(type) {
+	case *Scalar, *Enum, *InputObject:
+		return true
+	case *List:
+		return IsInputType(t.OfType)
+	case *NonNull:
+		return IsInputType(t.OfType)
+	}
+
+	return false
+}
+
+// IsOutputType reports whether the type may be used as the type of a field.
+func IsOutputType(t Type) bool {
+	switch t := t.(type) {
+	case *Scalar, *Object, *Interface, *Union, *Enum:
+		return true
+	case *List:
+		return IsOutputType(t.OfType)
+	case *NonNull:
+		return IsOutputType(t.OfType)
+	}
+
+	return false
+}
+
+// IsLeafType reports whether the type is a Scalar or an Enum.
+func IsLeafType(t Type) bool {
+	_, ok := t.(LeafType)
+	return ok
+}
+
+// IsCompositeType reports whether the type is an Object, an Interface or a
+// Union.
+func IsCompositeType(t Type) bool {
+	_, ok := t.(CompositeType)
+	return ok
+}
+
+// IsAbstractType reports whether the type is an Interface or a Union.
+func IsAbstractType(t Type) bool {
+	_, ok := t.(AbstractType)
+	return ok
+}
+
+// IsNamedType reports whether the type is not a List or NonNull wrapper.
+func IsNamedType(t Type) bool {
+	_, ok := t.(NamedType)
+	return ok
+}
+
+// IsWrappingType reports whether the type is a List or a NonNull.
+func IsWrappingType(t Type) bool {
+	_, ok := t.(WrappingType)
+	return ok
+}
+
+// GetNullableType returns the type wrapped by a NonNull, or the type itself.
+func GetNullableType(t Type) Type {
+	if nonNull, ok := t.(*NonNull); ok {
+		return nonNull.OfType
+	}
+
+	return t
+}
+
+// GetNamedType unwraps every List and NonNull around the type.
+func GetNamedType(t Type) NamedType {
+	for {
+		switch wrapper := t.(type) {
+		case WrappingType:
+			t = wrapper.GetOfType()
+		case NamedType:
+			return wrapper
+		default:
+			return nil
+		}
+	}
+}
+
+// Resolvers
+
+// FieldResolveFn produces the value of a field.
+type FieldResolveFn func(p ResolveParams) (interface{}, error)
+
+// TypeResolveFn determines the concrete Object type of a value of an
+// abstract type.
+type TypeResolveFn func(p ResolveTypeParams) *Object
+
+// IsTypeOfFn reports whether a value belongs to an Object type. It is used
+// when the abstract type a value is returned as has no TypeResolveFn.
+type IsTypeOfFn func(p IsTypeOfParams) bool
+
+// ResolveParams are the parameters a FieldResolveFn is called with.
+type ResolveParams struct {
+	/**
+	 * Source is the value of the parent object.
+	 */
+	Source interface{}
+
+	/**
+	 * Args are the coerced values of the field's arguments.
+	 */
+	Args map[string]interface{}
+
+	/**
+	 * Context is the context of the request.
+	 */
+	Context context.Context
+
+	/**
+	 * Info holds the execution state of the field.
+	 */
+	Info ResolveInfo
+}
+
+// ResolveTypeParams are the parameters a TypeResolveFn is called with.
+type ResolveTypeParams struct {
+	Value   interface{}
+	Context context.Context
+	Info    ResolveInfo
+}
+
+// IsTypeOfParams are the parameters an IsTypeOfFn is called with.
+type IsTypeOfParams struct {
+	Value   interface{}
+	Context context.Context
+	Info    ResolveInfo
+}
+
+// ResolveInfo describes the field being resolved and the operation it
+// belongs to.
+type ResolveInfo struct {
+	FieldName      string
+	FieldNodes     []*language.FieldNode
+	ReturnType     Type
+	ParentType     CompositeType
+	Path           *ResponsePath
+	Schema         *Schema
+	Fragments      map[string]*language.FragmentDefinitionNode
+	RootValue      interface{}
+	Operation      *language.OperationDefinitionNode
+	VariableValues map[string]interface{}
+}
+
+// ResponsePath is a linked list of the keys leading from the root of the
+// response to a field.
+type ResponsePath struct {
+	Prev *ResponsePath
+	Key  interface{}
+}
+
+// AsSlice returns the keys of the path from the root of the response, in the
+// form used by the path of errors.
+func (p *ResponsePath) AsSlice() []interface{} {
+	var keys []interface{}
+	for curr := p; curr != nil; curr = curr.Prev {
+		keys = append([]interface{}{curr.Key}, keys...)
+	}
+
+	return keys
+}
+
+// Scalar Type Definition
+
+// SerializeFn converts an internal value to the value sent in a response.
+type SerializeFn func(value interface{}) (interface{}, error)
+
+// ParseValueFn converts a value from variables to its internal value.
+type ParseValueFn func(value interface{}) (interface{}, error)
+
+// ParseLiteralFn converts a literal in a query document to its internal
+// value.
+type ParseLiteralFn func(valueNode language.ValueNode) (interface{}, error)
+
+// ScalarConfig configuration for a Scalar
+type ScalarConfig struct {
+	Name           string
+	Description    string
+	SpecifiedByURL string
+	Serialize      SerializeFn
+	ParseValue     ParseValueFn
+	ParseLiteral   ParseLiteralFn
+	AstNode        *language.ScalarTypeDefinitionNode
+}
+
+// Scalar is a leaf value of a response, such as a string or a number. Its
+// configuration describes how values are serialized in responses and parsed
+// from variables and literals.
+type Scalar struct {
+	Name        string
+	Description string
+	AstNode     *language.ScalarTypeDefinitionNode
+
+	/**
+	 * SpecifiedByURL links to the specification of the scalar, and is
+	 * exposed through the @specifiedBy directive.
+	 */
+	SpecifiedByURL string
+
+	config ScalarConfig
+}
+
+// NewScalar creates a Scalar from its configuration.
+func NewScalar(config ScalarConfig) *Scalar {
+	return &Scalar{
+		Name:           config.Name,
+		Description:    config.Description,
+		AstNode:        config.AstNode,
+		SpecifiedByURL: config.SpecifiedByURL,
+		config:         config,
+	}
+}
+
+// ScalarDefinition describes how the values of a custom scalar are coerced.
+// Serialize converts internal values for responses, ParseValue converts
+// values of variables, and ParseLiteral converts literals in documents.
+// Failures should be reported with NewScalarError and NewLiteralError.
+type ScalarDefinition interface {
+	Serialize(value interface{}) (interface{}, error)
+	ParseValue(value interface{}) (interface{}, error)
+	ParseLiteral(valueNode ValueNode) (interface{}, error)
+}
+
+// DefineScalar creates a Scalar whose values are coerced by the given
+// definition.
+//
+//	type money struct{}
+//
+//	func (money) Serialize(value interface{}) (interface{}, error) { ... }
+//	func (money) ParseValue(value interface{}) (interface{}, error) { ... }
+//	func (money) ParseLiteral(valueNode schema.ValueNode) (interface{}, error) { ... }
+//
+//	var Money = schema.DefineScalar("Money", "An amount in cents.", money{})
+func DefineScalar(name string, description string, definition ScalarDefinition) *Scalar {
+	return NewScalar(ScalarConfig{
+		Name:         name,
+		Description:  description,
+		Serialize:    definition.Serialize,
+		ParseValue:   definition.ParseValue,
+		ParseLiteral: definition.ParseLiteral,
+	})
+}
+
+// Serialize converts an internal value to the value sent in a response.
+func (s *Scalar) Serialize(value interface{}) (interface{}, error) {
+	if s.config.Serialize == nil {
+		return value, nil
+	}
+
+	return s.config.Serialize(value)
+}
+
+// ParseValue converts a value from variables to its internal value.
+func (s *Scalar) ParseValue(value interface{}) (interface{}, error) {
+	if s.config.ParseValue == nil {
+		return value, nil
+	}
+
+	return s.config.ParseValue(value)
+}
+
+// ParseLiteral converts a literal in a query document to its internal value.
+func (s *Scalar) ParseLiteral(valueNode language.ValueNode) (interface{}, error) {
+	if s.config.ParseLiteral == nil {
+		return ValueFromASTUntyped(valueNode), nil
+	}
+
+	return s.config.ParseLiteral(valueNode)
+}
+
+// GetName returns the name of the type.
+func (s *Scalar) GetName() string { return s.Name }
+
+// GetDescription returns the description of the type.
+func (s *Scalar) GetDescription() string { return s.Description }
+
+func (s *Scalar) String() string { return s.Name }
+
+func (s *Scalar) isType() {}
+
+// Object Type Definition
+
+// Field a field of an Object or Interface type. The same struct is used to
+// configure a field and to describe it once the type is defined.
+type Field struct {
+	Name              string
+	Description       string
+	Type              Type
+	Args              []*Argument
+	Resolve           FieldResolveFn
+	DeprecationReason string
+	AstNode           *language.FieldDefinitionNode
+
+	/**
+	 * IsDeprecated is set when the type is defined, from DeprecationReason.
+	 */
+	IsDeprecated bool
+}
+
+// Argument an argument of a Field or a Directive.
+type Argument struct {
+	Name              string
+	Description       string
+	Type              Type
+	DefaultValue      interface{}
+	DeprecationReason string
+	AstNode           *language.InputValueDefinitionNode
+}
+
+// GetArg returns the argument with the given name, or nil.
+func (f *Field) GetArg(name string) *Argument {
+	for _, arg := range f.Args {
+		if arg.Name == name {
+			return arg
+		}
+	}
+
+	return nil
+}
+
+// FieldsThunk provides the fields of an Object or Interface. It is either a
+// Fields list, or a FieldsFunc when the fields refer to types that are not
+// yet declared.
+type FieldsThunk interface {
+	resolveFields() Fields
+}
+
+// Fields is a list of fields, in the order they are defined.
+type Fields []*Field
+
+func (f Fields) resolveFields() Fields { return f }
+
+// FieldsFunc returns the fields of a type when the type is first used.
+type FieldsFunc func() Fields
+
+func (f FieldsFunc) resolveFields() Fields { return f() }
+
+// InterfacesThunk provides the interfaces an Object implements. It is either
+// an Interfaces list or an InterfacesFunc.
+type InterfacesThunk interface {
+	resolveInterfaces() Interfaces
+}
+
+// Interfaces is a list of interfaces.
+type Interfaces []*Interface
+
+func (i Interfaces) resolveInterfaces() Interfaces { return i }
+
+// InterfacesFunc returns the interfaces of an Object when it is first used.
+type InterfacesFunc func() Interfaces
+
+func (f InterfacesFunc) resolveInterfaces() Interfaces { return f() }
+
+// ObjectConfig configuration for an Object
+type ObjectConfig struct {
+	Name              string
+	Description       string
+	Interfaces        InterfacesThunk
+	Fields            FieldsThunk
+	IsTypeOf          IsTypeOfFn
+	AstNode           *language.ObjectTypeDefinitionNode
+	ExtensionASTNodes []*language.TypeExtensionDefinitionNode
+}
+
+// Object is the type of most values in a response, a named set of fields.
+//
+// Fields and interfaces may be given as functions so that types can refer to
+// each other, or to themselves:
+//
+//	var Person = schema.NewObject(schema.ObjectConfig{
+//		Name: "Person",
+//		Fields: schema.FieldsFunc(func() schema.Fields {
+//			return schema.Fields{
+//				{Name: "name", Type: schema.String},
+//				{Name: "bestFriend", Type: Person},
+//			}
+//		}),
+//	})
+type Object struct {
+	Name              string
+	Description       string
+	IsTypeOf          IsTypeOfFn
+	AstNode           *language.ObjectTypeDefinitionNode
+	ExtensionASTNodes []*language.TypeExtensionDefinitionNode
+
+	config ObjectConfig
+
+	fieldsOnce sync.Once
+	fields     fieldMap
+
+	interfacesOnce sync.Once
+	interfaces     []*Interface
+}
+
+// NewObject creates an Object from its configuration. Its fields and
+// interfaces are resolved the first time they are requested.
+func NewObject(config ObjectConfig) *Object {
+	return &Object{
+		Name:              config.Name,
+		Description:       config.Description,
+		IsTypeOf:          config.IsTypeOf,
+		AstNode:           config.AstNode,
+		ExtensionASTNodes: config.ExtensionASTNodes,
+		config:            config,
+	}
+}
+
+// GetFields returns the fields of the type, in the order they are defined.
+func (o *Object) GetFields() []*Field {
+	o.fieldsOnce.Do(func() {
+		o.fields = defineFieldMap(o.config.Fields)
+	})
+
+	return o.fields.list
+}
+
+// GetField returns the field with the given name, or nil.
+func (o *Object) GetField(name string) *Field {
+	o.GetFields()
+	return o.fields.byName[name]
+}
+
+// GetInterfaces returns the interfaces the type implements.
+func (o *Object) GetInterfaces() []*Interface {
+	o.interfacesOnce.Do(func() {
+		if o.config.Interfaces != nil {
+			o.interfaces = o.config.Interfaces.resolveInterfaces()
+		}
+	})
+
+	return o.interfaces
+}
+
+// GetName returns the name of the type.
+func (o *Object) GetName() string { return o.Name }
+
+// GetDescription returns the description of the type.
+func (o *Object) GetDescription() string { return o.Description }
+
+func (o *Object) String() string { return o.Name }
+
+func (o *Object) isType()          {}
+func (o *Object) isCompositeType() {}
+
+// fieldMap holds the fields of a type both in definition order and by name.
+type fieldMap struct {
+	list   []*Field
+	byName map[string]*Field
+}
+
+func defineFieldMap(thunk FieldsThunk) fieldMap {
+	var fields Fields
+	if thunk != nil {
+		fields = thunk.resolveFields()
+	}
+
+	m := fieldMap{byName: make(map[string]*Field, len(fields))}
+	for _, config := range fields {
+		if config == nil {
+			continue
+		}
+
+		field := *config
+		field.IsDeprecated = field.DeprecationReason != ""
+
+		m.list = append(m.list, &field)
+		if _, exists := m.byName[field.Name]; !exists {
+			m.byName[field.Name] = &field
+		}
+	}
+
+	return m
+}
+
+// Interface Type Definition
+
+// InterfaceConfig configuration for an Interface
+type InterfaceConfig struct {
+	Name        string
+	Description string
+	Fields      FieldsThunk
+	ResolveType TypeResolveFn
+	AstNode     *language.InterfaceTypeDefinitionNode
+}
+
+// Interface is an abstract type with a set of fields that the Object types
+// implementing it are guaranteed to have.
+type Interface struct {
+	Name        string
+	Description string
+	ResolveType TypeResolveFn
+	AstNode     *language.InterfaceTypeDefinitionNode
+
+	config InterfaceConfig
+
+	fieldsOnce sync.Once
+	fields     fieldMap
+}
+
+// NewInterface creates an Interface from its configuration.
+func NewInterface(config InterfaceConfig) *Interface {
+	return &Interface{
+		Name:        config.Name,
+		Description: config.Description,
+		ResolveType: config.ResolveType,
+		AstNode:     config.AstNode,
+		config:      config,
+	}
+}
+
+// GetFields returns the fields of the type, in the order they are defined.
+func (i *Interface) GetFields() []*Field {
+	i.fieldsOnce.Do(func() {
+		i.fields = defineFieldMap(i.config.Fields)
+	})
+
+	return i.fields.list
+}
+
+// GetField returns the field with the given name, or nil.
+func (i *Interface) GetField(name string) *Field {
+	i.GetFields()
+	return i.fields.byName[name]
+}
+
+// GetResolveType returns the function determining the concrete type of
+// values, which may be nil.
+func (i *Interface) GetResolveType() TypeResolveFn { return i.ResolveType }
+
+// GetName returns the name of the type.
+func (i *Interface) GetName() string { return i.Name }
+
+// GetDescription returns the description of the type.
+func (i *Interface) GetDescription() string { return i.Description }
+
+func (i *Interface) String() string { return i.Name }
+
+func (i *Interface) isType()          {}
+func (i *Interface) isCompositeType() {}
+
+// Union Type Definition
+
+// ObjectsThunk provides the member types of a Union. It is either an Objects
+// list or an ObjectsFunc.
+type ObjectsThunk interface {
+	resolveObjects() Objects
+}
+
+// Objects is a list of Object types.
+type Objects []*Object
+
+func (o Objects) resolveObjects() Objects { return o }
+
+// ObjectsFunc returns the member types of a Union when it is first used.
+type ObjectsFunc func() Objects
+
+func (f ObjectsFunc) resolveObjects() Objects { return f() }
+
+// UnionConfig configuration for a Union
+type UnionConfig struct {
+	Name        string
+	Description string
+	Types       ObjectsThunk
+	ResolveType TypeResolveFn
+	AstNode     *language.UnionTypeDefinitionNode
+}
+
+// Union is an abstract type whose values are one of a list of Object types.
+type Union struct {
+	Name        string
+	Description string
+	ResolveType TypeResolveFn
+	AstNode     *language.UnionTypeDefinitionNode
+
+	config UnionConfig
+
+	typesOnce sync.Once
+	types     []*Object
+}
+
+// NewUnion creates a Union from its configuration.
+func NewUnion(config UnionConfig) *Union {
+	return &Union{
+		Name:        config.Name,
+		Description: config.Description,
+		ResolveType: config.ResolveType,
+		AstNode:     config.AstNode,
+		config:      config,
+	}
+}
+
+// GetTypes returns the member types of the union.
+func (u *Union) GetTypes() []*Object {
+	u.typesOnce.Do(func() {
+		if u.config.Types != nil {
+			u.types = u.config.Types.resolveObjects()
+		}
+	})
+
+	return u.types
+}
+
+// GetResolveType returns the function determining the concrete type of
+// values, which may be nil.
+func (u *Union) GetResolveType() TypeResolveFn { return u.ResolveType }
+
+// GetName returns the name of the type.
+func (u *Union) GetName() string { return u.Name }
+
+// GetDescription returns the description of the type.
+func (u *Union) GetDescription() string { return u.Description }
+
+func (u *Union) String() string { return u.Name }
+
+func (u *Union) isType()          {}
+func (u *Union) isCompositeType() {}
+
+// Enum Type Definition
+
+// EnumValue a value of an Enum. Value is the internal value the name maps
+// to, and defaults to the name.
+type EnumValue struct {
+	Name              string
+	Description       string
+	Value             interface{}
+	DeprecationReason string
+	AstNode           *language.EnumValueDefinitionNode
+
+	/**
+	 * IsDeprecated is set when the type is defined, from DeprecationReason.
+	 */
+	IsDeprecated bool
+}
+
+// EnumConfig configuration for an Enum
+type EnumConfig struct {
+	Name        string
+	Description string
+	Values      []*EnumValue
+	AstNode     *language.EnumTypeDefinitionNode
+}
+
+// Enum is a leaf type whose values are one of a list of names. Each name may
+// map to an internal value, which is what resolvers return and receive.
+type Enum struct {
+	Name        string
+	Description string
+	AstNode     *language.EnumTypeDefinitionNode
+
+	values []*EnumValue
+	byName map[string]*EnumValue
+}
+
+// NewEnum creates an Enum from its configuration.
+func NewEnum(config EnumConfig) *Enum {
+	e := &Enum{
+		Name:        config.Name,
+		Description: config.Description,
+		AstNode:     config.AstNode,
+		byName:      make(map[string]*EnumValue, len(config.Values)),
+	}
+
+	for _, config := range config.Values {
+		if config == nil {
+			continue
+		}
+
+		value := *config
+		if value.Value == nil {
+			value.Value = value.Name
+		}
+		value.IsDeprecated = value.DeprecationReason != ""
+
+		e.values = append(e.values, &value)
+		if _, exists := e.byName[value.Name]; !exists {
+			e.byName[value.Name] = &value
+		}
+	}
+
+	return e
+}
+
+// GetValues returns the values of the enum, in the order they are defined.
+func (e *Enum) GetValues() []*EnumValue {
+	return e.values
+}
+
+// GetValue returns the value with the given name, or nil.
+func (e *Enum) GetValue(name string) *EnumValue {
+	return e.byName[name]
+}
+
+// Serialize returns the name of the enum value the internal value maps to.
+// A pointer maps to the same value as the value it points to.
+func (e *Enum) Serialize(outputValue interface{}) (interface{}, error) {
+	for _, value := range []interface{}{outputValue, indirect(outputValue)} {
+		for _, enumValue := range e.values {
+			if equalValues(enumValue.Value, value) {
+				return enumValue.Name, nil
+			}
+		}
+	}
+
+	return nil, e.error(errors.MsgEnumCannotRepresent, inspect(outputValue))
+}
+
+// ParseValue returns the internal value of the enum value with the given
+// name.
+func (e *Enum) ParseValue(inputValue interface{}) (interface{}, error) {
+	name, ok := indirect(inputValue).(string)
+	if !ok {
+		return nil, e.error(errors.MsgEnumNonString, inspect(inputValue))
+	}
+
+	enumValue := e.byName[name]
+	if enumValue == nil {
+		return nil, errors.NewLocalizableError(
+			errors.UnknownEnumValueMessage(name, e.Name, e.suggestions(name)),
+		)
+	}
+
+	return enumValue.Value, nil
+}
+
+// ParseLiteral returns the internal value of the enum value named by the
+// literal.
+func (e *Enum) ParseLiteral(valueNode language.ValueNode) (interface{}, error) {
+	node, ok := valueNode.(*language.EnumValueNode)
+	if !ok {
+		message := errors.Message{
+			ID:   errors.MsgEnumNonEnum,
+			Args: map[string]interface{}{"enum": e.Name, "value": language.Print(valueNode)},
+		}
+		if valueNode == nil {
+			return nil, errors.NewLocalizableError(message)
+		}
+		return nil, errors.NewLocalizableError(message, valueNode)
+	}
+
+	enumValue := e.byName[node.Value]
+	if enumValue == nil {
+		return nil, errors.NewLocalizableError(
+			errors.UnknownEnumValueMessage(node.Value, e.Name, e.suggestions(node.Value)),
+			valueNode,
+		)
+	}
+
+	return enumValue.Value, nil
+}
+
+func (e *Enum) error(id errors.MessageID, value string) error {
+	return errors.NewLocalizableError(errors.Message{
+		ID:   id,
+		Args: map[string]interface{}{"enum": e.Name, "value": value},
+	})
+}
+
+// suggestions returns the names of the values similar to name.
+func (e *Enum) suggestions(name string) []string {
+	names := make([]string, len(e.values))
+	for idx, enumValue := range e.values {
+		names[idx] = enumValue.Name
+	}
+
+	return errors.SuggestionList(name, names)
+}
+
+// GetName returns the name of the type.
+func (e *Enum) GetName() string { return e.Name }
+
+// GetDescription returns the description of the type.
+func (e *Enum) GetDescription() string { return e.Description }
+
+func (e *Enum) String() string { return e.Name }
+
+func (e *Enum) isType() {}
+
+// Input Object Type Definition
+
+// InputField a field of an InputObject.
+type InputField struct {
+	Name              string
+	Description       string
+	Type              Type
+	DefaultValue      interface{}
+	DeprecationReason string
+	AstNode           *language.InputValueDefinitionNode
+}
+
+// InputFieldsThunk provides the fields of an InputObject. It is either an
+// InputFields list or an InputFieldsFunc.
+type InputFieldsThunk interface {
+	resolveInputFields() InputFields
+}
+
+// InputFields is a list of input fields, in the order they are defined.
+type InputFields []*InputField
+
+func (f InputFields) resolveInputFields() InputFields { return f }
+
+// InputFieldsFunc returns the fields of an InputObject when it is first
+// used.
+type InputFieldsFunc func() InputFields
+
+func (f InputFieldsFunc) resolveInputFields() InputFields { return f() }
+
+// InputObjectConfig configuration for an InputObject
+type InputObjectConfig struct {
+	Name        string
+	Description string
+	Fields      InputFieldsThunk
+	AstNode     *language.InputObjectTypeDefinitionNode
+}
+
+// InputObject is a structured value accepted as an argument or variable.
+type InputObject struct {
+	Name        string
+	Description string
+	AstNode     *language.InputObjectTypeDefinitionNode
+
+	config InputObjectConfig
+
+	fieldsOnce sync.Once
+	fields     []*InputField
+	byName     map[string]*InputField
+}
+
+// NewInputObject creates an InputObject from its configuration.
+func NewInputObject(config InputObjectConfig) *InputObject {
+	return &InputObject{
+		Name:        config.Name,
+		Description: config.Description,
+		AstNode:     config.AstNode,
+		config:      config,
+	}
+}
+
+// GetFields returns the fields of the type, in the order they are defined.
+func (i *InputObject) GetFields() []*InputField {
+	i.fieldsOnce.Do(func() {
+		var fields InputFields
+		if i.config.Fields != nil {
+			fields = i.config.Fields.resolveInputFields()
+		}
+
+		i.byName = make(map[string]*InputField, len(fields))
+		for _, field := range fields {
+			if field == nil {
+				continue
+			}
+
+			i.fields = append(i.fields, field)
+			if _, exists := i.byName[field.Name]; !exists {
+				i.byName[field.Name] = field
+			}
+		}
+	})
+
+	return i.fields
+}
+
+// GetField returns the field with the given name, or nil.
+func (i *InputObject) GetField(name string) *InputField {
+	i.GetFields()
+	return i.byName[name]
+}
+
+// GetName returns the name of the type.
+func (i *InputObject) GetName() string { return i.Name }
+
+// GetDescription returns the description of the type.
+func (i *InputObject) GetDescription() string { return i.Description }
+
+func (i *InputObject) String() string { return i.Name }
+
+func (i *InputObject) isType() {}
+
+// List Type Wrapper
+
+// List wraps a type to indicate a list of values of that type.
+type List struct {
+	OfType Type
+}
+
+// NewList creates a List of the given type.
+func NewList(ofType Type) *List {
+	if ofType == nil {
+		panic("Can only create List of a GraphQLType but got: <nil>.")
+	}
+
+	return &List{OfType: ofType}
+}
+
+// GetOfType returns the wrapped type.
+func (l *List) GetOfType() Type { return l.OfType }
+
+func (l *List) String() string { return "[" + l.OfType.String() + "]" }
+
+func (l *List) isType() {}
+
+// Non-Null Type Wrapper
+
+// NonNull wraps a type to indicate that null is never a valid value for it.
+// Arguments and input fields of a NonNull type are required.
+type NonNull struct {
+	OfType Type
+}
+
+// NewNonNull creates a NonNull of the given type. As with the other
+// constructors of wrapping types, it panics if the type cannot be wrapped,
+// since that is a programming error.
+func NewNonNull(ofType Type) *NonNull {
+	if ofType == nil {
+		panic("Can only create NonNull of a Nullable GraphQLType but got: <nil>.")
+	}
+	if _, ok := ofType.(*NonNull); ok {
+		panic(fmt.Sprintf("Can only create NonNull of a Nullable GraphQLType but got: %s.", ofType))
+	}
+
+	return &NonNull{OfType: ofType}
+}
+
+// GetOfType returns the wrapped type.
+func (n *NonNull) GetOfType() Type { return n.OfType }
+
+func (n *NonNull) String() string { return n.OfType.String() + "!" }
+
+func (n *NonNull) isType() {}
@@ -0,0 +1,318 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+)
+
+var (
+	// The blog types refer to each other, so they are created in init.
+	blogImage, blogAuthor, blogArticle, blogQuery, blogMutation *Object
+
+	objectType      = NewObject(ObjectConfig{Name: "Object"})
+	interfaceType   = NewInterface(InterfaceConfig{Name: "Interface"})
+	unionType       = NewUnion(UnionConfig{Name: "Union", Types: Objects{objectType}})
+	enumType        = NewEnum(EnumConfig{Name: "Enum", Values: []*EnumValue{{Name: "foo"}}})
+	inputObjectType = NewInputObject(InputObjectConfig{Name: "InputObject"})
+)
+
+func init() {
+	blogImage = NewObject(ObjectConfig{
+		Name: "Image",
+		Fields: Fields{
+			{Name: "url", Type: String},
+			{Name: "width", Type: Int},
+			{Name: "height", Type: Int},
+		},
+	})
+
+	blogAuthor = NewObject(ObjectConfig{
+		Name: "Author",
+		Fields: FieldsFunc(func() Fields {
+			return Fields{
+				{Name: "id", Type: String},
+				{Name: "name", Type: String},
+				{
+					Name: "pic",
+					Args: []*Argument{
+						{Name: "width", Type: Int},
+						{Name: "height", Type: Int},
+					},
+					Type: blogImage,
+				},
+				{Name: "recentArticle", Type: blogArticle},
+			}
+		}),
+	})
+
+	blogArticle = NewObject(ObjectConfig{
+		Name: "Article",
+		Fields: Fields{
+			{Name: "id", Type: String},
+			{Name: "isPublished", Type: Boolean},
+			{Name: "author", Type: blogAuthor},
+			{Name: "title", Type: String},
+			{Name: "body", Type: String},
+		},
+	})
+
+	blogQuery = NewObject(ObjectConfig{
+		Name: "Query",
+		Fields: Fields{
+			{
+				Name: "article",
+				Args: []*Argument{{Name: "id", Type: String}},
+				Type: blogArticle,
+			},
+			{Name: "feed", Type: NewList(blogArticle)},
+		},
+	})
+
+	blogMutation = NewObject(ObjectConfig{
+		Name:   "Mutation",
+		Fields: Fields{{Name: "writeArticle", Type: blogArticle}},
+	})
+}
+
+func TestDefinesAQueryOnlySchema(t *testing.T) {
+	blogSchema, err := NewSchema(SchemaConfig{Query: blogQuery})
+	if err != nil {
+		t.Fatalf("NewSchema; got error %v", err)
+	}
+
+	if blogSchema.GetQueryType() != blogQuery {
+		t.Errorf("GetQueryType; got %v wanted %v", blogSchema.GetQueryType(), blogQuery)
+	}
+
+	articleField := blogQuery.GetField("article")
+	if articleField.Type != blogArticle || articleField.Name != "article" {
+		t.Errorf("article field; got %s: %s", articleField.Name, articleField.Type)
+	}
+
+	titleField := blogArticle.GetField("title")
+	if titleField.Type != String {
+		t.Errorf("title field; got type %s wanted String", titleField.Type)
+	}
+
+	recentArticleField := blogAuthor.GetField("recentArticle")
+	if recentArticleField.Type != blogArticle {
+		t.Errorf("recentArticle field; got type %s wanted Article", recentArticleField.Type)
+	}
+
+	feedField := blogQuery.GetField("feed")
+	if feedField.Type.(*List).OfType != blogArticle {
+		t.Errorf("feed field; got type %s wanted [Article]", feedField.Type)
+	}
+
+	for _, name := range []string{"Query", "Article", "Author", "Image", "String", "Int", "Boolean"} {
+		if blogSchema.GetType(name) == nil {
+			t.Errorf("GetType(%q); got nil", name)
+		}
+	}
+}
+
+func TestDefinesAMutationSchema(t *testing.T) {
+	blogSchema, err := NewSchema(SchemaConfig{Query: blogQuery, Mutation: blogMutation})
+	if err != nil {
+		t.Fatalf("NewSchema; got error %v", err)
+	}
+
+	if blogSchema.GetMutationType() != blogMutation {
+		t.Errorf("GetMutationType; got %v wanted %v", blogSchema.GetMutationType(), blogMutation)
+	}
+}
+
+func TestFieldsKeepDefinitionOrder(t *testing.T) {
+	var names []string
+	for _, field := range blogArticle.GetFields() {
+		names = append(names, field.Name)
+	}
+
+	if got := strings.Join(names, ","); got != "id,isPublished,author,title,body" {
+		t.Errorf("GetFields; got %s", got)
+	}
+}
+
+func TestIncludesTypesOnlyReachableThroughTypes(t *testing.T) {
+	iface := NewInterface(InterfaceConfig{
+		Name:   "SomeInterface",
+		Fields: Fields{{Name: "f", Type: Int}},
+	})
+	impl := NewObject(ObjectConfig{
+		Name:       "SomeSubtype",
+		Fields:     Fields{{Name: "f", Type: Int}},
+		Interfaces: Interfaces{iface},
+	})
+	query := NewObject(ObjectConfig{
+		Name:   "Query",
+		Fields: Fields{{Name: "iface", Type: iface}},
+	})
+
+	schema, err := NewSchema(SchemaConfig{Query: query, Types: []NamedType{impl}})
+	if err != nil {
+		t.Fatalf("NewSchema; got error %v", err)
+	}
+
+	if schema.GetType("SomeSubtype") != impl {
+		t.Errorf("GetType(SomeSubtype); got %v", schema.GetType("SomeSubtype"))
+	}
+}
+
+func TestRejectsSchemaWithDuplicateTypeNames(t *testing.T) {
+	query := NewObject(ObjectConfig{
+		Name: "Query",
+		Fields: Fields{
+			{Name: "a", Type: NewObject(ObjectConfig{Name: "Same", Fields: Fields{{Name: "x", Type: Int}}})},
+			{Name: "b", Type: NewObject(ObjectConfig{Name: "Same", Fields: Fields{{Name: "x", Type: Int}}})},
+		},
+	})
+
+	_, err := NewSchema(SchemaConfig{Query: query})
+	want := `Schema must contain unique named types but contains multiple types named "Same".`
+	if err == nil || err.Error() != want {
+		t.Errorf("NewSchema; got %v wanted %s", err, want)
+	}
+}
+
+func TestStringifiesTypes(t *testing.T) {
+	tests := []struct {
+		t    Type
+		want string
+	}{
+		{Int, "Int"},
+		{blogArticle, "Article"},
+		{interfaceType, "Interface"},
+		{unionType, "Union"},
+		{enumType, "Enum"},
+		{inputObjectType, "InputObject"},
+		{NewNonNull(Int), "Int!"},
+		{NewList(Int), "[Int]"},
+		{NewNonNull(NewList(Int)), "[Int]!"},
+		{NewList(NewNonNull(Int)), "[Int!]"},
+		{NewList(NewList(Int)), "[[Int]]"},
+	}
+
+	for _, test := range tests {
+		if got := test.t.String(); got != test.want {
+			t.Errorf("String(); got %s wanted %s", got, test.want)
+		}
+	}
+}
+
+func TestIdentifiesInputAndOutputTypes(t *testing.T) {
+	tests := []struct {
+		t      Type
+		input  bool
+		output bool
+	}{
+		{Int, true, true},
+		{objectType, false, true},
+		{interfaceType, false, true},
+		{unionType, false, true},
+		{enumType, true, true},
+		{inputObjectType, true, false},
+	}
+
+	for _, test := range tests {
+		for _, wrapped := range []Type{test.t, NewList(test.t), NewNonNull(test.t)} {
+			if got := IsInputType(wrapped); got != test.input {
+				t.Errorf("IsInputType(%s); got %v wanted %v", wrapped, got, test.input)
+			}
+			if got := IsOutputType(wrapped); got != test.output {
+				t.Errorf("IsOutputType(%s); got %v wanted %v", wrapped, got, test.output)
+			}
+		}
+	}
+}
+
+func TestUnwrapsTypes(t *testing.T) {
+	wrapped := NewNonNull(NewList(NewNonNull(Int)))
+
+	if got := GetNamedType(wrapped); got != Int {
+		t.Errorf("GetNamedType; got %v wanted Int", got)
+	}
+	if got := GetNullableType(wrapped).String(); got != "[Int!]" {
+		t.Errorf("GetNullableType; got %s wanted [Int!]", got)
+	}
+	if got := GetNullableType(Int); got != Int {
+		t.Errorf("GetNullableType; got %v wanted Int", got)
+	}
+}
+
+func TestProhibitsNestingNonNullInsideNonNull(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("NewNonNull(NonNull); expected a panic")
+		}
+	}()
+
+	NewNonNull(NewNonNull(Int))
+}
+
+func TestDefinesEnumValues(t *testing.T) {
+	enum := NewEnum(EnumConfig{
+		Name: "Color",
+		Values: []*EnumValue{
+			{Name: "RED", Value: 0},
+			{Name: "GREEN"},
+			{Name: "BLUE", DeprecationReason: "Use GREEN"},
+		},
+	})
+
+	if got := enum.GetValue("RED").Value; got != 0 {
+		t.Errorf("RED; got %v wanted 0", got)
+	}
+	if got := enum.GetValue("GREEN").Value; got != "GREEN" {
+		t.Errorf("GREEN; got %v wanted GREEN", got)
+	}
+	if !enum.GetValue("BLUE").IsDeprecated {
+		t.Error("BLUE; expected to be deprecated")
+	}
+
+	if got, err := enum.Serialize(0); err != nil || got != "RED" {
+		t.Errorf("Serialize(0); got %v, %v wanted RED", got, err)
+	}
+	if got, err := enum.ParseValue("RED"); err != nil || got != 0 {
+		t.Errorf("ParseValue(RED); got %v, %v wanted 0", got, err)
+	}
+	if _, err := enum.Serialize(7); err == nil {
+		t.Error("Serialize(7); expected an error")
+	}
+	red := 0
+	if got, err := enum.Serialize(&red); err != nil || got != "RED" {
+		t.Errorf("Serialize(&0); got %v, %v wanted RED", got, err)
+	}
+}
+
+// box is comparable, but holds values which may not be.
+type box struct {
+	V interface{}
+}
+
+func TestSerializesUncomparableEnumValues(t *testing.T) {
+	enum := NewEnum(EnumConfig{
+		Name: "Shape",
+		Values: []*EnumValue{
+			{Name: "SQUARE", Value: []int{1, 1}},
+			{Name: "LINE", Value: map[string]int{"length": 1}},
+			{Name: "POINT", Value: func() {}},
+			{Name: "BOX", Value: box{V: []int{1}}},
+		},
+	})
+
+	if got, err := enum.Serialize([]int{1, 1}); err != nil || got != "SQUARE" {
+		t.Errorf("Serialize([1 1]); got %v, %v wanted SQUARE", got, err)
+	}
+	if got, err := enum.Serialize(map[string]int{"length": 1}); err != nil || got != "LINE" {
+		t.Errorf("Serialize(map[length:1]); got %v, %v wanted LINE", got, err)
+	}
+	if got, err := enum.Serialize(box{V: []int{1}}); err != nil || got != "BOX" {
+		t.Errorf("Serialize(box{[1]}); got %v, %v wanted BOX", got, err)
+	}
+	if _, err := enum.Serialize([]int{2}); err == nil {
+		t.Error("Serialize([2]); expected an error")
+	}
+	if _, err := enum.Serialize(func() {}); err == nil {
+		t.Error("Serialize(func); expected an error")
+	}
+}
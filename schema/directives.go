@@ -0,0 +1,332 @@
+package schema
+
+import (
+	"github.com/ijsnow/goql/internal/errors"
+	"github.com/ijsnow/goql/internal/language"
+)
+
+// DirectiveLocation is a place in a document or a schema where a directive
+// may be used.
+type DirectiveLocation string
+
+// The locations a directive may be used at.
+const (
+	// Operations
+	DirectiveLocationQuery              DirectiveLocation = "QUERY"
+	DirectiveLocationMutation           DirectiveLocation = "MUTATION"
+	DirectiveLocationSubscription       DirectiveLocation = "SUBSCRIPTION"
+	DirectiveLocationField              DirectiveLocation = "FIELD"
+	DirectiveLocationFragmentDefinition DirectiveLocation = "FRAGMENT_DEFINITION"
+	DirectiveLocationFragmentSpread     DirectiveLocation = "FRAGMENT_SPREAD"
+	DirectiveLocationInlineFragment     DirectiveLocation = "INLINE_FRAGMENT"
+
+	// Schema Definitions
+	DirectiveLocationSchema               DirectiveLocation = "SCHEMA"
+	DirectiveLocationScalar               DirectiveLocation = "SCALAR"
+	DirectiveLocationObject               DirectiveLocation = "OBJECT"
+	DirectiveLocationFieldDefinition      DirectiveLocation = "FIELD_DEFINITION"
+	DirectiveLocationArgumentDefinition   DirectiveLocation = "ARGUMENT_DEFINITION"
+	DirectiveLocationInterface            DirectiveLocation = "INTERFACE"
+	DirectiveLocationUnion                DirectiveLocation = "UNION"
+	DirectiveLocationEnum                 DirectiveLocation = "ENUM"
+	DirectiveLocationEnumValue            DirectiveLocation = "ENUM_VALUE"
+	DirectiveLocationInputObject          DirectiveLocation = "INPUT_OBJECT"
+	DirectiveLocationInputFieldDefinition DirectiveLocation = "INPUT_FIELD_DEFINITION"
+)
+
+// DirectiveLocations are all the valid directive locations, in the order of
+// the specification.
+var DirectiveLocations = []DirectiveLocation{
+	DirectiveLocationQuery,
+	DirectiveLocationMutation,
+	DirectiveLocationSubscription,
+	DirectiveLocationField,
+	DirectiveLocationFragmentDefinition,
+	DirectiveLocationFragmentSpread,
+	DirectiveLocationInlineFragment,
+	DirectiveLocationSchema,
+	DirectiveLocationScalar,
+	DirectiveLocationObject,
+	DirectiveLocationFieldDefinition,
+	DirectiveLocationArgumentDefinition,
+	DirectiveLocationInterface,
+	DirectiveLocationUnion,
+	DirectiveLocationEnum,
+	DirectiveLocationEnumValue,
+	DirectiveLocationInputObject,
+	DirectiveLocationInputFieldDefinition,
+}
+
+// DirectiveConfig configuration for a Directive
+type DirectiveConfig struct {
+	Name         string
+	Description  string
+	Locations    []DirectiveLocation
+	Args         []*Argument
+	IsRepeatable bool
+	AstNode      *language.DirectiveDefinitionNode
+}
+
+// Directive is used to annotate parts of a document or a schema, for
+// example to tell the executor to skip a field.
+type Directive struct {
+	Name         string
+	Description  string
+	Locations    []DirectiveLocation
+	Args         []*Argument
+	IsRepeatable bool
+	AstNode      *language.DirectiveDefinitionNode
+}
+
+// NewDirective creates a Directive from its configuration. Custom directives
+// are made available to a schema through SchemaConfig.Directives.
+func NewDirective(config DirectiveConfig) *Directive {
+	return &Directive{
+		Name:         config.Name,
+		Description:  config.Description,
+		Locations:    config.Locations,
+		Args:         config.Args,
+		IsRepeatable: config.IsRepeatable,
+		AstNode:      config.AstNode,
+	}
+}
+
+// GetArg returns the argument with the given name, or nil.
+func (d *Directive) GetArg(name string) *Argument {
+	for _, arg := range d.Args {
+		if arg.Name == name {
+			return arg
+		}
+	}
+
+	return nil
+}
+
+// HasLocation reports whether the directive may be used at the location.
+func (d *Directive) HasLocation(location DirectiveLocation) bool {
+	for _, l := range d.Locations {
+		if l == location {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ValidateUsage checks that the directive may be applied where it is used.
+// appliedTo is the node the directive is applied to, and parent is the node
+// containing it, which is needed to tell arguments from input fields.
+func (d *Directive) ValidateUsage(directiveNode *language.DirectiveNode, appliedTo, parent language.ASTNode) error {
+	location, ok := DirectiveLocationOf(appliedTo, parent)
+	if ok && d.HasLocation(location) {
+		return nil
+	}
+
+	message := errors.Message{
+		ID:   errors.MsgMisplacedDirective,
+		Args: map[string]interface{}{"directive": d.Name, "location": location},
+	}
+	if directiveNode == nil {
+		return errors.NewValidationError(message)
+	}
+
+	return errors.NewValidationError(message, directiveNode)
+}
+
+func (d *Directive) String() string {
+	return "@" + d.Name
+}
+
+// IncludeDirective is used to conditionally include fields or fragments.
+var IncludeDirective = NewDirective(DirectiveConfig{
+	Name: "include",
+	Description: "Directs the executor to include this field or fragment only when " +
+		"the `if` argument is true.",
+	Locations: []DirectiveLocation{
+		DirectiveLocationField,
+		DirectiveLocationFragmentSpread,
+		DirectiveLocationInlineFragment,
+	},
+	Args: []*Argument{{
+		Name:        "if",
+		Type:        NewNonNull(Boolean),
+		Description: "Included when true.",
+	}},
+})
+
+// SkipDirective is used to conditionally skip (exclude) fields or fragments.
+var SkipDirective = NewDirective(DirectiveConfig{
+	Name: "skip",
+	Description: "Directs the executor to skip this field or fragment when the `if` " +
+		"argument is true.",
+	Locations: []DirectiveLocation{
+		DirectiveLocationField,
+		DirectiveLocationFragmentSpread,
+		DirectiveLocationInlineFragment,
+	},
+	Args: []*Argument{{
+		Name:        "if",
+		Type:        NewNonNull(Boolean),
+		Description: "Skipped when true.",
+	}},
+})
+
+// DefaultDeprecationReason is the reason of @deprecated when none is given.
+const DefaultDeprecationReason = "No longer supported"
+
+// DeprecatedDirective is used to declare elements of a schema as deprecated.
+var DeprecatedDirective = NewDirective(DirectiveConfig{
+	Name:        "deprecated",
+	Description: "Marks an element of a GraphQL schema as no longer supported.",
+	Locations: []DirectiveLocation{
+		DirectiveLocationFieldDefinition,
+		DirectiveLocationArgumentDefinition,
+		DirectiveLocationInputFieldDefinition,
+		DirectiveLocationEnumValue,
+	},
+	Args: []*Argument{{
+		Name: "reason",
+		Type: String,
+		Description: "Explains why this element was deprecated, usually also including a " +
+			"suggestion for how to access supported similar data. Formatted " +
+			"in [Markdown](https://daringfireball.net/projects/markdown/).",
+		DefaultValue: DefaultDeprecationReason,
+	}},
+})
+
+// SpecifiedByDirective is used to link a custom scalar to its
+// specification.
+var SpecifiedByDirective = NewDirective(DirectiveConfig{
+	Name:        "specifiedBy",
+	Description: "Exposes a URL that specifies the behaviour of this scalar.",
+	Locations: []DirectiveLocation{
+		DirectiveLocationScalar,
+	},
+	Args: []*Argument{{
+		Name:        "url",
+		Type:        NewNonNull(String),
+		Description: "The URL that specifies the behaviour of this scalar.",
+	}},
+})
+
+// SpecifiedDirectives are the directives defined by the specification. They
+// are available in every schema.
+var SpecifiedDirectives = []*Directive{
+	IncludeDirective,
+	SkipDirective,
+	DeprecatedDirective,
+	SpecifiedByDirective,
+}
+
+// IsSpecifiedDirective reports whether the directive is one of the
+// directives defined by the specification, or a directive of the same name.
+func IsSpecifiedDirective(directive *Directive) bool {
+	for _, specified := range SpecifiedDirectives {
+		if directive.Name == specified.Name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DirectiveLocationOf returns the location of a directive applied to the
+// node. parent is the node containing it, and is only needed for input value
+// definitions, which are input fields within input objects and arguments
+// elsewhere.
+func DirectiveLocationOf(node, parent language.ASTNode) (DirectiveLocation, bool) {
+	switch node := node.(type) {
+	case *language.OperationDefinitionNode:
+		switch node.Operation {
+		case language.OperationTypeQuery:
+			return DirectiveLocationQuery, true
+		case language.OperationTypeMutation:
+			return DirectiveLocationMutation, true
+		case language.OperationTypeSubscription:
+			return DirectiveLocationSubscription, true
+		}
+	case *language.FieldNode:
+		return DirectiveLocationField, true
+	case *language.FragmentSpreadNode:
+		return DirectiveLocationFragmentSpread, true
+	case *language.InlineFragmentNode:
+		return DirectiveLocationInlineFragment, true
+	case *language.FragmentDefinitionNode:
+		return DirectiveLocationFragmentDefinition, true
+	case *language.SchemaDefinitionNode:
+		return DirectiveLocationSchema, true
+	case *language.ScalarTypeDefinitionNode:
+		return DirectiveLocationScalar, true
+	case *language.ObjectTypeDefinitionNode:
+		return DirectiveLocationObject, true
+	case *language.FieldDefinitionNode:
+		return DirectiveLocationFieldDefinition, true
+	case *language.InterfaceTypeDefinitionNode:
+		return DirectiveLocationInterface, true
+	case *language.UnionTypeDefinitionNode:
+		return DirectiveLocationUnion, true
+	case *language.EnumTypeDefinitionNode:
+		return DirectiveLocationEnum, true
+	case *language.EnumValueDefinitionNode:
+		return DirectiveLocationEnumValue, true
+	case *language.InputObjectTypeDefinitionNode:
+		return DirectiveLocationInputObject, true
+	case *language.InputValueDefinitionNode:
+		if _, ok := parent.(*language.InputObjectTypeDefinitionNode); ok {
+			return DirectiveLocationInputFieldDefinition, true
+		}
+		return DirectiveLocationArgumentDefinition, true
+	}
+
+	return "", false
+}
+
+// ValidateDirectiveDefinition checks the locations of a directive
+// definition, returning an error located at each location that is unknown
+// or listed more than once.
+func ValidateDirectiveDefinition(node *language.DirectiveDefinitionNode) []error {
+	var errs []error
+
+	names := make([]string, len(DirectiveLocations))
+	for idx, location := range DirectiveLocations {
+		names[idx] = string(location)
+	}
+
+	seen := map[string]bool{}
+	for idx := range node.Locations {
+		nameNode := &node.Locations[idx]
+		location := nameNode.Value
+
+		if !isDirectiveLocation(location) {
+			errs = append(errs, errors.NewValidationError(
+				errors.UnknownDirectiveLocationMessage(
+					node.Name.Value,
+					location,
+					errors.SuggestionList(location, names),
+				),
+				nameNode,
+			))
+			continue
+		}
+
+		if seen[location] {
+			errs = append(errs, errors.NewValidationError(errors.Message{
+				ID:   errors.MsgDuplicateDirectiveLocation,
+				Args: map[string]interface{}{"directive": node.Name.Value, "location": location},
+			}, nameNode))
+		}
+		seen[location] = true
+	}
+
+	return errs
+}
+
+func isDirectiveLocation(name string) bool {
+	for _, location := range DirectiveLocations {
+		if string(location) == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,104 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/ijsnow/goql/internal/language"
+)
+
+func TestIncludesSpecifiedDirectivesByDefault(t *testing.T) {
+	schema, err := NewSchema(SchemaConfig{Query: blogQuery})
+	if err != nil {
+		t.Fatalf("NewSchema; got error %v", err)
+	}
+
+	for _, name := range []string{"include", "skip", "deprecated", "specifiedBy"} {
+		if schema.GetDirective(name) == nil {
+			t.Errorf("GetDirective(%q); got nil", name)
+		}
+	}
+
+	if schema.GetType("Boolean") == nil || schema.GetType("String") == nil {
+		t.Error("types of directive arguments are missing from the type map")
+	}
+}
+
+func TestRegistersCustomDirectives(t *testing.T) {
+	auth := NewDirective(DirectiveConfig{
+		Name:      "auth",
+		Locations: []DirectiveLocation{DirectiveLocationFieldDefinition, DirectiveLocationObject},
+		Args:      []*Argument{{Name: "role", Type: NewNonNull(String)}},
+	})
+	skip := NewDirective(DirectiveConfig{
+		Name:      "skip",
+		Locations: []DirectiveLocation{DirectiveLocationField},
+	})
+
+	schema, err := NewSchema(SchemaConfig{Query: blogQuery, Directives: []*Directive{auth, skip}})
+	if err != nil {
+		t.Fatalf("NewSchema; got error %v", err)
+	}
+
+	if schema.GetDirective("auth") != auth {
+		t.Errorf("GetDirective(auth); got %v", schema.GetDirective("auth"))
+	}
+	if schema.GetDirective("skip") != skip {
+		t.Errorf("GetDirective(skip); got %v wanted the custom directive", schema.GetDirective("skip"))
+	}
+	if len(schema.GetDirectives()) != 5 {
+		t.Errorf("GetDirectives; got %d directives wanted 5", len(schema.GetDirectives()))
+	}
+
+	_, err = NewSchema(SchemaConfig{Query: blogQuery, Directives: []*Directive{auth, auth}})
+	want := `Schema must contain unique named directives but contains multiple directives named "@auth".`
+	if err == nil || err.Error() != want {
+		t.Errorf("NewSchema; got %v wanted %s", err, want)
+	}
+}
+
+func TestValidatesDirectiveDefinitionLocations(t *testing.T) {
+	node := &language.DirectiveDefinitionNode{
+		Name: language.NameNode{Value: "auth"},
+		Locations: []language.NameNode{
+			{Value: "FIELD_DEFINITION"},
+			{Value: "OBJEKT"},
+			{Value: "FIELD_DEFINITION"},
+		},
+	}
+
+	errs := ValidateDirectiveDefinition(node)
+	want := []string{
+		`Directive "@auth" has the unknown location "OBJEKT". Did you mean "OBJECT"?`,
+		`Directive "@auth" has the location "FIELD_DEFINITION" more than once.`,
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("ValidateDirectiveDefinition; got %v wanted %v", errs, want)
+	}
+	for idx, err := range errs {
+		if err.Error() != want[idx] {
+			t.Errorf("ValidateDirectiveDefinition; got %s wanted %s", err, want[idx])
+		}
+	}
+}
+
+func TestValidatesDirectiveUsage(t *testing.T) {
+	directiveNode := &language.DirectiveNode{Name: language.NameNode{Value: "deprecated"}}
+
+	if err := DeprecatedDirective.ValidateUsage(directiveNode, &language.FieldDefinitionNode{}, nil); err != nil {
+		t.Errorf("@deprecated on a field definition; got error %v", err)
+	}
+
+	if err := DeprecatedDirective.ValidateUsage(directiveNode, &language.InputValueDefinitionNode{}, &language.InputObjectTypeDefinitionNode{}); err != nil {
+		t.Errorf("@deprecated on an input field; got error %v", err)
+	}
+
+	err := DeprecatedDirective.ValidateUsage(directiveNode, &language.ObjectTypeDefinitionNode{}, nil)
+	want := `Directive "deprecated" may not be used on OBJECT.`
+	if err == nil || err.Error() != want {
+		t.Errorf("@deprecated on an object; got %v wanted %s", err, want)
+	}
+
+	if location, _ := DirectiveLocationOf(&language.InputValueDefinitionNode{}, &language.FieldDefinitionNode{}); location != DirectiveLocationArgumentDefinition {
+		t.Errorf("DirectiveLocationOf(argument); got %s", location)
+	}
+}
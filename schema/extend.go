@@ -0,0 +1,302 @@
+package schema
+
+import (
+	"sort"
+
+	"github.com/ijsnow/goql/internal/errors"
+	"github.com/ijsnow/goql/internal/language"
+)
+
+// ExtendSchema produces a new schema from an existing schema and a document
+// of type extensions and definitions. The existing schema is left unaltered.
+//
+// Object types are extended with `extend type`, which may add fields and
+// interfaces, and new types and directives may be defined. The fields of the
+// existing schema keep their resolvers, and its types keep their IsTypeOf
+// and ResolveType functions and their coercion, so the extended schema can
+// be executed like the original:
+//
+//	extended, err := schema.ExtendSchema(base, document)
+//
+// Defining a type or directive that already exists, extending a type that
+// does not, and redefining a field or an implemented interface are errors
+// located at the document. If the document neither extends nor defines
+// anything, the existing schema is returned.
+func ExtendSchema(schema *Schema, document *DocumentNode, options ...BuildOptions) (*Schema, error) {
+	b := newASTBuilder(schema, options)
+
+	var typeDefs []language.ASTNode
+	var directiveDefs []*language.DirectiveDefinitionNode
+
+	for _, definition := range document.Definitions {
+		switch definition := definition.(type) {
+		case *language.ScalarTypeDefinitionNode,
+			*language.ObjectTypeDefinitionNode,
+			*language.InterfaceTypeDefinitionNode,
+			*language.UnionTypeDefinitionNode,
+			*language.EnumTypeDefinitionNode,
+			*language.InputObjectTypeDefinitionNode:
+			node := definition.(language.ASTNode)
+			typeName := typeDefinitionName(node)
+			if schema.GetType(typeName) != nil {
+				return nil, errors.NewValidationError(errors.Message{
+					ID:   errors.MsgTypeAlreadyExists,
+					Args: map[string]interface{}{"type": typeName},
+				}, node)
+			}
+			if existing, ok := b.nodeMap[typeName]; ok {
+				return nil, errors.NewValidationError(errors.Message{
+					ID:   errors.MsgDuplicateTypeDefinition,
+					Args: map[string]interface{}{"type": typeName},
+				}, existing, node)
+			}
+			typeDefs = append(typeDefs, node)
+			b.nodeMap[typeName] = node
+		case *language.TypeExtensionDefinitionNode:
+			typeName := definition.Definition.Name.Value
+			existing := schema.GetType(typeName)
+			if existing == nil {
+				return nil, errors.NewValidationError(errors.Message{
+					ID:   errors.MsgExtendUnknownType,
+					Args: map[string]interface{}{"type": typeName},
+				}, &definition.Definition)
+			}
+			if _, ok := existing.(*Object); !ok {
+				return nil, errors.NewValidationError(errors.Message{
+					ID:   errors.MsgExtendNonObjectType,
+					Args: map[string]interface{}{"type": typeName},
+				}, &definition.Definition)
+			}
+			b.extensions[typeName] = append(b.extensions[typeName], definition)
+		case *language.DirectiveDefinitionNode:
+			directiveName := definition.Name.Value
+			if schema.GetDirective(directiveName) != nil {
+				return nil, errors.NewValidationError(errors.Message{
+					ID:   errors.MsgDirectiveAlreadyExists,
+					Args: map[string]interface{}{"directive": directiveName},
+				}, definition)
+			}
+			directiveDefs = append(directiveDefs, definition)
+		}
+	}
+
+	if len(typeDefs) == 0 && len(b.extensions) == 0 && len(directiveDefs) == 0 {
+		return schema, nil
+	}
+
+	config := SchemaConfig{
+		Query:        b.extendRootType(schema.GetQueryType()),
+		Mutation:     b.extendRootType(schema.GetMutationType()),
+		Subscription: b.extendRootType(schema.GetSubscriptionType()),
+		Directives:   schema.GetDirectives(),
+		AstNode:      schema.AstNode,
+	}
+
+	// Copy every existing type, so that types which are not referenced by a
+	// field are kept, and then add the new types.
+	typeMap := schema.GetTypeMap()
+	typeNames := make([]string, 0, len(typeMap))
+	for typeName := range typeMap {
+		typeNames = append(typeNames, typeName)
+	}
+	sort.Strings(typeNames)
+
+	for _, typeName := range typeNames {
+		t, _ := b.typeDefNamed(typeName, nil)
+		config.Types = append(config.Types, t)
+	}
+	for _, typeDef := range typeDefs {
+		t, err := b.typeDefNamed(typeDefinitionName(typeDef), typeDef)
+		if err != nil {
+			return nil, err
+		}
+		config.Types = append(config.Types, t)
+	}
+
+	for _, directiveDef := range directiveDefs {
+		directive, err := b.getDirective(directiveDef)
+		if err != nil {
+			return nil, err
+		}
+		config.Directives = append(config.Directives, directive)
+	}
+
+	extended, err := NewSchema(config)
+	if b.err != nil {
+		return nil, b.err
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return extended, nil
+}
+
+// extendRootType returns the copy of a root type of the existing schema.
+func (b *astBuilder) extendRootType(root *Object) *Object {
+	if root == nil {
+		return nil
+	}
+
+	t, _ := b.typeDefNamed(root.Name, nil)
+	return t.(*Object)
+}
+
+// extendNamedType returns the copy of a type of the existing schema.
+func (b *astBuilder) extendNamedType(t NamedType) NamedType {
+	extended, _ := b.typeDefNamed(t.GetName(), nil)
+	return extended
+}
+
+// extendType copies a type of the existing schema. Objects, Interfaces and
+// Unions refer to other types, so they are copied to refer to the copies;
+// the other types are used as they are.
+func (b *astBuilder) extendType(t NamedType) NamedType {
+	switch t := t.(type) {
+	case *Object:
+		var extensionASTNodes []*language.TypeExtensionDefinitionNode
+		extensionASTNodes = append(extensionASTNodes, t.ExtensionASTNodes...)
+		extensionASTNodes = append(extensionASTNodes, b.extensions[t.Name]...)
+
+		return NewObject(ObjectConfig{
+			Name:        t.Name,
+			Description: t.Description,
+			Interfaces: InterfacesFunc(func() Interfaces {
+				var interfaces Interfaces
+				for _, iface := range t.GetInterfaces() {
+					interfaces = append(interfaces, b.extendNamedType(iface).(*Interface))
+				}
+				return b.extendInterfaces(t.Name, interfaces)
+			}),
+			Fields: FieldsFunc(func() Fields {
+				return b.extendFields(t.Name, b.extendFieldMap(t.GetFields()))
+			}),
+			IsTypeOf:          t.IsTypeOf,
+			AstNode:           t.AstNode,
+			ExtensionASTNodes: extensionASTNodes,
+		})
+	case *Interface:
+		return NewInterface(InterfaceConfig{
+			Name:        t.Name,
+			Description: t.Description,
+			Fields: FieldsFunc(func() Fields {
+				return b.extendFieldMap(t.GetFields())
+			}),
+			ResolveType: t.ResolveType,
+			AstNode:     t.AstNode,
+		})
+	case *Union:
+		return NewUnion(UnionConfig{
+			Name:        t.Name,
+			Description: t.Description,
+			Types: ObjectsFunc(func() Objects {
+				var objects Objects
+				for _, object := range t.GetTypes() {
+					objects = append(objects, b.extendNamedType(object).(*Object))
+				}
+				return objects
+			}),
+			ResolveType: t.ResolveType,
+			AstNode:     t.AstNode,
+		})
+	}
+
+	return t
+}
+
+// extendFieldMap copies the fields of a type of the existing schema, along
+// with their resolvers.
+func (b *astBuilder) extendFieldMap(existing []*Field) Fields {
+	fields := make(Fields, len(existing))
+	for idx, field := range existing {
+		extended := *field
+		extended.Type = b.extendFieldType(field.Type)
+		fields[idx] = &extended
+	}
+
+	return fields
+}
+
+func (b *astBuilder) extendFieldType(t Type) Type {
+	switch t := t.(type) {
+	case *List:
+		return NewList(b.extendFieldType(t.OfType))
+	case *NonNull:
+		return NewNonNull(b.extendFieldType(t.OfType))
+	case NamedType:
+		return b.extendNamedType(t)
+	}
+
+	return t
+}
+
+// extendFields adds the fields of the extensions of a type to its fields.
+func (b *astBuilder) extendFields(typeName string, fields Fields) Fields {
+	names := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		names[field.Name] = true
+	}
+
+	for _, extension := range b.extensions[typeName] {
+		defs := extension.Definition.Fields
+		for idx := range defs {
+			def := &defs[idx]
+			if names[def.Name.Value] {
+				b.fail(errors.NewValidationError(errors.Message{
+					ID:   errors.MsgFieldAlreadyExists,
+					Args: map[string]interface{}{"type": typeName, "field": def.Name.Value},
+				}, def))
+				continue
+			}
+			names[def.Name.Value] = true
+
+			fields = append(fields, b.makeFieldDefMap(defs[idx:idx+1])...)
+		}
+	}
+
+	return fields
+}
+
+// extendInterfaces adds the interfaces of the extensions of a type to the
+// interfaces it implements.
+func (b *astBuilder) extendInterfaces(typeName string, interfaces Interfaces) Interfaces {
+	for _, extension := range b.extensions[typeName] {
+		if extension.Definition.Interfaces == nil {
+			continue
+		}
+
+		for idx := range *extension.Definition.Interfaces {
+			ifaceNode := &(*extension.Definition.Interfaces)[idx]
+
+			implemented := false
+			for _, iface := range interfaces {
+				implemented = implemented || iface.Name == ifaceNode.Name.Value
+			}
+			if implemented {
+				b.fail(errors.NewValidationError(errors.Message{
+					ID:   errors.MsgInterfaceAlreadyImplemented,
+					Args: map[string]interface{}{"type": typeName, "interface": ifaceNode.Name.Value},
+				}, ifaceNode))
+				continue
+			}
+
+			t, err := b.produceType(ifaceNode)
+			if err != nil {
+				b.fail(err)
+				continue
+			}
+
+			iface, ok := t.(*Interface)
+			if !ok {
+				b.fail(errors.NewValidationError(errors.Message{
+					ID:   errors.MsgNonInterfaceImplemented,
+					Args: map[string]interface{}{"type": typeName, "interface": t.String()},
+				}, ifaceNode))
+				continue
+			}
+			interfaces = append(interfaces, iface)
+		}
+	}
+
+	return interfaces
+}
@@ -0,0 +1,227 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ijsnow/goql/internal/errors"
+)
+
+// extensionBase is a schema with resolvers for ExtendSchema to preserve.
+func extensionBase(t *testing.T) *Schema {
+	node := NewInterface(InterfaceConfig{
+		Name:   "Node",
+		Fields: Fields{{Name: "id", Type: NewNonNull(ID)}},
+		ResolveType: func(p ResolveTypeParams) *Object {
+			return nil
+		},
+	})
+	someInterface := NewInterface(InterfaceConfig{
+		Name:   "SomeInterface",
+		Fields: Fields{{Name: "name", Type: String}},
+	})
+	foo := NewObject(ObjectConfig{
+		Name:       "Foo",
+		Interfaces: Interfaces{someInterface},
+		Fields: FieldsFunc(func() Fields {
+			return Fields{
+				{Name: "name", Type: String},
+				{Name: "tree", Type: NewNonNull(NewList(node))},
+			}
+		}),
+	})
+	query := NewObject(ObjectConfig{
+		Name: "Query",
+		Fields: Fields{
+			{
+				Name: "hello",
+				Type: String,
+				Resolve: func(p ResolveParams) (interface{}, error) {
+					return "world", nil
+				},
+			},
+			{Name: "foo", Type: foo},
+		},
+	})
+
+	schema, err := NewSchema(SchemaConfig{Query: query, Types: []NamedType{node}})
+	if err != nil {
+		t.Fatalf("NewSchema; got error %v", err)
+	}
+
+	return schema
+}
+
+func extend(t *testing.T, schema *Schema, sdl string) (*Schema, error) {
+	document, err := Parse(sdl)
+	if err != nil {
+		t.Fatalf("Parse; got error %v", err)
+	}
+
+	return ExtendSchema(schema, document)
+}
+
+func TestExtendSchema(t *testing.T) {
+	base := extensionBase(t)
+
+	extended, err := extend(t, base, `
+extend type Foo implements Node {
+  id: ID!
+  "New field."
+  newField(arg: Color = RED): Bar
+}
+
+extend type Query {
+  bar: Bar
+}
+
+type Bar {
+  foo: Foo
+}
+
+enum Color { RED GREEN }
+
+directive @neat on FIELD
+`)
+	if err != nil {
+		t.Fatalf("ExtendSchema; got error %v", err)
+	}
+	if errs := Validate(extended); len(errs) != 0 {
+		t.Fatalf("Validate; got %v", errs)
+	}
+
+	query := extended.GetQueryType()
+	if query == base.GetQueryType() {
+		t.Fatalf("query type; got the existing type wanted a copy")
+	}
+	result, err := query.GetField("hello").Resolve(ResolveParams{})
+	if err != nil || result != "world" {
+		t.Errorf("hello resolver; got %v, %v wanted world", result, err)
+	}
+
+	foo := extended.GetType("Foo").(*Object)
+	if got := len(foo.GetFields()); got != 4 {
+		t.Errorf("Foo fields; got %d wanted 4", got)
+	}
+	if got := foo.GetField("newField"); got.Description != "New field." || got.AstNode == nil {
+		t.Errorf("newField; got %+v", got)
+	}
+	if got := foo.GetField("newField").GetArg("arg").DefaultValue; got != "RED" {
+		t.Errorf("newField(arg:) default; got %#v wanted RED", got)
+	}
+	if got := len(foo.ExtensionASTNodes); got != 1 {
+		t.Errorf("Foo extension nodes; got %d wanted 1", got)
+	}
+	if query.GetField("foo").Type != foo || foo.GetField("tree").Type.String() != "[Node]!" {
+		t.Errorf("field types; got %v and %v", query.GetField("foo").Type, foo.GetField("tree").Type)
+	}
+
+	node := extended.GetType("Node").(*Interface)
+	if node.ResolveType == nil {
+		t.Errorf("Node; got no ResolveType wanted the existing one")
+	}
+	if impls := extended.Implementations(node); len(impls) != 1 || impls[0] != foo {
+		t.Errorf("Implementations(Node); got %v wanted [Foo]", impls)
+	}
+
+	if extended.GetType("Bar") == nil || extended.GetType("Color") == nil || extended.GetDirective("neat") == nil {
+		t.Errorf("new definitions; got Bar %v, Color %v and @neat %v",
+			extended.GetType("Bar"), extended.GetType("Color"), extended.GetDirective("neat"))
+	}
+	if extended.GetDirective("deprecated") == nil {
+		t.Errorf("@deprecated; got nil wanted the existing directive")
+	}
+
+	// The existing schema is left unaltered.
+	if base.GetType("Bar") != nil || base.GetDirective("neat") != nil {
+		t.Errorf("existing schema; got new definitions")
+	}
+	if got := len(base.GetType("Foo").(*Object).GetFields()); got != 2 {
+		t.Errorf("existing Foo fields; got %d wanted 2", got)
+	}
+}
+
+func TestExtendSchemaWithoutExtensions(t *testing.T) {
+	base := extensionBase(t)
+
+	extended, err := extend(t, base, `{ hello }`)
+	if err != nil {
+		t.Fatalf("ExtendSchema; got error %v", err)
+	}
+	if extended != base {
+		t.Errorf("ExtendSchema; got a new schema wanted the existing one")
+	}
+}
+
+func TestExtendSchemaErrors(t *testing.T) {
+	set := [][]string{
+		[]string{
+			"type Foo { a: String }",
+			`Type "Foo" already exists in the schema. It cannot also be defined in this type definition.`,
+		},
+		[]string{
+			"extend type Unknown { a: String }",
+			`Cannot extend type "Unknown" because it does not exist in the existing schema.`,
+		},
+		[]string{
+			"extend type SomeInterface { a: String }",
+			`Cannot extend non-object type "SomeInterface".`,
+		},
+		[]string{
+			"directive @include on FIELD",
+			`Directive "include" already exists in the schema. It cannot be redefined.`,
+		},
+		[]string{
+			"extend type Foo { name: String }",
+			`Field "Foo.name" already exists in the schema. It cannot also be defined in this type extension.`,
+		},
+		[]string{
+			"extend type Foo { a: String } extend type Foo { a: Int }",
+			`Field "Foo.a" already exists in the schema. It cannot also be defined in this type extension.`,
+		},
+		[]string{
+			"extend type Foo implements SomeInterface { other: String }",
+			`Type "Foo" already implements "SomeInterface". It cannot also be implemented in this type extension.`,
+		},
+		[]string{
+			"extend type Foo { quux: Quux }",
+			`Unknown type: "Quux". Ensure that this type exists either in the original schema, or is added in a type definition.`,
+		},
+	}
+
+	for _, test := range set {
+		_, err := extend(t, extensionBase(t), test[0])
+		if err == nil {
+			t.Errorf("ExtendSchema(%q); got no error wanted %s", test[0], test[1])
+			continue
+		}
+		if got := err.Error(); !strings.HasPrefix(got, test[1]) {
+			t.Errorf("ExtendSchema(%q); got %s wanted %s", test[0], got, test[1])
+		}
+	}
+}
+
+func TestBuildFromSDLAppliesExtensions(t *testing.T) {
+	schema, err := BuildFromSDL(`
+type Query { a: String }
+extend type Query { b: String }
+`)
+	if err != nil {
+		t.Fatalf("BuildFromSDL; got error %v", err)
+	}
+	if got := len(schema.GetQueryType().GetFields()); got != 2 {
+		t.Errorf("Query fields; got %d wanted 2", got)
+	}
+
+	_, err = BuildFromSDL(`
+type Query { a: String }
+extend type Query { a: Int }
+`)
+	want := `Field "Query.a" already exists in the schema. It cannot also be defined in this type extension.`
+	if err == nil || !strings.HasPrefix(err.Error(), want) {
+		t.Fatalf("BuildFromSDL; got %v wanted %s", err, want)
+	}
+	if locations := err.(*errors.GraphQLError).Locations; len(locations) != 1 || locations[0].Line != 3 {
+		t.Errorf("BuildFromSDL; got locations %+v wanted line 3", locations)
+	}
+}
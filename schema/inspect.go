@@ -0,0 +1,68 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// inspect renders a value for error messages, the way it would be written in
+// JSON where possible.
+func inspect(value interface{}) string {
+	value = indirect(value)
+
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return strconv.Quote(v)
+	case json.Number:
+		return v.String()
+	case float32:
+		return inspectFloat(float64(v))
+	case float64:
+		return inspectFloat(v)
+	}
+
+	return fmt.Sprintf("%v", value)
+}
+
+func inspectFloat(f float64) string {
+	switch {
+	case math.IsNaN(f):
+		return "NaN"
+	case math.IsInf(f, 1):
+		return "Infinity"
+	case math.IsInf(f, -1):
+		return "-Infinity"
+	}
+
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// indirect dereferences pointers until it reaches a value, returning nil for
+// a nil pointer.
+func indirect(value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	return v.Interface()
+}
+
+// equalValues reports whether two values are equal. They are compared
+// deeply, since == panics on slices, maps and funcs, including those held in
+// the interface fields of comparable structs and arrays.
+func equalValues(a, b interface{}) bool {
+	return reflect.DeepEqual(a, b)
+}
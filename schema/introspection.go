@@ -0,0 +1,726 @@
+package schema
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ijsnow/goql/internal/language"
+)
+
+// TypeKind is the kind of a type, as reported by introspection.
+type TypeKind string
+
+// The kinds of types.
+const (
+	TypeKindScalar      TypeKind = "SCALAR"
+	TypeKindObject      TypeKind = "OBJECT"
+	TypeKindInterface   TypeKind = "INTERFACE"
+	TypeKindUnion       TypeKind = "UNION"
+	TypeKindEnum        TypeKind = "ENUM"
+	TypeKindInputObject TypeKind = "INPUT_OBJECT"
+	TypeKindList        TypeKind = "LIST"
+	TypeKindNonNull     TypeKind = "NON_NULL"
+)
+
+// The types of the introspection system. They are part of every schema and
+// are created in init, since they refer to each other.
+var (
+	SchemaType            *Object
+	DirectiveType         *Object
+	DirectiveLocationType *Enum
+	TypeType              *Object
+	FieldType             *Object
+	InputValueType        *Object
+	EnumValueType         *Object
+	TypeKindType          *Enum
+)
+
+// IntrospectionTypes are the types of the introspection system.
+var IntrospectionTypes []NamedType
+
+// The meta-fields are available on every schema without being defined on
+// its types. __schema and __type may be selected on the query type, and
+// __typename on any object, interface or union. Note that, unlike other
+// fields, they are not returned by GetFields; use GetFieldDef to look up a
+// field that may be one of them.
+var (
+	SchemaMetaFieldDef   *Field
+	TypeMetaFieldDef     *Field
+	TypeNameMetaFieldDef *Field
+)
+
+// IsIntrospectionType reports whether the type is one of the types of the
+// introspection system.
+func IsIntrospectionType(t NamedType) bool {
+	for _, introspectionType := range IntrospectionTypes {
+		if t.GetName() == introspectionType.GetName() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetFieldDef returns the definition of the field selected on the parent
+// type, including the meta-fields, or nil if there is no such field.
+func GetFieldDef(schema *Schema, parentType CompositeType, fieldName string) *Field {
+	switch {
+	case fieldName == SchemaMetaFieldDef.Name && schema.GetQueryType() == parentType:
+		return SchemaMetaFieldDef
+	case fieldName == TypeMetaFieldDef.Name && schema.GetQueryType() == parentType:
+		return TypeMetaFieldDef
+	case fieldName == TypeNameMetaFieldDef.Name:
+		return TypeNameMetaFieldDef
+	}
+
+	switch t := parentType.(type) {
+	case *Object:
+		return t.GetField(fieldName)
+	case *Interface:
+		return t.GetField(fieldName)
+	}
+
+	return nil
+}
+
+// nullableString resolves an empty string as null.
+func nullableString(str string) interface{} {
+	if str == "" {
+		return nil
+	}
+
+	return str
+}
+
+// includeDeprecated reads the includeDeprecated argument, which defaults to
+// false.
+func includeDeprecated(p ResolveParams) bool {
+	include, _ := p.Args["includeDeprecated"].(bool)
+	return include
+}
+
+var includeDeprecatedArgs = []*Argument{{
+	Name:         "includeDeprecated",
+	Type:         Boolean,
+	DefaultValue: false,
+}}
+
+// typeKind returns the kind of the type.
+func typeKind(t Type) (TypeKind, error) {
+	switch t.(type) {
+	case *Scalar:
+		return TypeKindScalar, nil
+	case *Object:
+		return TypeKindObject, nil
+	case *Interface:
+		return TypeKindInterface, nil
+	case *Union:
+		return TypeKindUnion, nil
+	case *Enum:
+		return TypeKindEnum, nil
+	case *InputObject:
+		return TypeKindInputObject, nil
+	case *List:
+		return TypeKindList, nil
+	case *NonNull:
+		return TypeKindNonNull, nil
+	}
+
+	return "", fmt.Errorf("Unknown kind of type: %v", t)
+}
+
+// inputValue is the common view of arguments and input fields, which are
+// both described by __InputValue.
+type inputValue struct {
+	name              string
+	description       string
+	t                 Type
+	defaultValue      interface{}
+	deprecationReason string
+}
+
+func inputValueOf(source interface{}) inputValue {
+	switch v := source.(type) {
+	case *Argument:
+		return inputValue{v.Name, v.Description, v.Type, v.DefaultValue, v.DeprecationReason}
+	case *InputField:
+		return inputValue{v.Name, v.Description, v.Type, v.DefaultValue, v.DeprecationReason}
+	}
+
+	return inputValue{}
+}
+
+// activeArgs returns the arguments that are not deprecated, unless the
+// includeDeprecated argument is true.
+func activeArgs(p ResolveParams, args []*Argument) []*Argument {
+	active := []*Argument{}
+	for _, arg := range args {
+		if includeDeprecated(p) || arg.DeprecationReason == "" {
+			active = append(active, arg)
+		}
+	}
+
+	return active
+}
+
+// The descriptions of the directive locations, for __DirectiveLocation.
+var directiveLocationDescriptions = map[DirectiveLocation]string{
+	DirectiveLocationQuery:                "Location adjacent to a query operation.",
+	DirectiveLocationMutation:             "Location adjacent to a mutation operation.",
+	DirectiveLocationSubscription:         "Location adjacent to a subscription operation.",
+	DirectiveLocationField:                "Location adjacent to a field.",
+	DirectiveLocationFragmentDefinition:   "Location adjacent to a fragment definition.",
+	DirectiveLocationFragmentSpread:       "Location adjacent to a fragment spread.",
+	DirectiveLocationInlineFragment:       "Location adjacent to an inline fragment.",
+	DirectiveLocationSchema:               "Location adjacent to a schema definition.",
+	DirectiveLocationScalar:               "Location adjacent to a scalar definition.",
+	DirectiveLocationObject:               "Location adjacent to an object type definition.",
+	DirectiveLocationFieldDefinition:      "Location adjacent to a field definition.",
+	DirectiveLocationArgumentDefinition:   "Location adjacent to an argument definition.",
+	DirectiveLocationInterface:            "Location adjacent to an interface definition.",
+	DirectiveLocationUnion:                "Location adjacent to a union definition.",
+	DirectiveLocationEnum:                 "Location adjacent to an enum definition.",
+	DirectiveLocationEnumValue:            "Location adjacent to an enum value definition.",
+	DirectiveLocationInputObject:          "Location adjacent to an input object type definition.",
+	DirectiveLocationInputFieldDefinition: "Location adjacent to an input object field definition.",
+}
+
+func init() {
+	SchemaType = NewObject(ObjectConfig{
+		Name: "__Schema",
+		Description: "A GraphQL Schema defines the capabilities of a GraphQL server. It " +
+			"exposes all available types and directives on the server, as well as " +
+			"the entry points for query, mutation, and subscription operations.",
+		Fields: FieldsFunc(func() Fields {
+			return Fields{
+				{
+					Name:        "types",
+					Description: "A list of all types supported by this server.",
+					Type:        NewNonNull(NewList(NewNonNull(TypeType))),
+					Resolve: func(p ResolveParams) (interface{}, error) {
+						typeMap := p.Source.(*Schema).GetTypeMap()
+
+						names := make([]string, 0, len(typeMap))
+						for name := range typeMap {
+							names = append(names, name)
+						}
+						sort.Strings(names)
+
+						types := make([]NamedType, len(names))
+						for idx, name := range names {
+							types[idx] = typeMap[name]
+						}
+						return types, nil
+					},
+				},
+				{
+					Name:        "queryType",
+					Description: "The type that query operations will be rooted at.",
+					Type:        NewNonNull(TypeType),
+					Resolve: func(p ResolveParams) (interface{}, error) {
+						return p.Source.(*Schema).GetQueryType(), nil
+					},
+				},
+				{
+					Name: "mutationType",
+					Description: "If this server supports mutation, the type that " +
+						"mutation operations will be rooted at.",
+					Type: TypeType,
+					Resolve: func(p ResolveParams) (interface{}, error) {
+						if t := p.Source.(*Schema).GetMutationType(); t != nil {
+							return t, nil
+						}
+						return nil, nil
+					},
+				},
+				{
+					Name: "subscriptionType",
+					Description: "If this server support subscription, the type that " +
+						"subscription operations will be rooted at.",
+					Type: TypeType,
+					Resolve: func(p ResolveParams) (interface{}, error) {
+						if t := p.Source.(*Schema).GetSubscriptionType(); t != nil {
+							return t, nil
+						}
+						return nil, nil
+					},
+				},
+				{
+					Name:        "directives",
+					Description: "A list of all directives supported by this server.",
+					Type:        NewNonNull(NewList(NewNonNull(DirectiveType))),
+					Resolve: func(p ResolveParams) (interface{}, error) {
+						return p.Source.(*Schema).GetDirectives(), nil
+					},
+				},
+			}
+		}),
+	})
+
+	DirectiveType = NewObject(ObjectConfig{
+		Name: "__Directive",
+		Description: "A Directive provides a way to describe alternate runtime execution and " +
+			"type validation behavior in a GraphQL document." +
+			"\n\nIn some cases, you need to provide options to alter GraphQL's " +
+			"execution behavior in ways field arguments will not suffice, such as " +
+			"conditionally including or skipping a field. Directives provide this by " +
+			"describing additional information to the executor.",
+		Fields: FieldsFunc(func() Fields {
+			return Fields{
+				{
+					Name: "name",
+					Type: NewNonNull(String),
+					Resolve: func(p ResolveParams) (interface{}, error) {
+						return p.Source.(*Directive).Name, nil
+					},
+				},
+				{
+					Name: "description",
+					Type: String,
+					Resolve: func(p ResolveParams) (interface{}, error) {
+						return nullableString(p.Source.(*Directive).Description), nil
+					},
+				},
+				{
+					Name: "isRepeatable",
+					Type: NewNonNull(Boolean),
+					Resolve: func(p ResolveParams) (interface{}, error) {
+						return p.Source.(*Directive).IsRepeatable, nil
+					},
+				},
+				{
+					Name: "locations",
+					Type: NewNonNull(NewList(NewNonNull(DirectiveLocationType))),
+					Resolve: func(p ResolveParams) (interface{}, error) {
+						return p.Source.(*Directive).Locations, nil
+					},
+				},
+				{
+					Name: "args",
+					Type: NewNonNull(NewList(NewNonNull(InputValueType))),
+					Args: includeDeprecatedArgs,
+					Resolve: func(p ResolveParams) (interface{}, error) {
+						return activeArgs(p, p.Source.(*Directive).Args), nil
+					},
+				},
+			}
+		}),
+	})
+
+	locationValues := make([]*EnumValue, len(DirectiveLocations))
+	for idx, location := range DirectiveLocations {
+		locationValues[idx] = &EnumValue{
+			Name:        string(location),
+			Value:       location,
+			Description: directiveLocationDescriptions[location],
+		}
+	}
+	DirectiveLocationType = NewEnum(EnumConfig{
+		Name: "__DirectiveLocation",
+		Description: "A Directive can be adjacent to many parts of the GraphQL language, a " +
+			"__DirectiveLocation describes one such possible adjacencies.",
+		Values: locationValues,
+	})
+
+	TypeType = NewObject(ObjectConfig{
+		Name: "__Type",
+		Description: "The fundamental unit of any GraphQL Schema is the type. There are " +
+			"many kinds of types in GraphQL as represented by the `__TypeKind` enum." +
+			"\n\nDepending on the kind of a type, certain fields describe " +
+			"information about that type. Scalar types provide no information " +
+			"beyond a name, description and optional `specifiedByURL`, while Enum types provide their values. " +
+			"Object and Interface types provide the fields they describe. Abstract " +
+			"types, Union and Interface, provide the Object types possible " +
+			"at runtime. List and NonNull types compose other types.",
+		Fields: FieldsFunc(func() Fields {
+			return Fields{
+				{
+					Name: "kind",
+					Type: NewNonNull(TypeKindType),
+					Resolve: func(p ResolveParams) (interface{}, error) {
+						return typeKind(p.Source.(Type))
+					},
+				},
+				{
+					Name: "name",
+					Type: String,
+					Resolve: func(p ResolveParams) (interface{}, error) {
+						if t, ok := p.Source.(NamedType); ok {
+							return t.GetName(), nil
+						}
+						return nil, nil
+					},
+				},
+				{
+					Name: "description",
+					Type: String,
+					Resolve: func(p ResolveParams) (interface{}, error) {
+						if t, ok := p.Source.(NamedType); ok {
+							return nullableString(t.GetDescription()), nil
+						}
+						return nil, nil
+					},
+				},
+				{
+					Name: "specifiedByURL",
+					Type: String,
+					Resolve: func(p ResolveParams) (interface{}, error) {
+						if t, ok := p.Source.(*Scalar); ok {
+							return nullableString(t.SpecifiedByURL), nil
+						}
+						return nil, nil
+					},
+				},
+				{
+					Name: "fields",
+					Type: NewList(NewNonNull(FieldType)),
+					Args: includeDeprecatedArgs,
+					Resolve: func(p ResolveParams) (interface{}, error) {
+						var fields []*Field
+						switch t := p.Source.(type) {
+						case *Object:
+							fields = t.GetFields()
+						case *Interface:
+							fields = t.GetFields()
+						default:
+							return nil, nil
+						}
+
+						if includeDeprecated(p) {
+							return fields, nil
+						}
+						active := []*Field{}
+						for _, field := range fields {
+							if !field.IsDeprecated {
+								active = append(active, field)
+							}
+						}
+						return active, nil
+					},
+				},
+				{
+					Name: "interfaces",
+					Type: NewList(NewNonNull(TypeType)),
+					Resolve: func(p ResolveParams) (interface{}, error) {
+						if t, ok := p.Source.(*Object); ok {
+							if interfaces := t.GetInterfaces(); interfaces != nil {
+								return interfaces, nil
+							}
+							return []*Interface{}, nil
+						}
+						return nil, nil
+					},
+				},
+				{
+					Name: "possibleTypes",
+					Type: NewList(NewNonNull(TypeType)),
+					Resolve: func(p ResolveParams) (interface{}, error) {
+						if t, ok := p.Source.(AbstractType); ok {
+							if possible := p.Info.Schema.PossibleTypes(t); possible != nil {
+								return possible, nil
+							}
+							return []*Object{}, nil
+						}
+						return nil, nil
+					},
+				},
+				{
+					Name: "enumValues",
+					Type: NewList(NewNonNull(EnumValueType)),
+					Args: includeDeprecatedArgs,
+					Resolve: func(p ResolveParams) (interface{}, error) {
+						t, ok := p.Source.(*Enum)
+						if !ok {
+							return nil, nil
+						}
+
+						values := []*EnumValue{}
+						for _, value := range t.GetValues() {
+							if includeDeprecated(p) || !value.IsDeprecated {
+								values = append(values, value)
+							}
+						}
+						return values, nil
+					},
+				},
+				{
+					Name: "inputFields",
+					Type: NewList(NewNonNull(InputValueType)),
+					Args: includeDeprecatedArgs,
+					Resolve: func(p ResolveParams) (interface{}, error) {
+						t, ok := p.Source.(*InputObject)
+						if !ok {
+							return nil, nil
+						}
+
+						fields := []*InputField{}
+						for _, field := range t.GetFields() {
+							if includeDeprecated(p) || field.DeprecationReason == "" {
+								fields = append(fields, field)
+							}
+						}
+						return fields, nil
+					},
+				},
+				{
+					Name: "ofType",
+					Type: TypeType,
+					Resolve: func(p ResolveParams) (interface{}, error) {
+						if t, ok := p.Source.(WrappingType); ok {
+							return t.GetOfType(), nil
+						}
+						return nil, nil
+					},
+				},
+			}
+		}),
+	})
+
+	FieldType = NewObject(ObjectConfig{
+		Name: "__Field",
+		Description: "Object and Interface types are described by a list of Fields, each of " +
+			"which has a name, potentially a list of arguments, and a return type.",
+		Fields: FieldsFunc(func() Fields {
+			return Fields{
+				{
+					Name: "name",
+					Type: NewNonNull(String),
+					Resolve: func(p ResolveParams) (interface{}, error) {
+						return p.Source.(*Field).Name, nil
+					},
+				},
+				{
+					Name: "description",
+					Type: String,
+					Resolve: func(p ResolveParams) (interface{}, error) {
+						return nullableString(p.Source.(*Field).Description), nil
+					},
+				},
+				{
+					Name: "args",
+					Type: NewNonNull(NewList(NewNonNull(InputValueType))),
+					Args: includeDeprecatedArgs,
+					Resolve: func(p ResolveParams) (interface{}, error) {
+						return activeArgs(p, p.Source.(*Field).Args), nil
+					},
+				},
+				{
+					Name: "type",
+					Type: NewNonNull(TypeType),
+					Resolve: func(p ResolveParams) (interface{}, error) {
+						return p.Source.(*Field).Type, nil
+					},
+				},
+				{
+					Name: "isDeprecated",
+					Type: NewNonNull(Boolean),
+					Resolve: func(p ResolveParams) (interface{}, error) {
+						return p.Source.(*Field).IsDeprecated, nil
+					},
+				},
+				{
+					Name: "deprecationReason",
+					Type: String,
+					Resolve: func(p ResolveParams) (interface{}, error) {
+						return nullableString(p.Source.(*Field).DeprecationReason), nil
+					},
+				},
+			}
+		}),
+	})
+
+	InputValueType = NewObject(ObjectConfig{
+		Name: "__InputValue",
+		Description: "Arguments provided to Fields or Directives and the input fields of an " +
+			"InputObject are represented as Input Values which describe their type " +
+			"and optionally a default value.",
+		Fields: FieldsFunc(func() Fields {
+			return Fields{
+				{
+					Name: "name",
+					Type: NewNonNull(String),
+					Resolve: func(p ResolveParams) (interface{}, error) {
+						return inputValueOf(p.Source).name, nil
+					},
+				},
+				{
+					Name: "description",
+					Type: String,
+					Resolve: func(p ResolveParams) (interface{}, error) {
+						return nullableString(inputValueOf(p.Source).description), nil
+					},
+				},
+				{
+					Name: "type",
+					Type: NewNonNull(TypeType),
+					Resolve: func(p ResolveParams) (interface{}, error) {
+						return inputValueOf(p.Source).t, nil
+					},
+				},
+				{
+					Name: "defaultValue",
+					Description: "A GraphQL-formatted string representing the default value for this " +
+						"input value.",
+					Type: String,
+					Resolve: func(p ResolveParams) (interface{}, error) {
+						value := inputValueOf(p.Source)
+						if value.defaultValue == nil {
+							return nil, nil
+						}
+
+						valueNode, err := AstFromValue(value.defaultValue, value.t)
+						if err != nil || valueNode == nil {
+							return nil, err
+						}
+						return language.Print(valueNode), nil
+					},
+				},
+				{
+					Name: "isDeprecated",
+					Type: NewNonNull(Boolean),
+					Resolve: func(p ResolveParams) (interface{}, error) {
+						return inputValueOf(p.Source).deprecationReason != "", nil
+					},
+				},
+				{
+					Name: "deprecationReason",
+					Type: String,
+					Resolve: func(p ResolveParams) (interface{}, error) {
+						return nullableString(inputValueOf(p.Source).deprecationReason), nil
+					},
+				},
+			}
+		}),
+	})
+
+	EnumValueType = NewObject(ObjectConfig{
+		Name: "__EnumValue",
+		Description: "One possible value for a given Enum. Enum values are unique values, not " +
+			"a placeholder for a string or numeric value. However an Enum value is " +
+			"returned in a JSON response as a string.",
+		Fields: FieldsFunc(func() Fields {
+			return Fields{
+				{
+					Name: "name",
+					Type: NewNonNull(String),
+					Resolve: func(p ResolveParams) (interface{}, error) {
+						return p.Source.(*EnumValue).Name, nil
+					},
+				},
+				{
+					Name: "description",
+					Type: String,
+					Resolve: func(p ResolveParams) (interface{}, error) {
+						return nullableString(p.Source.(*EnumValue).Description), nil
+					},
+				},
+				{
+					Name: "isDeprecated",
+					Type: NewNonNull(Boolean),
+					Resolve: func(p ResolveParams) (interface{}, error) {
+						return p.Source.(*EnumValue).IsDeprecated, nil
+					},
+				},
+				{
+					Name: "deprecationReason",
+					Type: String,
+					Resolve: func(p ResolveParams) (interface{}, error) {
+						return nullableString(p.Source.(*EnumValue).DeprecationReason), nil
+					},
+				},
+			}
+		}),
+	})
+
+	TypeKindType = NewEnum(EnumConfig{
+		Name:        "__TypeKind",
+		Description: "An enum describing what kind of type a given `__Type` is.",
+		Values: []*EnumValue{
+			{
+				Name:        "SCALAR",
+				Value:       TypeKindScalar,
+				Description: "Indicates this type is a scalar.",
+			},
+			{
+				Name:        "OBJECT",
+				Value:       TypeKindObject,
+				Description: "Indicates this type is an object. `fields` and `interfaces` are valid fields.",
+			},
+			{
+				Name:        "INTERFACE",
+				Value:       TypeKindInterface,
+				Description: "Indicates this type is an interface. `fields` and `possibleTypes` are valid fields.",
+			},
+			{
+				Name:        "UNION",
+				Value:       TypeKindUnion,
+				Description: "Indicates this type is a union. `possibleTypes` is a valid field.",
+			},
+			{
+				Name:        "ENUM",
+				Value:       TypeKindEnum,
+				Description: "Indicates this type is an enum. `enumValues` is a valid field.",
+			},
+			{
+				Name:        "INPUT_OBJECT",
+				Value:       TypeKindInputObject,
+				Description: "Indicates this type is an input object. `inputFields` is a valid field.",
+			},
+			{
+				Name:        "LIST",
+				Value:       TypeKindList,
+				Description: "Indicates this type is a list. `ofType` is a valid field.",
+			},
+			{
+				Name:        "NON_NULL",
+				Value:       TypeKindNonNull,
+				Description: "Indicates this type is a non-null. `ofType` is a valid field.",
+			},
+		},
+	})
+
+	IntrospectionTypes = []NamedType{
+		SchemaType,
+		DirectiveType,
+		DirectiveLocationType,
+		TypeType,
+		FieldType,
+		InputValueType,
+		EnumValueType,
+		TypeKindType,
+	}
+
+	SchemaMetaFieldDef = &Field{
+		Name:        "__schema",
+		Type:        NewNonNull(SchemaType),
+		Description: "Access the current type schema of this server.",
+		Args:        []*Argument{},
+		Resolve: func(p ResolveParams) (interface{}, error) {
+			return p.Info.Schema, nil
+		},
+	}
+
+	TypeMetaFieldDef = &Field{
+		Name:        "__type",
+		Type:        TypeType,
+		Description: "Request the type information of a single type.",
+		Args:        []*Argument{{Name: "name", Type: NewNonNull(String)}},
+		Resolve: func(p ResolveParams) (interface{}, error) {
+			name, _ := p.Args["name"].(string)
+			if t := p.Info.Schema.GetType(name); t != nil {
+				return t, nil
+			}
+			return nil, nil
+		},
+	}
+
+	TypeNameMetaFieldDef = &Field{
+		Name:        "__typename",
+		Type:        NewNonNull(String),
+		Description: "The name of the current Object type at runtime.",
+		Args:        []*Argument{},
+		Resolve: func(p ResolveParams) (interface{}, error) {
+			return p.Info.ParentType.GetName(), nil
+		},
+	}
+}
@@ -0,0 +1,304 @@
+package schema
+
+import "strings"
+
+// IntrospectionQuery is the query that fetches everything needed to build a
+// client schema with BuildClientSchema. It is the query returned by
+// GetIntrospectionQuery for the default options, which include descriptions.
+const IntrospectionQuery = `query IntrospectionQuery {
+  __schema {
+    queryType { name }
+    mutationType { name }
+    subscriptionType { name }
+    types {
+      ...FullType
+    }
+    directives {
+      name
+      description
+      locations
+      args {
+        ...InputValue
+      }
+    }
+  }
+}
+
+fragment FullType on __Type {
+  kind
+  name
+  description
+  fields(includeDeprecated: true) {
+    name
+    description
+    args {
+      ...InputValue
+    }
+    type {
+      ...TypeRef
+    }
+    isDeprecated
+    deprecationReason
+  }
+  inputFields {
+    ...InputValue
+  }
+  interfaces {
+    ...TypeRef
+  }
+  enumValues(includeDeprecated: true) {
+    name
+    description
+    isDeprecated
+    deprecationReason
+  }
+  possibleTypes {
+    ...TypeRef
+  }
+}
+
+fragment InputValue on __InputValue {
+  name
+  description
+  type { ...TypeRef }
+  defaultValue
+}
+
+fragment TypeRef on __Type {
+  kind
+  name
+  ofType {
+    kind
+    name
+    ofType {
+      kind
+      name
+      ofType {
+        kind
+        name
+        ofType {
+          kind
+          name
+          ofType {
+            kind
+            name
+            ofType {
+              kind
+              name
+              ofType {
+                kind
+                name
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}
+`
+
+// IntrospectionOptions selects the optional parts of the introspection
+// query. Servers that do not support a part reject queries asking for it.
+type IntrospectionOptions struct {
+	/**
+	 * Descriptions includes the descriptions of types, fields, arguments,
+	 * enum values and directives.
+	 */
+	Descriptions bool
+
+	/**
+	 * SpecifiedByURL includes the specifiedByURL of scalars.
+	 */
+	SpecifiedByURL bool
+
+	/**
+	 * DirectiveIsRepeatable includes whether directives are repeatable.
+	 */
+	DirectiveIsRepeatable bool
+
+	/**
+	 * InputValueDeprecation includes deprecated arguments and input fields,
+	 * along with their deprecation.
+	 */
+	InputValueDeprecation bool
+}
+
+// DefaultIntrospectionOptions are the options of IntrospectionQuery.
+var DefaultIntrospectionOptions = IntrospectionOptions{Descriptions: true}
+
+// introspectionQueryTemplate is IntrospectionQuery with a placeholder on its
+// own line, or at the end of a field name, for each optional part.
+const introspectionQueryTemplate = `query IntrospectionQuery {
+  __schema {
+    queryType { name }
+    mutationType { name }
+    subscriptionType { name }
+    types {
+      ...FullType
+    }
+    directives {
+      name
+      {description}
+      {isRepeatable}
+      locations
+      args{includeDeprecated} {
+        ...InputValue
+      }
+    }
+  }
+}
+
+fragment FullType on __Type {
+  kind
+  name
+  {description}
+  {specifiedByURL}
+  fields(includeDeprecated: true) {
+    name
+    {description}
+    args{includeDeprecated} {
+      ...InputValue
+    }
+    type {
+      ...TypeRef
+    }
+    isDeprecated
+    deprecationReason
+  }
+  inputFields{includeDeprecated} {
+    ...InputValue
+  }
+  interfaces {
+    ...TypeRef
+  }
+  enumValues(includeDeprecated: true) {
+    name
+    {description}
+    isDeprecated
+    deprecationReason
+  }
+  possibleTypes {
+    ...TypeRef
+  }
+}
+
+fragment InputValue on __InputValue {
+  name
+  {description}
+  type { ...TypeRef }
+  defaultValue
+  {isDeprecated}
+  {deprecationReason}
+}
+`
+
+// GetIntrospectionQuery returns the introspection query for the options.
+func GetIntrospectionQuery(options IntrospectionOptions) string {
+	part := func(include bool, text string) string {
+		if include {
+			return text
+		}
+		return ""
+	}
+
+	replacer := strings.NewReplacer(
+		"{description}", part(options.Descriptions, "description"),
+		"{isRepeatable}", part(options.DirectiveIsRepeatable, "isRepeatable"),
+		"{specifiedByURL}", part(options.SpecifiedByURL, "specifiedByURL"),
+		"{includeDeprecated}", part(options.InputValueDeprecation, "(includeDeprecated: true)"),
+		"{isDeprecated}", part(options.InputValueDeprecation, "isDeprecated"),
+		"{deprecationReason}", part(options.InputValueDeprecation, "deprecationReason"),
+	)
+
+	var lines []string
+	for _, line := range strings.Split(introspectionQueryTemplate, "\n") {
+		replaced := replacer.Replace(line)
+
+		// Drop the lines of the parts left out.
+		if line != "" && strings.TrimSpace(replaced) == "" {
+			continue
+		}
+		lines = append(lines, replaced)
+	}
+
+	// The TypeRef fragment is the same for all options.
+	typeRef := IntrospectionQuery[strings.Index(IntrospectionQuery, "\nfragment TypeRef"):]
+
+	return strings.Join(lines, "\n") + typeRef
+}
+
+// IntrospectionResult is the result of the introspection query, as decoded
+// from the data of a response.
+type IntrospectionResult struct {
+	Schema IntrospectionSchema `json:"__schema"`
+}
+
+// IntrospectionSchema describes a schema.
+type IntrospectionSchema struct {
+	QueryType        *IntrospectionTypeRef    `json:"queryType"`
+	MutationType     *IntrospectionTypeRef    `json:"mutationType"`
+	SubscriptionType *IntrospectionTypeRef    `json:"subscriptionType"`
+	Types            []IntrospectionType      `json:"types"`
+	Directives       []IntrospectionDirective `json:"directives"`
+}
+
+// IntrospectionType describes a named type. Which fields are set depends on
+// its kind.
+type IntrospectionType struct {
+	Kind           TypeKind                  `json:"kind"`
+	Name           string                    `json:"name"`
+	Description    string                    `json:"description,omitempty"`
+	SpecifiedByURL string                    `json:"specifiedByURL,omitempty"`
+	Fields         []IntrospectionField      `json:"fields"`
+	InputFields    []IntrospectionInputValue `json:"inputFields"`
+	Interfaces     []IntrospectionTypeRef    `json:"interfaces"`
+	EnumValues     []IntrospectionEnumValue  `json:"enumValues"`
+	PossibleTypes  []IntrospectionTypeRef    `json:"possibleTypes"`
+}
+
+// IntrospectionTypeRef refers to a type, which is a named type wrapped in
+// any number of lists and non-nulls.
+type IntrospectionTypeRef struct {
+	Kind   TypeKind              `json:"kind"`
+	Name   string                `json:"name,omitempty"`
+	OfType *IntrospectionTypeRef `json:"ofType,omitempty"`
+}
+
+// IntrospectionField describes a field of an object or interface.
+type IntrospectionField struct {
+	Name              string                    `json:"name"`
+	Description       string                    `json:"description,omitempty"`
+	Args              []IntrospectionInputValue `json:"args"`
+	Type              IntrospectionTypeRef      `json:"type"`
+	IsDeprecated      bool                      `json:"isDeprecated"`
+	DeprecationReason string                    `json:"deprecationReason,omitempty"`
+}
+
+// IntrospectionInputValue describes an argument or an input field. Its
+// default value is written as a literal, or is nil if it has none.
+type IntrospectionInputValue struct {
+	Name              string               `json:"name"`
+	Description       string               `json:"description,omitempty"`
+	Type              IntrospectionTypeRef `json:"type"`
+	DefaultValue      *string              `json:"defaultValue"`
+	IsDeprecated      bool                 `json:"isDeprecated,omitempty"`
+	DeprecationReason string               `json:"deprecationReason,omitempty"`
+}
+
+// IntrospectionEnumValue describes a value of an enum.
+type IntrospectionEnumValue struct {
+	Name              string `json:"name"`
+	Description       string `json:"description,omitempty"`
+	IsDeprecated      bool   `json:"isDeprecated"`
+	DeprecationReason string `json:"deprecationReason,omitempty"`
+}
+
+// IntrospectionDirective describes a directive.
+type IntrospectionDirective struct {
+	Name         string                    `json:"name"`
+	Description  string                    `json:"description,omitempty"`
+	IsRepeatable bool                      `json:"isRepeatable,omitempty"`
+	Locations    []DirectiveLocation       `json:"locations"`
+	Args         []IntrospectionInputValue `json:"args"`
+}
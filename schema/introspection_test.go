@@ -0,0 +1,174 @@
+package schema
+
+import (
+	"reflect"
+	"testing"
+)
+
+// resolve calls the resolver of a field of an introspection type.
+func resolve(t *testing.T, object *Object, fieldName string, source interface{}, args map[string]interface{}, schema *Schema) interface{} {
+	t.Helper()
+
+	field := object.GetField(fieldName)
+	if field == nil {
+		t.Fatalf("%s has no field %s", object, fieldName)
+	}
+
+	result, err := field.Resolve(ResolveParams{
+		Source: source,
+		Args:   args,
+		Info:   ResolveInfo{FieldName: fieldName, Schema: schema, ParentType: object},
+	})
+	if err != nil {
+		t.Fatalf("%s.%s; got error %v", object, fieldName, err)
+	}
+
+	return result
+}
+
+func TestIncludesIntrospectionTypes(t *testing.T) {
+	schema, err := NewSchema(SchemaConfig{Query: blogQuery})
+	if err != nil {
+		t.Fatalf("NewSchema; got error %v", err)
+	}
+
+	for _, introspectionType := range IntrospectionTypes {
+		if schema.GetType(introspectionType.GetName()) != introspectionType {
+			t.Errorf("GetType(%s); got %v", introspectionType.GetName(), schema.GetType(introspectionType.GetName()))
+		}
+	}
+
+	if errs := Validate(schema); len(errs) != 0 {
+		t.Errorf("Validate; got %v", errs)
+	}
+
+	if got := resolve(t, SchemaType, "queryType", schema, nil, schema); got != blogQuery {
+		t.Errorf("__Schema.queryType; got %v", got)
+	}
+	if got := resolve(t, SchemaType, "subscriptionType", schema, nil, schema); got != nil {
+		t.Errorf("__Schema.subscriptionType; got %#v wanted nil", got)
+	}
+}
+
+func TestIntrospectsTypes(t *testing.T) {
+	pet := NewInterface(InterfaceConfig{Name: "Pet", Fields: Fields{{Name: "name", Type: String}}})
+	dog := NewObject(ObjectConfig{
+		Name:        "Dog",
+		Description: "A good boy.",
+		Interfaces:  Interfaces{pet},
+		Fields: Fields{
+			{Name: "name", Type: String},
+			{Name: "barks", Type: Boolean, DeprecationReason: "Always true."},
+		},
+	})
+	schema, err := NewSchema(SchemaConfig{Query: NewObject(ObjectConfig{
+		Name:   "Query",
+		Fields: Fields{{Name: "pets", Type: NewNonNull(NewList(pet))}},
+	}), Types: []NamedType{dog}})
+	if err != nil {
+		t.Fatalf("NewSchema; got error %v", err)
+	}
+
+	tests := []struct {
+		fieldName string
+		source    Type
+		args      map[string]interface{}
+		want      interface{}
+	}{
+		{"kind", dog, nil, TypeKindObject},
+		{"kind", NewList(dog), nil, TypeKindList},
+		{"name", dog, nil, "Dog"},
+		{"name", NewNonNull(dog), nil, nil},
+		{"description", dog, nil, "A good boy."},
+		{"description", pet, nil, nil},
+		{"interfaces", dog, nil, []*Interface{pet}},
+		{"interfaces", pet, nil, nil},
+		{"possibleTypes", pet, nil, []*Object{dog}},
+		{"possibleTypes", dog, nil, nil},
+		{"ofType", NewNonNull(dog), nil, dog},
+		{"fields", String, nil, nil},
+	}
+	for _, test := range tests {
+		got := resolve(t, TypeType, test.fieldName, test.source, test.args, schema)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("__Type.%s of %s; got %#v wanted %#v", test.fieldName, test.source, got, test.want)
+		}
+	}
+
+	fields := resolve(t, TypeType, "fields", dog, nil, schema).([]*Field)
+	if len(fields) != 1 || fields[0].Name != "name" {
+		t.Errorf("__Type.fields; got %v wanted only name", fields)
+	}
+
+	fields = resolve(t, TypeType, "fields", dog, map[string]interface{}{"includeDeprecated": true}, schema).([]*Field)
+	if len(fields) != 2 {
+		t.Fatalf("__Type.fields(includeDeprecated: true); got %v", fields)
+	}
+	if resolve(t, FieldType, "isDeprecated", fields[1], nil, schema) != true ||
+		resolve(t, FieldType, "deprecationReason", fields[1], nil, schema) != "Always true." {
+		t.Error("__Field; got the wrong deprecation of Dog.barks")
+	}
+}
+
+func TestIntrospectsDefaultValues(t *testing.T) {
+	color := NewEnum(EnumConfig{Name: "Color", Values: []*EnumValue{
+		{Name: "RED", Value: 0},
+		{Name: "BLUE", Value: 1},
+	}})
+	filter := NewInputObject(InputObjectConfig{Name: "Filter", Fields: InputFields{
+		{Name: "colors", Type: NewList(color)},
+		{Name: "text", Type: String},
+	}})
+
+	tests := []struct {
+		arg  *Argument
+		want interface{}
+	}{
+		{&Argument{Name: "a", Type: String}, nil},
+		{&Argument{Name: "a", Type: String, DefaultValue: "say \"hi\""}, `"say \"hi\""`},
+		{&Argument{Name: "a", Type: NewList(Int), DefaultValue: []int{1, 2}}, "[1, 2]"},
+		{&Argument{Name: "a", Type: color, DefaultValue: 1}, "BLUE"},
+		{
+			&Argument{Name: "a", Type: filter, DefaultValue: map[string]interface{}{"colors": []int{0}}},
+			"{colors: [RED]}",
+		},
+	}
+	for _, test := range tests {
+		if got := resolve(t, InputValueType, "defaultValue", test.arg, nil, nil); got != test.want {
+			t.Errorf("__InputValue.defaultValue of %#v; got %#v wanted %#v", test.arg.DefaultValue, got, test.want)
+		}
+	}
+}
+
+func TestGetsMetaFieldDefs(t *testing.T) {
+	schema, err := NewSchema(SchemaConfig{Query: blogQuery})
+	if err != nil {
+		t.Fatalf("NewSchema; got error %v", err)
+	}
+
+	tests := []struct {
+		parentType CompositeType
+		fieldName  string
+		want       *Field
+	}{
+		{blogQuery, "__schema", SchemaMetaFieldDef},
+		{blogQuery, "__type", TypeMetaFieldDef},
+		{blogQuery, "__typename", TypeNameMetaFieldDef},
+		{blogArticle, "__typename", TypeNameMetaFieldDef},
+		{blogArticle, "__schema", nil},
+		{blogArticle, "title", blogArticle.GetField("title")},
+	}
+	for _, test := range tests {
+		if got := GetFieldDef(schema, test.parentType, test.fieldName); got != test.want {
+			t.Errorf("GetFieldDef(%s, %s); got %v wanted %v", test.parentType, test.fieldName, got, test.want)
+		}
+	}
+
+	got, _ := TypeMetaFieldDef.Resolve(ResolveParams{
+		Args: map[string]interface{}{"name": "Article"},
+		Info: ResolveInfo{Schema: schema},
+	})
+	if got != blogArticle {
+		t.Errorf("__type(name: \"Article\"); got %v", got)
+	}
+}
@@ -0,0 +1,370 @@
+package schema
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ijsnow/goql/internal/language"
+)
+
+// PrintOptions configuration for PrintSchema, PrintIntrospectionSchema and
+// PrintType
+type PrintOptions struct {
+	/**
+	 * Sorted prints fields, arguments, input fields, enum values, interfaces,
+	 * union members and directives sorted by name, rather than in the order
+	 * they are defined in. Types are always sorted by name, so that printing
+	 * a schema twice gives the same result either way.
+	 */
+	Sorted bool
+}
+
+// PrintSchema prints the types and custom directives of a schema in the
+// schema definition language. The specified scalars and directives and the
+// introspection types are left out, and a schema block is only printed when
+// the root types are not named Query, Mutation and Subscription.
+//
+// Descriptions are printed as block strings, so the result can be read
+// back with BuildFromSDL. An error is returned if a default value cannot be
+// represented as a literal of its type.
+func PrintSchema(schema *Schema, options ...PrintOptions) (string, error) {
+	p := newPrinter(options)
+	printed := p.printFilteredSchema(schema, func(directive *Directive) bool {
+		return !IsSpecifiedDirective(directive)
+	}, isDefinedType)
+
+	return printed, p.err
+}
+
+// PrintIntrospectionSchema prints the specified directives and the
+// introspection types in the schema definition language.
+func PrintIntrospectionSchema(schema *Schema, options ...PrintOptions) (string, error) {
+	p := newPrinter(options)
+	printed := p.printFilteredSchema(schema, IsSpecifiedDirective, IsIntrospectionType)
+
+	return printed, p.err
+}
+
+// PrintType prints the definition of a type in the schema definition
+// language.
+func PrintType(t NamedType, options ...PrintOptions) (string, error) {
+	p := newPrinter(options)
+	printed := p.printType(t)
+
+	return printed, p.err
+}
+
+func isDefinedType(t NamedType) bool {
+	return !IsIntrospectionType(t) && !IsSpecifiedScalarType(t)
+}
+
+type printer struct {
+	options PrintOptions
+
+	// err is the first default value which could not be printed.
+	err error
+}
+
+func newPrinter(options []PrintOptions) *printer {
+	p := &printer{}
+	if len(options) > 0 {
+		p.options = options[0]
+	}
+
+	return p
+}
+
+func (p *printer) printFilteredSchema(schema *Schema, directiveFilter func(*Directive) bool, typeFilter func(NamedType) bool) string {
+	var definitions []string
+	if definition := printSchemaDefinition(schema); definition != "" {
+		definitions = append(definitions, definition)
+	}
+
+	var directives []*Directive
+	for _, directive := range schema.GetDirectives() {
+		if directiveFilter(directive) {
+			directives = append(directives, directive)
+		}
+	}
+	if p.options.Sorted {
+		sort.Slice(directives, func(i, j int) bool { return directives[i].Name < directives[j].Name })
+	}
+	for _, directive := range directives {
+		definitions = append(definitions, p.printDirective(directive))
+	}
+
+	typeMap := schema.GetTypeMap()
+	typeNames := make([]string, 0, len(typeMap))
+	for typeName, t := range typeMap {
+		if typeFilter(t) {
+			typeNames = append(typeNames, typeName)
+		}
+	}
+	sort.Strings(typeNames)
+	for _, typeName := range typeNames {
+		definitions = append(definitions, p.printType(typeMap[typeName]))
+	}
+
+	return strings.Join(definitions, "\n\n") + "\n"
+}
+
+func printSchemaDefinition(schema *Schema) string {
+	if isSchemaOfCommonNames(schema) {
+		return ""
+	}
+
+	var operationTypes []string
+	if queryType := schema.GetQueryType(); queryType != nil {
+		operationTypes = append(operationTypes, "  query: "+queryType.Name)
+	}
+	if mutationType := schema.GetMutationType(); mutationType != nil {
+		operationTypes = append(operationTypes, "  mutation: "+mutationType.Name)
+	}
+	if subscriptionType := schema.GetSubscriptionType(); subscriptionType != nil {
+		operationTypes = append(operationTypes, "  subscription: "+subscriptionType.Name)
+	}
+
+	return "schema {\n" + strings.Join(operationTypes, "\n") + "\n}"
+}
+
+// isSchemaOfCommonNames reports whether the root types follow the common
+// naming convention, Query, Mutation and Subscription, in which case the
+// schema block can be omitted.
+func isSchemaOfCommonNames(schema *Schema) bool {
+	if queryType := schema.GetQueryType(); queryType != nil && queryType.Name != "Query" {
+		return false
+	}
+	if mutationType := schema.GetMutationType(); mutationType != nil && mutationType.Name != "Mutation" {
+		return false
+	}
+	if subscriptionType := schema.GetSubscriptionType(); subscriptionType != nil && subscriptionType.Name != "Subscription" {
+		return false
+	}
+
+	return true
+}
+
+func (p *printer) printType(t NamedType) string {
+	switch t := t.(type) {
+	case *Scalar:
+		return p.printScalar(t)
+	case *Object:
+		return p.printObject(t)
+	case *Interface:
+		return p.printInterface(t)
+	case *Union:
+		return p.printUnion(t)
+	case *Enum:
+		return p.printEnum(t)
+	case *InputObject:
+		return p.printInputObject(t)
+	}
+
+	return ""
+}
+
+func (p *printer) printScalar(t *Scalar) string {
+	specifiedBy := ""
+	if t.SpecifiedByURL != "" {
+		specifiedBy = " @specifiedBy(url: " + printStringValue(t.SpecifiedByURL) + ")"
+	}
+
+	return printDescription(t.Description, "", true) + "scalar " + t.Name + specifiedBy
+}
+
+func (p *printer) printObject(t *Object) string {
+	interfaces := append([]*Interface(nil), t.GetInterfaces()...)
+	if p.options.Sorted {
+		sort.Slice(interfaces, func(i, j int) bool { return interfaces[i].Name < interfaces[j].Name })
+	}
+
+	implementedInterfaces := ""
+	if len(interfaces) > 0 {
+		names := make([]string, len(interfaces))
+		for idx, iface := range interfaces {
+			names[idx] = iface.Name
+		}
+		implementedInterfaces = " implements " + strings.Join(names, ", ")
+	}
+
+	return printDescription(t.Description, "", true) +
+		"type " + t.Name + implementedInterfaces + " {\n" +
+		p.printFields(t.Name, t.GetFields()) + "\n" +
+		"}"
+}
+
+func (p *printer) printInterface(t *Interface) string {
+	return printDescription(t.Description, "", true) +
+		"interface " + t.Name + " {\n" +
+		p.printFields(t.Name, t.GetFields()) + "\n" +
+		"}"
+}
+
+func (p *printer) printUnion(t *Union) string {
+	types := append([]*Object(nil), t.GetTypes()...)
+	if p.options.Sorted {
+		sort.Slice(types, func(i, j int) bool { return types[i].Name < types[j].Name })
+	}
+
+	names := make([]string, len(types))
+	for idx, object := range types {
+		names[idx] = object.Name
+	}
+
+	return printDescription(t.Description, "", true) +
+		"union " + t.Name + " = " + strings.Join(names, " | ")
+}
+
+func (p *printer) printEnum(t *Enum) string {
+	values := append([]*EnumValue(nil), t.GetValues()...)
+	if p.options.Sorted {
+		sort.Slice(values, func(i, j int) bool { return values[i].Name < values[j].Name })
+	}
+
+	lines := make([]string, len(values))
+	for idx, value := range values {
+		lines[idx] = printDescription(value.Description, "  ", idx == 0) + "  " +
+			value.Name + printDeprecated(value.DeprecationReason)
+	}
+
+	return printDescription(t.Description, "", true) +
+		"enum " + t.Name + " {\n" +
+		strings.Join(lines, "\n") + "\n" +
+		"}"
+}
+
+func (p *printer) printInputObject(t *InputObject) string {
+	fields := append([]*InputField(nil), t.GetFields()...)
+	if p.options.Sorted {
+		sort.Slice(fields, func(i, j int) bool { return fields[i].Name < fields[j].Name })
+	}
+
+	lines := make([]string, len(fields))
+	for idx, field := range fields {
+		lines[idx] = printDescription(field.Description, "  ", idx == 0) + "  " +
+			p.printInputValue(t.Name+"."+field.Name, field.Name, field.Type, field.DefaultValue, field.DeprecationReason)
+	}
+
+	return printDescription(t.Description, "", true) +
+		"input " + t.Name + " {\n" +
+		strings.Join(lines, "\n") + "\n" +
+		"}"
+}
+
+func (p *printer) printFields(typeName string, fields []*Field) string {
+	fields = append([]*Field(nil), fields...)
+	if p.options.Sorted {
+		sort.Slice(fields, func(i, j int) bool { return fields[i].Name < fields[j].Name })
+	}
+
+	lines := make([]string, len(fields))
+	for idx, field := range fields {
+		lines[idx] = printDescription(field.Description, "  ", idx == 0) + "  " +
+			field.Name + p.printArgs(field.Args, "  ", typeName+"."+field.Name) + ": " +
+			field.Type.String() + printDeprecated(field.DeprecationReason)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// printArgs prints the arguments of the field or directive named by owner.
+func (p *printer) printArgs(args []*Argument, indentation string, owner string) string {
+	if len(args) == 0 {
+		return ""
+	}
+
+	args = append([]*Argument(nil), args...)
+	if p.options.Sorted {
+		sort.Slice(args, func(i, j int) bool { return args[i].Name < args[j].Name })
+	}
+
+	// If every arg does not have a description, print them on one line.
+	described := false
+	for _, arg := range args {
+		described = described || arg.Description != ""
+	}
+	if !described {
+		values := make([]string, len(args))
+		for idx, arg := range args {
+			values[idx] = p.printInputValue(owner+"("+arg.Name+":)", arg.Name, arg.Type, arg.DefaultValue, arg.DeprecationReason)
+		}
+		return "(" + strings.Join(values, ", ") + ")"
+	}
+
+	lines := make([]string, len(args))
+	for idx, arg := range args {
+		lines[idx] = printDescription(arg.Description, "  "+indentation, idx == 0) + "  " + indentation +
+			p.printInputValue(owner+"("+arg.Name+":)", arg.Name, arg.Type, arg.DefaultValue, arg.DeprecationReason)
+	}
+
+	return "(\n" + strings.Join(lines, "\n") + "\n" + indentation + ")"
+}
+
+// printInputValue prints an argument or an input field. A default value
+// which cannot be printed is left out, and recorded as the error of the
+// printer.
+func (p *printer) printInputValue(coordinate string, name string, t Type, defaultValue interface{}, deprecationReason string) string {
+	decl := name + ": " + t.String()
+	if defaultValue != nil {
+		valueNode, err := AstFromValue(defaultValue, t)
+		if err != nil && p.err == nil {
+			p.err = fmt.Errorf("Cannot print the default value of %s: %v", coordinate, err)
+		}
+		if err == nil && valueNode != nil {
+			decl += " = " + PrintValue(valueNode)
+		}
+	}
+
+	return decl + printDeprecated(deprecationReason)
+}
+
+func (p *printer) printDirective(directive *Directive) string {
+	repeatable := ""
+	if directive.IsRepeatable {
+		repeatable = " repeatable"
+	}
+
+	locations := make([]string, len(directive.Locations))
+	for idx, location := range directive.Locations {
+		locations[idx] = string(location)
+	}
+
+	return printDescription(directive.Description, "", true) +
+		"directive @" + directive.Name + p.printArgs(directive.Args, "", "@"+directive.Name) +
+		repeatable + " on " + strings.Join(locations, " | ")
+}
+
+func printDeprecated(reason string) string {
+	if reason == "" {
+		return ""
+	}
+	if reason == DefaultDeprecationReason {
+		return " @deprecated"
+	}
+
+	return " @deprecated(reason: " + printStringValue(reason) + ")"
+}
+
+// printDescription prints a description as a block string on the lines
+// before the definition it describes. Descriptions within a block, other
+// than the first, are separated from the previous definition by a blank
+// line.
+func printDescription(description, indentation string, firstInBlock bool) string {
+	if description == "" {
+		return ""
+	}
+
+	blockString := language.PrintBlockString(description, len(description) > 70)
+
+	prefix := indentation
+	if indentation != "" && !firstInBlock {
+		prefix = "\n" + indentation
+	}
+
+	return prefix + strings.Replace(blockString, "\n", "\n"+indentation, -1) + "\n"
+}
+
+func printStringValue(value string) string {
+	return PrintValue(&language.StringValueNode{Value: value})
+}
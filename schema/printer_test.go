@@ -0,0 +1,271 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+const printedPets = `schema {
+  query: Root
+  mutation: Mutation
+}
+
+"""Tags a field."""
+directive @tag(name: String!) repeatable on FIELD_DEFINITION | OBJECT
+
+type Cat implements Pet {
+  name: String
+}
+
+scalar Date @specifiedBy(url: "https://tools.ietf.org/html/rfc3339")
+
+type Dog implements Pet {
+  name: String
+  barks: Boolean
+}
+
+input Filter {
+  name: String
+  kind: Kind = CAT
+}
+
+enum Kind {
+  DOG
+  CAT @deprecated(reason: "Cats are out.")
+}
+
+type Mutation {
+  adopt(id: ID!, reason: String @deprecated(reason: "Unused.")): Pet
+}
+
+interface Pet {
+  name: String
+}
+
+"""The query root."""
+type Root {
+  """All the pets."""
+  pets(first: Int = 10, kind: Kind = DOG): [Pet]!
+  search(filter: Filter = {name: "Rex", kind: CAT}): [SearchResult]
+  oldPets: [Pet] @deprecated
+  date: Date
+}
+
+union SearchResult = Dog | Cat
+`
+
+func printSchema(t *testing.T, schema *Schema, options ...PrintOptions) string {
+	t.Helper()
+
+	printed, err := PrintSchema(schema, options...)
+	if err != nil {
+		t.Fatalf("PrintSchema; got error %v", err)
+	}
+	return printed
+}
+
+func printType(t *testing.T, namedType NamedType) string {
+	t.Helper()
+
+	printed, err := PrintType(namedType)
+	if err != nil {
+		t.Fatalf("PrintType; got error %v", err)
+	}
+	return printed
+}
+
+func TestPrintSchema(t *testing.T) {
+	schema, err := BuildFromSDL(petsSDL)
+	if err != nil {
+		t.Fatalf("BuildFromSDL; got error %v", err)
+	}
+
+	printed := printSchema(t, schema)
+	if printed != printedPets {
+		t.Fatalf("PrintSchema; got\n%s\nwanted\n%s", printed, printedPets)
+	}
+
+	// The printed schema builds the same schema.
+	rebuilt, err := BuildFromSDL(printed)
+	if err != nil {
+		t.Fatalf("BuildFromSDL(PrintSchema); got error %v", err)
+	}
+	if got := printSchema(t, rebuilt); got != printed {
+		t.Errorf("PrintSchema(BuildFromSDL(PrintSchema)); got\n%s\nwanted\n%s", got, printed)
+	}
+}
+
+func TestPrintSchemaDescriptions(t *testing.T) {
+	query := NewObject(ObjectConfig{
+		Name:        "Query",
+		Description: "The root.\n\n  Indented line.",
+		Fields: Fields{
+			{Name: "a", Type: String, Description: "Ends with a quote \""},
+			{
+				Name:        "b",
+				Type:        String,
+				Description: "A description long enough to be printed on lines of its own, apart from the quotes.",
+				Args: []*Argument{
+					{Name: "first", Type: Int, Description: "How many."},
+					{Name: "after", Type: String},
+				},
+			},
+		},
+	})
+	schema, err := NewSchema(SchemaConfig{Query: query})
+	if err != nil {
+		t.Fatalf("NewSchema; got error %v", err)
+	}
+
+	want := `"""
+The root.
+
+  Indented line.
+"""
+type Query {
+  """
+  Ends with a quote "
+  """
+  a: String
+
+  """
+  A description long enough to be printed on lines of its own, apart from the quotes.
+  """
+  b(
+    """How many."""
+    first: Int
+    after: String
+  ): String
+}
+`
+	printed := printSchema(t, schema)
+	if printed != want {
+		t.Fatalf("PrintSchema; got\n%s\nwanted\n%s", printed, want)
+	}
+
+	rebuilt, err := BuildFromSDL(printed)
+	if err != nil {
+		t.Fatalf("BuildFromSDL(PrintSchema); got error %v", err)
+	}
+	if got := rebuilt.GetQueryType().Description; got != query.Description {
+		t.Errorf("Query description; got %q wanted %q", got, query.Description)
+	}
+	if got := rebuilt.GetQueryType().GetField("a").Description; got != "Ends with a quote \"" {
+		t.Errorf("a description; got %q", got)
+	}
+}
+
+func TestPrintSchemaSorted(t *testing.T) {
+	schema, err := BuildFromSDL(`
+directive @b on FIELD
+directive @a on FIELD
+
+interface Y { y: Int }
+interface X { x: Int }
+
+type Query implements Y, X {
+  y(b: Int, a: Int): Int
+  x: Int
+}
+
+enum E { B A }
+`)
+	if err != nil {
+		t.Fatalf("BuildFromSDL; got error %v", err)
+	}
+
+	want := `directive @a on FIELD
+
+directive @b on FIELD
+
+enum E {
+  A
+  B
+}
+
+type Query implements X, Y {
+  x: Int
+  y(a: Int, b: Int): Int
+}
+
+interface X {
+  x: Int
+}
+
+interface Y {
+  y: Int
+}
+`
+	if got := printSchema(t, schema, PrintOptions{Sorted: true}); got != want {
+		t.Errorf("PrintSchema; got\n%s\nwanted\n%s", got, want)
+	}
+
+	unsorted := printSchema(t, schema)
+	if !strings.Contains(unsorted, "directive @b on FIELD\n\ndirective @a on FIELD") ||
+		!strings.Contains(unsorted, "type Query implements Y, X {\n  y(b: Int, a: Int): Int\n  x: Int\n}") {
+		t.Errorf("PrintSchema; got\n%s\nwanted definition order", unsorted)
+	}
+}
+
+// opaqueScalar is a scalar whose values cannot be serialized, so that its
+// default values cannot be printed.
+var opaqueScalar = NewScalar(ScalarConfig{
+	Name: "Opaque",
+	Serialize: func(value interface{}) (interface{}, error) {
+		return nil, fmt.Errorf("Opaque cannot represent %v", value)
+	},
+})
+
+func TestPrintSchemaUnprintableDefaultValue(t *testing.T) {
+	schema, err := NewSchema(SchemaConfig{
+		Query: NewObject(ObjectConfig{
+			Name: "Query",
+			Fields: Fields{{
+				Name: "f",
+				Type: String,
+				Args: []*Argument{{Name: "o", Type: opaqueScalar, DefaultValue: 1}},
+			}},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("NewSchema; got error %v", err)
+	}
+
+	want := "Cannot print the default value of Query.f(o:): Opaque cannot represent 1"
+	if _, err := PrintSchema(schema); err == nil || err.Error() != want {
+		t.Errorf("PrintSchema; got error %v wanted %s", err, want)
+	}
+	if _, err := PrintType(schema.GetQueryType()); err == nil || err.Error() != want {
+		t.Errorf("PrintType; got error %v wanted %s", err, want)
+	}
+}
+
+func TestPrintIntrospectionSchema(t *testing.T) {
+	schema := schemaWithField(t, String)
+
+	printed, err := PrintIntrospectionSchema(schema)
+	if err != nil {
+		t.Fatalf("PrintIntrospectionSchema; got error %v", err)
+	}
+	for _, want := range []string{
+		"directive @include(\n",
+		"directive @specifiedBy(\n",
+		"\ntype __Schema {\n",
+		"\nenum __TypeKind {\n",
+	} {
+		if !strings.Contains(printed, want) {
+			t.Errorf("PrintIntrospectionSchema; got\n%s\nwanted it to contain %q", printed, want)
+		}
+	}
+	if strings.Contains(printed, "type Query") || strings.Contains(printed, "schema {") {
+		t.Errorf("PrintIntrospectionSchema; got\n%s\nwanted no defined types", printed)
+	}
+}
+
+func TestPrintType(t *testing.T) {
+	want := "type Query {\n  f: [String!]\n}"
+	if got := printType(t, schemaWithField(t, NewList(NewNonNull(String))).GetQueryType()); got != want {
+		t.Errorf("PrintType; got\n%s\nwanted\n%s", got, want)
+	}
+}
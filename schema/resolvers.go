@@ -0,0 +1,408 @@
+package schema
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/ijsnow/goql/internal/errors"
+	"github.com/ijsnow/goql/internal/language"
+)
+
+// ResolverMap holds the behavior of a schema whose types were defined
+// without it, such as a schema built from SDL. Every entry is keyed by the
+// name of what it implements.
+type ResolverMap struct {
+	/**
+	 * Fields are the resolvers of the fields of Object and Interface types,
+	 * keyed by coordinate, such as "Query.pets".
+	 */
+	Fields map[string]FieldResolveFn
+
+	/**
+	 * ResolveType determines the concrete types of Interface and Union
+	 * types, keyed by the name of the abstract type.
+	 */
+	ResolveType map[string]TypeResolveFn
+
+	/**
+	 * IsTypeOf identifies the values of Object types, keyed by the name of
+	 * the type.
+	 */
+	IsTypeOf map[string]IsTypeOfFn
+
+	/**
+	 * Scalars implement custom scalars, keyed by the name of the scalar. A
+	 * *Scalar, such as those of the scalars package, implements one too.
+	 */
+	Scalars map[string]ScalarDefinition
+
+	/**
+	 * Enums are the internal values of enum values, keyed by the name of the
+	 * Enum type and then by the name of the value, such as:
+	 *
+	 *	"Kind": {"DOG": KindDog}
+	 */
+	Enums map[string]map[string]interface{}
+}
+
+// BindOptions configures which resolvers BindResolvers requires.
+type BindOptions struct {
+	/**
+	 * RequireAllFields requires a resolver for every field of an Object
+	 * type. By default only the fields of the root types and the fields
+	 * with arguments require one; other fields may read the value of
+	 * their parent.
+	 */
+	RequireAllFields bool
+
+	/**
+	 * AllowMissing skips the checks for missing resolvers, ResolveType
+	 * functions and scalar implementations.
+	 */
+	AllowMissing bool
+
+	/**
+	 * AllowExtraneous ignores the entries of the resolver map which name
+	 * nothing in the schema.
+	 */
+	AllowExtraneous bool
+}
+
+// MakeExecutableSchema builds a Schema from SDL and binds the resolver map
+// to it. See BuildFromSDL and BindResolvers.
+//
+//	s, err := schema.MakeExecutableSchema(`
+//		type Query { pets: [Pet] }
+//		type Pet { name: String }
+//	`, schema.ResolverMap{
+//		Fields: map[string]schema.FieldResolveFn{
+//			"Query.pets": func(p schema.ResolveParams) (interface{}, error) {
+//				return store.Pets(p.Context)
+//			},
+//		},
+//	})
+func MakeExecutableSchema(sdl string, resolvers ResolverMap, options ...BindOptions) (*Schema, error) {
+	schema, err := BuildFromSDL(sdl)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := BindResolvers(schema, resolvers, options...); err != nil {
+		return nil, err
+	}
+
+	return schema, nil
+}
+
+// BindResolvers sets the resolvers, ResolveType and IsTypeOf functions,
+// scalar implementations and enum values of the resolver map on the types
+// of the schema.
+// It is meant to be called once at startup, before the schema is used.
+//
+// The resolver map is checked against the schema first, and nothing is
+// bound if it is incomplete or names anything the schema does not define.
+// Every such problem is then reported by the returned *InvalidSchemaError,
+// located at the definitions of the schema when it was built from SDL.
+// Custom scalars require an implementation, and abstract types require a
+// ResolveType function unless all of their possible types have an IsTypeOf
+// function. See BindOptions for the fields which require a resolver.
+func BindResolvers(schema *Schema, resolvers ResolverMap, options ...BindOptions) error {
+	var opts BindOptions
+	if len(options) > 0 {
+		opts = options[0]
+	}
+
+	b := &resolverBinder{schema: schema, resolvers: resolvers, options: opts}
+	if !opts.AllowExtraneous {
+		b.checkExtraneous()
+	}
+	if !opts.AllowMissing {
+		b.checkMissing()
+	}
+	if len(b.errors) != 0 {
+		return &InvalidSchemaError{Errors: b.errors}
+	}
+
+	b.bind()
+
+	return nil
+}
+
+type resolverBinder struct {
+	schema    *Schema
+	resolvers ResolverMap
+	options   BindOptions
+	errors    []error
+}
+
+func (b *resolverBinder) report(message errors.Message, nodes ...language.ASTNode) {
+	b.errors = append(b.errors, errors.NewValidationError(message, astNodes(nodes...)...))
+}
+
+// namedTypes returns the types of the schema which are not introspection
+// types, sorted by name.
+func (b *resolverBinder) namedTypes() []NamedType {
+	var types []NamedType
+	for name, t := range b.schema.GetTypeMap() {
+		if !strings.HasPrefix(name, "__") {
+			types = append(types, t)
+		}
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i].GetName() < types[j].GetName() })
+
+	return types
+}
+
+// typeNames returns the names of the types for which keep returns true.
+func (b *resolverBinder) typeNames(keep func(NamedType) bool) []string {
+	var names []string
+	for _, t := range b.namedTypes() {
+		if keep(t) {
+			names = append(names, t.GetName())
+		}
+	}
+
+	return names
+}
+
+func (b *resolverBinder) checkExtraneous() {
+	for _, coordinate := range sortedKeys(b.resolvers.Fields) {
+		if b.field(coordinate) == nil {
+			b.report(errors.NotInSchemaMessage(
+				errors.MsgResolverNotInSchema,
+				coordinate,
+				b.suggestFields(coordinate),
+			))
+		}
+	}
+
+	for _, name := range sortedKeys(b.resolvers.ResolveType) {
+		if !IsAbstractType(b.schema.GetType(name)) {
+			b.report(errors.NotInSchemaMessage(
+				errors.MsgResolveTypeNotInSchema,
+				name,
+				errors.SuggestionList(name, b.typeNames(func(t NamedType) bool { return IsAbstractType(t) })),
+			))
+		}
+	}
+
+	for _, name := range sortedKeys(b.resolvers.IsTypeOf) {
+		if _, ok := b.schema.GetType(name).(*Object); !ok {
+			b.report(errors.NotInSchemaMessage(
+				errors.MsgIsTypeOfNotInSchema,
+				name,
+				errors.SuggestionList(name, b.typeNames(func(t NamedType) bool {
+					_, ok := t.(*Object)
+					return ok
+				})),
+			))
+		}
+	}
+
+	for _, name := range sortedKeys(b.resolvers.Scalars) {
+		if !isCustomScalar(b.schema.GetType(name)) {
+			b.report(errors.NotInSchemaMessage(
+				errors.MsgScalarNotInSchema,
+				name,
+				errors.SuggestionList(name, b.typeNames(isCustomScalar)),
+			))
+		}
+	}
+
+	for _, name := range sortedKeys(b.resolvers.Enums) {
+		enum, _ := b.schema.GetType(name).(*Enum)
+		for _, valueName := range sortedKeys(b.resolvers.Enums[name]) {
+			if enum != nil && enum.GetValue(valueName) != nil {
+				continue
+			}
+
+			var suggestions []string
+			if enum != nil {
+				for _, suggestion := range enum.suggestions(valueName) {
+					suggestions = append(suggestions, name+"."+suggestion)
+				}
+			}
+			b.report(errors.NotInSchemaMessage(errors.MsgEnumValueNotInSchema, name+"."+valueName, suggestions))
+		}
+	}
+}
+
+// suggestFields suggests the coordinates of fields similar to one not in
+// the schema: the other fields of its type, or else the field on similarly
+// named types.
+func (b *resolverBinder) suggestFields(coordinate string) []string {
+	typeName, fieldName := splitCoordinate(coordinate)
+
+	fields := fieldsOf(b.schema.GetType(typeName))
+	if fields == nil {
+		var suggestions []string
+		for _, name := range errors.SuggestionList(typeName, b.typeNames(func(t NamedType) bool { return fieldsOf(t) != nil })) {
+			if b.field(name+"."+fieldName) != nil {
+				suggestions = append(suggestions, name+"."+fieldName)
+			}
+		}
+		return suggestions
+	}
+
+	names := make([]string, len(fields))
+	for idx, field := range fields {
+		names[idx] = field.Name
+	}
+
+	var suggestions []string
+	for _, name := range errors.SuggestionList(fieldName, names) {
+		suggestions = append(suggestions, typeName+"."+name)
+	}
+
+	return suggestions
+}
+
+func (b *resolverBinder) checkMissing() {
+	roots := map[*Object]bool{}
+	for _, root := range []*Object{b.schema.GetQueryType(), b.schema.GetMutationType(), b.schema.GetSubscriptionType()} {
+		if root != nil {
+			roots[root] = true
+		}
+	}
+
+	for _, t := range b.namedTypes() {
+		switch t := t.(type) {
+		case *Object:
+			for _, field := range t.GetFields() {
+				required := b.options.RequireAllFields || roots[t] || len(field.Args) != 0
+				if required && field.Resolve == nil && b.resolvers.Fields[t.Name+"."+field.Name] == nil {
+					b.report(errors.Message{ID: errors.MsgResolverMissing, Args: map[string]interface{}{
+						"type":  t.Name,
+						"field": field.Name,
+					}}, field.AstNode)
+				}
+			}
+		case AbstractType:
+			if !b.resolvesType(t) {
+				b.report(errors.Message{ID: errors.MsgResolveTypeMissing, Args: map[string]interface{}{
+					"type": t.GetName(),
+				}}, typeASTNode(t))
+			}
+		case *Scalar:
+			if isCustomScalar(t) && t.config.Serialize == nil && b.resolvers.Scalars[t.Name] == nil {
+				b.report(errors.Message{ID: errors.MsgScalarImplementationMissing, Args: map[string]interface{}{
+					"type": t.Name,
+				}}, t.AstNode)
+			}
+		}
+	}
+}
+
+// resolvesType reports whether the concrete types of an abstract type can be
+// determined once the resolver map is bound.
+func (b *resolverBinder) resolvesType(t AbstractType) bool {
+	if t.GetResolveType() != nil || b.resolvers.ResolveType[t.GetName()] != nil {
+		return true
+	}
+
+	for _, object := range b.schema.PossibleTypes(t) {
+		if object.IsTypeOf == nil && b.resolvers.IsTypeOf[object.Name] == nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (b *resolverBinder) bind() {
+	for coordinate, resolve := range b.resolvers.Fields {
+		if field := b.field(coordinate); field != nil {
+			field.Resolve = resolve
+		}
+	}
+
+	for name, resolveType := range b.resolvers.ResolveType {
+		switch t := b.schema.GetType(name).(type) {
+		case *Interface:
+			t.ResolveType = resolveType
+		case *Union:
+			t.ResolveType = resolveType
+		}
+	}
+
+	for name, isTypeOf := range b.resolvers.IsTypeOf {
+		if object, ok := b.schema.GetType(name).(*Object); ok {
+			object.IsTypeOf = isTypeOf
+		}
+	}
+
+	for name, definition := range b.resolvers.Scalars {
+		if scalar, ok := b.schema.GetType(name).(*Scalar); ok && isCustomScalar(scalar) {
+			scalar.config.Serialize = definition.Serialize
+			scalar.config.ParseValue = definition.ParseValue
+			scalar.config.ParseLiteral = definition.ParseLiteral
+		}
+	}
+
+	for name, values := range b.resolvers.Enums {
+		if enum, ok := b.schema.GetType(name).(*Enum); ok {
+			for valueName, value := range values {
+				if enumValue := enum.GetValue(valueName); enumValue != nil {
+					enumValue.Value = value
+				}
+			}
+		}
+	}
+}
+
+// field returns the field of an Object or Interface type at a coordinate.
+func (b *resolverBinder) field(coordinate string) *Field {
+	typeName, fieldName := splitCoordinate(coordinate)
+
+	switch t := b.schema.GetType(typeName).(type) {
+	case *Object:
+		return t.GetField(fieldName)
+	case *Interface:
+		return t.GetField(fieldName)
+	}
+
+	return nil
+}
+
+// splitCoordinate splits a coordinate such as "Query.pets" into the name of
+// the type and the name of the field.
+func splitCoordinate(coordinate string) (string, string) {
+	idx := strings.Index(coordinate, ".")
+	if idx < 0 {
+		return coordinate, ""
+	}
+
+	return coordinate[:idx], coordinate[idx+1:]
+}
+
+// fieldsOf returns the fields of an Object or Interface type, or nil for
+// other types.
+func fieldsOf(t NamedType) []*Field {
+	switch t := t.(type) {
+	case *Object:
+		return t.GetFields()
+	case *Interface:
+		return t.GetFields()
+	}
+
+	return nil
+}
+
+// isCustomScalar reports whether a type is a scalar other than the scalars
+// of the specification.
+func isCustomScalar(t NamedType) bool {
+	scalar, ok := t.(*Scalar)
+	return ok && !IsSpecifiedScalarType(scalar)
+}
+
+// sortedKeys returns the keys of a map keyed by strings, sorted.
+func sortedKeys(m interface{}) []string {
+	var keys []string
+	for _, key := range reflect.ValueOf(m).MapKeys() {
+		keys = append(keys, key.String())
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,228 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ijsnow/goql/internal/errors"
+)
+
+const resolversSDL = `
+type Query {
+  pets: [Pet]
+  pet(id: ID!): Pet
+}
+
+interface Pet { name: String }
+
+type Dog implements Pet {
+  name: String
+  born: Date
+}
+
+type Cat implements Pet {
+  name: String
+  lives(min: Int): Int
+}
+
+union Result = Dog | Cat
+
+scalar Date
+
+enum Kind { DOG CAT }
+`
+
+func resolverFn(value interface{}) FieldResolveFn {
+	return func(p ResolveParams) (interface{}, error) { return value, nil }
+}
+
+func resolveTypeFn(p ResolveTypeParams) *Object { return nil }
+
+func isTypeOfFn(p IsTypeOfParams) bool { return true }
+
+type dateDefinition struct{}
+
+func (dateDefinition) Serialize(value interface{}) (interface{}, error)  { return "2017-01-01", nil }
+func (dateDefinition) ParseValue(value interface{}) (interface{}, error) { return value, nil }
+func (dateDefinition) ParseLiteral(valueNode ValueNode) (interface{}, error) {
+	return PrintValue(valueNode), nil
+}
+
+func completeResolvers() ResolverMap {
+	return ResolverMap{
+		Fields: map[string]FieldResolveFn{
+			"Query.pets": resolverFn("pets"),
+			"Query.pet":  resolverFn("pet"),
+			"Cat.lives":  resolverFn(9),
+			"Pet.name":   resolverFn("name"),
+		},
+		ResolveType: map[string]TypeResolveFn{"Pet": resolveTypeFn},
+		IsTypeOf: map[string]IsTypeOfFn{
+			"Dog": isTypeOfFn,
+			"Cat": isTypeOfFn,
+		},
+		Scalars: map[string]ScalarDefinition{"Date": dateDefinition{}},
+		Enums: map[string]map[string]interface{}{
+			"Kind": {"DOG": 1},
+		},
+	}
+}
+
+func TestMakeExecutableSchema(t *testing.T) {
+	schema, err := MakeExecutableSchema(resolversSDL, completeResolvers())
+	if err != nil {
+		t.Fatalf("MakeExecutableSchema; got error %v", err)
+	}
+
+	query := schema.GetQueryType()
+	if value, _ := query.GetField("pets").Resolve(ResolveParams{}); value != "pets" {
+		t.Errorf("Query.pets; got %v wanted pets", value)
+	}
+	if value, _ := schema.GetType("Cat").(*Object).GetField("lives").Resolve(ResolveParams{}); value != 9 {
+		t.Errorf("Cat.lives; got %v wanted 9", value)
+	}
+	if value, _ := schema.GetType("Pet").(*Interface).GetField("name").Resolve(ResolveParams{}); value != "name" {
+		t.Errorf("Pet.name; got %v wanted name", value)
+	}
+	if schema.GetType("Dog").(*Object).GetField("name").Resolve != nil {
+		t.Errorf("Dog.name; got a resolver wanted none")
+	}
+
+	if schema.GetType("Pet").(*Interface).ResolveType == nil {
+		t.Errorf("Pet; got no ResolveType")
+	}
+	if schema.GetType("Dog").(*Object).IsTypeOf == nil {
+		t.Errorf("Dog; got no IsTypeOf")
+	}
+
+	date := schema.GetType("Date").(*Scalar)
+	if value, _ := date.Serialize("today"); value != "2017-01-01" {
+		t.Errorf("Date.Serialize; got %v", value)
+	}
+	if value, _ := date.ParseLiteral(&StringValueNode{Value: "x"}); value != `"x"` {
+		t.Errorf("Date.ParseLiteral; got %v", value)
+	}
+
+	kind := schema.GetType("Kind").(*Enum)
+	if value, _ := kind.Serialize(1); value != "DOG" {
+		t.Errorf("Kind.Serialize(1); got %v wanted DOG", value)
+	}
+	if value, _ := kind.ParseValue("CAT"); value != "CAT" {
+		t.Errorf("Kind.ParseValue(CAT); got %v wanted CAT", value)
+	}
+}
+
+func TestBindResolversReportsMissing(t *testing.T) {
+	schema := buildSDL(t, resolversSDL)
+
+	err := BindResolvers(schema, ResolverMap{
+		Fields: map[string]FieldResolveFn{"Query.pets": resolverFn(nil)},
+	})
+	invalid, ok := err.(*InvalidSchemaError)
+	if !ok {
+		t.Fatalf("BindResolvers; got %v wanted an *InvalidSchemaError", err)
+	}
+
+	want := []string{
+		`Field "Cat.lives" has no resolver.`,
+		`Scalar "Date" has no implementation.`,
+		`Abstract type "Pet" has no ResolveType function, and not all of its possible types have an IsTypeOf function.`,
+		`Field "Query.pet" has no resolver.`,
+		`Abstract type "Result" has no ResolveType function, and not all of its possible types have an IsTypeOf function.`,
+	}
+	if len(invalid.Errors) != len(want) {
+		t.Fatalf("BindResolvers; got %v wanted %d errors", invalid.Errors, len(want))
+	}
+	for idx, err := range invalid.Errors {
+		if err.Error() != want[idx] {
+			t.Errorf("BindResolvers[%d]; got %q wanted %q", idx, err.Error(), want[idx])
+		}
+	}
+
+	// The errors are located at the definitions.
+	if got := invalid.Errors[3].(*errors.GraphQLError).Locations; len(got) != 1 || got[0].Line != 4 || got[0].Column != 3 {
+		t.Errorf("Query.pet; got locations %+v wanted 4:3", got)
+	}
+
+	// Nothing is bound when the resolver map is rejected.
+	if schema.GetQueryType().GetField("pets").Resolve != nil {
+		t.Errorf("Query.pets; got a resolver wanted none")
+	}
+}
+
+func TestBindResolversReportsExtraneous(t *testing.T) {
+	resolvers := completeResolvers()
+	resolvers.Fields["Query.pest"] = resolverFn(nil)
+	resolvers.Fields["Cats.lives"] = resolverFn(nil)
+	resolvers.Fields["Unknown"] = resolverFn(nil)
+	resolvers.ResolveType["Results"] = resolveTypeFn
+	resolvers.IsTypeOf["Dogs"] = isTypeOfFn
+	resolvers.Scalars["String"] = String
+	resolvers.Enums["Kind"]["DOGS"] = 1
+	resolvers.Enums["Kinds"] = map[string]interface{}{"CAT": 2}
+
+	_, err := MakeExecutableSchema(resolversSDL, resolvers)
+	invalid, ok := err.(*InvalidSchemaError)
+	if !ok {
+		t.Fatalf("MakeExecutableSchema; got %v wanted an *InvalidSchemaError", err)
+	}
+
+	want := []string{
+		`Resolver "Cats.lives" is defined, but the schema has no such field. Did you mean "Cat.lives"?`,
+		`Resolver "Query.pest" is defined, but the schema has no such field. Did you mean "Query.pets" or "Query.pet"?`,
+		`Resolver "Unknown" is defined, but the schema has no such field.`,
+		`ResolveType "Results" is defined, but the schema has no such Interface or Union type. Did you mean "Result"?`,
+		`IsTypeOf "Dogs" is defined, but the schema has no such Object type. Did you mean "Dog"?`,
+		`Scalar "String" is implemented, but the schema has no such custom scalar.`,
+		`Enum value "Kind.DOGS" is defined, but the schema has no such enum value. Did you mean "Kind.DOG"?`,
+		`Enum value "Kinds.CAT" is defined, but the schema has no such enum value.`,
+	}
+	if len(invalid.Errors) != len(want) {
+		t.Fatalf("MakeExecutableSchema; got %v wanted %d errors", invalid.Errors, len(want))
+	}
+	for idx, err := range invalid.Errors {
+		if err.Error() != want[idx] {
+			t.Errorf("MakeExecutableSchema[%d]; got %q wanted %q", idx, err.Error(), want[idx])
+		}
+	}
+}
+
+func TestBindResolversOptions(t *testing.T) {
+	resolvers := completeResolvers()
+	if err := BindResolvers(buildSDL(t, resolversSDL), resolvers, BindOptions{RequireAllFields: true}); err == nil ||
+		!strings.Contains(err.Error(), `Field "Dog.born" has no resolver.`) ||
+		!strings.Contains(err.Error(), `Field "Cat.name" has no resolver.`) {
+		t.Errorf("RequireAllFields; got error %v", err)
+	}
+
+	schema := buildSDL(t, resolversSDL)
+	if err := BindResolvers(schema, ResolverMap{
+		Fields: map[string]FieldResolveFn{"Query.pets": resolverFn("pets")},
+	}, BindOptions{AllowMissing: true}); err != nil {
+		t.Errorf("AllowMissing; got error %v", err)
+	}
+	if schema.GetQueryType().GetField("pets").Resolve == nil {
+		t.Errorf("AllowMissing; got no resolver for Query.pets")
+	}
+
+	resolvers.Fields["Query.unknown"] = resolverFn(nil)
+	if err := BindResolvers(buildSDL(t, resolversSDL), resolvers, BindOptions{AllowExtraneous: true}); err != nil {
+		t.Errorf("AllowExtraneous; got error %v", err)
+	}
+}
+
+func TestBindResolversIsTypeOf(t *testing.T) {
+	// The possible types of an abstract type may identify their values
+	// instead of a ResolveType function.
+	resolvers := completeResolvers()
+	delete(resolvers.ResolveType, "Pet")
+	if err := BindResolvers(buildSDL(t, resolversSDL), resolvers); err != nil {
+		t.Errorf("BindResolvers; got error %v", err)
+	}
+
+	delete(resolvers.IsTypeOf, "Cat")
+	if err := BindResolvers(buildSDL(t, resolversSDL), resolvers); err == nil ||
+		!strings.Contains(err.Error(), `Abstract type "Pet" has no ResolveType function`) {
+		t.Errorf("BindResolvers; got error %v", err)
+	}
+}
@@ -0,0 +1,416 @@
+package schema
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"strconv"
+
+	"github.com/ijsnow/goql/internal/errors"
+	"github.com/ijsnow/goql/internal/language"
+)
+
+// As per the GraphQL Spec, Integers are only treated as valid when a valid
+// 32-bit signed integer, providing the broadest support across platforms.
+const (
+	maxInt = math.MaxInt32
+	minInt = math.MinInt32
+)
+
+// Int is the built-in Int scalar. Its values are Go ints.
+var Int = NewScalar(ScalarConfig{
+	Name: "Int",
+	Description: "The `Int` scalar type represents non-fractional signed whole numeric " +
+		"values. Int can represent values between -(2^31) and 2^31 - 1.",
+	Serialize:    serializeInt,
+	ParseValue:   parseIntValue,
+	ParseLiteral: parseIntLiteral,
+})
+
+// Float is the built-in Float scalar. Its values are Go float64s.
+var Float = NewScalar(ScalarConfig{
+	Name: "Float",
+	Description: "The `Float` scalar type represents signed double-precision fractional " +
+		"values as specified by " +
+		"[IEEE 754](https://en.wikipedia.org/wiki/IEEE_floating_point).",
+	Serialize:    serializeFloat,
+	ParseValue:   parseFloatValue,
+	ParseLiteral: parseFloatLiteral,
+})
+
+// String is the built-in String scalar. Its values are Go strings.
+var String = NewScalar(ScalarConfig{
+	Name: "String",
+	Description: "The `String` scalar type represents textual data, represented as UTF-8 " +
+		"character sequences. The String type is most often used by GraphQL to " +
+		"represent free-form human-readable text.",
+	Serialize:    serializeString,
+	ParseValue:   parseStringValue,
+	ParseLiteral: parseStringLiteral,
+})
+
+// Boolean is the built-in Boolean scalar. Its values are Go bools.
+var Boolean = NewScalar(ScalarConfig{
+	Name:         "Boolean",
+	Description:  "The `Boolean` scalar type represents `true` or `false`.",
+	Serialize:    serializeBoolean,
+	ParseValue:   parseBooleanValue,
+	ParseLiteral: parseBooleanLiteral,
+})
+
+// ID is the built-in ID scalar. Its values are Go strings, and integers are
+// accepted as input.
+var ID = NewScalar(ScalarConfig{
+	Name: "ID",
+	Description: "The `ID` scalar type represents a unique identifier, often used to " +
+		"refetch an object or as key for a cache. The ID type appears in a JSON " +
+		"response as a String; however, it is not intended to be human-readable. " +
+		"When expected as an input type, any string (such as `\"4\"`) or integer " +
+		"(such as `4`) input value will be accepted as an ID.",
+	Serialize:    serializeID,
+	ParseValue:   parseIDValue,
+	ParseLiteral: parseIDLiteral,
+})
+
+// SpecifiedScalarTypes are the scalars defined by the specification.
+var SpecifiedScalarTypes = []*Scalar{String, Int, Float, Boolean, ID}
+
+// IsSpecifiedScalarType reports whether the type is one of the scalars
+// defined by the specification, or a type of the same name.
+func IsSpecifiedScalarType(t NamedType) bool {
+	for _, scalar := range SpecifiedScalarTypes {
+		if t.GetName() == scalar.Name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Int
+
+func serializeInt(outputValue interface{}) (interface{}, error) {
+	value := indirect(outputValue)
+
+	num, ok := numberOf(value)
+	if !ok {
+		switch v := reflect.ValueOf(value); v.Kind() {
+		case reflect.Bool:
+			if v.Bool() {
+				return 1, nil
+			}
+			return 0, nil
+		case reflect.String:
+			if parsed, err := strconv.ParseFloat(v.String(), 64); err == nil {
+				num, ok = parsed, true
+			}
+		}
+	}
+
+	if !ok {
+		return nil, scalarError(errors.MsgIntNonInteger, value)
+	}
+
+	return coerceInt(num, value)
+}
+
+func parseIntValue(inputValue interface{}) (interface{}, error) {
+	value := indirect(inputValue)
+
+	num, ok := numberOf(value)
+	if !ok {
+		return nil, scalarError(errors.MsgIntNonInteger, value)
+	}
+
+	return coerceInt(num, value)
+}
+
+func coerceInt(num float64, value interface{}) (interface{}, error) {
+	if math.IsNaN(num) || math.IsInf(num, 0) || math.Trunc(num) != num {
+		return nil, scalarError(errors.MsgIntNonInteger, value)
+	}
+	if num > maxInt || num < minInt {
+		return nil, scalarError(errors.MsgIntOutOfRange, value)
+	}
+
+	return int(num), nil
+}
+
+func parseIntLiteral(valueNode language.ValueNode) (interface{}, error) {
+	node, ok := valueNode.(*language.IntValueNode)
+	if !ok {
+		return nil, literalError(errors.MsgIntNonInteger, valueNode)
+	}
+
+	num, err := strconv.ParseInt(node.Value, 10, 64)
+	if err != nil || num > maxInt || num < minInt {
+		return nil, literalError(errors.MsgIntOutOfRange, valueNode)
+	}
+
+	return int(num), nil
+}
+
+// Float
+
+func serializeFloat(outputValue interface{}) (interface{}, error) {
+	value := indirect(outputValue)
+
+	num, ok := numberOf(value)
+	if !ok {
+		switch v := reflect.ValueOf(value); v.Kind() {
+		case reflect.Bool:
+			if v.Bool() {
+				return 1.0, nil
+			}
+			return 0.0, nil
+		case reflect.String:
+			if parsed, err := strconv.ParseFloat(v.String(), 64); err == nil {
+				num, ok = parsed, true
+			}
+		}
+	}
+
+	if !ok || math.IsNaN(num) || math.IsInf(num, 0) {
+		return nil, scalarError(errors.MsgFloatNonNumeric, value)
+	}
+
+	return num, nil
+}
+
+func parseFloatValue(inputValue interface{}) (interface{}, error) {
+	value := indirect(inputValue)
+
+	num, ok := numberOf(value)
+	if !ok || math.IsNaN(num) || math.IsInf(num, 0) {
+		return nil, scalarError(errors.MsgFloatNonNumeric, value)
+	}
+
+	return num, nil
+}
+
+func parseFloatLiteral(valueNode language.ValueNode) (interface{}, error) {
+	var raw string
+	switch node := valueNode.(type) {
+	case *language.IntValueNode:
+		raw = node.Value
+	case *language.FloatValueNode:
+		raw = node.Value
+	default:
+		return nil, literalError(errors.MsgFloatNonNumeric, valueNode)
+	}
+
+	num, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return nil, literalError(errors.MsgFloatNonNumeric, valueNode)
+	}
+
+	return num, nil
+}
+
+// String
+
+func serializeString(outputValue interface{}) (interface{}, error) {
+	value := indirect(outputValue)
+
+	if n, ok := value.(json.Number); ok {
+		return n.String(), nil
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		if f := v.Float(); !math.IsNaN(f) && !math.IsInf(f, 0) {
+			return strconv.FormatFloat(f, 'f', -1, 64), nil
+		}
+	}
+
+	return nil, scalarError(errors.MsgStringCannotRepresent, value)
+}
+
+func parseStringValue(inputValue interface{}) (interface{}, error) {
+	value := indirect(inputValue)
+
+	if _, ok := value.(json.Number); !ok {
+		if v := reflect.ValueOf(value); v.Kind() == reflect.String {
+			return v.String(), nil
+		}
+	}
+
+	return nil, scalarError(errors.MsgStringNonString, value)
+}
+
+func parseStringLiteral(valueNode language.ValueNode) (interface{}, error) {
+	if node, ok := valueNode.(*language.StringValueNode); ok {
+		return node.Value, nil
+	}
+
+	return nil, literalError(errors.MsgStringNonString, valueNode)
+}
+
+// Boolean
+
+func serializeBoolean(outputValue interface{}) (interface{}, error) {
+	value := indirect(outputValue)
+
+	if v := reflect.ValueOf(value); v.Kind() == reflect.Bool {
+		return v.Bool(), nil
+	}
+
+	if num, ok := numberOf(value); ok && !math.IsNaN(num) && !math.IsInf(num, 0) {
+		return num != 0, nil
+	}
+
+	return nil, scalarError(errors.MsgBooleanNonBoolean, value)
+}
+
+func parseBooleanValue(inputValue interface{}) (interface{}, error) {
+	value := indirect(inputValue)
+
+	if v := reflect.ValueOf(value); v.Kind() == reflect.Bool {
+		return v.Bool(), nil
+	}
+
+	return nil, scalarError(errors.MsgBooleanNonBoolean, value)
+}
+
+func parseBooleanLiteral(valueNode language.ValueNode) (interface{}, error) {
+	if node, ok := valueNode.(*language.BooleanValueNode); ok {
+		return node.Value, nil
+	}
+
+	return nil, literalError(errors.MsgBooleanNonBoolean, valueNode)
+}
+
+// ID
+
+func serializeID(outputValue interface{}) (interface{}, error) {
+	value := indirect(outputValue)
+
+	if id, ok := idOf(value); ok {
+		return id, nil
+	}
+
+	return nil, scalarError(errors.MsgIDCannotRepresent, value)
+}
+
+func parseIDValue(inputValue interface{}) (interface{}, error) {
+	value := indirect(inputValue)
+
+	if id, ok := idOf(value); ok {
+		return id, nil
+	}
+
+	return nil, scalarError(errors.MsgIDCannotRepresent, value)
+}
+
+// idOf converts a string or an integer to an ID. Floats are accepted when
+// they hold an integer, since that is how integers decode from JSON.
+func idOf(value interface{}) (string, bool) {
+	if n, ok := value.(json.Number); ok {
+		if _, err := n.Int64(); err == nil {
+			return n.String(), true
+		}
+		return "", false
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10), true
+	case reflect.Float32, reflect.Float64:
+		if f := v.Float(); !math.IsInf(f, 0) && math.Trunc(f) == f {
+			return strconv.FormatFloat(f, 'f', -1, 64), true
+		}
+	}
+
+	return "", false
+}
+
+func parseIDLiteral(valueNode language.ValueNode) (interface{}, error) {
+	switch node := valueNode.(type) {
+	case *language.StringValueNode:
+		return node.Value, nil
+	case *language.IntValueNode:
+		return node.Value, nil
+	}
+
+	return nil, literalError(errors.MsgIDNonStringNonInteger, valueNode)
+}
+
+// numberOf converts any of Go's numeric types, and json.Number, to a
+// float64.
+func numberOf(value interface{}) (float64, bool) {
+	if n, ok := value.(json.Number); ok {
+		f, err := n.Float64()
+		return f, err == nil
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	}
+
+	return 0, false
+}
+
+// scalarError reports a value that a scalar cannot represent.
+func scalarError(id errors.MessageID, value interface{}) error {
+	return errors.NewLocalizableError(errors.Message{
+		ID:   id,
+		Args: map[string]interface{}{"value": inspect(value)},
+	})
+}
+
+// literalError reports a literal that a scalar cannot represent, located at
+// the literal.
+func literalError(id errors.MessageID, valueNode language.ValueNode) error {
+	message := errors.Message{
+		ID:   id,
+		Args: map[string]interface{}{"value": language.Print(valueNode)},
+	}
+	if valueNode == nil {
+		return errors.NewLocalizableError(message)
+	}
+
+	return errors.NewLocalizableError(message, valueNode)
+}
+
+// NewScalarError returns the error a scalar reports for a value it cannot
+// represent, for use by custom scalars.
+func NewScalarError(typeName string, value interface{}) error {
+	return errors.NewLocalizableError(errors.Message{
+		ID:   errors.MsgScalarCannotRepresent,
+		Args: map[string]interface{}{"type": typeName, "value": inspect(value)},
+	})
+}
+
+// NewLiteralError returns the error a scalar reports for a literal it cannot
+// represent, located at the literal, for use by custom scalars.
+func NewLiteralError(typeName string, valueNode ValueNode) error {
+	message := errors.Message{
+		ID:   errors.MsgScalarCannotRepresent,
+		Args: map[string]interface{}{"type": typeName, "value": language.Print(valueNode)},
+	}
+	if valueNode == nil {
+		return errors.NewLocalizableError(message)
+	}
+
+	return errors.NewLocalizableError(message, valueNode)
+}
@@ -0,0 +1,231 @@
+package schema
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/ijsnow/goql/internal/errors"
+	"github.com/ijsnow/goql/internal/language"
+)
+
+type coercionTest struct {
+	value interface{}
+	want  interface{}
+	err   string
+}
+
+func testCoercion(t *testing.T, name string, coerce func(interface{}) (interface{}, error), tests []coercionTest) {
+	for _, test := range tests {
+		got, err := coerce(test.value)
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("%s(%#v); got error %v wanted %s", name, test.value, err, test.err)
+			}
+			if _, ok := err.(*errors.GraphQLError); err != nil && !ok {
+				t.Errorf("%s(%#v); got error of type %T wanted *GraphQLError", name, test.value, err)
+			}
+			continue
+		}
+
+		if err != nil || got != test.want {
+			t.Errorf("%s(%#v); got %#v, %v wanted %#v", name, test.value, got, err, test.want)
+		}
+	}
+}
+
+func TestSerializesOutputInt(t *testing.T) {
+	one := 1
+	var nilInt *int
+
+	testCoercion(t, "Int.Serialize", Int.Serialize, []coercionTest{
+		{value: 1, want: 1},
+		{value: "123", want: 123},
+		{value: 0, want: 0},
+		{value: -1, want: -1},
+		{value: 1e5, want: 100000},
+		{value: int8(-8), want: -8},
+		{value: uint16(16), want: 16},
+		{value: int64(math.MaxInt32), want: math.MaxInt32},
+		{value: float32(4), want: 4},
+		{value: json.Number("42"), want: 42},
+		{value: &one, want: 1},
+		{value: true, want: 1},
+		{value: false, want: 0},
+		// The GraphQL specification does not allow serializing non-integer
+		// values as Int to avoid accidental data loss.
+		{value: 0.1, err: "Int cannot represent non-integer value: 0.1"},
+		{value: 1.1, err: "Int cannot represent non-integer value: 1.1"},
+		{value: -1.1, err: "Int cannot represent non-integer value: -1.1"},
+		{value: "-1.1", err: `Int cannot represent non-integer value: "-1.1"`},
+		{value: 9876504321, err: "Int cannot represent non 32-bit signed integer value: 9876504321"},
+		{value: -9876504321, err: "Int cannot represent non 32-bit signed integer value: -9876504321"},
+		{value: uint64(math.MaxUint64), err: "Int cannot represent non 32-bit signed integer value: 18446744073709551615"},
+		{value: 1e100, err: "Int cannot represent non 32-bit signed integer value: 1e+100"},
+		{value: -1e100, err: "Int cannot represent non 32-bit signed integer value: -1e+100"},
+		{value: math.NaN(), err: "Int cannot represent non-integer value: NaN"},
+		{value: math.Inf(1), err: "Int cannot represent non-integer value: Infinity"},
+		{value: "one", err: `Int cannot represent non-integer value: "one"`},
+		{value: "", err: `Int cannot represent non-integer value: ""`},
+		{value: nilInt, err: "Int cannot represent non-integer value: null"},
+		{value: []int{5}, err: "Int cannot represent non-integer value: [5]"},
+	})
+}
+
+func TestParsesInputInt(t *testing.T) {
+	testCoercion(t, "Int.ParseValue", Int.ParseValue, []coercionTest{
+		{value: 1, want: 1},
+		{value: float64(123), want: 123},
+		{value: json.Number("-7"), want: -7},
+		{value: "123", err: `Int cannot represent non-integer value: "123"`},
+		{value: true, err: "Int cannot represent non-integer value: true"},
+		{value: 1.5, err: "Int cannot represent non-integer value: 1.5"},
+		{value: int64(math.MinInt32) - 1, err: "Int cannot represent non 32-bit signed integer value: -2147483649"},
+	})
+}
+
+func TestSerializesOutputFloat(t *testing.T) {
+	testCoercion(t, "Float.Serialize", Float.Serialize, []coercionTest{
+		{value: 1, want: 1.0},
+		{value: 0, want: 0.0},
+		{value: "123.5", want: 123.5},
+		{value: -1, want: -1.0},
+		{value: 0.1, want: 0.1},
+		{value: 1.1, want: 1.1},
+		{value: -1.1, want: -1.1},
+		{value: "-1.1", want: -1.1},
+		{value: json.Number("2.5"), want: 2.5},
+		{value: false, want: 0.0},
+		{value: true, want: 1.0},
+		{value: math.NaN(), err: "Float cannot represent non numeric value: NaN"},
+		{value: math.Inf(-1), err: "Float cannot represent non numeric value: -Infinity"},
+		{value: "one", err: `Float cannot represent non numeric value: "one"`},
+		{value: "", err: `Float cannot represent non numeric value: ""`},
+		{value: []int{5}, err: "Float cannot represent non numeric value: [5]"},
+	})
+}
+
+func TestParsesInputFloat(t *testing.T) {
+	testCoercion(t, "Float.ParseValue", Float.ParseValue, []coercionTest{
+		{value: 1, want: 1.0},
+		{value: 2.5, want: 2.5},
+		{value: "2.5", err: `Float cannot represent non numeric value: "2.5"`},
+		{value: true, err: "Float cannot represent non numeric value: true"},
+		{value: math.Inf(1), err: "Float cannot represent non numeric value: Infinity"},
+	})
+}
+
+func TestSerializesOutputString(t *testing.T) {
+	s := "ptr"
+
+	testCoercion(t, "String.Serialize", String.Serialize, []coercionTest{
+		{value: "string", want: "string"},
+		{value: 1, want: "1"},
+		{value: -1.1, want: "-1.1"},
+		{value: true, want: "true"},
+		{value: false, want: "false"},
+		{value: json.Number("12"), want: "12"},
+		{value: &s, want: "ptr"},
+		{value: math.NaN(), err: "String cannot represent value: NaN"},
+		{value: []string{}, err: "String cannot represent value: []"},
+	})
+}
+
+func TestParsesInputString(t *testing.T) {
+	testCoercion(t, "String.ParseValue", String.ParseValue, []coercionTest{
+		{value: "string", want: "string"},
+		{value: 1, err: "String cannot represent a non string value: 1"},
+		{value: true, err: "String cannot represent a non string value: true"},
+		{value: json.Number("1"), err: "String cannot represent a non string value: 1"},
+	})
+}
+
+func TestSerializesOutputBoolean(t *testing.T) {
+	testCoercion(t, "Boolean.Serialize", Boolean.Serialize, []coercionTest{
+		{value: true, want: true},
+		{value: false, want: false},
+		{value: 1, want: true},
+		{value: 0, want: false},
+		{value: "true", err: `Boolean cannot represent a non boolean value: "true"`},
+		{value: math.NaN(), err: "Boolean cannot represent a non boolean value: NaN"},
+	})
+}
+
+func TestParsesInputBoolean(t *testing.T) {
+	testCoercion(t, "Boolean.ParseValue", Boolean.ParseValue, []coercionTest{
+		{value: true, want: true},
+		{value: 1, err: "Boolean cannot represent a non boolean value: 1"},
+		{value: "false", err: `Boolean cannot represent a non boolean value: "false"`},
+	})
+}
+
+func TestSerializesOutputID(t *testing.T) {
+	testCoercion(t, "ID.Serialize", ID.Serialize, []coercionTest{
+		{value: "string", want: "string"},
+		{value: "false", want: "false"},
+		{value: "", want: ""},
+		{value: 123, want: "123"},
+		{value: 0, want: "0"},
+		{value: -1, want: "-1"},
+		{value: int64(math.MaxInt64), want: "9223372036854775807"},
+		{value: json.Number("77"), want: "77"},
+		{value: float64(5), want: "5"},
+		{value: 1.1, err: "ID cannot represent value: 1.1"},
+		{value: true, err: "ID cannot represent value: true"},
+		{value: json.Number("7.5"), err: "ID cannot represent value: 7.5"},
+	})
+}
+
+func TestParsesInputID(t *testing.T) {
+	testCoercion(t, "ID.ParseValue", ID.ParseValue, []coercionTest{
+		{value: "abc", want: "abc"},
+		{value: 4, want: "4"},
+		{value: float64(4), want: "4"},
+		{value: 4.5, err: "ID cannot represent value: 4.5"},
+		{value: false, err: "ID cannot represent value: false"},
+		{value: nil, err: "ID cannot represent value: null"},
+	})
+}
+
+func TestParsesLiterals(t *testing.T) {
+	tests := []struct {
+		scalar *Scalar
+		node   language.ValueNode
+		want   interface{}
+		err    string
+	}{
+		{scalar: Int, node: &language.IntValueNode{Value: "123"}, want: 123},
+		{scalar: Int, node: &language.IntValueNode{Value: "2147483648"}, err: "Int cannot represent non 32-bit signed integer value: 2147483648"},
+		{scalar: Int, node: &language.FloatValueNode{Value: "1.5"}, err: "Int cannot represent non-integer value: 1.5"},
+		{scalar: Int, node: &language.StringValueNode{Value: "1"}, err: `Int cannot represent non-integer value: "1"`},
+		{scalar: Float, node: &language.IntValueNode{Value: "1"}, want: 1.0},
+		{scalar: Float, node: &language.FloatValueNode{Value: "1.5e3"}, want: 1500.0},
+		{scalar: Float, node: &language.EnumValueNode{Value: "NaN"}, err: "Float cannot represent non numeric value: NaN"},
+		{scalar: String, node: &language.StringValueNode{Value: "abc"}, want: "abc"},
+		{scalar: String, node: &language.IntValueNode{Value: "1"}, err: "String cannot represent a non string value: 1"},
+		{scalar: Boolean, node: &language.BooleanValueNode{Value: true}, want: true},
+		{scalar: Boolean, node: &language.NullValueNode{}, err: "Boolean cannot represent a non boolean value: null"},
+		{scalar: ID, node: &language.StringValueNode{Value: "abc"}, want: "abc"},
+		{scalar: ID, node: &language.IntValueNode{Value: "123"}, want: "123"},
+		{scalar: ID, node: &language.FloatValueNode{Value: "1.0"}, err: "ID cannot represent a non-string and non-integer value: 1.0"},
+		{
+			scalar: ID,
+			node:   &language.ListValueNode{Values: []language.ValueNode{&language.IntValueNode{Value: "1"}}},
+			err:    "ID cannot represent a non-string and non-integer value: [1]",
+		},
+	}
+
+	for _, test := range tests {
+		got, err := test.scalar.ParseLiteral(test.node)
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("%s.ParseLiteral(%s); got error %v wanted %s", test.scalar, language.Print(test.node), err, test.err)
+			}
+			continue
+		}
+
+		if err != nil || got != test.want {
+			t.Errorf("%s.ParseLiteral(%s); got %#v, %v wanted %#v", test.scalar, language.Print(test.node), got, err, test.want)
+		}
+	}
+}
@@ -1,17 +1,353 @@
 // Package schema holds the types and functions for creating your graphql schema
-//
 package schema
 
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/ijsnow/goql/internal/language"
+)
+
+// TypeMap maps the name of each type in a schema to the type.
+type TypeMap map[string]NamedType
+
+// SchemaConfig configuration for a Schema
+type SchemaConfig struct {
+	/**
+	 * Query is the root type of query operations.
+	 */
+	Query *Object
+
+	/**
+	 * Mutation is the root type of mutation operations, if the schema
+	 * supports them.
+	 */
+	Mutation *Object
+
+	/**
+	 * Subscription is the root type of subscription operations, if the
+	 * schema supports them.
+	 */
+	Subscription *Object
+
+	/**
+	 * Types are added to the schema in addition to the types reachable from
+	 * the root types. This is needed for Object types that are only
+	 * referenced through the interfaces they implement.
+	 */
+	Types []NamedType
+
+	/**
+	 * Directives are the custom directives of the schema. The specified
+	 * directives, @include, @skip, @deprecated and @specifiedBy, are always
+	 * available unless a directive of the same name is given.
+	 */
+	Directives []*Directive
+
+	AstNode *language.SchemaDefinitionNode
+}
+
 // Schema is the type that will hold your graphql schema
+//
+// A Schema is created by supplying the root types of each type of operation,
+// query and mutation (optional). A schema definition is then supplied to the
+// validator and executor.
+//
+//	myAppSchema, err := schema.NewSchema(schema.SchemaConfig{
+//		Query:    MyAppQueryRootType,
+//		Mutation: MyAppMutationRootType,
+//	})
 type Schema struct {
-	astNode SchemaDefinitionNode
-	//   _queryType: GraphQLObjectType;
-	//   _mutationType: ?GraphQLObjectType;
-	//   _subscriptionType: ?GraphQLObjectType;
-	//   _directives: Array<GraphQLDirective>;
-	//   _typeMap: TypeMap;
-	//   _implementations: { [interfaceName: string]: Array<GraphQLObjectType> };
-	//   _possibleTypeMap: ?{
-	//     [abstractName: string]: { [possibleName: string]: boolean }
-	//   };
+	AstNode *language.SchemaDefinitionNode
+
+	queryType        *Object
+	mutationType     *Object
+	subscriptionType *Object
+	typeMap          TypeMap
+	directives       []*Directive
+
+	// implementations and possibleTypeMap are computed by NewSchema and
+	// only read afterwards, so they are safe for concurrent use.
+	implementations map[string][]*Object
+	possibleTypeMap map[string]map[string]bool
+
+	validationOnce   sync.Once
+	validationErrors []error
+}
+
+// NewSchema creates a Schema and collects every type reachable from its root
+// types, the introspection types and config.Types into its type map. It
+// returns an error if two different types share a name.
+func NewSchema(config SchemaConfig) (*Schema, error) {
+	schema := &Schema{
+		AstNode:          config.AstNode,
+		queryType:        config.Query,
+		mutationType:     config.Mutation,
+		subscriptionType: config.Subscription,
+		typeMap:          TypeMap{},
+	}
+
+	directives, err := withSpecifiedDirectives(config.Directives)
+	if err != nil {
+		return nil, err
+	}
+	schema.directives = directives
+
+	// Build type map now to detect any errors within this schema.
+	initialTypes := []Type{}
+	for _, root := range []*Object{config.Query, config.Mutation, config.Subscription} {
+		if root != nil {
+			initialTypes = append(initialTypes, root)
+		}
+	}
+	initialTypes = append(initialTypes, SchemaType)
+	for _, t := range config.Types {
+		if t != nil {
+			initialTypes = append(initialTypes, t)
+		}
+	}
+
+	for _, t := range initialTypes {
+		if err := typeMapReducer(schema.typeMap, t); err != nil {
+			return nil, err
+		}
+	}
+
+	// Include the types of the arguments of directives.
+	for _, directive := range schema.directives {
+		for _, arg := range directive.Args {
+			if err := typeMapReducer(schema.typeMap, arg.Type); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	schema.collectPossibleTypes()
+
+	return schema, nil
+}
+
+// GetQueryType returns the root type of query operations.
+func (s *Schema) GetQueryType() *Object {
+	return s.queryType
+}
+
+// GetMutationType returns the root type of mutation operations, or nil.
+func (s *Schema) GetMutationType() *Object {
+	return s.mutationType
+}
+
+// GetSubscriptionType returns the root type of subscription operations, or
+// nil.
+func (s *Schema) GetSubscriptionType() *Object {
+	return s.subscriptionType
+}
+
+// GetTypeMap returns every named type in the schema by name.
+func (s *Schema) GetTypeMap() TypeMap {
+	return s.typeMap
+}
+
+// GetType returns the type with the given name, or nil.
+func (s *Schema) GetType(name string) NamedType {
+	return s.typeMap[name]
+}
+
+// GetDirectives returns the directives available in the schema.
+func (s *Schema) GetDirectives() []*Directive {
+	return s.directives
+}
+
+// GetDirective returns the directive with the given name, or nil.
+func (s *Schema) GetDirective(name string) *Directive {
+	for _, directive := range s.directives {
+		if directive.Name == name {
+			return directive
+		}
+	}
+
+	return nil
+}
+
+// PossibleTypes returns the object types that are possible at runtime for
+// the abstract type: the members of a union or the implementations of an
+// interface.
+func (s *Schema) PossibleTypes(abstract AbstractType) []*Object {
+	switch t := abstract.(type) {
+	case *Union:
+		return t.GetTypes()
+	case *Interface:
+		return s.implementations[t.Name]
+	}
+
+	return nil
+}
+
+// IsPossibleType reports whether the object type is possible at runtime for
+// the abstract type. The object type must be the type of the schema with its
+// name, rather than another type of the same name.
+func (s *Schema) IsPossibleType(abstract AbstractType, object *Object) bool {
+	if abstract == nil || object == nil {
+		return false
+	}
+	if s.GetType(object.Name) != NamedType(object) {
+		return false
+	}
+
+	return s.possibleTypeMap[abstract.GetName()][object.Name]
+}
+
+// Implementations returns the object types of the schema that implement the
+// interface, ordered by name.
+func (s *Schema) Implementations(iface *Interface) []*Object {
+	return s.implementations[iface.Name]
+}
+
+// collectPossibleTypes records the implementations of each interface and
+// the possible types of each abstract type in the type map.
+func (s *Schema) collectPossibleTypes() {
+	s.implementations = map[string][]*Object{}
+	s.possibleTypeMap = map[string]map[string]bool{}
+
+	names := make([]string, 0, len(s.typeMap))
+	for name := range s.typeMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		object, ok := s.typeMap[name].(*Object)
+		if !ok {
+			continue
+		}
+
+		for _, iface := range object.GetInterfaces() {
+			if iface != nil {
+				s.implementations[iface.Name] = append(s.implementations[iface.Name], object)
+			}
+		}
+	}
+
+	for _, t := range s.typeMap {
+		abstract, ok := t.(AbstractType)
+		if !ok {
+			continue
+		}
+
+		possible := map[string]bool{}
+		for _, object := range s.PossibleTypes(abstract) {
+			if object != nil {
+				possible[object.Name] = true
+			}
+		}
+		s.possibleTypeMap[abstract.GetName()] = possible
+	}
+}
+
+// withSpecifiedDirectives returns the custom directives preceded by the
+// specified directives they do not replace.
+func withSpecifiedDirectives(custom []*Directive) ([]*Directive, error) {
+	byName := map[string]bool{}
+	for _, directive := range custom {
+		if directive == nil {
+			continue
+		}
+		if byName[directive.Name] {
+			return nil, fmt.Errorf(
+				"Schema must contain unique named directives but contains multiple directives named \"@%s\".",
+				directive.Name,
+			)
+		}
+		byName[directive.Name] = true
+	}
+
+	var directives []*Directive
+	for _, directive := range SpecifiedDirectives {
+		if !byName[directive.Name] {
+			directives = append(directives, directive)
+		}
+	}
+	for _, directive := range custom {
+		if directive != nil {
+			directives = append(directives, directive)
+		}
+	}
+
+	return directives, nil
+}
+
+func typeMapReducer(typeMap TypeMap, t Type) error {
+	if t == nil {
+		return nil
+	}
+
+	if wrapper, ok := t.(WrappingType); ok {
+		return typeMapReducer(typeMap, wrapper.GetOfType())
+	}
+
+	named, ok := t.(NamedType)
+	if !ok {
+		return nil
+	}
+
+	if existing, ok := typeMap[named.GetName()]; ok {
+		if existing != named {
+			return fmt.Errorf(
+				"Schema must contain unique named types but contains multiple types named %q.",
+				named.GetName(),
+			)
+		}
+
+		return nil
+	}
+	typeMap[named.GetName()] = named
+
+	var reduce []Type
+
+	switch t := named.(type) {
+	case *Union:
+		for _, member := range t.GetTypes() {
+			if member != nil {
+				reduce = append(reduce, member)
+			}
+		}
+	case *Object:
+		for _, iface := range t.GetInterfaces() {
+			if iface != nil {
+				reduce = append(reduce, iface)
+			}
+		}
+		reduce = append(reduce, fieldTypes(t.GetFields())...)
+	case *Interface:
+		reduce = append(reduce, fieldTypes(t.GetFields())...)
+	case *InputObject:
+		for _, field := range t.GetFields() {
+			reduce = append(reduce, field.Type)
+		}
+	}
+
+	for _, t := range reduce {
+		if err := typeMapReducer(typeMap, t); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fieldTypes returns the types of the arguments of each field followed by
+// the type of the field.
+func fieldTypes(fields []*Field) []Type {
+	var types []Type
+	for _, field := range fields {
+		for _, arg := range field.Args {
+			if arg != nil {
+				types = append(types, arg.Type)
+			}
+		}
+		types = append(types, field.Type)
+	}
+
+	return types
 }
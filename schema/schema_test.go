@@ -0,0 +1,63 @@
+package schema
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestComputesPossibleTypes(t *testing.T) {
+	pet := NewInterface(InterfaceConfig{Name: "Pet", Fields: Fields{{Name: "name", Type: String}}})
+	dog := NewObject(ObjectConfig{Name: "Dog", Interfaces: Interfaces{pet}, Fields: Fields{{Name: "name", Type: String}}})
+	cat := NewObject(ObjectConfig{Name: "Cat", Interfaces: Interfaces{pet}, Fields: Fields{{Name: "name", Type: String}}})
+	human := NewObject(ObjectConfig{Name: "Human", Fields: Fields{{Name: "name", Type: String}}})
+	being := NewUnion(UnionConfig{Name: "Being", Types: Objects{dog, human}})
+
+	schema, err := NewSchema(SchemaConfig{
+		Query: NewObject(ObjectConfig{Name: "Query", Fields: Fields{
+			{Name: "pet", Type: pet},
+			{Name: "being", Type: being},
+		}}),
+		Types: []NamedType{cat},
+	})
+	if err != nil {
+		t.Fatalf("NewSchema; got error %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if got := schema.Implementations(pet); len(got) != 2 || got[0] != cat || got[1] != dog {
+				t.Errorf("Implementations(Pet); got %v wanted [Cat Dog]", got)
+			}
+			if got := schema.PossibleTypes(being); len(got) != 2 || got[0] != dog || got[1] != human {
+				t.Errorf("PossibleTypes(Being); got %v wanted [Dog Human]", got)
+			}
+		}()
+	}
+	wg.Wait()
+
+	tests := []struct {
+		abstract AbstractType
+		object   *Object
+		want     bool
+	}{
+		{pet, dog, true},
+		{pet, cat, true},
+		{pet, human, false},
+		{being, dog, true},
+		{being, cat, false},
+		{pet, NewObject(ObjectConfig{Name: "Dog", Interfaces: Interfaces{pet}, Fields: Fields{{Name: "name", Type: String}}}), false},
+	}
+	for _, test := range tests {
+		if got := schema.IsPossibleType(test.abstract, test.object); got != test.want {
+			t.Errorf("IsPossibleType(%s, %s); got %t wanted %t", test.abstract, test.object, got, test.want)
+		}
+	}
+
+	if !DoTypesOverlap(schema, pet, being) || DoTypesOverlap(schema, cat, being) {
+		t.Error("DoTypesOverlap; got the wrong overlap of Pet, Cat and Being")
+	}
+}
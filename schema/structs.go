@@ -0,0 +1,798 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// StructOptions configures how Go types are mapped to GraphQL types by
+// FromStruct and a StructBuilder.
+type StructOptions struct {
+	/**
+	 * Mutation and Subscription are the values, or pointers to the values,
+	 * whose types become the mutation and subscription root types.
+	 */
+	Mutation     interface{}
+	Subscription interface{}
+
+	/**
+	 * Enums are the Enum types of named Go types. The values of an Enum are
+	 * registered for the Go type of their Value, such as:
+	 *
+	 *	{Name: "DOG", Value: KindDog}
+	 */
+	Enums []*Enum
+
+	/**
+	 * Types maps Go types to the GraphQL types they are represented by, in
+	 * place of the types derived from them, such as custom scalars.
+	 */
+	Types map[reflect.Type]NamedType
+}
+
+// FromStruct builds a Schema whose query root type is derived from the type
+// of query, and whose mutation and subscription root types are derived from
+// options.Mutation and options.Subscription. See StructBuilder for how Go
+// types are mapped. The root values are the values given when executing
+// operations; the types are all that is read from these.
+//
+//	type Query struct {
+//		_    struct{} `graphql:"Query,description=The query root."`
+//		Pets []*Pet  `graphql:"pets,description=All the pets."`
+//	}
+//
+//	s, err := schema.FromStruct(&Query{})
+func FromStruct(query interface{}, options ...StructOptions) (*Schema, error) {
+	b := NewStructBuilder(options...)
+
+	var opts StructOptions
+	if len(options) > 0 {
+		opts = options[0]
+	}
+
+	config := SchemaConfig{}
+
+	var err error
+	if config.Query, err = b.Object(query); err != nil {
+		return nil, err
+	}
+	if opts.Mutation != nil {
+		if config.Mutation, err = b.Object(opts.Mutation); err != nil {
+			return nil, err
+		}
+	}
+	if opts.Subscription != nil {
+		if config.Subscription, err = b.Object(opts.Subscription); err != nil {
+			return nil, err
+		}
+	}
+
+	return NewSchema(config)
+}
+
+// StructBuilder derives GraphQL types from Go types by reflection:
+//
+//   - Structs are Object types, or InputObject types when used as input.
+//     Their exported fields are fields, and the fields of embedded structs
+//     are promoted like in Go.
+//   - Exported methods are fields of Object types if they return a value,
+//     or a value and an error. They may take a context.Context and then a
+//     struct, or a pointer to one, whose fields are the arguments. The
+//     String and Error methods are not fields.
+//   - Pointers are nullable, and other values are non-null.
+//   - Slices and arrays are lists.
+//   - Bools, integers, floats and strings are the Boolean, Int, Float and
+//     String scalars, unless the named type is registered as an Enum or in
+//     StructOptions.Types.
+//
+// The names of fields and arguments are their Go names starting with a
+// lowercase letter, unless given by a `graphql` struct tag which may also
+// set a description and deprecate the field:
+//
+//	Name string `graphql:"name,description=The name, in full.,deprecated=Use fullName."`
+//
+// A tag of "-" skips the field. Types are named after their Go types, and a
+// blank field may set the name and description of the type:
+//
+//	_ struct{} `graphql:"Pet,description=A pet."`
+//
+// The resolvers of fields read the field or call the method on the source
+// value, which is the struct or a pointer to it.
+type StructBuilder struct {
+	types   map[reflect.Type]NamedType
+	objects map[reflect.Type]*Object
+	inputs  map[reflect.Type]*InputObject
+}
+
+// NewStructBuilder creates a StructBuilder. Only the Enums and Types of the
+// options are used.
+func NewStructBuilder(options ...StructOptions) *StructBuilder {
+	b := &StructBuilder{
+		types:   map[reflect.Type]NamedType{},
+		objects: map[reflect.Type]*Object{},
+		inputs:  map[reflect.Type]*InputObject{},
+	}
+
+	for _, option := range options {
+		for _, enum := range option.Enums {
+			for _, value := range enum.GetValues() {
+				// Values defaulting to their names are plain strings, which
+				// must remain String.
+				if t := reflect.TypeOf(value.Value); t != nil && t.PkgPath() != "" {
+					b.types[t] = enum
+				}
+			}
+		}
+		for t, named := range option.Types {
+			b.types[t] = named
+		}
+	}
+
+	return b
+}
+
+// Object returns the Object type derived from the type of value, which is a
+// struct or a pointer to one. It may also be the reflect.Type of these.
+func (b *StructBuilder) Object(value interface{}) (*Object, error) {
+	t, ok := value.(reflect.Type)
+	if !ok {
+		t = reflect.TypeOf(value)
+	}
+	if t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("Expected a struct or a pointer to one, but received: %v.", t)
+	}
+
+	return b.object(t)
+}
+
+// OutputType returns the type of fields derived from a Go type.
+func (b *StructBuilder) OutputType(t reflect.Type) (Type, error) {
+	return b.typeOf(t, false)
+}
+
+// InputType returns the type of arguments derived from a Go type.
+func (b *StructBuilder) InputType(t reflect.Type) (Type, error) {
+	return b.typeOf(t, true)
+}
+
+func (b *StructBuilder) typeOf(t reflect.Type, input bool) (Type, error) {
+	nullable := t.Kind() == reflect.Ptr
+	if nullable {
+		t = t.Elem()
+	}
+
+	var result Type
+	if named, ok := b.types[t]; ok {
+		result = named
+	} else {
+		switch t.Kind() {
+		case reflect.Slice, reflect.Array:
+			ofType, err := b.typeOf(t.Elem(), input)
+			if err != nil {
+				return nil, err
+			}
+			result = NewList(ofType)
+		case reflect.Struct:
+			var err error
+			if input {
+				result, err = b.inputObject(t)
+			} else {
+				result, err = b.object(t)
+			}
+			if err != nil {
+				return nil, err
+			}
+		case reflect.Bool:
+			result = Boolean
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			result = Int
+		case reflect.Float32, reflect.Float64:
+			result = Float
+		case reflect.String:
+			result = String
+		default:
+			return nil, fmt.Errorf("Cannot represent Go type %s as a GraphQL type.", t)
+		}
+	}
+
+	if input && !IsInputType(result) {
+		return nil, fmt.Errorf("Go type %s is represented by %s, which is not an input type.", t, result)
+	}
+	if !input && !IsOutputType(result) {
+		return nil, fmt.Errorf("Go type %s is represented by %s, which is not an output type.", t, result)
+	}
+
+	if !nullable {
+		return NewNonNull(result), nil
+	}
+
+	return result, nil
+}
+
+func (b *StructBuilder) object(t reflect.Type) (*Object, error) {
+	if object, ok := b.objects[t]; ok {
+		return object, nil
+	}
+
+	name, description, err := structTypeTag(t)
+	if err != nil {
+		return nil, err
+	}
+
+	// The fields are set after the Object is registered, so that they may
+	// refer to it.
+	var fields Fields
+	object := NewObject(ObjectConfig{
+		Name:        name,
+		Description: description,
+		Fields:      FieldsFunc(func() Fields { return fields }),
+	})
+	b.objects[t] = object
+
+	taken := map[string]bool{}
+	for _, sf := range structFields(t) {
+		tag := parseStructTag(sf)
+		if tag.skip {
+			continue
+		}
+
+		fieldType, err := b.typeOf(sf.Type, false)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %v", name, tag.name, err)
+		}
+
+		taken[tag.name] = true
+		fields = append(fields, &Field{
+			Name:              tag.name,
+			Description:       tag.description,
+			Type:              fieldType,
+			Resolve:           structFieldResolver(sf.Index),
+			DeprecationReason: tag.deprecated,
+		})
+	}
+
+	ptr := reflect.PtrTo(t)
+	for idx := 0; idx < ptr.NumMethod(); idx++ {
+		method := ptr.Method(idx)
+		fieldName := lowerFirst(method.Name)
+		if method.Name == "String" || method.Name == "Error" || taken[fieldName] {
+			continue
+		}
+
+		sig, ok := resolverSignature(method.Type)
+		if !ok {
+			continue
+		}
+
+		fieldType, err := b.typeOf(method.Type.Out(0), false)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %v", name, fieldName, err)
+		}
+
+		field := &Field{
+			Name:    fieldName,
+			Type:    fieldType,
+			Resolve: methodResolver(method, sig),
+		}
+		if sig.args != nil {
+			if field.Args, err = b.arguments(sig.args); err != nil {
+				return nil, fmt.Errorf("%s.%s: %v", name, fieldName, err)
+			}
+		}
+
+		fields = append(fields, field)
+	}
+
+	return object, nil
+}
+
+func (b *StructBuilder) inputObject(t reflect.Type) (*InputObject, error) {
+	if inputObject, ok := b.inputs[t]; ok {
+		return inputObject, nil
+	}
+
+	name, description, err := structTypeTag(t)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields InputFields
+	inputObject := NewInputObject(InputObjectConfig{
+		Name:        name,
+		Description: description,
+		Fields:      InputFieldsFunc(func() InputFields { return fields }),
+	})
+	b.inputs[t] = inputObject
+
+	for _, sf := range structFields(t) {
+		tag := parseStructTag(sf)
+		if tag.skip {
+			continue
+		}
+
+		fieldType, err := b.typeOf(sf.Type, true)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %v", name, tag.name, err)
+		}
+
+		fields = append(fields, &InputField{
+			Name:              tag.name,
+			Description:       tag.description,
+			Type:              fieldType,
+			DeprecationReason: tag.deprecated,
+		})
+	}
+
+	return inputObject, nil
+}
+
+// arguments returns the arguments given by the fields of an args struct.
+func (b *StructBuilder) arguments(t reflect.Type) ([]*Argument, error) {
+	var args []*Argument
+	for _, sf := range structFields(t) {
+		tag := parseStructTag(sf)
+		if tag.skip {
+			continue
+		}
+
+		argType, err := b.typeOf(sf.Type, true)
+		if err != nil {
+			return nil, fmt.Errorf("argument %s: %v", tag.name, err)
+		}
+
+		args = append(args, &Argument{
+			Name:              tag.name,
+			Description:       tag.description,
+			Type:              argType,
+			DeprecationReason: tag.deprecated,
+		})
+	}
+
+	return args, nil
+}
+
+// structFields returns the exported fields of a struct, and those promoted
+// from its embedded structs. The blank field naming the type is left out.
+func structFields(t reflect.Type) []reflect.StructField {
+	var fields []reflect.StructField
+	for idx := 0; idx < t.NumField(); idx++ {
+		sf := t.Field(idx)
+		if sf.Name == "_" {
+			continue
+		}
+
+		embedded := sf.Type
+		if embedded.Kind() == reflect.Ptr {
+			embedded = embedded.Elem()
+		}
+		if sf.Anonymous && embedded.Kind() == reflect.Struct && sf.Tag.Get("graphql") == "" {
+			for _, promoted := range structFields(embedded) {
+				promoted.Index = append([]int{idx}, promoted.Index...)
+				fields = append(fields, promoted)
+			}
+			continue
+		}
+
+		if sf.PkgPath != "" {
+			continue
+		}
+		fields = append(fields, sf)
+	}
+
+	return fields
+}
+
+// structTag is a parsed `graphql` struct tag.
+type structTag struct {
+	name        string
+	description string
+	deprecated  string
+	skip        bool
+}
+
+// parseStructTag parses the tag of a field. The options after the name are
+// separated by commas, and a comma not followed by a known option is part
+// of the value of the previous option, so that descriptions may contain
+// commas.
+func parseStructTag(sf reflect.StructField) structTag {
+	value := sf.Tag.Get("graphql")
+	if value == "-" {
+		return structTag{skip: true}
+	}
+
+	parts := strings.Split(value, ",")
+	tag := structTag{name: parts[0]}
+	if tag.name == "" {
+		tag.name = lowerFirst(sf.Name)
+	}
+
+	var current *string
+	for _, part := range parts[1:] {
+		option := strings.SplitN(part, "=", 2)
+		switch option[0] {
+		case "description":
+			current = &tag.description
+		case "deprecated":
+			current = &tag.deprecated
+			if len(option) == 1 {
+				tag.deprecated = DefaultDeprecationReason
+				continue
+			}
+		default:
+			if current != nil {
+				*current += "," + part
+			}
+			continue
+		}
+
+		if len(option) == 2 {
+			*current = option[1]
+		}
+	}
+
+	return tag
+}
+
+// structTypeTag returns the name and description of the type derived from a
+// struct, given by the tag of its blank field.
+func structTypeTag(t reflect.Type) (string, string, error) {
+	name, description := t.Name(), ""
+	if sf, ok := t.FieldByName("_"); ok {
+		tag := parseStructTag(sf)
+		if tag.name != "_" {
+			name = tag.name
+		}
+		description = tag.description
+	}
+
+	if name == "" {
+		return "", "", fmt.Errorf("Cannot name the GraphQL type of anonymous struct %s; name it with the tag of a blank field.", t)
+	}
+
+	return name, description, nil
+}
+
+// lowerFirst lowercases the leading capital letters of a Go name, keeping
+// the last one of an initialism followed by a word: ID becomes id, and
+// URLPath becomes urlPath.
+func lowerFirst(name string) string {
+	upper := 0
+	for upper < len(name) && name[upper] >= 'A' && name[upper] <= 'Z' {
+		upper++
+	}
+
+	switch {
+	case upper == 0:
+		return name
+	case upper == len(name):
+		return strings.ToLower(name)
+	case upper > 1:
+		upper--
+	}
+
+	return strings.ToLower(name[:upper]) + name[upper:]
+}
+
+var (
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// signature describes the parameters of a method resolving a field.
+type signature struct {
+	context bool
+	args    reflect.Type
+	pointer bool
+	err     bool
+}
+
+// resolverSignature reports whether a method, including its receiver, may
+// resolve a field.
+func resolverSignature(t reflect.Type) (signature, bool) {
+	var sig signature
+
+	in := 1
+	if in < t.NumIn() && t.In(in) == contextType {
+		sig.context = true
+		in++
+	}
+	if in < t.NumIn() {
+		args := t.In(in)
+		if args.Kind() == reflect.Ptr {
+			sig.pointer = true
+			args = args.Elem()
+		}
+		if args.Kind() != reflect.Struct {
+			return sig, false
+		}
+		sig.args = args
+		in++
+	}
+	if in != t.NumIn() {
+		return sig, false
+	}
+
+	switch t.NumOut() {
+	case 1:
+		return sig, t.Out(0) != errorType
+	case 2:
+		sig.err = true
+		return sig, t.Out(1) == errorType
+	}
+
+	return sig, false
+}
+
+// structFieldResolver resolves a field by reading the struct field at the
+// index of the source value.
+func structFieldResolver(index []int) FieldResolveFn {
+	return func(p ResolveParams) (interface{}, error) {
+		v := reflect.ValueOf(p.Source)
+		for _, idx := range index {
+			v = reflect.Indirect(v)
+			if !v.IsValid() {
+				return nil, nil
+			}
+			v = v.Field(idx)
+		}
+
+		return resolvedValue(v), nil
+	}
+}
+
+// methodResolver resolves a field by calling a method of the source value.
+// Root types may be resolved without a root value, on the zero value of
+// their struct.
+func methodResolver(method reflect.Method, sig signature) FieldResolveFn {
+	receiverType := method.Type.In(0)
+
+	return func(p ResolveParams) (interface{}, error) {
+		receiver := reflect.ValueOf(p.Source)
+		switch {
+		case !receiver.IsValid():
+			receiver = reflect.New(receiverType.Elem())
+		case receiver.Kind() != reflect.Ptr:
+			ptr := reflect.New(receiver.Type())
+			ptr.Elem().Set(receiver)
+			receiver = ptr
+		case receiver.IsNil():
+			return nil, nil
+		}
+
+		in := []reflect.Value{receiver}
+		if sig.context {
+			ctx := p.Context
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			in = append(in, reflect.ValueOf(ctx))
+		}
+		if sig.args != nil {
+			args := reflect.New(sig.args)
+			if err := assignArgs(args.Elem(), p.Args); err != nil {
+				return nil, err
+			}
+			if !sig.pointer {
+				args = args.Elem()
+			}
+			in = append(in, args)
+		}
+
+		out := method.Func.Call(in)
+		if sig.err && !out[1].IsNil() {
+			return nil, out[1].Interface().(error)
+		}
+
+		return resolvedValue(out[0]), nil
+	}
+}
+
+// resolvedValue returns the value a resolver produces for a Go value.
+// Pointers to leaf values are dereferenced, since scalars and enums
+// serialize the values themselves.
+func resolvedValue(v reflect.Value) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		if v.Kind() == reflect.Ptr && v.Elem().Kind() != reflect.Struct {
+			return v.Elem().Interface()
+		}
+	}
+
+	return v.Interface()
+}
+
+// DecodeArgs sets the fields of the struct dst points to from the coerced
+// values of arguments, such as ResolveParams.Args. The fields are named as
+// by a StructBuilder, and input object values are decoded into structs the
+// same way.
+//
+//	var args struct {
+//		ID    string `graphql:"id"`
+//		First *int   `graphql:"first"`
+//	}
+//	if err := schema.DecodeArgs(p.Args, &args); err != nil {
+//		return nil, err
+//	}
+func DecodeArgs(args map[string]interface{}, dst interface{}) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("Expected a pointer to a struct, but received: %T.", dst)
+	}
+
+	return assignArgs(v.Elem(), args)
+}
+
+// assignArgs sets the fields of an args struct, or of an input object, from
+// coerced argument values.
+func assignArgs(dst reflect.Value, values map[string]interface{}) error {
+	for _, sf := range structFields(dst.Type()) {
+		tag := parseStructTag(sf)
+		if tag.skip {
+			continue
+		}
+
+		value, ok := values[tag.name]
+		if !ok {
+			continue
+		}
+
+		if err := assignValue(allocFieldByIndex(dst, sf.Index), value); err != nil {
+			return fmt.Errorf("%s: %v", tag.name, err)
+		}
+	}
+
+	return nil
+}
+
+// allocFieldByIndex returns the field at the index of a struct, allocating
+// the embedded structs it is promoted from.
+func allocFieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for idx, field := range index {
+		if idx > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(field)
+	}
+
+	return v
+}
+
+// assignValue sets a Go value from a coerced input value.
+func assignValue(dst reflect.Value, value interface{}) error {
+	if value == nil {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+
+	switch dst.Kind() {
+	case reflect.Ptr:
+		ptr := reflect.New(dst.Type().Elem())
+		if err := assignValue(ptr.Elem(), value); err != nil {
+			return err
+		}
+		dst.Set(ptr)
+		return nil
+	case reflect.Slice, reflect.Array:
+		list, ok := value.([]interface{})
+		if !ok {
+			list = []interface{}{value}
+		}
+		items := dst
+		if dst.Kind() == reflect.Slice {
+			items = reflect.MakeSlice(dst.Type(), len(list), len(list))
+		} else if len(list) > dst.Len() {
+			return fmt.Errorf("cannot assign %d values to Go type %s", len(list), dst.Type())
+		} else {
+			items.Set(reflect.Zero(dst.Type()))
+		}
+		for idx, item := range list {
+			if err := assignValue(items.Index(idx), item); err != nil {
+				return err
+			}
+		}
+		dst.Set(items)
+		return nil
+	case reflect.Struct:
+		if fields, ok := value.(map[string]interface{}); ok {
+			return assignArgs(dst, fields)
+		}
+	}
+
+	v := reflect.ValueOf(value)
+	switch {
+	case v.Type().AssignableTo(dst.Type()):
+		dst.Set(v)
+	case v.Kind() == reflect.String && dst.Kind() == reflect.String:
+		dst.SetString(v.String())
+	case v.Kind() == reflect.Bool && dst.Kind() == reflect.Bool:
+		dst.SetBool(v.Bool())
+	case isNumberKind(v.Kind()) && isNumberKind(dst.Kind()):
+		return assignNumber(dst, v)
+	default:
+		return fmt.Errorf("cannot assign %s to Go type %s", v.Type(), dst.Type())
+	}
+
+	return nil
+}
+
+// isNumberKind reports whether a kind is an integer or floating point kind.
+func isNumberKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+
+	return false
+}
+
+// assignNumber sets a Go number from a number of another type, failing
+// rather than losing the integer part of the number or its fraction.
+func assignNumber(dst, v reflect.Value) error {
+	var (
+		isInt, isUint bool
+		i             int64
+		u             uint64
+		f             float64
+	)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, f = v.Int(), float64(v.Int())
+		isInt, isUint = true, i >= 0
+		u = uint64(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, f = v.Uint(), float64(v.Uint())
+		isInt, isUint = u <= math.MaxInt64, true
+		i = int64(u)
+	default:
+		f = v.Float()
+		if f == math.Trunc(f) {
+			isInt = f >= math.MinInt64 && f < math.MaxInt64
+			isUint = f >= 0 && f < math.MaxUint64
+			i, u = int64(f), uint64(f)
+		}
+	}
+
+	assigned := false
+	switch dst.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if isInt && !dst.OverflowInt(i) {
+			dst.SetInt(i)
+			assigned = true
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if isUint && !dst.OverflowUint(u) {
+			dst.SetUint(u)
+			assigned = true
+		}
+	default:
+		if !dst.OverflowFloat(f) {
+			dst.SetFloat(f)
+			assigned = true
+		}
+	}
+
+	if !assigned {
+		return fmt.Errorf("cannot assign %v to Go type %s", v.Interface(), dst.Type())
+	}
+
+	return nil
+}
@@ -0,0 +1,415 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type structKind string
+
+const (
+	structKindDog structKind = "DOG"
+	structKindCat structKind = "CAT"
+)
+
+var structKindEnum = NewEnum(EnumConfig{
+	Name: "Kind",
+	Values: []*EnumValue{
+		{Name: "DOG", Value: structKindDog},
+		{Name: "CAT", Value: structKindCat, DeprecationReason: "Cats are out."},
+	},
+})
+
+type structNode struct {
+	ID string
+}
+
+type structPet struct {
+	_ struct{} `graphql:"Pet,description=A pet, or an animal."`
+
+	structNode
+	Name     string       `graphql:"name,description=The name, in full."`
+	Nickname *string      `graphql:",deprecated=Use name."`
+	Kind     structKind   `graphql:"kind"`
+	Tags     []string     `graphql:"tags"`
+	Friends  []*structPet `graphql:"friends"`
+	Owner    *structOwner
+	private  string
+	Skipped  string `graphql:"-"`
+}
+
+func (p *structPet) Greeting(ctx context.Context, args struct {
+	Polite bool `graphql:"polite,description=Whether to say please."`
+}) (string, error) {
+	if p.Name == "" {
+		return "", fmt.Errorf("no name")
+	}
+	if args.Polite {
+		return "Hello, " + p.Name + ".", nil
+	}
+
+	return "Hi " + p.Name, nil
+}
+
+func (p structPet) String() string { return p.Name }
+
+type structOwner struct {
+	Name string
+	Age  int32
+}
+
+type structFilter struct {
+	Name  *string
+	Kinds []structKind
+	Owner *structOwnerFilter
+}
+
+type structOwnerFilter struct {
+	MinAge int
+}
+
+type structQuery struct {
+	_ struct{} `graphql:"Query,description=The query root."`
+
+	Pets []*structPet `graphql:"pets,description=All the pets."`
+}
+
+func (q *structQuery) Search(args *struct {
+	Filter structFilter
+	First  *int
+}) []*structPet {
+	var pets []*structPet
+	for _, pet := range q.Pets {
+		if args.Filter.Name != nil && pet.Name != *args.Filter.Name {
+			continue
+		}
+		if len(args.Filter.Kinds) > 0 && pet.Kind != args.Filter.Kinds[0] {
+			continue
+		}
+		if args.Filter.Owner != nil && (pet.Owner == nil || int(pet.Owner.Age) < args.Filter.Owner.MinAge) {
+			continue
+		}
+		pets = append(pets, pet)
+	}
+	if args.First != nil && *args.First < len(pets) {
+		pets = pets[:*args.First]
+	}
+
+	return pets
+}
+
+func (q *structQuery) URLPath() string { return "/pets" }
+
+type structMutation struct{}
+
+func (structMutation) Adopt(args struct{ ID string }) (*structPet, error) {
+	return &structPet{structNode: structNode{ID: args.ID}, Name: "Rex"}, nil
+}
+
+const printedStructs = `schema {
+  query: Query
+  mutation: structMutation
+}
+
+enum Kind {
+  DOG
+  CAT @deprecated(reason: "Cats are out.")
+}
+
+"""A pet, or an animal."""
+type Pet {
+  id: String!
+
+  """The name, in full."""
+  name: String!
+  nickname: String @deprecated(reason: "Use name.")
+  kind: Kind!
+  tags: [String!]!
+  friends: [Pet]!
+  owner: structOwner
+  greeting(
+    """Whether to say please."""
+    polite: Boolean!
+  ): String!
+}
+
+"""The query root."""
+type Query {
+  """All the pets."""
+  pets: [Pet]!
+  search(filter: structFilter!, first: Int): [Pet]!
+  urlPath: String!
+}
+
+input structFilter {
+  name: String
+  kinds: [Kind!]!
+  owner: structOwnerFilter
+}
+
+type structMutation {
+  adopt(id: String!): Pet
+}
+
+type structOwner {
+  name: String!
+  age: Int!
+}
+
+input structOwnerFilter {
+  minAge: Int!
+}
+`
+
+func structSchema(t *testing.T) *Schema {
+	t.Helper()
+
+	schema, err := FromStruct(&structQuery{}, StructOptions{
+		Mutation: structMutation{},
+		Enums:    []*Enum{structKindEnum},
+	})
+	if err != nil {
+		t.Fatalf("FromStruct; got error %v", err)
+	}
+
+	return schema
+}
+
+func TestFromStruct(t *testing.T) {
+	schema := structSchema(t)
+
+	if printed := printSchema(t, schema); printed != printedStructs {
+		t.Fatalf("PrintSchema; got\n%s\nwanted\n%s", printed, printedStructs)
+	}
+	if errs := Validate(schema); len(errs) != 0 {
+		t.Fatalf("Validate; got %v", errs)
+	}
+}
+
+func TestFromStructResolvers(t *testing.T) {
+	schema := structSchema(t)
+	pet := schema.GetType("Pet").(*Object)
+	query := schema.GetQueryType()
+
+	nickname := "Dogo"
+	rex := &structPet{
+		structNode: structNode{ID: "1"},
+		Name:       "Rex",
+		Nickname:   &nickname,
+		Kind:       structKindDog,
+		Owner:      &structOwner{Name: "Ann", Age: 30},
+	}
+	tom := &structPet{Name: "Tom", Kind: structKindCat}
+	root := &structQuery{Pets: []*structPet{rex, tom}}
+
+	resolve := func(field *Field, source interface{}, args map[string]interface{}) interface{} {
+		t.Helper()
+
+		value, err := field.Resolve(ResolveParams{Source: source, Args: args})
+		if err != nil {
+			t.Fatalf("%s; got error %v", field.Name, err)
+		}
+
+		return value
+	}
+
+	for _, test := range []struct {
+		field string
+		value interface{}
+	}{
+		{"id", "1"},
+		{"name", "Rex"},
+		{"nickname", "Dogo"},
+		{"kind", structKindDog},
+		{"owner", rex.Owner},
+	} {
+		if got := resolve(pet.GetField(test.field), rex, nil); !reflect.DeepEqual(got, test.value) {
+			t.Errorf("%s; got %#v wanted %#v", test.field, got, test.value)
+		}
+	}
+
+	// Nil pointers resolve to null, and struct values are sources too.
+	if got := resolve(pet.GetField("nickname"), *tom, nil); got != nil {
+		t.Errorf("nickname; got %#v wanted nil", got)
+	}
+	if got := resolve(pet.GetField("owner"), tom, nil); got != nil {
+		t.Errorf("owner; got %#v wanted nil", got)
+	}
+
+	greeting := pet.GetField("greeting")
+	if got := resolve(greeting, *rex, map[string]interface{}{"polite": true}); got != "Hello, Rex." {
+		t.Errorf("greeting; got %#v", got)
+	}
+	if _, err := greeting.Resolve(ResolveParams{Source: &structPet{}, Args: map[string]interface{}{}}); err == nil || err.Error() != "no name" {
+		t.Errorf("greeting; got error %v wanted no name", err)
+	}
+
+	search := query.GetField("search")
+	for _, test := range []struct {
+		args map[string]interface{}
+		want []*structPet
+	}{
+		{map[string]interface{}{"filter": map[string]interface{}{"kinds": []interface{}{structKindCat}}}, []*structPet{tom}},
+		{map[string]interface{}{"filter": map[string]interface{}{"name": "Rex"}}, []*structPet{rex}},
+		{map[string]interface{}{"filter": map[string]interface{}{"owner": map[string]interface{}{"minAge": 18}}}, []*structPet{rex}},
+		{map[string]interface{}{"filter": map[string]interface{}{}, "first": 1}, []*structPet{rex}},
+		{map[string]interface{}{"filter": map[string]interface{}{"name": nil}, "first": nil}, []*structPet{rex, tom}},
+	} {
+		if got := resolve(search, root, test.args); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("search(%v); got %v wanted %v", test.args, got, test.want)
+		}
+	}
+
+	// Root types resolve without a root value.
+	if got := resolve(query.GetField("urlPath"), nil, nil); got != "/pets" {
+		t.Errorf("urlPath; got %#v", got)
+	}
+	adopted := resolve(schema.GetMutationType().GetField("adopt"), nil, map[string]interface{}{"id": "2"})
+	if pet, ok := adopted.(*structPet); !ok || pet.ID != "2" {
+		t.Errorf("adopt; got %#v", adopted)
+	}
+}
+
+func TestFromStructTypes(t *testing.T) {
+	date := NewScalar(ScalarConfig{Name: "Date"})
+
+	type event struct {
+		On   structOwner
+		Kind structKind
+	}
+
+	b := NewStructBuilder(StructOptions{
+		Types: map[reflect.Type]NamedType{reflect.TypeOf(structOwner{}): date},
+	})
+	object, err := b.Object(reflect.TypeOf(event{}))
+	if err != nil {
+		t.Fatalf("Object; got error %v", err)
+	}
+
+	// Named types without a registered enum are represented by their kind.
+	if got := printType(t, object); got != "type event {\n  on: Date!\n  kind: String!\n}" {
+		t.Errorf("PrintType; got\n%s", got)
+	}
+}
+
+func TestDecodeArgs(t *testing.T) {
+	var args struct {
+		ID     string       `graphql:"id"`
+		First  *int         `graphql:"first"`
+		Filter structFilter `graphql:"filter"`
+	}
+	err := DecodeArgs(map[string]interface{}{
+		"id":     "1",
+		"first":  10,
+		"filter": map[string]interface{}{"kinds": []interface{}{structKindDog}},
+	}, &args)
+	if err != nil {
+		t.Fatalf("DecodeArgs; got error %v", err)
+	}
+	if args.ID != "1" || args.First == nil || *args.First != 10 || !reflect.DeepEqual(args.Filter.Kinds, []structKind{structKindDog}) {
+		t.Errorf("DecodeArgs; got %+v", args)
+	}
+
+	if err := DecodeArgs(map[string]interface{}{"id": []interface{}{}}, &args); err == nil {
+		t.Errorf("DecodeArgs; got no error for a list wanted a string")
+	}
+	if err := DecodeArgs(nil, args); err == nil {
+		t.Errorf("DecodeArgs; got no error for a struct value")
+	}
+}
+
+func TestDecodeArgsConversions(t *testing.T) {
+	var args struct {
+		Small  int8       `graphql:"small"`
+		Count  uint       `graphql:"count"`
+		Ratio  float32    `graphql:"ratio"`
+		Whole  int        `graphql:"whole"`
+		Kind   structKind `graphql:"kind"`
+		Points [3]int     `graphql:"points"`
+	}
+	err := DecodeArgs(map[string]interface{}{
+		"small":  100,
+		"count":  7,
+		"ratio":  0.5,
+		"whole":  2.0,
+		"kind":   "DOG",
+		"points": []interface{}{1, 2},
+	}, &args)
+	if err != nil {
+		t.Fatalf("DecodeArgs; got error %v", err)
+	}
+	if args.Small != 100 || args.Count != 7 || args.Ratio != 0.5 || args.Whole != 2 || args.Kind != "DOG" || args.Points != [3]int{1, 2, 0} {
+		t.Errorf("DecodeArgs; got %+v", args)
+	}
+
+	tests := []struct {
+		args map[string]interface{}
+		want string
+	}{
+		{map[string]interface{}{"small": 300}, "small: cannot assign 300 to Go type int8"},
+		{map[string]interface{}{"count": -1}, "count: cannot assign -1 to Go type uint"},
+		{map[string]interface{}{"whole": 1.5}, "whole: cannot assign 1.5 to Go type int"},
+		{map[string]interface{}{"kind": 65}, "kind: cannot assign int to Go type schema.structKind"},
+		{map[string]interface{}{"ratio": "0.5"}, "ratio: cannot assign string to Go type float32"},
+		{map[string]interface{}{"points": []interface{}{1, 2, 3, 4}}, "points: cannot assign 4 values to Go type [3]int"},
+	}
+
+	for _, test := range tests {
+		if err := DecodeArgs(test.args, &args); err == nil || err.Error() != test.want {
+			t.Errorf("DecodeArgs(%v); got error %v wanted %s", test.args, err, test.want)
+		}
+	}
+}
+
+func TestFromStructErrors(t *testing.T) {
+	type withMap struct {
+		Values map[string]string
+	}
+	type withAnonymous struct {
+		Nested struct{ C chan int }
+	}
+
+	for _, test := range []struct {
+		value interface{}
+		want  string
+	}{
+		{"query", "Expected a struct or a pointer to one, but received: string."},
+		{withMap{}, "withMap.values: Cannot represent Go type map[string]string as a GraphQL type."},
+		{withAnonymous{}, "withAnonymous.nested: Cannot name the GraphQL type of anonymous struct struct { C chan int }"},
+	} {
+		_, err := FromStruct(test.value)
+		if err == nil || !strings.HasPrefix(err.Error(), test.want) {
+			t.Errorf("FromStruct(%T); got error %v wanted %q", test.value, err, test.want)
+		}
+	}
+
+	date := NewScalar(ScalarConfig{Name: "Date"})
+	_, err := NewStructBuilder(StructOptions{
+		Types: map[reflect.Type]NamedType{reflect.TypeOf(structOwner{}): structSchema(t).GetQueryType()},
+	}).InputType(reflect.TypeOf(structOwner{}))
+	if err == nil || !strings.Contains(err.Error(), "which is not an input type") {
+		t.Errorf("InputType; got error %v", err)
+	}
+	if _, err := NewStructBuilder(StructOptions{
+		Types: map[reflect.Type]NamedType{reflect.TypeOf(structOwner{}): date},
+	}).InputType(reflect.TypeOf(structOwner{})); err != nil {
+		t.Errorf("InputType; got error %v", err)
+	}
+}
+
+func TestLowerFirst(t *testing.T) {
+	for _, test := range [][]string{
+		[]string{"Name", "name"},
+		[]string{"ID", "id"},
+		[]string{"URLPath", "urlPath"},
+		[]string{"UserID", "userID"},
+		[]string{"name", "name"},
+	} {
+		if got := lowerFirst(test[0]); got != test[1] {
+			t.Errorf("lowerFirst(%q); got %q wanted %q", test[0], got, test[1])
+		}
+	}
+}
@@ -0,0 +1,596 @@
+package schema
+
+import (
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ijsnow/goql/internal/errors"
+	"github.com/ijsnow/goql/internal/language"
+)
+
+// Validate checks that the schema satisfies the rules of the type system and
+// returns every violation found, each located at the SDL nodes the offending
+// definitions were built from, if any. The result is computed once per
+// schema; later calls return the same errors.
+func Validate(schema *Schema) []error {
+	schema.validationOnce.Do(func() {
+		context := &validationContext{schema: schema}
+		context.validateRootTypes()
+		context.validateDirectives()
+		context.validateTypes()
+
+		schema.validationErrors = context.errors
+	})
+
+	return schema.validationErrors
+}
+
+// InvalidSchemaError is returned by AssertValidSchema for a schema that
+// fails validation, and by BindResolvers for a resolver map that does not
+// match its schema.
+type InvalidSchemaError struct {
+	Errors []error
+}
+
+func (e *InvalidSchemaError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for idx, err := range e.Errors {
+		messages[idx] = err.Error()
+	}
+
+	return strings.Join(messages, "\n\n")
+}
+
+// AssertValidSchema returns an *InvalidSchemaError holding every violation
+// found by Validate, or nil if the schema is valid.
+func AssertValidSchema(schema *Schema) error {
+	if errs := Validate(schema); len(errs) != 0 {
+		return &InvalidSchemaError{Errors: errs}
+	}
+
+	return nil
+}
+
+var namePattern = regexp.MustCompile(`^[_a-zA-Z][_a-zA-Z0-9]*$`)
+
+type validationContext struct {
+	schema *Schema
+	errors []error
+
+	// The state of the detection of cycles of non-null input fields.
+	visitedInputObjects       map[string]bool
+	inputFieldPath            []*InputField
+	inputFieldPathIndexByType map[string]int
+}
+
+func (c *validationContext) report(id errors.MessageID, args map[string]interface{}, nodes ...language.ASTNode) {
+	c.reportMessage(errors.Message{ID: id, Args: args}, nodes...)
+}
+
+func (c *validationContext) reportMessage(message errors.Message, nodes ...language.ASTNode) {
+	c.errors = append(c.errors, errors.NewValidationError(message, astNodes(nodes...)...))
+}
+
+func (c *validationContext) validateRootTypes() {
+	if c.schema.GetQueryType() == nil {
+		c.report(errors.MsgQueryRootMissing, nil, c.schema.AstNode)
+	}
+}
+
+func (c *validationContext) validateName(name string, node language.ASTNode) {
+	if strings.HasPrefix(name, "__") {
+		c.report(errors.MsgReservedName, map[string]interface{}{"name": name}, node)
+	} else if !namePattern.MatchString(name) {
+		c.report(errors.MsgInvalidName, map[string]interface{}{"name": name}, node)
+	}
+}
+
+func (c *validationContext) validateDirectives() {
+	names := make([]string, len(DirectiveLocations))
+	for idx, location := range DirectiveLocations {
+		names[idx] = string(location)
+	}
+
+	for _, directive := range c.schema.GetDirectives() {
+		c.validateName(directive.Name, directive.AstNode)
+
+		if len(directive.Locations) == 0 {
+			c.report(errors.MsgDirectiveWithoutLocations, map[string]interface{}{
+				"directive": directive.Name,
+			}, directive.AstNode)
+		}
+		for _, location := range directive.Locations {
+			if !isDirectiveLocation(string(location)) {
+				c.reportMessage(errors.UnknownDirectiveLocationMessage(
+					directive.Name,
+					string(location),
+					errors.SuggestionList(string(location), names),
+				), directive.AstNode)
+			}
+		}
+
+		c.validateArgs("@"+directive.Name, directive.Args)
+	}
+}
+
+// validateArgs checks the arguments of a directive or a field, identified by
+// coordinate, such as "@include" or "Query.user".
+func (c *validationContext) validateArgs(coordinate string, args []*Argument) {
+	seen := map[string]*Argument{}
+	for _, arg := range args {
+		if arg == nil {
+			continue
+		}
+		argCoordinate := coordinate + "(" + arg.Name + ":)"
+
+		if first, ok := seen[arg.Name]; ok {
+			c.report(errors.MsgDuplicateArgDefinition, map[string]interface{}{
+				"coordinate": argCoordinate,
+			}, first.AstNode, arg.AstNode)
+			continue
+		}
+		seen[arg.Name] = arg
+
+		c.validateName(arg.Name, arg.AstNode)
+
+		if !IsInputType(arg.Type) {
+			c.report(errors.MsgInputTypeExpected, map[string]interface{}{
+				"coordinate": argCoordinate,
+				"type":       typeString(arg.Type),
+			}, argTypeNode(arg))
+		}
+
+		if isRequiredInput(arg.Type, arg.DefaultValue) && arg.DeprecationReason != "" {
+			c.report(errors.MsgRequiredArgDeprecated, map[string]interface{}{
+				"coordinate": argCoordinate,
+			}, arg.AstNode)
+		}
+	}
+}
+
+func (c *validationContext) validateTypes() {
+	typeMap := c.schema.GetTypeMap()
+
+	names := make([]string, 0, len(typeMap))
+	for name := range typeMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	c.visitedInputObjects = map[string]bool{}
+	c.inputFieldPathIndexByType = map[string]int{}
+
+	for _, name := range names {
+		t := typeMap[name]
+
+		// The introspection types are the only ones allowed to use the
+		// reserved names.
+		if !IsIntrospectionType(t) {
+			c.validateName(name, typeNodes(t)[0])
+		}
+
+		switch t := t.(type) {
+		case *Object:
+			c.validateFields(t, t.GetFields())
+			c.validateInterfaces(t)
+		case *Interface:
+			c.validateFields(t, t.GetFields())
+		case *Union:
+			c.validateUnionMembers(t)
+		case *Enum:
+			c.validateEnumValues(t)
+		case *InputObject:
+			c.validateInputFields(t)
+
+			// Input objects may only reference themselves through fields
+			// that can be null, or no value could ever be given.
+			c.detectInputObjectCycle(t)
+		}
+	}
+}
+
+func (c *validationContext) validateFields(t NamedType, fields []*Field) {
+	if len(fields) == 0 {
+		c.report(errors.MsgTypeWithoutFields, map[string]interface{}{
+			"type": t.GetName(),
+		}, typeNodes(t)...)
+	}
+
+	seen := map[string]*Field{}
+	for _, field := range fields {
+		coordinate := t.GetName() + "." + field.Name
+
+		if first, ok := seen[field.Name]; ok {
+			c.report(errors.MsgDuplicateFieldDefinition, map[string]interface{}{
+				"coordinate": coordinate,
+			}, first.AstNode, field.AstNode)
+			continue
+		}
+		seen[field.Name] = field
+
+		c.validateName(field.Name, field.AstNode)
+
+		if !IsOutputType(field.Type) {
+			c.report(errors.MsgOutputTypeExpected, map[string]interface{}{
+				"coordinate": coordinate,
+				"type":       typeString(field.Type),
+			}, fieldTypeNode(field))
+		}
+
+		c.validateArgs(coordinate, field.Args)
+	}
+}
+
+func (c *validationContext) validateInterfaces(object *Object) {
+	seen := map[*Interface]bool{}
+	for _, iface := range object.GetInterfaces() {
+		if iface == nil {
+			c.report(errors.MsgNonInterfaceImplemented, map[string]interface{}{
+				"type":      object.Name,
+				"interface": typeString(nil),
+			}, typeNodes(object)...)
+			continue
+		}
+
+		if seen[iface] {
+			c.report(errors.MsgDuplicateInterface, map[string]interface{}{
+				"type":      object.Name,
+				"interface": iface.Name,
+			}, implementsNodes(object, iface.Name)...)
+			continue
+		}
+		seen[iface] = true
+
+		c.validateObjectImplementsInterface(object, iface)
+	}
+}
+
+func (c *validationContext) validateObjectImplementsInterface(object *Object, iface *Interface) {
+	for _, ifaceField := range iface.GetFields() {
+		fieldName := ifaceField.Name
+		objectField := object.GetField(fieldName)
+
+		// Assert interface field exists on object.
+		if objectField == nil {
+			c.report(errors.MsgMissingInterfaceField, map[string]interface{}{
+				"interface": iface.Name,
+				"field":     fieldName,
+				"type":      object.Name,
+			}, append([]language.ASTNode{ifaceField.AstNode}, typeNodes(object)...)...)
+			continue
+		}
+
+		// Assert interface field type is satisfied by object field type, by
+		// being a valid subtype (covariant).
+		if !IsTypeSubTypeOf(c.schema, objectField.Type, ifaceField.Type) {
+			c.report(errors.MsgInterfaceFieldType, map[string]interface{}{
+				"interface":     iface.Name,
+				"field":         fieldName,
+				"interfaceType": typeString(ifaceField.Type),
+				"type":          object.Name,
+				"fieldType":     typeString(objectField.Type),
+			}, fieldTypeNode(ifaceField), fieldTypeNode(objectField))
+		}
+
+		// Assert each interface field arg is implemented.
+		for _, ifaceArg := range ifaceField.Args {
+			if ifaceArg == nil {
+				continue
+			}
+			argName := ifaceArg.Name
+			objectArg := argNamed(objectField.Args, argName)
+
+			if objectArg == nil {
+				c.report(errors.MsgMissingInterfaceArg, map[string]interface{}{
+					"interface": iface.Name,
+					"field":     fieldName,
+					"arg":       argName,
+					"type":      object.Name,
+				}, ifaceArg.AstNode, objectField.AstNode)
+				continue
+			}
+
+			// Assert interface field arg type matches object field arg type
+			// (invariant).
+			if !IsEqualType(ifaceArg.Type, objectArg.Type) {
+				c.report(errors.MsgInterfaceArgType, map[string]interface{}{
+					"interface":     iface.Name,
+					"field":         fieldName,
+					"arg":           argName,
+					"interfaceType": typeString(ifaceArg.Type),
+					"type":          object.Name,
+					"argType":       typeString(objectArg.Type),
+				}, argTypeNode(ifaceArg), argTypeNode(objectArg))
+			}
+		}
+
+		// Assert additional arguments must not be required.
+		for _, objectArg := range objectField.Args {
+			if objectArg == nil || argNamed(ifaceField.Args, objectArg.Name) != nil {
+				continue
+			}
+
+			if isRequiredInput(objectArg.Type, objectArg.DefaultValue) {
+				c.report(errors.MsgRequiredArgNotInInterface, map[string]interface{}{
+					"type":      object.Name,
+					"field":     fieldName,
+					"arg":       objectArg.Name,
+					"interface": iface.Name,
+				}, objectArg.AstNode, ifaceField.AstNode)
+			}
+		}
+	}
+}
+
+func (c *validationContext) validateUnionMembers(union *Union) {
+	members := union.GetTypes()
+	if len(members) == 0 {
+		c.report(errors.MsgUnionWithoutMembers, map[string]interface{}{
+			"union": union.Name,
+		}, union.AstNode)
+	}
+
+	seen := map[*Object]bool{}
+	for _, member := range members {
+		if member == nil {
+			c.report(errors.MsgNonObjectUnionMember, map[string]interface{}{
+				"union": union.Name,
+				"type":  typeString(nil),
+			}, union.AstNode)
+			continue
+		}
+
+		if seen[member] {
+			c.report(errors.MsgDuplicateUnionMember, map[string]interface{}{
+				"union": union.Name,
+				"type":  member.Name,
+			}, unionMemberNodes(union, member.Name)...)
+			continue
+		}
+		seen[member] = true
+	}
+}
+
+func (c *validationContext) validateEnumValues(enum *Enum) {
+	values := enum.GetValues()
+	if len(values) == 0 {
+		c.report(errors.MsgEnumWithoutValues, map[string]interface{}{
+			"enum": enum.Name,
+		}, enum.AstNode)
+	}
+
+	seen := map[string]*EnumValue{}
+	for _, value := range values {
+		if first, ok := seen[value.Name]; ok {
+			c.report(errors.MsgDuplicateEnumValue, map[string]interface{}{
+				"enum":  enum.Name,
+				"value": value.Name,
+			}, first.AstNode, value.AstNode)
+			continue
+		}
+		seen[value.Name] = value
+
+		c.validateName(value.Name, value.AstNode)
+
+		switch value.Name {
+		case "true", "false", "null":
+			c.report(errors.MsgReservedEnumValue, map[string]interface{}{
+				"enum":  enum.Name,
+				"value": value.Name,
+			}, value.AstNode)
+		}
+	}
+}
+
+func (c *validationContext) validateInputFields(inputObject *InputObject) {
+	fields := inputObject.GetFields()
+	if len(fields) == 0 {
+		c.report(errors.MsgInputObjectWithoutFields, map[string]interface{}{
+			"type": inputObject.Name,
+		}, inputObject.AstNode)
+	}
+
+	seen := map[string]*InputField{}
+	for _, field := range fields {
+		coordinate := inputObject.Name + "." + field.Name
+
+		if first, ok := seen[field.Name]; ok {
+			c.report(errors.MsgDuplicateFieldDefinition, map[string]interface{}{
+				"coordinate": coordinate,
+			}, first.AstNode, field.AstNode)
+			continue
+		}
+		seen[field.Name] = field
+
+		c.validateName(field.Name, field.AstNode)
+
+		if !IsInputType(field.Type) {
+			var typeNode language.ASTNode
+			if field.AstNode != nil {
+				typeNode, _ = field.AstNode.Type.(language.ASTNode)
+			}
+
+			c.report(errors.MsgInputTypeExpected, map[string]interface{}{
+				"coordinate": coordinate,
+				"type":       typeString(field.Type),
+			}, typeNode)
+		}
+
+		if isRequiredInput(field.Type, field.DefaultValue) && field.DeprecationReason != "" {
+			c.report(errors.MsgRequiredInputFieldDeprecated, map[string]interface{}{
+				"coordinate": coordinate,
+			}, field.AstNode)
+		}
+	}
+}
+
+// detectInputObjectCycle reports each cycle of non-null input fields
+// reachable from the input object. It is a depth-first search that keeps the
+// path of fields followed, and the index in that path at which each input
+// object on it was entered.
+func (c *validationContext) detectInputObjectCycle(inputObject *InputObject) {
+	if c.visitedInputObjects[inputObject.Name] {
+		return
+	}
+	c.visitedInputObjects[inputObject.Name] = true
+	c.inputFieldPathIndexByType[inputObject.Name] = len(c.inputFieldPath)
+
+	for _, field := range inputObject.GetFields() {
+		nonNull, ok := field.Type.(*NonNull)
+		if !ok {
+			continue
+		}
+		fieldType, ok := nonNull.OfType.(*InputObject)
+		if !ok {
+			continue
+		}
+
+		c.inputFieldPath = append(c.inputFieldPath, field)
+
+		if cycleIndex, ok := c.inputFieldPathIndexByType[fieldType.Name]; ok {
+			cyclePath := c.inputFieldPath[cycleIndex:]
+
+			names := make([]string, len(cyclePath))
+			nodes := make([]language.ASTNode, len(cyclePath))
+			for idx, pathField := range cyclePath {
+				names[idx] = pathField.Name
+				nodes[idx] = pathField.AstNode
+			}
+
+			c.report(errors.MsgInputObjectCycle, map[string]interface{}{
+				"type": fieldType.Name,
+				"path": strings.Join(names, "."),
+			}, nodes...)
+		} else {
+			c.detectInputObjectCycle(fieldType)
+		}
+
+		c.inputFieldPath = c.inputFieldPath[:len(c.inputFieldPath)-1]
+	}
+
+	delete(c.inputFieldPathIndexByType, inputObject.Name)
+}
+
+func argNamed(args []*Argument, name string) *Argument {
+	for _, arg := range args {
+		if arg != nil && arg.Name == name {
+			return arg
+		}
+	}
+
+	return nil
+}
+
+// typeString renders a type for error messages.
+func typeString(t Type) string {
+	if t == nil {
+		return "nil"
+	}
+
+	return t.String()
+}
+
+// typeNodes returns the definition of a named type followed by its
+// extensions. The definition may be nil, but the result is never empty.
+func typeNodes(t NamedType) []language.ASTNode {
+	switch t := t.(type) {
+	case *Scalar:
+		return []language.ASTNode{t.AstNode}
+	case *Object:
+		nodes := []language.ASTNode{t.AstNode}
+		for _, extension := range t.ExtensionASTNodes {
+			nodes = append(nodes, extension)
+		}
+		return nodes
+	case *Interface:
+		return []language.ASTNode{t.AstNode}
+	case *Union:
+		return []language.ASTNode{t.AstNode}
+	case *Enum:
+		return []language.ASTNode{t.AstNode}
+	case *InputObject:
+		return []language.ASTNode{t.AstNode}
+	}
+
+	return []language.ASTNode{nil}
+}
+
+func fieldTypeNode(field *Field) language.ASTNode {
+	if field.AstNode == nil {
+		return nil
+	}
+
+	node, _ := field.AstNode.Type.(language.ASTNode)
+	return node
+}
+
+func argTypeNode(arg *Argument) language.ASTNode {
+	if arg.AstNode == nil {
+		return nil
+	}
+
+	node, _ := arg.AstNode.Type.(language.ASTNode)
+	return node
+}
+
+// implementsNodes returns the references to the named interface in the
+// definition and extensions of the object.
+func implementsNodes(object *Object, name string) []language.ASTNode {
+	definitions := []*language.ObjectTypeDefinitionNode{object.AstNode}
+	for _, extension := range object.ExtensionASTNodes {
+		if extension != nil {
+			definitions = append(definitions, &extension.Definition)
+		}
+	}
+
+	var nodes []language.ASTNode
+	for _, definition := range definitions {
+		if definition == nil || definition.Interfaces == nil {
+			continue
+		}
+		for idx := range *definition.Interfaces {
+			if node := &(*definition.Interfaces)[idx]; node.Name.Value == name {
+				nodes = append(nodes, node)
+			}
+		}
+	}
+
+	return nodes
+}
+
+// unionMemberNodes returns the references to the named member in the
+// definition of the union.
+func unionMemberNodes(union *Union, name string) []language.ASTNode {
+	if union.AstNode == nil {
+		return nil
+	}
+
+	var nodes []language.ASTNode
+	for idx := range union.AstNode.Types {
+		if node := &union.AstNode.Types[idx]; node.Name.Value == name {
+			nodes = append(nodes, node)
+		}
+	}
+
+	return nodes
+}
+
+// astNodes drops the nodes that are unset, since a type only has nodes when
+// it was built from SDL.
+func astNodes(nodes ...language.ASTNode) []language.ASTNode {
+	var set []language.ASTNode
+	for _, node := range nodes {
+		if node == nil {
+			continue
+		}
+		if value := reflect.ValueOf(node); value.Kind() == reflect.Ptr && value.IsNil() {
+			continue
+		}
+
+		set = append(set, node)
+	}
+
+	return set
+}
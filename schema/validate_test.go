@@ -0,0 +1,261 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/ijsnow/goql/internal/errors"
+	"github.com/ijsnow/goql/internal/language"
+)
+
+// schemaWithField builds a schema whose query type has a single field of the
+// given type.
+func schemaWithField(t *testing.T, fieldType Type, types ...NamedType) *Schema {
+	schema, err := NewSchema(SchemaConfig{
+		Query: NewObject(ObjectConfig{
+			Name:   "Query",
+			Fields: Fields{{Name: "f", Type: fieldType}},
+		}),
+		Types: types,
+	})
+	if err != nil {
+		t.Fatalf("NewSchema; got error %v", err)
+	}
+
+	return schema
+}
+
+// expectErrors checks the messages of the errors Validate returns.
+func expectErrors(t *testing.T, schema *Schema, want ...string) {
+	t.Helper()
+
+	errs := Validate(schema)
+	if len(errs) != len(want) {
+		t.Fatalf("Validate; got %v wanted %v", errs, want)
+	}
+	for idx, err := range errs {
+		if err.Error() != want[idx] {
+			t.Errorf("Validate; got %s wanted %s", err, want[idx])
+		}
+	}
+}
+
+func TestValidatesRootTypes(t *testing.T) {
+	expectErrors(t, schemaWithField(t, String))
+
+	schema, _ := NewSchema(SchemaConfig{Mutation: blogMutation})
+	expectErrors(t, schema, "Query root type must be provided.")
+}
+
+func TestValidatesNames(t *testing.T) {
+	badName := NewObject(ObjectConfig{
+		Name:   "__Bad",
+		Fields: Fields{{Name: "bad-name", Type: String}},
+	})
+
+	expectErrors(t, schemaWithField(t, badName),
+		`Name "__Bad" must not begin with "__", which is reserved by GraphQL introspection.`,
+		`Names must match /^[_a-zA-Z][_a-zA-Z0-9]*$/ but "bad-name" does not.`,
+	)
+}
+
+func TestValidatesFieldsAndArguments(t *testing.T) {
+	empty := NewObject(ObjectConfig{Name: "Empty"})
+	input := NewInputObject(InputObjectConfig{
+		Name:   "Input",
+		Fields: InputFields{{Name: "object", Type: empty}},
+	})
+	object := NewObject(ObjectConfig{
+		Name: "Object",
+		Fields: Fields{
+			{Name: "input", Type: input},
+			{Name: "arg", Type: String, Args: []*Argument{
+				{Name: "a", Type: empty},
+				{Name: "a", Type: String},
+			}},
+			{Name: "arg", Type: String},
+		},
+	})
+
+	expectErrors(t, schemaWithField(t, object, input),
+		"Type Empty must define one or more fields.",
+		"The type of Input.object must be Input Type but got: Empty.",
+		"The type of Object.input must be Output Type but got: Input.",
+		"The type of Object.arg(a:) must be Input Type but got: Empty.",
+		"Argument Object.arg(a:) can only be defined once.",
+		"Field Object.arg can only be defined once.",
+	)
+}
+
+func TestValidatesDeprecatedRequiredInputs(t *testing.T) {
+	input := NewInputObject(InputObjectConfig{
+		Name: "Input",
+		Fields: InputFields{
+			{Name: "required", Type: NewNonNull(String), DeprecationReason: "Unused."},
+			{Name: "defaulted", Type: NewNonNull(String), DefaultValue: "a", DeprecationReason: "Unused."},
+			{Name: "optional", Type: String, DeprecationReason: "Unused."},
+		},
+	})
+	object := NewObject(ObjectConfig{
+		Name: "Object",
+		Fields: Fields{
+			{Name: "f", Type: String, Args: []*Argument{
+				{Name: "required", Type: NewNonNull(input), DeprecationReason: "Unused."},
+				{Name: "optional", Type: input, DeprecationReason: "Unused."},
+			}},
+		},
+	})
+
+	expectErrors(t, schemaWithField(t, object),
+		"Required input field Input.required cannot be deprecated.",
+		"Required argument Object.f(required:) cannot be deprecated.",
+	)
+
+	built, err := BuildFromSDL("type Query { f(a: Int! @deprecated): Int }")
+	if err != nil {
+		t.Fatalf("BuildFromSDL; got error %v", err)
+	}
+	expectErrors(t, built, "Required argument Query.f(a:) cannot be deprecated.")
+}
+
+func TestValidatesInterfaceImplementations(t *testing.T) {
+	iface := NewInterface(InterfaceConfig{
+		Name: "Node",
+		Fields: Fields{
+			{Name: "id", Type: NewNonNull(ID)},
+			{Name: "parent", Type: unionType},
+			{Name: "children", Type: NewList(interfaceType), Args: []*Argument{
+				{Name: "first", Type: Int},
+				{Name: "after", Type: String},
+			}},
+			{Name: "name", Type: String},
+		},
+	})
+	object := NewObject(ObjectConfig{
+		Name:       "Thing",
+		Interfaces: Interfaces{iface, iface},
+		Fields: Fields{
+			// A non-null subtype is allowed.
+			{Name: "id", Type: NewNonNull(ID)},
+			// An object type possible for the union is allowed.
+			{Name: "parent", Type: objectType},
+			{Name: "children", Type: interfaceType, Args: []*Argument{
+				{Name: "first", Type: NewNonNull(Int)},
+				{Name: "last", Type: NewNonNull(Int)},
+				{Name: "before", Type: NewNonNull(String), DefaultValue: ""},
+			}},
+		},
+	})
+
+	expectErrors(t, schemaWithField(t, object),
+		"Type Interface must define one or more fields.",
+		"Type Object must define one or more fields.",
+		"Interface field Node.children expects type [Interface] but Thing.children is type Interface.",
+		"Interface field argument Node.children(first:) expects type Int but Thing.children(first:) is type Int!.",
+		"Interface field argument Node.children(after:) expected but Thing.children does not provide it.",
+		"Object field Thing.children includes required argument last that is missing from the Interface field Node.children.",
+		"Interface field Node.name expected but Thing does not provide it.",
+		"Type Thing can only implement Node once.",
+	)
+}
+
+func TestValidatesUnionsAndEnums(t *testing.T) {
+	value := NewObject(ObjectConfig{Name: "Value", Fields: Fields{{Name: "v", Type: String}}})
+	union := NewUnion(UnionConfig{Name: "Result", Types: Objects{value, value}})
+	empty := NewUnion(UnionConfig{Name: "Nothing"})
+	enum := NewEnum(EnumConfig{Name: "Answer", Values: []*EnumValue{
+		{Name: "yes"}, {Name: "true"}, {Name: "yes"},
+	}})
+	noValues := NewEnum(EnumConfig{Name: "None"})
+
+	expectErrors(t, schemaWithField(t, union, empty, enum, noValues),
+		"Enum type Answer cannot include value: true.",
+		"Enum value Answer.yes can only be defined once.",
+		"Enum type None must define one or more values.",
+		"Union type Nothing must define one or more member types.",
+		"Union type Result can only include type Value once.",
+	)
+}
+
+func TestValidatesInputObjectCycles(t *testing.T) {
+	var a, b *InputObject
+	a = NewInputObject(InputObjectConfig{
+		Name: "A",
+		Fields: InputFieldsFunc(func() InputFields {
+			return InputFields{
+				{Name: "b", Type: NewNonNull(b)},
+				{Name: "self", Type: a},
+				{Name: "list", Type: NewNonNull(NewList(NewNonNull(a)))},
+			}
+		}),
+	})
+	b = NewInputObject(InputObjectConfig{
+		Name: "B",
+		Fields: InputFieldsFunc(func() InputFields {
+			return InputFields{{Name: "a", Type: NewNonNull(a)}}
+		}),
+	})
+
+	query := NewObject(ObjectConfig{
+		Name: "Query",
+		Fields: Fields{{
+			Name: "f",
+			Type: String,
+			Args: []*Argument{{Name: "a", Type: a}},
+		}},
+	})
+	schema, err := NewSchema(SchemaConfig{Query: query})
+	if err != nil {
+		t.Fatalf("NewSchema; got error %v", err)
+	}
+
+	expectErrors(t, schema,
+		`Cannot reference Input Object "A" within itself through a series of non-null fields: "b.a".`,
+	)
+}
+
+func TestValidatesCustomDirectives(t *testing.T) {
+	directive := NewDirective(DirectiveConfig{
+		Name:      "cached",
+		Locations: []DirectiveLocation{"FEILD"},
+		Args:      []*Argument{{Name: "scope", Type: blogAuthor}},
+	})
+	noLocations := NewDirective(DirectiveConfig{Name: "nowhere"})
+
+	schema, err := NewSchema(SchemaConfig{Query: blogQuery, Directives: []*Directive{directive, noLocations}})
+	if err != nil {
+		t.Fatalf("NewSchema; got error %v", err)
+	}
+
+	expectErrors(t, schema,
+		`Directive "@cached" has the unknown location "FEILD". Did you mean "FIELD"?`,
+		"The type of @cached(scope:) must be Input Type but got: Author.",
+		"Directive @nowhere must include 1 or more locations.",
+	)
+}
+
+func TestLocatesErrorsAtDefinitions(t *testing.T) {
+	source := language.Source{Body: "type Query {\n  f: Int\n  f: String\n}", Name: "GraphQL request"}
+	first := &language.FieldDefinitionNode{Node: language.Node{Loc: &language.Location{Start: 15, End: 21, Source: source}}}
+	second := &language.FieldDefinitionNode{Node: language.Node{Loc: &language.Location{Start: 24, End: 33, Source: source}}}
+
+	schema := schemaWithField(t, NewObject(ObjectConfig{
+		Name: "Thing",
+		Fields: Fields{
+			{Name: "f", Type: Int, AstNode: first},
+			{Name: "f", Type: String, AstNode: second},
+		},
+	}))
+
+	errs := Validate(schema)
+	if len(errs) != 1 {
+		t.Fatalf("Validate; got %v", errs)
+	}
+	err := errs[0].(*errors.GraphQLError)
+	if len(err.Locations) != 2 || err.Locations[0].Line != 2 || err.Locations[1].Line != 3 {
+		t.Errorf("Validate; got locations %v wanted lines 2 and 3", err.Locations)
+	}
+
+	if AssertValidSchema(schema) == nil {
+		t.Error("AssertValidSchema; got nil wanted an error")
+	}
+}
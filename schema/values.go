@@ -0,0 +1,430 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/ijsnow/goql/internal/errors"
+	"github.com/ijsnow/goql/internal/language"
+)
+
+// ValueFromASTUntyped produces a Go value from a literal without knowing its
+// type. Integers become ints, floats float64s, lists []interface{} and
+// objects map[string]interface{}. Variables are not known here, so they
+// produce nil.
+func ValueFromASTUntyped(valueNode language.ValueNode) interface{} {
+	switch node := valueNode.(type) {
+	case *language.IntValueNode:
+		if num, err := strconv.ParseInt(node.Value, 10, 0); err == nil {
+			return int(num)
+		}
+		num, _ := strconv.ParseFloat(node.Value, 64)
+		return num
+	case *language.FloatValueNode:
+		num, _ := strconv.ParseFloat(node.Value, 64)
+		return num
+	case *language.StringValueNode:
+		return node.Value
+	case *language.BooleanValueNode:
+		return node.Value
+	case *language.EnumValueNode:
+		return node.Value
+	case *language.ListValueNode:
+		values := make([]interface{}, len(node.Values))
+		for idx, value := range node.Values {
+			values[idx] = ValueFromASTUntyped(value)
+		}
+		return values
+	case *language.ObjectValueNode:
+		fields := make(map[string]interface{}, len(node.Fields))
+		for _, field := range node.Fields {
+			fields[field.Name.Value] = ValueFromASTUntyped(field.Value)
+		}
+		return fields
+	}
+
+	return nil
+}
+
+// ValueFromAST produces a Go value of the input type from a literal. Values
+// of variables are taken from variables, which hold values already coerced
+// to the types of the variables. It returns false if the literal is not a
+// valid value of the type, which validation should have reported.
+//
+// The values of input objects are map[string]interface{} and the values of
+// lists []interface{}. Leaf values are parsed with ParseLiteral.
+func ValueFromAST(valueNode language.ValueNode, t Type, variables map[string]interface{}) (interface{}, bool) {
+	if valueNode == nil {
+		// When there is no node, then there is also no value.
+		return nil, false
+	}
+
+	if nonNull, ok := t.(*NonNull); ok {
+		if _, isNull := valueNode.(*language.NullValueNode); isNull {
+			return nil, false
+		}
+		return ValueFromAST(valueNode, nonNull.OfType, variables)
+	}
+
+	if _, isNull := valueNode.(*language.NullValueNode); isNull {
+		// This is explicitly returning the value null.
+		return nil, true
+	}
+
+	if variable, ok := valueNode.(*language.VariableNode); ok {
+		value, ok := variables[variable.Name.Value]
+
+		// Note: we're not doing any checking that this variable is correct.
+		// We're assuming that this query has been validated and the variable
+		// usage here is of the correct type.
+		return value, ok
+	}
+
+	switch t := t.(type) {
+	case *List:
+		list, ok := valueNode.(*language.ListValueNode)
+		if !ok {
+			coerced, ok := ValueFromAST(valueNode, t.OfType, variables)
+			if !ok {
+				return nil, false
+			}
+			return []interface{}{coerced}, true
+		}
+
+		coerced := make([]interface{}, 0, len(list.Values))
+		for _, itemNode := range list.Values {
+			if isMissingVariable(itemNode, variables) {
+				// If an array contains a missing variable, it is either
+				// coerced to null or if the item type is non-null, it is
+				// considered invalid.
+				if _, ok := t.OfType.(*NonNull); ok {
+					return nil, false
+				}
+				coerced = append(coerced, nil)
+				continue
+			}
+
+			itemValue, ok := ValueFromAST(itemNode, t.OfType, variables)
+			if !ok {
+				return nil, false
+			}
+			coerced = append(coerced, itemValue)
+		}
+		return coerced, true
+
+	case *InputObject:
+		object, ok := valueNode.(*language.ObjectValueNode)
+		if !ok {
+			return nil, false
+		}
+
+		fieldNodes := make(map[string]language.ValueNode, len(object.Fields))
+		for _, fieldNode := range object.Fields {
+			fieldNodes[fieldNode.Name.Value] = fieldNode.Value
+		}
+
+		coerced := map[string]interface{}{}
+		for _, field := range t.GetFields() {
+			fieldNode, ok := fieldNodes[field.Name]
+			if !ok || isMissingVariable(fieldNode, variables) {
+				if field.DefaultValue != nil {
+					coerced[field.Name] = field.DefaultValue
+				} else if _, ok := field.Type.(*NonNull); ok {
+					return nil, false
+				}
+				continue
+			}
+
+			fieldValue, ok := ValueFromAST(fieldNode, field.Type, variables)
+			if !ok {
+				return nil, false
+			}
+			coerced[field.Name] = fieldValue
+		}
+		return coerced, true
+
+	case LeafType:
+		parsed, err := t.ParseLiteral(valueNode)
+		if err != nil {
+			return nil, false
+		}
+		return parsed, true
+	}
+
+	return nil, false
+}
+
+// GetDirectiveValues returns the values of the arguments of the directive
+// where it is applied among directiveNodes, or nil if it is not applied.
+// Arguments that are not given take their default value, and arguments
+// given as variables take their value from variables.
+func GetDirectiveValues(directive *Directive, directiveNodes []language.DirectiveNode, variables map[string]interface{}) (map[string]interface{}, error) {
+	for idx := range directiveNodes {
+		directiveNode := &directiveNodes[idx]
+		if directiveNode.Name.Value != directive.Name {
+			continue
+		}
+
+		var argNodes []language.ArgumentNode
+		if directiveNode.Arguments != nil {
+			argNodes = *directiveNode.Arguments
+		}
+
+		return argumentValues(directive.Args, argNodes, directiveNode, variables)
+	}
+
+	return nil, nil
+}
+
+// argumentValues coerces the arguments given to a field or a directive.
+// node is the field or directive, which locates the error of a missing
+// argument.
+func argumentValues(argDefs []*Argument, argNodes []language.ArgumentNode, node language.ASTNode, variables map[string]interface{}) (map[string]interface{}, error) {
+	coerced := make(map[string]interface{}, len(argDefs))
+
+	argNodeMap := make(map[string]*language.ArgumentNode, len(argNodes))
+	for idx := range argNodes {
+		argNodeMap[argNodes[idx].Name.Value] = &argNodes[idx]
+	}
+
+	for _, argDef := range argDefs {
+		name := argDef.Name
+		_, isRequired := argDef.Type.(*NonNull)
+
+		argNode, ok := argNodeMap[name]
+		if !ok {
+			if argDef.DefaultValue != nil {
+				coerced[name] = argDef.DefaultValue
+			} else if isRequired {
+				return nil, errors.NewValidationError(errors.Message{
+					ID:   errors.MsgArgNotProvided,
+					Args: map[string]interface{}{"arg": name, "type": argDef.Type.String()},
+				}, node)
+			}
+			continue
+		}
+
+		if variable, ok := argNode.Value.(*language.VariableNode); ok {
+			if value, ok := variables[variable.Name.Value]; ok {
+				// Note: this does not check that this variable value is
+				// correct. This assumes that this query has been validated
+				// and the variable usage here is of the correct type.
+				coerced[name] = value
+			} else if argDef.DefaultValue != nil {
+				coerced[name] = argDef.DefaultValue
+			} else if isRequired {
+				return nil, errors.NewValidationError(errors.Message{
+					ID: errors.MsgArgVariableNotProvided,
+					Args: map[string]interface{}{
+						"arg":      name,
+						"type":     argDef.Type.String(),
+						"variable": variable.Name.Value,
+					},
+				}, variable)
+			}
+			continue
+		}
+
+		value, ok := ValueFromAST(argNode.Value, argDef.Type, variables)
+		if !ok {
+			return nil, errors.NewValidationError(errors.Message{
+				ID:   errors.MsgArgInvalidValue,
+				Args: map[string]interface{}{"arg": name, "value": language.Print(argNode.Value)},
+			}, argNode.Value)
+		}
+		coerced[name] = value
+	}
+
+	return coerced, nil
+}
+
+// isMissingVariable reports whether the node is a variable that was not
+// given a value.
+func isMissingVariable(valueNode language.ValueNode, variables map[string]interface{}) bool {
+	variable, ok := valueNode.(*language.VariableNode)
+	if !ok {
+		return false
+	}
+
+	_, given := variables[variable.Name.Value]
+	return !given
+}
+
+var integerPattern = regexp.MustCompile(`^-?(?:0|[1-9][0-9]*)$`)
+
+// AstFromValue produces a literal of the input type representing the Go
+// value, the way it would be written in a document. It returns nil if the
+// value cannot be represented, such as null for a non-null type.
+//
+// Input objects are given as maps with string keys, and lists as slices or
+// arrays. Any other value given for a list type is treated as a list of one.
+// The values of leaf types are serialized first, so enums become the names
+// of their values.
+func AstFromValue(value interface{}, t Type) (language.ValueNode, error) {
+	if nonNull, ok := t.(*NonNull); ok {
+		astValue, err := AstFromValue(value, nonNull.OfType)
+		if _, isNull := astValue.(*language.NullValueNode); isNull {
+			return nil, err
+		}
+		return astValue, err
+	}
+
+	value = indirect(value)
+	if value == nil {
+		return &language.NullValueNode{}, nil
+	}
+
+	switch t := t.(type) {
+	case *List:
+		v := reflect.ValueOf(value)
+		if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+			return AstFromValue(value, t.OfType)
+		}
+
+		valueNodes := []language.ValueNode{}
+		for idx := 0; idx < v.Len(); idx++ {
+			itemNode, err := AstFromValue(v.Index(idx).Interface(), t.OfType)
+			if err != nil {
+				return nil, err
+			}
+			if itemNode != nil {
+				valueNodes = append(valueNodes, itemNode)
+			}
+		}
+		return &language.ListValueNode{Values: valueNodes}, nil
+
+	case *InputObject:
+		v := reflect.ValueOf(value)
+		if v.Kind() != reflect.Map || v.Type().Key().Kind() != reflect.String {
+			return nil, nil
+		}
+
+		fieldNodes := []language.ObjectFieldNode{}
+		for _, field := range t.GetFields() {
+			fieldValue := v.MapIndex(reflect.ValueOf(field.Name).Convert(v.Type().Key()))
+			if !fieldValue.IsValid() {
+				continue
+			}
+
+			valueNode, err := AstFromValue(fieldValue.Interface(), field.Type)
+			if err != nil {
+				return nil, err
+			}
+			if valueNode != nil {
+				fieldNodes = append(fieldNodes, language.ObjectFieldNode{
+					Name:  language.NameNode{Value: field.Name},
+					Value: valueNode,
+				})
+			}
+		}
+		return &language.ObjectValueNode{Fields: fieldNodes}, nil
+
+	case LeafType:
+		serialized, err := t.Serialize(value)
+		if err != nil {
+			return nil, err
+		}
+
+		return astFromSerialized(serialized, t)
+	}
+
+	return nil, fmt.Errorf("Must provide Input Type, cannot use: %v", t)
+}
+
+// astFromSerialized produces the literal of a serialized leaf value.
+func astFromSerialized(serialized interface{}, t LeafType) (language.ValueNode, error) {
+	serialized = indirect(serialized)
+
+	switch v := serialized.(type) {
+	case nil:
+		return nil, nil
+	case bool:
+		return &language.BooleanValueNode{Value: v}, nil
+	case json.Number:
+		return numberNode(v.String()), nil
+	case string:
+		if _, ok := t.(*Enum); ok {
+			return &language.EnumValueNode{Value: v}, nil
+		}
+		if t == ID && integerPattern.MatchString(v) {
+			return &language.IntValueNode{Value: v}, nil
+		}
+		return &language.StringValueNode{Value: v}, nil
+	}
+
+	v := reflect.ValueOf(serialized)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &language.IntValueNode{Value: strconv.FormatInt(v.Int(), 10)}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &language.IntValueNode{Value: strconv.FormatUint(v.Uint(), 10)}, nil
+	case reflect.Float32, reflect.Float64:
+		return numberNode(strconv.FormatFloat(v.Float(), 'g', -1, 64)), nil
+	case reflect.Slice, reflect.Array, reflect.Map:
+		// Scalars such as JSON serialize to structured values, which are
+		// written as list and object literals.
+		return astFromUntyped(serialized, t)
+	}
+
+	return nil, fmt.Errorf("Cannot convert value to AST: %v", inspect(serialized))
+}
+
+// astFromUntyped produces the literal of a structured serialized value.
+func astFromUntyped(value interface{}, t LeafType) (language.ValueNode, error) {
+	v := reflect.ValueOf(value)
+
+	if v.Kind() == reflect.Map {
+		keys := make([]string, 0, v.Len())
+		for _, key := range v.MapKeys() {
+			if key.Kind() != reflect.String {
+				return nil, fmt.Errorf("Cannot convert value to AST: %v", inspect(value))
+			}
+			keys = append(keys, key.String())
+		}
+		sort.Strings(keys)
+
+		fieldNodes := []language.ObjectFieldNode{}
+		for _, key := range keys {
+			fieldValue := v.MapIndex(reflect.ValueOf(key).Convert(v.Type().Key())).Interface()
+			valueNode, err := astFromSerialized(fieldValue, t)
+			if err != nil {
+				return nil, err
+			}
+			if valueNode == nil {
+				valueNode = &language.NullValueNode{}
+			}
+			fieldNodes = append(fieldNodes, language.ObjectFieldNode{
+				Name:  language.NameNode{Value: key},
+				Value: valueNode,
+			})
+		}
+		return &language.ObjectValueNode{Fields: fieldNodes}, nil
+	}
+
+	valueNodes := []language.ValueNode{}
+	for idx := 0; idx < v.Len(); idx++ {
+		valueNode, err := astFromSerialized(v.Index(idx).Interface(), t)
+		if err != nil {
+			return nil, err
+		}
+		if valueNode == nil {
+			valueNode = &language.NullValueNode{}
+		}
+		valueNodes = append(valueNodes, valueNode)
+	}
+	return &language.ListValueNode{Values: valueNodes}, nil
+}
+
+// numberNode produces an int literal for integral numbers and a float
+// literal otherwise.
+func numberNode(num string) language.ValueNode {
+	if integerPattern.MatchString(num) {
+		return &language.IntValueNode{Value: num}
+	}
+
+	return &language.FloatValueNode{Value: num}
+}